@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/urfave/cli/v2"
+)
+
+var aspInfoCommand = cli.Command{
+	Name:   "asp-info",
+	Usage:  "Shows the terms of the ASP, the connected one or the one given with --ark-url",
+	Action: aspInfoAction,
+	Flags:  []cli.Flag{&urlFlag},
+}
+
+func aspInfoAction(ctx *cli.Context) error {
+	var (
+		client arkv1.ArkServiceClient
+		close  func()
+		err    error
+	)
+	// the terms can be inspected before connecting the wallet to the ASP.
+	if url := ctx.String(urlFlag.Name); len(url) > 0 {
+		client, close, err = getClient(url)
+	} else {
+		client, close, err = getClientFromState(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	capabilities := common.Capabilities(info.GetCapabilities())
+	features := make([]map[string]interface{}, 0)
+	for _, capability := range capabilities.List() {
+		features = append(features, map[string]interface{}{
+			"name":                capability.String(),
+			"required":            capabilities.Requires(capability),
+			"supported_by_client": clientCapabilities.Supports(capability),
+		})
+	}
+
+	return printJSON(map[string]interface{}{
+		"pubkey":                info.GetPubkey(),
+		"network":               info.GetNetwork(),
+		"round_interval":        formatSeconds(info.GetRoundInterval()),
+		"round_lifetime":        formatSeconds(info.GetRoundLifetime()),
+		"unilateral_exit_delay": formatSeconds(info.GetUnilateralExitDelay()),
+		"dust":                  DUST,
+		"fees": map[string]interface{}{
+			"min_relay_fee":             info.GetMinRelayFee(),
+			"exit_fee":                  info.GetExitFee(),
+			"fee_sponsorship_threshold": info.GetFeeSponsorshipThreshold(),
+			"payment_flat_fee":          info.GetFeePolicy().GetFlatFee(),
+			"payment_proportional_fee":  info.GetFeePolicy().GetProportionalFeePpm(),
+			"payment_output_fee":        info.GetFeePolicy().GetOutputFee(),
+		},
+		"features": features,
+	})
+}
+
+// formatSeconds renders a duration given in seconds for humans, like 24h0m0s.
+func formatSeconds(seconds int64) string {
+	return fmt.Sprint(time.Duration(seconds) * time.Second)
+}
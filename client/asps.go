@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+var aspFlag = &cli.StringFlag{
+	Name:  "asp",
+	Usage: "Specify the public key or the url of the ASP to use among the ones the wallet is connected to, the one of init by default",
+}
+
+var aspCommand = cli.Command{
+	Name:  "asp",
+	Usage: "Manage the ASPs the wallet is connected to besides the one of init",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "add",
+			Usage:  "Connect the wallet to another ASP",
+			Action: withWalletLock(aspAddAction),
+			Flags:  []cli.Flag{&urlFlag},
+		},
+		{
+			Name:   "list",
+			Usage:  "List the ASPs with the offchain address and balance of the wallet for each",
+			Action: aspListAction,
+		},
+		{
+			Name:   "remove",
+			Usage:  "Disconnect the wallet from an ASP added with asp add",
+			Action: withWalletLock(aspRemoveAction),
+			Flags:  []cli.Flag{&urlFlag},
+		},
+	},
+}
+
+// aspInfo is an ASP the wallet is connected to, in addition to the one set
+// with init whose info is kept in the ASP_* entries of the state.
+type aspInfo struct {
+	URL                 string `json:"url"`
+	Pubkey              string `json:"pubkey"`
+	RoundLifetime       int64  `json:"round_lifetime"`
+	UnilateralExitDelay int64  `json:"unilateral_exit_delay"`
+}
+
+func (a aspInfo) stateEntries() map[string]string {
+	return map[string]string{
+		ASP_URL:               a.URL,
+		ASP_PUBKEY:            a.Pubkey,
+		ROUND_LIFETIME:        strconv.Itoa(int(a.RoundLifetime)),
+		UNILATERAL_EXIT_DELAY: strconv.Itoa(int(a.UnilateralExitDelay)),
+	}
+}
+
+// selectedAsp is the ASP the command works with, if other than the one of
+// init. The state returned by getState is overlaid with its entries, so that
+// the addresses, vtxos and balances are all the ones of this ASP.
+var selectedAsp *aspInfo
+
+// selectAsp makes the given ASP, or the one of init if nil, the one the
+// command works with.
+func selectAsp(asp *aspInfo) {
+	selectedAsp = asp
+}
+
+// setSelectedAsp selects the ASP given with --asp, if any.
+func setSelectedAsp(ctx *cli.Context) error {
+	selectAsp(nil)
+
+	id := ctx.String(aspFlag.Name)
+	if len(id) <= 0 {
+		return nil
+	}
+
+	primary, asps, err := getAsps(ctx)
+	if err != nil {
+		return err
+	}
+	if primary.URL == id || primary.Pubkey == id {
+		return nil
+	}
+	for _, asp := range asps {
+		if asp.URL == id || asp.Pubkey == id {
+			selected := asp
+			selectAsp(&selected)
+			return nil
+		}
+	}
+	return fmt.Errorf("not connected to ASP %s, use asp add to connect", id)
+}
+
+// getAsps returns the ASP of init and the other ones the wallet is connected
+// to, by pubkey.
+func getAsps(ctx *cli.Context) (*aspInfo, map[string]aspInfo, error) {
+	// the overlay of the selected ASP must not hide the one of init.
+	selected := selectedAsp
+	selectAsp(nil)
+	state, err := getState(ctx)
+	selectAsp(selected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	primary := &aspInfo{URL: state[ASP_URL], Pubkey: state[ASP_PUBKEY]}
+	// nolint:all
+	primary.RoundLifetime, _ = strconv.ParseInt(state[ROUND_LIFETIME], 10, 64)
+	// nolint:all
+	primary.UnilateralExitDelay, _ = strconv.ParseInt(state[UNILATERAL_EXIT_DELAY], 10, 64)
+
+	asps := make(map[string]aspInfo)
+	if len(state[ASPS]) > 0 {
+		if err := json.Unmarshal([]byte(state[ASPS]), &asps); err != nil {
+			return nil, nil, fmt.Errorf("invalid asps: %s", err)
+		}
+	}
+	return primary, asps, nil
+}
+
+func setAsps(ctx *cli.Context, asps map[string]aspInfo) error {
+	buf, err := json.Marshal(asps)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{ASPS: string(buf)})
+}
+
+// findAsp returns the ASP with the given pubkey among the ones the wallet is
+// connected to, the one of init included, or nil if not found.
+func findAsp(ctx *cli.Context, pubkey *secp256k1.PublicKey) (*aspInfo, error) {
+	primary, asps, err := getAsps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := hex.EncodeToString(pubkey.SerializeCompressed())
+	if primary.Pubkey == key {
+		return primary, nil
+	}
+	if asp, ok := asps[key]; ok {
+		return &asp, nil
+	}
+	return nil, nil
+}
+
+// selectAspForReceivers selects the ASP of the given offchain receivers, that
+// must all be served by the same ASP the wallet is connected to.
+func selectAspForReceivers(ctx *cli.Context, receivers []receiver) error {
+	var receiversAsp *secp256k1.PublicKey
+	for _, r := range receivers {
+		addr, err := common.DecodeArkAddress(r.To)
+		if err != nil {
+			return fmt.Errorf("invalid receiver address: %s", err)
+		}
+		if receiversAsp != nil && !bytes.Equal(
+			receiversAsp.SerializeCompressed(), addr.AspKey.SerializeCompressed(),
+		) {
+			return fmt.Errorf(
+				"receivers are served by different ASPs, pay them with separate sends",
+			)
+		}
+		receiversAsp = addr.AspKey
+	}
+	if receiversAsp == nil {
+		return nil
+	}
+
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(
+		aspPubkey.SerializeCompressed(), receiversAsp.SerializeCompressed(),
+	) {
+		return nil
+	}
+
+	if len(ctx.String(aspFlag.Name)) > 0 {
+		return fmt.Errorf("receivers are not served by the ASP given with --asp")
+	}
+	asp, err := findAsp(ctx, receiversAsp)
+	if err != nil {
+		return err
+	}
+	if asp == nil {
+		return fmt.Errorf(
+			"receivers are served by ASP %s the wallet is not connected to, use asp add to connect",
+			hex.EncodeToString(receiversAsp.SerializeCompressed()),
+		)
+	}
+
+	return useAsp(ctx, asp)
+}
+
+// useAsp selects the given ASP among the ones returned by findAsp.
+func useAsp(ctx *cli.Context, asp *aspInfo) error {
+	primary, _, err := getAsps(ctx)
+	if err != nil {
+		return err
+	}
+	if asp.Pubkey == primary.Pubkey {
+		selectAsp(nil)
+	} else {
+		selectAsp(asp)
+	}
+	return nil
+}
+
+func aspAddAction(ctx *cli.Context) error {
+	url := ctx.String(urlFlag.Name)
+	if len(url) <= 0 {
+		return fmt.Errorf("invalid ark url")
+	}
+
+	primary, asps, err := getAsps(ctx)
+	if err != nil {
+		return err
+	}
+	if len(primary.Pubkey) <= 0 {
+		return fmt.Errorf("wallet not initialized, use init to connect to the first ASP")
+	}
+
+	client, close, err := getClient(url)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	resp, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if err := checkCapabilities(
+		"ASP", common.Capabilities(resp.GetCapabilities()),
+	); err != nil {
+		return err
+	}
+
+	_, net := getNetwork(ctx)
+	if len(resp.GetNetwork()) > 0 && !strings.EqualFold(resp.GetNetwork(), net.Name) {
+		return fmt.Errorf("ASP is on %s network, wallet is on %s", resp.GetNetwork(), net.Name)
+	}
+	if resp.GetPubkey() == primary.Pubkey {
+		return fmt.Errorf("already connected to ASP %s", resp.GetPubkey())
+	}
+
+	asp := aspInfo{
+		URL:                 url,
+		Pubkey:              resp.GetPubkey(),
+		RoundLifetime:       resp.GetRoundLifetime(),
+		UnilateralExitDelay: resp.GetUnilateralExitDelay(),
+	}
+	asps[asp.Pubkey] = asp
+	if err := setAsps(ctx, asps); err != nil {
+		return err
+	}
+
+	selectAsp(&asp)
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"url":              asp.URL,
+		"pubkey":           asp.Pubkey,
+		"offchain_address": offchainAddr,
+	})
+}
+
+func aspListAction(ctx *cli.Context) error {
+	primary, asps, err := getAsps(ctx)
+	if err != nil {
+		return err
+	}
+
+	all := []*aspInfo{primary}
+	for _, asp := range asps {
+		asp := asp
+		all = append(all, &asp)
+	}
+
+	defer selectAsp(selectedAsp)
+
+	list := make([]map[string]interface{}, 0, len(all))
+	for i, asp := range all {
+		if i == 0 {
+			selectAsp(nil)
+		} else {
+			selectAsp(asp)
+		}
+
+		item := map[string]interface{}{
+			"url":     asp.URL,
+			"pubkey":  asp.Pubkey,
+			"primary": i == 0,
+		}
+		offchainAddr, _, _, err := getAddress(ctx)
+		if err != nil {
+			return err
+		}
+		item["offchain_address"] = offchainAddr
+
+		// an unreachable ASP doesn't prevent listing the others.
+		client, close, err := getClientFromState(ctx)
+		if err == nil {
+			balance, _, err := getOffchainBalance(
+				ctx, NewExplorer(ctx), client, offchainAddr, false,
+			)
+			close()
+			if err == nil {
+				item["offchain_balance"] = balance.total
+			} else {
+				item["error"] = err.Error()
+			}
+		} else {
+			item["error"] = err.Error()
+		}
+
+		list = append(list, item)
+	}
+	return printJSON(list)
+}
+
+func aspRemoveAction(ctx *cli.Context) error {
+	url := ctx.String(urlFlag.Name)
+
+	_, asps, err := getAsps(ctx)
+	if err != nil {
+		return err
+	}
+
+	for pubkey, asp := range asps {
+		if asp.URL != url && asp.Pubkey != url {
+			continue
+		}
+
+		// the vtxos would be left out of any balance otherwise.
+		selectAsp(&asp)
+		offchainAddr, _, _, err := getAddress(ctx)
+		if err != nil {
+			return err
+		}
+		client, close, err := getClientFromState(ctx)
+		if err != nil {
+			return err
+		}
+		defer close()
+		balance, _, err := getOffchainBalance(
+			ctx, NewExplorer(ctx), client, offchainAddr, false,
+		)
+		if err != nil {
+			return err
+		}
+		if balance.total > 0 {
+			return fmt.Errorf(
+				"the wallet still owns %d sats with ASP %s, move them before removing it",
+				balance.total, asp.URL,
+			)
+		}
+
+		delete(asps, pubkey)
+		if err := setAsps(ctx, asps); err != nil {
+			return err
+		}
+		fmt.Printf("ASP %s removed\n", asp.URL)
+		return nil
+	}
+	return fmt.Errorf("ASP %s not found among the added ones", url)
+}
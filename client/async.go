@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+var asyncFlag = cli.BoolFlag{
+	Name:  "async",
+	Usage: "pay the offchain receivers out of round, without waiting for the next round, they claim the funds in a round once online",
+}
+
+var claimCommand = cli.Command{
+	Name:   "claim",
+	Usage:  "Claims in the next round the payments received out of round, they're lost if not claimed before expiring",
+	Action: withWalletLock(claimAction),
+	Flags:  []cli.Flag{&passwordFlag, &roundRetriesFlag, &reviewFlag},
+}
+
+func claimAction(ctx *cli.Context) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending, err := getAsyncVtxos(ctx, client, offchainAddr)
+	if err != nil {
+		return err
+	}
+	if len(pending) <= 0 {
+		fmt.Println("no payments to claim")
+		return nil
+	}
+
+	amount := uint64(0)
+	for _, v := range pending {
+		amount += v.amount
+	}
+
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return err
+	}
+	netAmount, err := fees.forSelfPayment(amount)
+	if err != nil {
+		return err
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("claiming %d payments received out of round (%d sats)\n", len(pending), amount)
+
+	poolTxID, err := joinRound(
+		ctx, client, pending, signer,
+		[]*arkv1.Output{{Address: offchainAddr, Amount: netAmount}},
+	)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyClaim,
+		Txid:   poolTxID,
+		Amount: netAmount,
+		Fee:    amount - netAmount,
+	})
+
+	return printJSON(map[string]interface{}{
+		"pool_txid": poolTxID,
+		"vtxos":     len(pending),
+		"amount":    netAmount,
+		"fee":       amount - netAmount,
+	})
+}
+
+// getAsyncVtxos returns the vtxos received out of round still to be claimed,
+// the frozen ones excluded.
+func getAsyncVtxos(
+	ctx *cli.Context, client arkv1.ArkServiceClient, offchainAddr string,
+) ([]vtxo, error) {
+	vtxos, err := getVtxos(ctx, NewExplorer(ctx), client, offchainAddr, false)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]vtxo, 0)
+	for _, v := range vtxos {
+		if len(v.redeemTx) <= 0 || locked.has(v.txid, v.vout) {
+			continue
+		}
+		pending = append(pending, v)
+	}
+	return pending, nil
+}
+
+// withoutAsyncVtxos excludes the vtxos received out of round, that have no
+// branch of their own in a congestion tree until claimed.
+func withoutAsyncVtxos(vtxos []vtxo) []vtxo {
+	filtered := make([]vtxo, 0, len(vtxos))
+	for _, v := range vtxos {
+		if len(v.redeemTx) > 0 {
+			logger.Warn(
+				"vtxo received out of round can't be exited before being claimed with claim",
+				"outpoint", fmt.Sprintf("%s:%d", v.txid, v.vout),
+			)
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+	return filtered
+}
+
+// sendAsync pays the given receivers with an out-of-round redeem tx spending
+// the given coins, co-signed by the ASP, and returns its txid.
+func sendAsync(
+	ctx *cli.Context, client arkv1.ArkServiceClient, coins []vtxo,
+	signer walletSigner, receivers []*arkv1.Output,
+) (string, error) {
+	inputs := make([]*arkv1.Input, 0, len(coins))
+	for _, coin := range coins {
+		inputs = append(inputs, &arkv1.Input{Txid: coin.txid, Vout: coin.vout})
+	}
+
+	resp, err := client.CreateAsyncPayment(ctx.Context, &arkv1.CreateAsyncPaymentRequest{
+		Inputs:    inputs,
+		Receivers: receivers,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	pset, err := psetv2.NewPsetFromBase64(resp.GetRedeemTx())
+	if err != nil {
+		return "", fmt.Errorf("invalid redeem tx: %s", err)
+	}
+	if err := checkRedeemTxOutputs(ctx, pset, receivers); err != nil {
+		return "", err
+	}
+
+	explorer := NewExplorer(ctx)
+	if err := signer.signPset(ctx, pset, explorer); err != nil {
+		return "", err
+	}
+	signedRedeemTx, err := pset.ToBase64()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := client.CompleteAsyncPayment(ctx.Context, &arkv1.CompleteAsyncPaymentRequest{
+		SignedRedeemTx: signedRedeemTx,
+	}); err != nil {
+		return "", err
+	}
+
+	outpoints := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		outpoints = append(outpoints, fmt.Sprintf("%s:%d", coin.txid, coin.vout))
+	}
+	if err := forgetSpentVtxos(ctx, outpoints); err != nil {
+		return "", err
+	}
+
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return "", err
+	}
+	return utx.TxHash().String(), nil
+}
+
+// checkRedeemTxOutputs makes sure the redeem tx created by the ASP pays the
+// receivers, in order, and nothing else but the fee.
+func checkRedeemTxOutputs(
+	ctx *cli.Context, pset *psetv2.Pset, receivers []*arkv1.Output,
+) error {
+	// the receivers are all served by the ASP of the wallet.
+	exitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pset.Outputs) != len(receivers)+1 {
+		return fmt.Errorf(
+			"invalid redeem tx: expected %d outputs, got %d",
+			len(receivers)+1, len(pset.Outputs),
+		)
+	}
+
+	for i, r := range receivers {
+		addr, err := common.DecodeArkAddress(r.GetAddress())
+		if err != nil {
+			return err
+		}
+		tapKey, _, err := computeVtxoTaprootScript(
+			addr.UserKey, addr.AspKey, uint(exitDelay),
+		)
+		if err != nil {
+			return err
+		}
+
+		output := pset.Outputs[i]
+		if len(output.Script) != 34 ||
+			!bytes.Equal(output.Script[2:], schnorr.SerializePubKey(tapKey)) {
+			return fmt.Errorf("invalid redeem tx: output %d doesn't pay %s", i, r.GetAddress())
+		}
+		if output.Value != r.GetAmount() {
+			return fmt.Errorf(
+				"invalid redeem tx: output %d pays %d instead of %d",
+				i, output.Value, r.GetAmount(),
+			)
+		}
+	}
+	return nil
+}
+
+// validateRedeemTx checks that the vtxo received out of round is paid by a
+// redeem tx fully signed by the senders and the ASP, spending vtxos of the
+// same ASP, so that it can be claimed in a round.
+func validateRedeemTx(ctx *cli.Context, v *arkv1.Vtxo) error {
+	pset, err := psetv2.NewPsetFromBase64(v.GetRedeemTx())
+	if err != nil {
+		return fmt.Errorf("invalid redeem tx: %s", err)
+	}
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return fmt.Errorf("invalid redeem tx: %s", err)
+	}
+	if utx.TxHash().String() != v.GetOutpoint().GetTxid() {
+		return fmt.Errorf("redeem tx doesn't match the vtxo txid")
+	}
+
+	userPubkey, err := getWalletPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	exitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return err
+	}
+	tapKey, _, err := computeVtxoTaprootScript(userPubkey, aspPubkey, uint(exitDelay))
+	if err != nil {
+		return err
+	}
+
+	vout := int(v.GetOutpoint().GetVout())
+	if vout >= len(pset.Outputs) {
+		return fmt.Errorf("redeem tx has no output %d", vout)
+	}
+	output := pset.Outputs[vout]
+	if len(output.Script) != 34 ||
+		!bytes.Equal(output.Script[2:], schnorr.SerializePubKey(tapKey)) {
+		return fmt.Errorf("redeem tx output %d doesn't pay the wallet", vout)
+	}
+	if output.Value != v.GetReceiver().GetAmount() {
+		return fmt.Errorf("redeem tx output %d amount mismatch", vout)
+	}
+
+	_, liquidNet := getNetwork(ctx)
+	genesis, err := chainhash.NewHashFromStr(liquidNet.GenesisBlockHash)
+	if err != nil {
+		return err
+	}
+
+	// every input must be spent with the forfeit leaf of a vtxo of the ASP,
+	// signed by both its owner and the ASP.
+	for i, in := range pset.Inputs {
+		if len(in.TapLeafScript) != 1 {
+			return fmt.Errorf("redeem tx input %d has no forfeit leaf", i)
+		}
+		closure := &tree.ForfeitClosure{}
+		valid, err := closure.Decode(in.TapLeafScript[0].Script)
+		if err != nil || !valid {
+			return fmt.Errorf("redeem tx input %d has no forfeit leaf", i)
+		}
+		if !bytes.Equal(
+			closure.AspPubkey.SerializeCompressed(), aspPubkey.SerializeCompressed(),
+		) {
+			return fmt.Errorf("redeem tx input %d is not a vtxo of the ASP", i)
+		}
+
+		signers := map[string]bool{
+			string(schnorr.SerializePubKey(closure.Pubkey)):    false,
+			string(schnorr.SerializePubKey(closure.AspPubkey)): false,
+		}
+		leafHash := in.TapLeafScript[0].TapHash()
+		for _, tapScriptSig := range in.TapScriptSig {
+			if _, ok := signers[string(tapScriptSig.PubKey)]; !ok {
+				continue
+			}
+			preimage, err := common.TaprootPreimage(genesis, pset, i, &leafHash)
+			if err != nil {
+				return err
+			}
+			sig, err := schnorr.ParseSignature(tapScriptSig.Signature)
+			if err != nil {
+				return err
+			}
+			pubkey, err := schnorr.ParsePubKey(tapScriptSig.PubKey)
+			if err != nil {
+				return err
+			}
+			if !sig.Verify(preimage, pubkey) {
+				return fmt.Errorf("redeem tx input %d has an invalid signature", i)
+			}
+			signers[string(tapScriptSig.PubKey)] = true
+		}
+		for _, signed := range signers {
+			if !signed {
+				return fmt.Errorf("redeem tx input %d is not fully signed", i)
+			}
+		}
+	}
+	return nil
+}
+
+// claimAsyncPaymentsOnStartup runs claim with the given global args if any
+// payment received out of round is pending.
+func claimAsyncPaymentsOnStartup(ctx *cli.Context, globalArgs []string) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	pending, err := getAsyncVtxos(ctx, client, offchainAddr)
+	if err != nil {
+		return err
+	}
+	if len(pending) <= 0 {
+		return nil
+	}
+	return ctx.App.Run(append(globalArgs, claimCommand.Name))
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// backupVersion is the version of the format of the backup files. Files of
+// later versions are rejected.
+const backupVersion = 1
+
+var backupFileFlag = cli.StringFlag{
+	Name:     "file",
+	Usage:    "path of the backup file",
+	Required: true,
+}
+
+var backupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "Export or import the wallet, with the keys, the ASP info and the metadata of the vtxos, as a single encrypted file",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "export",
+			Usage:  "Write the encrypted backup of the wallet to a file, encrypted with the wallet password",
+			Action: withWalletLock(backupExportAction),
+			Flags:  []cli.Flag{&backupFileFlag, &passwordFlag},
+		},
+		{
+			Name:   "import",
+			Usage:  "Restore the wallet from a backup file into an empty datadir",
+			Action: withWalletLock(backupImportAction),
+			Flags:  []cli.Flag{&backupFileFlag, &passwordFlag},
+		},
+	},
+}
+
+// backupFile is the content of a backup file. The payload is the encrypted
+// state of the wallet, including its own checksum, while the outer checksum
+// allows to detect a corrupted file before asking for the password.
+type backupFile struct {
+	Version   int    `json:"version"`
+	Network   string `json:"network"`
+	CreatedAt int64  `json:"created_at"`
+	Payload   string `json:"payload"`
+	Checksum  string `json:"checksum"`
+}
+
+func (b backupFile) checksum() string {
+	buf := sha256.Sum256([]byte(
+		fmt.Sprintf("%d:%s:%d:%s", b.Version, b.Network, b.CreatedAt, b.Payload),
+	))
+	return hex.EncodeToString(buf[:])
+}
+
+func backupExportAction(ctx *cli.Context) error {
+	// the backup is always of the whole wallet, whatever the ASP selected.
+	selectAsp(nil)
+
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(state[PUBKEY]) <= 0 {
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var password []byte
+	if len(state[EXTERNAL_SIGNER]) > 0 {
+		// there's no wallet password to verify, the backup gets its own one.
+		password, err = readPassword(ctx, false)
+	} else {
+		password, err = readPassword(ctx, true)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the history and the labels are exported the way older versions kept
+	// them in the state, so that any version can import the backup.
+	history, err := getHistory(ctx)
+	if err != nil {
+		return err
+	}
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return err
+	}
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	state[HISTORY] = string(historyJSON)
+	state[LABELS] = string(labelsJSON)
+
+	checksum, err := stateChecksum(state)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(merge(state, map[string]string{
+		STATE_CHECKSUM: checksum,
+	}))
+	if err != nil {
+		return err
+	}
+
+	payload, err := newAES128Cypher().encrypt(plaintext, password)
+	if err != nil {
+		return err
+	}
+
+	backup := backupFile{
+		Version:   backupVersion,
+		Network:   state[NETWORK],
+		CreatedAt: time.Now().Unix(),
+		Payload:   hex.EncodeToString(payload),
+	}
+	backup.Checksum = backup.checksum()
+
+	buf, err := json.MarshalIndent(backup, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	path := cleanAndExpandPath(ctx.String(backupFileFlag.Name))
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("file %s already exists", path)
+	}
+	if err := os.WriteFile(path, buf, 0600); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"backup_file": path,
+		"version":     backup.Version,
+		"network":     backup.Network,
+	})
+}
+
+func backupImportAction(ctx *cli.Context) error {
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(state[PUBKEY]) > 0 {
+		return fmt.Errorf(
+			"wallet already initialized, use an empty datadir to import the backup",
+		)
+	}
+
+	path := cleanAndExpandPath(ctx.String(backupFileFlag.Name))
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var backup backupFile
+	if err := json.Unmarshal(buf, &backup); err != nil {
+		return fmt.Errorf("invalid backup file: %s", err)
+	}
+	if backup.Version <= 0 || backup.Version > backupVersion {
+		return fmt.Errorf("unsupported backup version %d", backup.Version)
+	}
+	if backup.Checksum != backup.checksum() {
+		return fmt.Errorf("invalid backup file: checksum mismatch")
+	}
+
+	payload, err := hex.DecodeString(backup.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid backup file: %s", err)
+	}
+
+	password, err := readPassword(ctx, false)
+	if err != nil {
+		return err
+	}
+	plaintext, err := newAES128Cypher().decrypt(payload, password)
+	if err != nil {
+		return err
+	}
+
+	restored := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &restored); err != nil {
+		return fmt.Errorf("invalid backup payload: %s", err)
+	}
+	checksum := restored[STATE_CHECKSUM]
+	delete(restored, STATE_CHECKSUM)
+	expectedChecksum, err := stateChecksum(restored)
+	if err != nil {
+		return err
+	}
+	if checksum != expectedChecksum {
+		return fmt.Errorf("invalid backup payload: checksum mismatch")
+	}
+	if restored[NETWORK] != backup.Network {
+		return fmt.Errorf("invalid backup file: network mismatch")
+	}
+
+	// the restored wallet is a new device for the sync.
+	delete(restored, DEVICE_ID)
+
+	history := make([]historyEntry, 0)
+	if len(restored[HISTORY]) > 0 {
+		if err := json.Unmarshal([]byte(restored[HISTORY]), &history); err != nil {
+			return fmt.Errorf("invalid backup history: %s", err)
+		}
+	}
+	labels := make(map[string]string)
+	if len(restored[LABELS]) > 0 {
+		if err := json.Unmarshal([]byte(restored[LABELS]), &labels); err != nil {
+			return fmt.Errorf("invalid backup labels: %s", err)
+		}
+	}
+	delete(restored, HISTORY)
+	delete(restored, LABELS)
+
+	if err := withStateTx(ctx, func(tx *sql.Tx) error {
+		if err := upsertState(tx, restored); err != nil {
+			return err
+		}
+		for _, entry := range history {
+			if err := insertHistoryEntry(tx, entry); err != nil {
+				return err
+			}
+		}
+		return upsertLabels(tx, labels)
+	}); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"network":    restored[NETWORK],
+		"asp_url":    restored[ASP_URL],
+		"created_at": backup.CreatedAt,
+	})
+}
@@ -52,22 +52,30 @@ func balanceAction(ctx *cli.Context) error {
 			ctx, explorer, client, offchainAddr, computeExpiryDetails,
 		)
 		if err != nil {
-			chRes <- balanceRes{0, 0, nil, nil, err}
+			chRes <- balanceRes{err: err}
 			return
 		}
 
-		chRes <- balanceRes{balance, 0, nil, amountByExpiration, nil}
+		chRes <- balanceRes{
+			offchainBalance:             &balance,
+			offchainBalanceByExpiration: amountByExpiration,
+		}
 	}()
 
 	go func() {
 		defer wg.Done()
 		explorer := NewExplorer(ctx)
-		balance, err := explorer.GetBalance(onchainAddr, network.AssetID)
+		confirmed, unconfirmed, err := explorer.GetBalance(
+			onchainAddr, network.AssetID,
+		)
 		if err != nil {
-			chRes <- balanceRes{0, 0, nil, nil, err}
+			chRes <- balanceRes{err: err}
 			return
 		}
-		chRes <- balanceRes{0, balance, nil, nil, nil}
+		chRes <- balanceRes{
+			onchainSpendableBalance:   confirmed + unconfirmed,
+			onchainUnconfirmedBalance: unconfirmed,
+		}
 	}()
 
 	go func() {
@@ -78,30 +86,36 @@ func balanceAction(ctx *cli.Context) error {
 			redemptionAddr, unilateralExitDelay,
 		)
 		if err != nil {
-			chRes <- balanceRes{0, 0, nil, nil, err}
+			chRes <- balanceRes{err: err}
 			return
 		}
 
-		chRes <- balanceRes{0, spendableBalance, lockedBalance, nil, err}
+		chRes <- balanceRes{
+			onchainSpendableBalance: spendableBalance,
+			onchainRedeemedBalance:  spendableBalance,
+			onchainLockedBalance:    lockedBalance,
+		}
 	}()
 
 	wg.Wait()
 
 	lockedOnchainBalance := []map[string]interface{}{}
 	details := make([]map[string]interface{}, 0)
-	offchainBalance, onchainBalance := uint64(0), uint64(0)
+	var offchain offchainBalance
+	onchainBalance, onchainUnconfirmedBalance := uint64(0), uint64(0)
+	onchainRedeemedBalance, onchainLockedTotal := uint64(0), uint64(0)
 	nextExpiration := int64(0)
 	count := 0
 	for res := range chRes {
 		if res.err != nil {
 			return res.err
 		}
-		if res.offchainBalance > 0 {
-			offchainBalance = res.offchainBalance
-		}
-		if res.onchainSpendableBalance > 0 {
-			onchainBalance += res.onchainSpendableBalance
+		if res.offchainBalance != nil {
+			offchain = *res.offchainBalance
 		}
+		onchainBalance += res.onchainSpendableBalance
+		onchainUnconfirmedBalance += res.onchainUnconfirmedBalance
+		onchainRedeemedBalance += res.onchainRedeemedBalance
 		if res.offchainBalanceByExpiration != nil {
 			for timestamp, amount := range res.offchainBalanceByExpiration {
 				if nextExpiration == 0 || timestamp < nextExpiration {
@@ -120,6 +134,7 @@ func balanceAction(ctx *cli.Context) error {
 		}
 		if res.onchainLockedBalance != nil {
 			for timestamp, amount := range res.onchainLockedBalance {
+				onchainLockedTotal += amount
 				fancyTime := time.Unix(timestamp, 0).Format("2006-01-02 15:04:05")
 				lockedOnchainBalance = append(
 					lockedOnchainBalance,
@@ -139,7 +154,14 @@ func balanceAction(ctx *cli.Context) error {
 
 	response := make(map[string]interface{})
 	response["onchain_balance"] = map[string]interface{}{
-		"spendable_amount": onchainBalance,
+		"spendable_amount":   onchainBalance,
+		"confirmed_amount":   onchainBalance - onchainUnconfirmedBalance,
+		"unconfirmed_amount": onchainUnconfirmedBalance,
+		// confirmed funds of the onchain address, spendable without any delay.
+		"trusted_amount": onchainBalance - onchainUnconfirmedBalance - onchainRedeemedBalance,
+		// funds of unilaterally redeemed vtxos whose exit delay expired.
+		"delayed_amount":      onchainRedeemedBalance,
+		"locked_total_amount": onchainLockedTotal,
 	}
 
 	if len(lockedOnchainBalance) > 0 {
@@ -147,7 +169,17 @@ func balanceAction(ctx *cli.Context) error {
 	}
 
 	offchainBalanceJSON := map[string]interface{}{
-		"total": offchainBalance,
+		"total":                  offchain.total,
+		"spendable_amount":       offchain.spendable,
+		"locked_in_round_amount": offchain.lockedInRound,
+		"pending_amount":         offchain.pending,
+		"unconfirmed_amount":     offchain.unconfirmed,
+		"expiry_buckets": map[string]interface{}{
+			"within_24h":     offchain.expiringWithinDay,
+			"within_7d":      offchain.expiringWithinWeek,
+			"later":          offchain.expiringLater,
+			"unknown_expiry": offchain.unknownExpiry,
+		},
 	}
 
 	fancyTimeExpiration := ""
@@ -178,14 +210,37 @@ func balanceAction(ctx *cli.Context) error {
 
 	offchainBalanceJSON["details"] = details
 
+	if len(offchain.labeled) > 0 {
+		offchainBalanceJSON["labeled_vtxos"] = offchain.labeled
+	}
+
 	response["offchain_balance"] = offchainBalanceJSON
 
+	prices, currency, err := getPriceProvider(ctx)
+	if err != nil {
+		return err
+	}
+	if prices != nil {
+		// the balance is shown anyway if the price feed is down.
+		rate, err := prices.getCurrentPrice(currency)
+		if err != nil {
+			logger.Warn("failed to fetch the fiat price", "err", err)
+		} else {
+			response["fiat"] = map[string]interface{}{
+				"onchain":  newFiatValue(currency, rate, onchainBalance),
+				"offchain": newFiatValue(currency, rate, offchain.total),
+			}
+		}
+	}
+
 	return printJSON(response)
 }
 
 type balanceRes struct {
-	offchainBalance             uint64
+	offchainBalance             *offchainBalance
 	onchainSpendableBalance     uint64
+	onchainUnconfirmedBalance   uint64
+	onchainRedeemedBalance      uint64
 	onchainLockedBalance        map[int64]uint64
 	offchainBalanceByExpiration map[int64]uint64
 	err                         error
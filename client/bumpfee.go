@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+// rbfSequence is the highest sequence signaling replaceability as per BIP125.
+const rbfSequence = 0xfffffffd
+
+var bumpFeeCommand = cli.Command{
+	Name:      "bumpfee",
+	Usage:     "Replace an unconfirmed onchain tx of the wallet with one paying a higher fee rate, spending the same inputs. The fee increase is taken from the change",
+	ArgsUsage: "<txid>",
+	Action:    withWalletLock(bumpFeeAction),
+	Flags:     []cli.Flag{&passwordFlag, &satPerVByteFlag},
+}
+
+func bumpFeeAction(ctx *cli.Context) error {
+	txid := ctx.Args().First()
+	if len(txid) <= 0 {
+		return fmt.Errorf("missing txid")
+	}
+	if !ctx.IsSet(satPerVByteFlag.Name) {
+		return fmt.Errorf("missing --sat-per-vbyte")
+	}
+	feeRate := ctx.Float64(satPerVByteFlag.Name)
+
+	confirmed, _, err := getTxBlocktime(ctx, txid)
+	if err != nil {
+		return err
+	}
+	if confirmed {
+		return fmt.Errorf("tx %s is already confirmed", txid)
+	}
+
+	explorer := NewExplorer(ctx)
+	txHex, err := explorer.GetTxHex(txid)
+	if err != nil {
+		return err
+	}
+	tx, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return err
+	}
+
+	_, onchainAddr, redemptionAddr, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	changeScript, err := address.ToOutputScript(onchainAddr)
+	if err != nil {
+		return err
+	}
+	redemptionScript, err := address.ToOutputScript(redemptionAddr)
+	if err != nil {
+		return err
+	}
+
+	// the inputs must all be owned by the wallet to sign the replacement.
+	utxos, delayedUtxos := make([]utxo, 0), make([]utxo, 0)
+	for _, in := range tx.Inputs {
+		if in.Sequence > rbfSequence {
+			return fmt.Errorf("tx %s doesn't signal replaceability", txid)
+		}
+
+		prevTxid := elementsutil.TxIDFromBytes(in.Hash)
+		prevTxHex, err := explorer.GetTxHex(prevTxid)
+		if err != nil {
+			return err
+		}
+		prevTx, err := transaction.NewTxFromHex(prevTxHex)
+		if err != nil {
+			return err
+		}
+		prevout := prevTx.Outputs[in.Index]
+		if prevout.IsConfidential() {
+			return fmt.Errorf("tx %s spends confidential outputs", txid)
+		}
+		amount, err := elementsutil.ValueFromBytes(prevout.Value)
+		if err != nil {
+			return err
+		}
+
+		u := utxo{
+			Txid:   prevTxid,
+			Vout:   in.Index,
+			Amount: amount,
+			Asset:  elementsutil.AssetHashFromBytes(prevout.Asset),
+		}
+		switch {
+		case bytes.Equal(prevout.Script, changeScript):
+			utxos = append(utxos, u)
+		case bytes.Equal(prevout.Script, redemptionScript):
+			delayedUtxos = append(delayedUtxos, u)
+		default:
+			return fmt.Errorf("tx %s spends inputs not owned by the wallet", txid)
+		}
+	}
+
+	_, net := getNetwork(ctx)
+	pset, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return err
+	}
+	if err := addInputs(ctx, updater, utxos, delayedUtxos, net); err != nil {
+		return err
+	}
+
+	oldFee := uint64(0)
+	changeIndex := -1
+	for _, out := range tx.Outputs {
+		if out.IsConfidential() {
+			return fmt.Errorf("tx %s has confidential outputs", txid)
+		}
+		amount, err := elementsutil.ValueFromBytes(out.Value)
+		if err != nil {
+			return err
+		}
+		if len(out.Script) <= 0 {
+			oldFee += amount
+			continue
+		}
+		if bytes.Equal(out.Script, changeScript) {
+			changeIndex = len(updater.Pset.Outputs)
+		}
+		if err := updater.AddOutputs([]psetv2.OutputArgs{
+			{
+				Asset:  elementsutil.AssetHashFromBytes(out.Asset),
+				Amount: amount,
+				Script: out.Script,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	if changeIndex < 0 {
+		return fmt.Errorf("tx %s has no change to pay the fee increase with", txid)
+	}
+
+	newFee, err := estimateOnchainFees(updater.Pset, feeRate)
+	if err != nil {
+		return err
+	}
+	// the replacement must also pay for its own relay (BIP125 rule 4).
+	minIncrement, err := estimateOnchainFees(updater.Pset, minFeeRate)
+	if err != nil {
+		return err
+	}
+	if newFee < oldFee+minIncrement {
+		return fmt.Errorf(
+			"fee rate too low, the new fee %d must be at least %d",
+			newFee, oldFee+minIncrement,
+		)
+	}
+
+	change := updater.Pset.Outputs[changeIndex].Value
+	increase := newFee - oldFee
+	if change < increase+DUST {
+		return fmt.Errorf(
+			"change %d not enough to pay the fee increase %d", change, increase,
+		)
+	}
+	updater.Pset.Outputs[changeIndex].Value = change - increase
+
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: newFee,
+		},
+	}); err != nil {
+		return err
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+	if err := signer.signPset(ctx, updater.Pset, explorer); err != nil {
+		return err
+	}
+	if err := psetv2.FinalizeAll(updater.Pset); err != nil {
+		return err
+	}
+
+	b64, err := updater.Pset.ToBase64()
+	if err != nil {
+		return err
+	}
+	newTxid, err := broadcast(ctx, explorer, b64)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceHistoryTx(ctx, txid, newTxid, newFee); err != nil {
+		logger.Warn("failed to update the history", "txid", txid, "err", err)
+	}
+
+	return printJSON(map[string]interface{}{
+		"replaced_txid": txid,
+		"txid":          newTxid,
+		"old_fee":       oldFee,
+		"fee":           newFee,
+	})
+}
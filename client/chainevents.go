@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// chainEvents are the onboardings and exits already notified by the daemon,
+// persisted not to notify them again after a restart.
+type chainEvents struct {
+	// ConfirmedOnboards are the boarding txs seen confirmed.
+	ConfirmedOnboards map[string]struct{} `json:"confirmed_onboards"`
+	// ConfirmedExit and ClaimableExit are the start times of the exit whose
+	// txs got confirmed, and that became claimable.
+	ConfirmedExit int64 `json:"confirmed_exit"`
+	ClaimableExit int64 `json:"claimable_exit"`
+}
+
+// checkChainEvents notifies the onboardings and the exits in progress that
+// progressed onchain since the last check.
+func checkChainEvents(ctx *cli.Context, hook *webhook) error {
+	events, err := getChainEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := getOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the outbox keeps the latest onboardings, the older ones are forgotten.
+	onboards := make(map[string]struct{})
+	for _, e := range entries {
+		if e.Kind != outboxOnboard || e.Status == outboxFailed {
+			continue
+		}
+		onboards[e.Ref] = struct{}{}
+		if _, ok := events.ConfirmedOnboards[e.Ref]; ok {
+			continue
+		}
+
+		// the tx might not be propagated yet, errors are just retried.
+		confirmed, blocktime, err := getTxBlocktime(ctx, e.Ref)
+		if err != nil || !confirmed {
+			continue
+		}
+		notifyEvent(hook, map[string]interface{}{
+			"event":        "onboard_confirmed",
+			"txid":         e.Ref,
+			"confirmed_at": time.Unix(blocktime, 0).Format("2006-01-02 15:04:05"),
+		})
+		events.ConfirmedOnboards[e.Ref] = struct{}{}
+	}
+	for txid := range events.ConfirmedOnboards {
+		if _, ok := onboards[txid]; !ok {
+			delete(events.ConfirmedOnboards, txid)
+		}
+	}
+
+	if err := checkExitEvents(ctx, hook, events); err != nil {
+		return err
+	}
+
+	return setChainEvents(ctx, events)
+}
+
+func checkExitEvents(ctx *cli.Context, hook *webhook, events *chainEvents) error {
+	exit, err := getPendingExit(ctx)
+	if err != nil {
+		return err
+	}
+	if exit == nil {
+		events.ConfirmedExit, events.ClaimableExit = 0, 0
+		return nil
+	}
+	if events.ClaimableExit == exit.StartedAt {
+		return nil
+	}
+
+	unilateralExitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return err
+	}
+	claimableAt, err := getExitClaimableTime(ctx, exit, unilateralExitDelay)
+	if err != nil {
+		return err
+	}
+	if claimableAt == nil {
+		return nil
+	}
+
+	if events.ConfirmedExit != exit.StartedAt {
+		notifyEvent(hook, map[string]interface{}{
+			"event":        "exit_confirmed",
+			"txids":        exit.Txids,
+			"amount":       exit.Amount,
+			"claimable_at": claimableAt.Format("2006-01-02 15:04:05"),
+		})
+		events.ConfirmedExit = exit.StartedAt
+	}
+	if claimableAt.After(time.Now()) {
+		return nil
+	}
+
+	notifyEvent(hook, map[string]interface{}{
+		"event":      "exit_claimable",
+		"txids":      exit.Txids,
+		"amount":     exit.Amount,
+		"started_at": time.Unix(exit.StartedAt, 0).Format("2006-01-02 15:04:05"),
+	})
+	events.ClaimableExit = exit.StartedAt
+	return nil
+}
+
+func getChainEvents(ctx *cli.Context) (*chainEvents, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := &chainEvents{}
+	if len(state[CHAIN_EVENTS]) > 0 {
+		if err := json.Unmarshal([]byte(state[CHAIN_EVENTS]), events); err != nil {
+			return nil, fmt.Errorf("invalid chain events: %s", err)
+		}
+	}
+	if events.ConfirmedOnboards == nil {
+		events.ConfirmedOnboards = make(map[string]struct{})
+	}
+	return events, nil
+}
+
+func setChainEvents(ctx *cli.Context, events *chainEvents) error {
+	buf, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{CHAIN_EVENTS: string(buf)})
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var (
+	newPasswordFlag = cli.StringFlag{
+		Name:   "new-password",
+		Usage:  "new password to encrypt the wallet key with",
+		Hidden: true,
+	}
+	kdfFlag = cli.StringFlag{
+		Name:  "kdf",
+		Usage: "function deriving the encryption key from the new password (argon2id, scrypt)",
+		Value: kdfArgon2id,
+	}
+	argon2TimeFlag = cli.UintFlag{
+		Name:  "argon2-time",
+		Usage: "number of passes of argon2id",
+		Value: uint(defaultArgon2idKDF.Time),
+	}
+	argon2MemoryFlag = cli.UintFlag{
+		Name:  "argon2-memory",
+		Usage: "memory used by argon2id, in KiB",
+		Value: uint(defaultArgon2idKDF.Memory),
+	}
+	argon2ThreadsFlag = cli.UintFlag{
+		Name:  "argon2-threads",
+		Usage: "degree of parallelism of argon2id",
+		Value: uint(defaultArgon2idKDF.Threads),
+	}
+)
+
+var changePasswordCommand = cli.Command{
+	Name:   "change-password",
+	Usage:  "Re-encrypt the wallet key with a new password, optionally upgrading the key derivation function",
+	Action: withWalletLock(changePasswordAction),
+	Flags: []cli.Flag{
+		&passwordFlag, &newPasswordFlag, &kdfFlag,
+		&argon2TimeFlag, &argon2MemoryFlag, &argon2ThreadsFlag,
+	},
+}
+
+func changePasswordAction(ctx *cli.Context) error {
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(state[EXTERNAL_SIGNER]) > 0 {
+		return fmt.Errorf("the wallet key is held by the external signer, there's no password")
+	}
+
+	kdf := kdfParams{Name: ctx.String(kdfFlag.Name)}
+	if kdf.Name == kdfArgon2id {
+		if ctx.Uint(argon2ThreadsFlag.Name) > 255 {
+			return fmt.Errorf("invalid argon2 threads, must be at most 255")
+		}
+		kdf.Time = uint32(ctx.Uint(argon2TimeFlag.Name))
+		kdf.Memory = uint32(ctx.Uint(argon2MemoryFlag.Name))
+		kdf.Threads = uint8(ctx.Uint(argon2ThreadsFlag.Name))
+	}
+	if err := kdf.validate(); err != nil {
+		return err
+	}
+
+	privateKey, err := privateKeyFromPassword(ctx)
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readNewPassword(ctx)
+	if err != nil {
+		return err
+	}
+
+	newState, err := encryptPrivateKey(privateKey, newPassword, kdf)
+	if err != nil {
+		return err
+	}
+
+	// make sure the key can be decrypted before replacing the old one.
+	encryptedPrivateKey, err := hex.DecodeString(newState[ENCRYPTED_PRVKEY])
+	if err != nil {
+		return err
+	}
+	decrypted, err := newCypherWithKDF(kdf).decrypt(encryptedPrivateKey, newPassword)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(decrypted, privateKey.Serialize()) {
+		return fmt.Errorf("failed to re-encrypt the wallet key")
+	}
+
+	// all the entries are replaced with a single atomic write of the state.
+	if err := setState(ctx, newState); err != nil {
+		return err
+	}
+
+	fmt.Println("password changed")
+	return nil
+}
+
+func readNewPassword(ctx *cli.Context) ([]byte, error) {
+	if password := ctx.String(newPasswordFlag.Name); len(password) > 0 {
+		return []byte(password), nil
+	}
+
+	fmt.Print("new password: ")
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return nil, err
+	}
+	if len(password) <= 0 {
+		return nil, fmt.Errorf("missing new password")
+	}
+
+	fmt.Print("confirm new password: ")
+	confirmation, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(password, confirmation) {
+		return nil, fmt.Errorf("passwords don't match")
+	}
+	return password, nil
+}
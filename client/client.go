@@ -2,14 +2,11 @@ package main
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type vtxo struct {
@@ -18,6 +15,8 @@ type vtxo struct {
 	vout     uint32
 	poolTxid string
 	expireAt *time.Time
+	// redeemTx is set for the vtxos received out of round, to be claimed.
+	redeemTx string
 }
 
 func getVtxos(
@@ -31,8 +30,45 @@ func getVtxos(
 		return nil, err
 	}
 
+	conflicts, err := reconcileVtxos(ctx, response)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		logger.Warn(
+			"vtxo spent by another device",
+			"outpoint", fmt.Sprintf("%s:%d", conflict.Txid, conflict.Vout),
+			"amount", conflict.Amount, "spent_by", conflict.SpentBy,
+		)
+	}
+
+	// coins being spent by other devices sharing this wallet can't be used.
+	remoteLockedVtxos, err := getRemoteLockedVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the clusters are tracked for all the vtxos of the wallet, the remotely
+	// locked ones included.
+	owned := make([]vtxo, 0, len(response.GetSpendableVtxos()))
+	for _, v := range response.GetSpendableVtxos() {
+		owned = append(owned, vtxo{
+			txid:     v.Outpoint.Txid,
+			vout:     v.Outpoint.Vout,
+			poolTxid: v.PoolTxid,
+			redeemTx: v.GetRedeemTx(),
+		})
+	}
+	if err := trackVtxoClusters(ctx, owned); err != nil {
+		return nil, err
+	}
+
 	vtxos := make([]vtxo, 0, len(response.GetSpendableVtxos()))
 	for _, v := range response.GetSpendableVtxos() {
+		outpoint := fmt.Sprintf("%s:%d", v.Outpoint.Txid, v.Outpoint.Vout)
+		if _, ok := remoteLockedVtxos[outpoint]; ok {
+			continue
+		}
 		var expireAt *time.Time
 		if v.ExpireAt > 0 {
 			t := time.Unix(v.ExpireAt, 0)
@@ -41,12 +77,22 @@ func getVtxos(
 		if v.Swept {
 			continue
 		}
+		if len(v.GetRedeemTx()) > 0 {
+			if err := validateRedeemTx(ctx, v); err != nil {
+				logger.Warn(
+					"ignoring payment received out of round",
+					"outpoint", outpoint, "err", err,
+				)
+				continue
+			}
+		}
 		vtxos = append(vtxos, vtxo{
 			amount:   v.Receiver.Amount,
 			txid:     v.Outpoint.Txid,
 			vout:     v.Outpoint.Vout,
 			poolTxid: v.PoolTxid,
 			expireAt: expireAt,
+			redeemTx: v.GetRedeemTx(),
 		})
 	}
 
@@ -85,21 +131,25 @@ func getClientFromState(ctx *cli.Context) (arkv1.ArkServiceClient, func(), error
 	if len(addr) <= 0 {
 		return nil, nil, fmt.Errorf("missing asp url")
 	}
+	if replSession != nil {
+		return replSession.getClient(addr)
+	}
 	return getClient(addr)
 }
 
 func getClient(addr string) (arkv1.ArkServiceClient, func(), error) {
-	creds := insecure.NewCredentials()
-	port := 80
-	if strings.HasPrefix(addr, "https://") {
-		addr = strings.TrimPrefix(addr, "https://")
-		creds = credentials.NewTLS(nil)
-		port = 443
-	}
-	if !strings.Contains(addr, ":") {
-		addr = fmt.Sprintf("%s:%d", addr, port)
+	creds, addr := aspTransportCredentials(addr)
+	opts, err := proxyDialOptions()
+	if err != nil {
+		return nil, nil, err
 	}
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	opts = append(
+		opts,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(aspRPCPolicy.unaryInterceptor),
+	)
+
+	conn, err := grpc.Dial(addr, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
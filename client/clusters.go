@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+)
+
+// vtxoClusters group the vtxos of the wallet by source. A vtxo received from
+// someone is a cluster of its own, the change of a payment belongs to the
+// cluster of the vtxos spent, which got linked together by the payment.
+type vtxoClusters struct {
+	// ByOutpoint are the clusters of the vtxos tracked so far.
+	ByOutpoint map[string]string `json:"by_outpoint"`
+	// ByPoolTxid are the clusters of the change of the rounds this wallet
+	// took part to, assigned to the vtxos of the round once tracked.
+	ByPoolTxid map[string]string `json:"by_pool_txid"`
+}
+
+// clusterOf returns the cluster of the vtxo with the given outpoint and pool
+// txid, the latter empty for the vtxos received out of round.
+func (c *vtxoClusters) clusterOf(outpoint, poolTxid string) string {
+	if cluster, ok := c.ByOutpoint[outpoint]; ok {
+		return cluster
+	}
+	if cluster, ok := c.ByPoolTxid[poolTxid]; ok && len(poolTxid) > 0 {
+		return cluster
+	}
+	return outpoint
+}
+
+// trackVtxoClusters assigns a cluster to the vtxos of the wallet not tracked
+// yet, and forgets the vtxos not in the list anymore. Like the known vtxos,
+// the clusters are updated only if the wallet is locked.
+func trackVtxoClusters(ctx *cli.Context, vtxos []vtxo) error {
+	if !walletLocked.Load() {
+		return nil
+	}
+
+	clusters, err := getVtxoClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	byOutpoint := make(map[string]string, len(vtxos))
+	consumed := make(map[string]struct{})
+	for _, v := range vtxos {
+		outpoint := fmt.Sprintf("%s:%d", v.txid, v.vout)
+		poolTxid := v.poolTxid
+		if len(v.redeemTx) > 0 {
+			poolTxid = ""
+		}
+		if _, ok := clusters.ByOutpoint[outpoint]; !ok {
+			changed = true
+			if _, ok := clusters.ByPoolTxid[poolTxid]; ok {
+				consumed[poolTxid] = struct{}{}
+			}
+		}
+		byOutpoint[outpoint] = clusters.clusterOf(outpoint, poolTxid)
+	}
+	if len(byOutpoint) != len(clusters.ByOutpoint) {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	for poolTxid := range consumed {
+		delete(clusters.ByPoolTxid, poolTxid)
+	}
+	clusters.ByOutpoint = byOutpoint
+	return setVtxoClusters(ctx, clusters)
+}
+
+// mergeVtxoClusters links the clusters of the vtxos spent by a payment, and
+// assigns the merged cluster to the change of the payment's round.
+func mergeVtxoClusters(ctx *cli.Context, poolTxid string, inputs []string) error {
+	if len(inputs) <= 0 || len(poolTxid) <= 0 {
+		return nil
+	}
+
+	clusters, err := getVtxoClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	cluster := clusters.clusterOf(inputs[0], "")
+	merged := make(map[string]struct{})
+	for _, input := range inputs {
+		merged[clusters.clusterOf(input, "")] = struct{}{}
+	}
+	for _, input := range inputs {
+		delete(clusters.ByOutpoint, input)
+	}
+	for outpoint, c := range clusters.ByOutpoint {
+		if _, ok := merged[c]; ok {
+			clusters.ByOutpoint[outpoint] = cluster
+		}
+	}
+	clusters.ByPoolTxid[poolTxid] = cluster
+
+	return setVtxoClusters(ctx, clusters)
+}
+
+func getVtxoClusters(ctx *cli.Context) (*vtxoClusters, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := &vtxoClusters{}
+	if len(state[VTXO_CLUSTERS]) > 0 {
+		if err := json.Unmarshal([]byte(state[VTXO_CLUSTERS]), clusters); err != nil {
+			return nil, fmt.Errorf("invalid vtxo clusters: %s", err)
+		}
+	}
+	if clusters.ByOutpoint == nil {
+		clusters.ByOutpoint = make(map[string]string)
+	}
+	if clusters.ByPoolTxid == nil {
+		clusters.ByPoolTxid = make(map[string]string)
+	}
+	return clusters, nil
+}
+
+func setVtxoClusters(ctx *cli.Context, clusters *vtxoClusters) error {
+	buf, err := json.Marshal(clusters)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{VTXO_CLUSTERS: string(buf)})
+}
+
+// privacySelector avoids spending together coins of different clusters, not
+// to link their sources. Only if no cluster covers the amount alone, the
+// fewest clusters are combined, the largest first.
+type privacySelector struct {
+	clusters *vtxoClusters
+}
+
+func (s privacySelector) selectCoins(
+	coins []selectionCoin, target, tolerance uint64,
+) []int {
+	byCluster := make(map[string][]int)
+	totals := make(map[string]uint64)
+	names := make([]string, 0)
+	for i, coin := range coins {
+		cluster := s.clusters.clusterOf(coin.outpoint, coin.poolTxid)
+		if _, ok := byCluster[cluster]; !ok {
+			names = append(names, cluster)
+		}
+		byCluster[cluster] = append(byCluster[cluster], i)
+		totals[cluster] += coin.amount
+	}
+
+	// the cluster covering the amount with the smallest excess, changeless if
+	// possible, then with the fewest coins.
+	var best []int
+	bestExcess := uint64(0)
+	for _, name := range names {
+		if totals[name] < target {
+			continue
+		}
+		indexes := byCluster[name]
+		subset := make([]selectionCoin, 0, len(indexes))
+		for _, i := range indexes {
+			subset = append(subset, coins[i])
+		}
+		selected := bnbSelector{}.selectCoins(subset, target, tolerance)
+		if selected == nil {
+			continue
+		}
+
+		sum := uint64(0)
+		for j, i := range selected {
+			selected[j] = indexes[i]
+			sum += coins[indexes[i]].amount
+		}
+		excess := sum - target
+		if best == nil || excess < bestExcess ||
+			(excess == bestExcess && len(selected) < len(best)) {
+			best, bestExcess = selected, excess
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return totals[names[i]] > totals[names[j]]
+	})
+	order := make([]int, 0, len(coins))
+	for _, name := range names {
+		indexes := append([]int{}, byCluster[name]...)
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return coins[indexes[i]].amount > coins[indexes[j]].amount
+		})
+		order = append(order, indexes...)
+	}
+	return accumulate(coins, order, target)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var coinCommand = cli.Command{
+	Name:  "coin",
+	Usage: "Freeze or unfreeze vtxos and utxos, so that the automatic coin selection never spends them",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "lock",
+			Usage:     "Exclude the given coins from the automatic coin selection, they're still spendable with --coins",
+			ArgsUsage: "<txid:vout>...",
+			Action:    withWalletLock(coinLockAction),
+		},
+		{
+			Name:      "unlock",
+			Usage:     "Make the given coins spendable by the automatic coin selection again",
+			ArgsUsage: "<txid:vout>...",
+			Action:    withWalletLock(coinUnlockAction),
+		},
+		{
+			Name:   "list",
+			Usage:  "List the locked coins",
+			Action: coinListAction,
+		},
+	},
+}
+
+type lockedCoin struct {
+	Outpoint string `json:"outpoint"`
+	LockedAt int64  `json:"locked_at"`
+}
+
+// lockedCoins are the coins excluded from the automatic coin selection, by
+// outpoint.
+type lockedCoins map[string]lockedCoin
+
+func (l lockedCoins) has(txid string, vout uint32) bool {
+	_, ok := l[fmt.Sprintf("%s:%d", txid, vout)]
+	return ok
+}
+
+func getLockedCoins(ctx *cli.Context) (lockedCoins, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coins := make(lockedCoins)
+	if len(state[LOCKED_COINS]) <= 0 {
+		return coins, nil
+	}
+	if err := json.Unmarshal([]byte(state[LOCKED_COINS]), &coins); err != nil {
+		return nil, fmt.Errorf("invalid locked coins: %s", err)
+	}
+	return coins, nil
+}
+
+func setLockedCoins(ctx *cli.Context, coins lockedCoins) error {
+	buf, err := json.Marshal(coins)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{LOCKED_COINS: string(buf)})
+}
+
+// parseOutpointArgs returns the normalized outpoints given as arguments.
+func parseOutpointArgs(ctx *cli.Context) ([]string, error) {
+	if ctx.NArg() <= 0 {
+		return nil, fmt.Errorf("missing coin, must be txid:vout")
+	}
+	outpoints := make([]string, 0, ctx.NArg())
+	for _, arg := range ctx.Args().Slice() {
+		outpoint, err := parseOutpoint(arg)
+		if err != nil {
+			return nil, err
+		}
+		outpoints = append(outpoints, outpoint)
+	}
+	return outpoints, nil
+}
+
+func coinLockAction(ctx *cli.Context) error {
+	outpoints, err := parseOutpointArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	coins, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+	for _, outpoint := range outpoints {
+		if _, ok := coins[outpoint]; ok {
+			continue
+		}
+		coins[outpoint] = lockedCoin{outpoint, time.Now().Unix()}
+	}
+	if err := setLockedCoins(ctx, coins); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d coins locked\n", len(outpoints))
+	return nil
+}
+
+func coinUnlockAction(ctx *cli.Context) error {
+	outpoints, err := parseOutpointArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	coins, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+	for _, outpoint := range outpoints {
+		if _, ok := coins[outpoint]; !ok {
+			return fmt.Errorf("coin %s is not locked", outpoint)
+		}
+		delete(coins, outpoint)
+	}
+	if err := setLockedCoins(ctx, coins); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d coins unlocked\n", len(outpoints))
+	return nil
+}
+
+func coinListAction(ctx *cli.Context) error {
+	coins, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+
+	list := make([]lockedCoin, 0, len(coins))
+	for _, coin := range coins {
+		list = append(list, coin)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LockedAt < list[j].LockedAt
+	})
+	return printJSON(list)
+}
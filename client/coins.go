@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/urfave/cli/v2"
+)
+
+var coinsFlag = cli.StringFlag{
+	Name:  "coins",
+	Usage: "comma separated list of vtxos or utxos to spend (txid:vout,...), bypassing the automatic coin selection. All the listed coins are spent",
+}
+
+// getCoins returns the normalized outpoints passed with --coins, if any.
+func getCoins(ctx *cli.Context) ([]string, error) {
+	if len(ctx.String(coinsFlag.Name)) <= 0 {
+		return nil, nil
+	}
+
+	coins := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, str := range strings.Split(ctx.String(coinsFlag.Name), ",") {
+		coin, err := parseOutpoint(str)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[coin]; ok {
+			return nil, fmt.Errorf("duplicated coin %s", coin)
+		}
+		seen[coin] = struct{}{}
+		coins = append(coins, coin)
+	}
+	return coins, nil
+}
+
+// parseOutpoint returns the normalized form of the given txid:vout.
+func parseOutpoint(str string) (string, error) {
+	txid, voutStr, found := strings.Cut(strings.TrimSpace(str), ":")
+	if !found {
+		return "", fmt.Errorf("invalid coin %s, must be txid:vout", str)
+	}
+	if _, err := chainhash.NewHashFromStr(txid); err != nil || len(txid) != 64 {
+		return "", fmt.Errorf("invalid coin %s: invalid txid", str)
+	}
+	vout, err := strconv.ParseUint(voutStr, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid coin %s: invalid vout", str)
+	}
+	return fmt.Sprintf("%s:%d", strings.ToLower(txid), vout), nil
+}
+
+// selectVtxosByOutpoint returns the given vtxos and the change for the given
+// amount, or an error if any of them is not spendable or if they don't cover
+// the amount.
+func selectVtxosByOutpoint(
+	vtxos []vtxo, coins []string, amount uint64,
+) ([]vtxo, uint64, error) {
+	byOutpoint := make(map[string]vtxo)
+	for _, v := range vtxos {
+		byOutpoint[fmt.Sprintf("%s:%d", v.txid, v.vout)] = v
+	}
+
+	selected := make([]vtxo, 0, len(coins))
+	selectedAmount := uint64(0)
+	for _, coin := range coins {
+		v, ok := byOutpoint[coin]
+		if !ok {
+			return nil, 0, fmt.Errorf("vtxo %s not found or not spendable", coin)
+		}
+		selected = append(selected, v)
+		selectedAmount += v.amount
+	}
+
+	if selectedAmount < amount {
+		return nil, 0, fmt.Errorf(
+			"selected coins (%d) don't cover amount %d", selectedAmount, amount,
+		)
+	}
+	return selected, selectedAmount - amount, nil
+}
+
+// selectUtxosByOutpoint is like coinSelectOnchain but spends the given
+// utxos, either owned by the onchain address or redeemed vtxos whose exit
+// delay expired.
+func selectUtxosByOutpoint(
+	ctx *cli.Context, explorer Explorer, coins []string, amount uint64,
+) ([]utxo, []utxo, uint64, error) {
+	_, onchainAddr, redemptionAddr, err := getAddress(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	unilateralExitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	onchainUtxos, err := explorer.GetUtxos(onchainAddr)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	redeemedUtxos, err := explorer.GetUtxos(redemptionAddr)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	byOutpoint := make(map[string]utxo)
+	isDelayed := make(map[string]bool)
+	for _, u := range onchainUtxos {
+		byOutpoint[fmt.Sprintf("%s:%d", u.Txid, u.Vout)] = u
+	}
+	for _, u := range redeemedUtxos {
+		availableAt := time.Unix(u.Status.Blocktime, 0).Add(
+			time.Duration(unilateralExitDelay) * time.Second,
+		)
+		if availableAt.After(time.Now()) {
+			continue
+		}
+		outpoint := fmt.Sprintf("%s:%d", u.Txid, u.Vout)
+		byOutpoint[outpoint] = u
+		isDelayed[outpoint] = true
+	}
+
+	utxos := make([]utxo, 0)
+	delayedUtxos := make([]utxo, 0)
+	selectedAmount := uint64(0)
+	for _, coin := range coins {
+		u, ok := byOutpoint[coin]
+		if !ok {
+			return nil, nil, 0, fmt.Errorf("utxo %s not found or not spendable", coin)
+		}
+		if isDelayed[coin] {
+			delayedUtxos = append(delayedUtxos, u)
+		} else {
+			utxos = append(utxos, u)
+		}
+		selectedAmount += u.Amount
+	}
+
+	if selectedAmount < amount {
+		return nil, nil, 0, fmt.Errorf(
+			"selected coins (%d) don't cover amount %d", selectedAmount, amount,
+		)
+	}
+	return utxos, delayedUtxos, selectedAmount - amount, nil
+}
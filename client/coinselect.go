@@ -0,0 +1,384 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// coin selection strategies.
+const (
+	// coinSelectionAccumulative selects the coins in the order they're listed
+	// until covering the amount.
+	coinSelectionAccumulative = "accumulative"
+	// coinSelectionBnB prefers the coins covering the amount without change,
+	// if any, and falls back to accumulative.
+	coinSelectionBnB = "bnb"
+	// coinSelectionLargestFirst selects the largest coins first, minimizing
+	// the number of inputs.
+	coinSelectionLargestFirst = "largest-first"
+	// coinSelectionOldestExpiry selects the vtxos closer to expiration first.
+	coinSelectionOldestExpiry = "oldest-expiry"
+	// coinSelectionPrivacy avoids combining vtxos from unrelated sources.
+	coinSelectionPrivacy = "privacy"
+)
+
+const (
+	// bnbMaxTries bounds the search of branchAndBound, like in bitcoin core.
+	bnbMaxTries = 100000
+	// p2wpkhInputSize and delayedInputSize are the vsizes of the onchain
+	// inputs, the spending witness included.
+	p2wpkhInputSize  = 41 + (1+1+72+1+33+3)/4
+	delayedInputSize = 41 + (1+1+64+1+70+1+65+3)/4
+	// changeOutputSize is the size of an unconfidential p2wpkh output, that
+	// is asset, value, empty nonce and script.
+	changeOutputSize = 33 + 9 + 1 + 1 + 22
+)
+
+var (
+	minLifetimeFlag = cli.DurationFlag{
+		Name:  "min-lifetime",
+		Usage: "skip the vtxos expiring within this time, so that the payment isn't built on coins that might be swept before the round finalizes. Ignored with --enable-expiry-coinselect",
+		Value: time.Hour,
+	}
+	allowExpiringFlag = cli.BoolFlag{
+		Name:  "allow-expiring",
+		Usage: "select the vtxos expiring within --min-lifetime too",
+	}
+)
+
+var coinSelectionFlag = cli.StringFlag{
+	Name: "coin-selection",
+	Usage: fmt.Sprintf(
+		"strategy to select the coins, one of %s, %s (default), %s, %s or %s",
+		coinSelectionAccumulative, coinSelectionBnB, coinSelectionLargestFirst,
+		coinSelectionOldestExpiry, coinSelectionPrivacy,
+	),
+}
+
+// selectionCoin is a vtxo or an utxo, as seen by a coinSelector.
+type selectionCoin struct {
+	outpoint string
+	amount   uint64
+	// expireAt is set only for the vtxos, if their expiration is computed.
+	expireAt *time.Time
+	// poolTxid is set only for the vtxos received in a round.
+	poolTxid string
+}
+
+// coinSelector is a coin selection strategy.
+type coinSelector interface {
+	// selectCoins returns the indexes of the coins covering the target amount,
+	// or nil if they don't. tolerance is the excess accepted by the strategies
+	// looking for a selection without change.
+	selectCoins(coins []selectionCoin, target, tolerance uint64) []int
+}
+
+// coinSelectVtxos selects the vtxos like coinSelect, skipping those expiring
+// within --min-lifetime unless --allow-expiring is set or the strategy spends
+// the vtxos closer to expiration first on purpose.
+func coinSelectVtxos(
+	ctx *cli.Context, vtxos []vtxo, amount uint64, selector coinSelector,
+	locked lockedCoins,
+) ([]vtxo, uint64, error) {
+	minLifetime := ctx.Duration(minLifetimeFlag.Name)
+	if needsExpiration(selector) || ctx.Bool(allowExpiringFlag.Name) ||
+		minLifetime <= 0 {
+		return coinSelect(vtxos, amount, selector, locked)
+	}
+
+	// the vtxos with unknown expiration are kept.
+	threshold := time.Now().Add(minLifetime)
+	candidates := make([]vtxo, 0, len(vtxos))
+	skippedAmount := uint64(0)
+	for _, v := range vtxos {
+		if v.expireAt != nil && v.expireAt.Before(threshold) &&
+			!locked.has(v.txid, v.vout) {
+			logger.Debug(
+				"skipping expiring vtxo", "txid", v.txid, "vout", v.vout,
+				"expire_at", v.expireAt.Unix(),
+			)
+			skippedAmount += v.amount
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+
+	selected, change, err := coinSelect(candidates, amount, selector, locked)
+	if err != nil && skippedAmount > 0 {
+		return nil, 0, fmt.Errorf(
+			"%s, %d sats in vtxos expiring within %s skipped, use "+
+				"--allow-expiring to spend them or refresh them first",
+			err, skippedAmount, minLifetime,
+		)
+	}
+	return selected, change, err
+}
+
+// getCoinSelector returns the strategy set with --coin-selection, or the
+// oldest-expiry one if --enable-expiry-coinselect is set.
+func getCoinSelector(ctx *cli.Context) (coinSelector, error) {
+	strategy := ctx.String(coinSelectionFlag.Name)
+	if ctx.Bool(enableExpiryCoinselectFlag.Name) {
+		if len(strategy) > 0 && strategy != coinSelectionOldestExpiry {
+			return nil, fmt.Errorf(
+				"--enable-expiry-coinselect can't be used along with --coin-selection %s",
+				strategy,
+			)
+		}
+		strategy = coinSelectionOldestExpiry
+	}
+	if strategy == coinSelectionPrivacy {
+		clusters, err := getVtxoClusters(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return privacySelector{clusters}, nil
+	}
+	return newCoinSelector(strategy)
+}
+
+func newCoinSelector(strategy string) (coinSelector, error) {
+	switch strategy {
+	case coinSelectionAccumulative:
+		return accumulativeSelector{}, nil
+	case "", coinSelectionBnB:
+		return bnbSelector{}, nil
+	case coinSelectionLargestFirst:
+		return largestFirstSelector{}, nil
+	case coinSelectionOldestExpiry:
+		return oldestExpirySelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %s", strategy)
+	}
+}
+
+// needsExpiration returns whether the strategy relies on the expiration of
+// the vtxos, to be computed when listing them.
+func needsExpiration(selector coinSelector) bool {
+	_, ok := selector.(oldestExpirySelector)
+	return ok
+}
+
+type accumulativeSelector struct{}
+
+func (accumulativeSelector) selectCoins(
+	coins []selectionCoin, target, _ uint64,
+) []int {
+	return accumulate(coins, listOrder(coins), target)
+}
+
+type bnbSelector struct{}
+
+func (bnbSelector) selectCoins(
+	coins []selectionCoin, target, tolerance uint64,
+) []int {
+	values := make([]uint64, 0, len(coins))
+	for _, coin := range coins {
+		values = append(values, coin.amount)
+	}
+	if indexes := branchAndBound(values, target, tolerance); indexes != nil {
+		return indexes
+	}
+	return accumulate(coins, listOrder(coins), target)
+}
+
+type largestFirstSelector struct{}
+
+func (largestFirstSelector) selectCoins(
+	coins []selectionCoin, target, _ uint64,
+) []int {
+	order := listOrder(coins)
+	sort.SliceStable(order, func(i, j int) bool {
+		return coins[order[i]].amount > coins[order[j]].amount
+	})
+	return accumulate(coins, order, target)
+}
+
+type oldestExpirySelector struct{}
+
+func (oldestExpirySelector) selectCoins(
+	coins []selectionCoin, target, _ uint64,
+) []int {
+	order := listOrder(coins)
+	// the coins with unknown expiration come last.
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := coins[order[i]].expireAt, coins[order[j]].expireAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+	return accumulate(coins, order, target)
+}
+
+func listOrder(coins []selectionCoin) []int {
+	order := make([]int, 0, len(coins))
+	for i := range coins {
+		order = append(order, i)
+	}
+	return order
+}
+
+// accumulate selects the coins in the given order until covering the target
+// amount, and returns their indexes or nil if they don't.
+func accumulate(coins []selectionCoin, order []int, target uint64) []int {
+	selected := make([]int, 0)
+	selectedAmount := uint64(0)
+	for _, i := range order {
+		if selectedAmount >= target {
+			break
+		}
+		selected = append(selected, i)
+		selectedAmount += coins[i].amount
+	}
+	if selectedAmount < target {
+		return nil
+	}
+	return selected
+}
+
+// branchAndBound searches, depth first, the subset of the given values whose
+// sum is between target and target+tolerance with the smallest excess. It
+// returns the indexes of the selected values, or nil if there is none.
+func branchAndBound(values []uint64, target, tolerance uint64) []int {
+	order := make([]int, 0, len(values))
+	available := uint64(0)
+	for i, value := range values {
+		if value > 0 {
+			order = append(order, i)
+			available += value
+		}
+	}
+	if target == 0 || available < target {
+		return nil
+	}
+	// trying the largest values first reaches the target sooner.
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	var best []int
+	bestExcess := uint64(0)
+	tries := 0
+	selected := make([]int, 0, len(order))
+
+	// search returns true to stop, once an exact match is found or the tries
+	// are exhausted.
+	var search func(depth int, sum, remaining uint64) bool
+	search = func(depth int, sum, remaining uint64) bool {
+		tries++
+		if tries > bnbMaxTries || sum > target+tolerance {
+			return tries > bnbMaxTries
+		}
+		if sum >= target {
+			if excess := sum - target; best == nil || excess < bestExcess {
+				best = append([]int{}, selected...)
+				bestExcess = excess
+			}
+			return bestExcess == 0
+		}
+		if depth >= len(order) || sum+remaining < target {
+			return false
+		}
+
+		value := values[order[depth]]
+		selected = append(selected, order[depth])
+		if search(depth+1, sum+value, remaining-value) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+		return search(depth+1, sum, remaining-value)
+	}
+	search(0, 0, available)
+
+	return best
+}
+
+// coinSelectOnchainChangeless selects the utxos covering the given amount
+// plus the fees of the given tx, without change output, once they're added
+// as inputs, along with the total fee paid by the tx like selectChangeless.
+// No utxos are returned if there's no such selection.
+func coinSelectOnchainChangeless(
+	ctx *cli.Context, explorer Explorer, pset *psetv2.Pset, amount uint64,
+	feeRate float64,
+) ([]utxo, []utxo, uint64, error) {
+	utxos, delayedUtxos, err := getSpendableUtxos(ctx, explorer, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	baseFee, err := estimateOnchainFees(pset, feeRate)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	selected, delayedSelected, fee := selectChangeless(
+		utxos, delayedUtxos, amount, baseFee, feeRate,
+	)
+	return selected, delayedSelected, fee, nil
+}
+
+// selectChangeless selects the utxos covering the given amount, the base fee
+// of the tx and the fees to spend them. It returns the total fee paid by the
+// tx, that is the selected amount minus the given one: the excess over the
+// estimated fees goes to the fees too, and it's less than the cost of adding
+// and later spending a change output.
+func selectChangeless(
+	utxos, delayedUtxos []utxo, amount, baseFee uint64, feeRate float64,
+) ([]utxo, []utxo, uint64) {
+	inputFee := func(size int) uint64 {
+		return uint64(math.Ceil(float64(size) * feeRate))
+	}
+
+	// the coins are weighted by their effective value, that is their amount
+	// minus the fee to spend them.
+	candidates := append(append([]utxo{}, utxos...), delayedUtxos...)
+	values := make([]uint64, 0, len(candidates))
+	for i, u := range candidates {
+		fee := inputFee(p2wpkhInputSize)
+		if i >= len(utxos) {
+			fee = inputFee(delayedInputSize)
+		}
+		value := uint64(0)
+		if u.Amount > fee {
+			value = u.Amount - fee
+		}
+		values = append(values, value)
+	}
+
+	tolerance := inputFee(changeOutputSize + p2wpkhInputSize)
+	indexes := branchAndBound(values, amount+baseFee, tolerance)
+	if indexes == nil {
+		return nil, nil, 0
+	}
+
+	selected, delayedSelected := make([]utxo, 0), make([]utxo, 0)
+	selectedAmount := uint64(0)
+	for _, i := range indexes {
+		if i < len(utxos) {
+			selected = append(selected, candidates[i])
+		} else {
+			delayedSelected = append(delayedSelected, candidates[i])
+		}
+		selectedAmount += candidates[i].Amount
+	}
+	return selected, delayedSelected, selectedAmount - amount
+}
+
+func vtxoSelectionCoin(v vtxo) selectionCoin {
+	coin := selectionCoin{
+		outpoint: fmt.Sprintf("%s:%d", v.txid, v.vout),
+		amount:   v.amount,
+		expireAt: v.expireAt,
+	}
+	// the vtxos received out of round don't share the source of the others
+	// of the round.
+	if len(v.redeemTx) <= 0 {
+		coin.poolTxid = v.poolTxid
+	}
+	return coin
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestBranchAndBound(t *testing.T) {
+	values := []uint64{5000, 3000, 2000, 1500}
+
+	fixtures := []struct {
+		name      string
+		target    uint64
+		tolerance uint64
+		expected  []int
+	}{
+		{
+			name:     "exact_match",
+			target:   4500,
+			expected: []int{1, 3},
+		},
+		{
+			name:      "smallest_excess",
+			target:    4400,
+			tolerance: 700,
+			expected:  []int{1, 3},
+		},
+		{
+			name:      "no_solution_within_tolerance",
+			target:    4600,
+			tolerance: 100,
+		},
+		{
+			name:      "insufficient_funds",
+			target:    20000,
+			tolerance: 1000,
+		},
+		{
+			name:   "zero_target",
+			target: 0,
+		},
+	}
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			indexes := branchAndBound(values, f.target, f.tolerance)
+			sort.Ints(indexes)
+			if !reflect.DeepEqual(indexes, f.expected) {
+				t.Fatalf("expected %v, got %v", f.expected, indexes)
+			}
+			if indexes == nil {
+				return
+			}
+
+			sum := uint64(0)
+			for _, i := range indexes {
+				sum += values[i]
+			}
+			if sum < f.target || sum-f.target > f.tolerance {
+				t.Fatalf(
+					"expected sum between %d and %d, got %d",
+					f.target, f.target+f.tolerance, sum,
+				)
+			}
+		})
+	}
+}
+
+func TestSelectChangeless(t *testing.T) {
+	const (
+		amount  = uint64(10000)
+		baseFee = uint64(200)
+		feeRate = 1.0
+	)
+	// the fees to spend the utxos at 1 sat/vbyte, and the cost of a change
+	// output bounding the excess that goes to the fees.
+	inputFee := uint64(p2wpkhInputSize)
+	delayedInputFee := uint64(delayedInputSize)
+	maxWaste := uint64(changeOutputSize + p2wpkhInputSize)
+
+	fixtures := []struct {
+		name         string
+		utxos        []utxo
+		delayedUtxos []utxo
+		expected     []string
+		expectedFee  uint64
+	}{
+		{
+			name: "changeless_utxo",
+			utxos: []utxo{
+				{Txid: "a", Amount: 20000},
+				{Txid: "b", Amount: amount + baseFee + inputFee + 32},
+				{Txid: "c", Amount: 5000},
+			},
+			expected:    []string{"b"},
+			expectedFee: baseFee + inputFee + 32,
+		},
+		{
+			name:  "changeless_delayed_utxo",
+			utxos: []utxo{{Txid: "a", Amount: 20000}},
+			delayedUtxos: []utxo{
+				{Txid: "b", Amount: amount + baseFee + delayedInputFee + 108},
+			},
+			expected:    []string{"b"},
+			expectedFee: baseFee + delayedInputFee + 108,
+		},
+		{
+			name: "no_solution",
+			utxos: []utxo{
+				{Txid: "a", Amount: 20000},
+				{Txid: "b", Amount: 5000},
+				{Txid: "c", Amount: 3000},
+			},
+		},
+		{
+			name: "excess_above_change_cost",
+			utxos: []utxo{
+				{Txid: "a", Amount: amount + baseFee + inputFee + maxWaste + 1},
+			},
+		},
+	}
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			selected, delayedSelected, fee := selectChangeless(
+				f.utxos, f.delayedUtxos, amount, baseFee, feeRate,
+			)
+			if f.expected == nil {
+				if len(selected)+len(delayedSelected) > 0 || fee != 0 {
+					t.Fatalf(
+						"expected no selection, got %v %v with fee %d",
+						selected, delayedSelected, fee,
+					)
+				}
+				return
+			}
+
+			txids := make([]string, 0)
+			spendFee := uint64(0)
+			for _, u := range selected {
+				txids = append(txids, u.Txid)
+				spendFee += inputFee
+			}
+			for _, u := range delayedSelected {
+				txids = append(txids, u.Txid)
+				spendFee += delayedInputFee
+			}
+			if !reflect.DeepEqual(txids, f.expected) {
+				t.Fatalf("expected %v, got %v", f.expected, txids)
+			}
+			if fee != f.expectedFee {
+				t.Fatalf("expected fee %d, got %d", f.expectedFee, fee)
+			}
+			if waste := fee - baseFee - spendFee; waste >= maxWaste {
+				t.Fatalf("expected waste below %d, got %d", maxWaste, waste)
+			}
+		})
+	}
+}
+
+func TestBnBSelectorFallback(t *testing.T) {
+	coins := []selectionCoin{
+		{outpoint: "a:0", amount: 20000},
+		{outpoint: "b:0", amount: 5000},
+		{outpoint: "c:0", amount: 3000},
+	}
+
+	selector := bnbSelector{}
+
+	// no subset matches 4600 exactly, the coins are accumulated in the order
+	// they're listed instead.
+	indexes := selector.selectCoins(coins, 4600, 0)
+	if expected := []int{0}; !reflect.DeepEqual(indexes, expected) {
+		t.Fatalf("expected %v, got %v", expected, indexes)
+	}
+
+	indexes = selector.selectCoins(coins, 8000, 0)
+	if expected := []int{1, 2}; !reflect.DeepEqual(indexes, expected) {
+		t.Fatalf("expected %v, got %v", expected, indexes)
+	}
+
+	if indexes := selector.selectCoins(coins, 30000, 0); indexes != nil {
+		t.Fatalf("expected no selection, got %v", indexes)
+	}
+}
+
+func TestCoinSelect(t *testing.T) {
+	now := time.Now()
+	expireIn := func(d time.Duration) *time.Time {
+		expireAt := now.Add(d)
+		return &expireAt
+	}
+	vtxos := []vtxo{
+		{txid: "a", amount: 1000, poolTxid: "p1", expireAt: expireIn(time.Hour)},
+		{txid: "b", amount: 5000, poolTxid: "p2", expireAt: expireIn(3 * time.Hour)},
+		{txid: "c", amount: 2000, poolTxid: "p1"},
+		{txid: "d", amount: 3500, poolTxid: "p3", expireAt: expireIn(2 * time.Hour)},
+	}
+	// a and c have been linked already by a payment.
+	privacy := privacySelector{&vtxoClusters{
+		ByOutpoint: map[string]string{"a:0": "x", "c:0": "x"},
+		ByPoolTxid: map[string]string{},
+	}}
+
+	fixtures := []struct {
+		name           string
+		selector       coinSelector
+		amount         uint64
+		locked         lockedCoins
+		expected       []string
+		expectedChange uint64
+	}{
+		{
+			name:     "bnb_exact_match",
+			selector: bnbSelector{},
+			amount:   4500,
+			expected: []string{"d", "a"},
+		},
+		{
+			name:           "bnb_fallback",
+			selector:       bnbSelector{},
+			amount:         4800,
+			expected:       []string{"a", "b"},
+			expectedChange: 1200,
+		},
+		{
+			name:           "accumulative",
+			selector:       accumulativeSelector{},
+			amount:         4500,
+			expected:       []string{"a", "b"},
+			expectedChange: 1500,
+		},
+		{
+			name:           "accumulative_locked",
+			selector:       accumulativeSelector{},
+			amount:         4500,
+			locked:         lockedCoins{"a:0": {Outpoint: "a:0"}},
+			expected:       []string{"b"},
+			expectedChange: 500,
+		},
+		{
+			name:           "largest_first",
+			selector:       largestFirstSelector{},
+			amount:         4500,
+			expected:       []string{"b"},
+			expectedChange: 500,
+		},
+		{
+			name:     "oldest_expiry_exact_match",
+			selector: oldestExpirySelector{},
+			amount:   4500,
+			expected: []string{"a", "d"},
+		},
+		{
+			name:           "oldest_expiry_unknown_last",
+			selector:       oldestExpirySelector{},
+			amount:         10000,
+			expected:       []string{"a", "d", "b", "c"},
+			expectedChange: 1500,
+		},
+		{
+			name:     "privacy_single_cluster",
+			selector: privacy,
+			amount:   3000,
+			expected: []string{"c", "a"},
+		},
+		{
+			name:           "privacy_fewest_clusters",
+			selector:       privacy,
+			amount:         9000,
+			expected:       []string{"b", "d", "c"},
+			expectedChange: 1500,
+		},
+		{
+			name:           "dust_change",
+			selector:       accumulativeSelector{},
+			amount:         5800,
+			expected:       []string{"a", "b", "c"},
+			expectedChange: 2200,
+		},
+	}
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			selected, change, err := coinSelect(vtxos, f.amount, f.selector, f.locked)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			txids := make([]string, 0, len(selected))
+			for _, v := range selected {
+				txids = append(txids, v.txid)
+			}
+			if !reflect.DeepEqual(txids, f.expected) {
+				t.Fatalf("expected %v, got %v", f.expected, txids)
+			}
+			if change != f.expectedChange {
+				t.Fatalf("expected change %d, got %d", f.expectedChange, change)
+			}
+		})
+	}
+
+	t.Run("insufficient_funds", func(t *testing.T) {
+		selectors := []coinSelector{
+			bnbSelector{}, accumulativeSelector{}, largestFirstSelector{},
+			oldestExpirySelector{}, privacy,
+		}
+		for _, selector := range selectors {
+			if _, _, err := coinSelect(vtxos, 20000, selector, nil); err == nil {
+				t.Fatalf("expected error with %T", selector)
+			}
+		}
+	})
+}
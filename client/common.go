@@ -8,16 +8,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
-	"sort"
+	"os"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/urfave/cli/v2"
@@ -76,10 +78,10 @@ func readPassword(ctx *cli.Context, verify bool) ([]byte, error) {
 	password := []byte(ctx.String("password"))
 
 	if len(password) == 0 {
-		fmt.Print("unlock your wallet with password: ")
+		fmt.Fprint(os.Stderr, "unlock your wallet with password: ")
 		var err error
 		password, err = term.ReadPassword(int(syscall.Stdin))
-		fmt.Println() // new line
+		fmt.Fprintln(os.Stderr) // new line
 		if err != nil {
 			return nil, err
 		}
@@ -96,6 +98,11 @@ func readPassword(ctx *cli.Context, verify bool) ([]byte, error) {
 }
 
 func privateKeyFromPassword(ctx *cli.Context) (*secp256k1.PrivateKey, error) {
+	// the repl unlocked the wallet already.
+	if replSession != nil && replSession.prvKey != nil {
+		return replSession.prvKey, nil
+	}
+
 	state, err := getState(ctx)
 	if err != nil {
 		return nil, err
@@ -111,13 +118,18 @@ func privateKeyFromPassword(ctx *cli.Context) (*secp256k1.PrivateKey, error) {
 		return nil, fmt.Errorf("invalid encrypted private key: %s", err)
 	}
 
+	kdf, err := parseKDFParams(state[PRVKEY_KDF])
+	if err != nil {
+		return nil, err
+	}
+
 	password, err := readPassword(ctx, true)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("wallet unlocked")
+	logger.Info("wallet unlocked")
 
-	cypher := newAES128Cypher()
+	cypher := newCypherWithKDF(kdf)
 	privateKeyBytes, err := cypher.decrypt(encryptedPrivateKey, password)
 	if err != nil {
 		return nil, err
@@ -127,6 +139,33 @@ func privateKeyFromPassword(ctx *cli.Context) (*secp256k1.PrivateKey, error) {
 	return privateKey, nil
 }
 
+// encryptPrivateKey returns the state entries of the given key encrypted with
+// the given password and kdf.
+func encryptPrivateKey(
+	privateKey *secp256k1.PrivateKey, password []byte, kdf kdfParams,
+) (map[string]string, error) {
+	if err := kdf.validate(); err != nil {
+		return nil, err
+	}
+	kdfJSON, err := json.Marshal(kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedPrivateKey, err := newCypherWithKDF(kdf).encrypt(
+		privateKey.Serialize(), password,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		ENCRYPTED_PRVKEY: hex.EncodeToString(encryptedPrivateKey),
+		PASSWORD_HASH:    hex.EncodeToString(hashPassword(password)),
+		PRVKEY_KDF:       string(kdfJSON),
+	}, nil
+}
+
 func getWalletPublicKey(ctx *cli.Context) (*secp256k1.PublicKey, error) {
 	state, err := getState(ctx)
 	if err != nil {
@@ -202,61 +241,145 @@ func getUnilateralExitDelay(ctx *cli.Context) (int64, error) {
 	return int64(redeemDelay), nil
 }
 
-func coinSelect(vtxos []vtxo, amount uint64, sortByExpirationTime bool) ([]vtxo, uint64, error) {
-	selected := make([]vtxo, 0)
-	notSelected := make([]vtxo, 0)
-	selectedAmount := uint64(0)
-
-	if sortByExpirationTime {
-		// sort vtxos by expiration (older first)
-		sort.SliceStable(vtxos, func(i, j int) bool {
-			if vtxos[i].expireAt == nil || vtxos[j].expireAt == nil {
-				return false
-			}
-
-			return vtxos[i].expireAt.Before(*vtxos[j].expireAt)
-		})
-	}
-
+// coinSelect selects the vtxos to cover the given amount with the given
+// strategy, skipping the locked ones. A change below dust is avoided by
+// adding another vtxo, if any.
+func coinSelect(
+	vtxos []vtxo, amount uint64, selector coinSelector, locked lockedCoins,
+) ([]vtxo, uint64, error) {
+	candidates := make([]vtxo, 0, len(vtxos))
+	coins := make([]selectionCoin, 0, len(vtxos))
 	for _, vtxo := range vtxos {
-		if selectedAmount >= amount {
-			notSelected = append(notSelected, vtxo)
-			break
+		if locked.has(vtxo.txid, vtxo.vout) {
+			continue
 		}
+		candidates = append(candidates, vtxo)
+		coins = append(coins, vtxoSelectionCoin(vtxo))
+	}
 
-		selected = append(selected, vtxo)
-		selectedAmount += vtxo.amount
+	indexes := selector.selectCoins(coins, amount, 0)
+	if indexes == nil {
+		return nil, 0, fmt.Errorf("not enough funds to cover amount %d", amount)
 	}
 
-	if selectedAmount < amount {
-		return nil, 0, fmt.Errorf("not enough funds to cover amount%d", amount)
+	isSelected := make(map[int]bool)
+	selected := make([]vtxo, 0, len(indexes))
+	selectedAmount := uint64(0)
+	for _, i := range indexes {
+		isSelected[i] = true
+		selected = append(selected, candidates[i])
+		selectedAmount += candidates[i].amount
 	}
 
 	change := selectedAmount - amount
-
-	if change < DUST {
-		if len(notSelected) > 0 {
-			selected = append(selected, notSelected[0])
-			change += notSelected[0].amount
+	if change > 0 && change < DUST {
+		// the smallest vtxo bringing the change above dust.
+		extra := -1
+		for i, vtxo := range candidates {
+			if isSelected[i] || change+vtxo.amount < DUST {
+				continue
+			}
+			if extra < 0 || vtxo.amount < candidates[extra].amount {
+				extra = i
+			}
+		}
+		if extra >= 0 {
+			selected = append(selected, candidates[extra])
+			change += candidates[extra].amount
 		}
 	}
 
 	return selected, change, nil
 }
 
+// offchainBalance is the offchain balance broken down by spendability.
+type offchainBalance struct {
+	total uint64
+	// coins that can be spent right away.
+	spendable uint64
+	// coins spent by a payment registered for a round not yet finalized.
+	lockedInRound uint64
+	// coins received out of round, that must be claimed with claim.
+	pending uint64
+	// coins received in a round whose pool tx is not confirmed yet.
+	unconfirmed uint64
+	labeled     []labeledVtxo
+	// buckets of the total by time left before the coins expire and must be
+	// refreshed.
+	expiringWithinDay  uint64
+	expiringWithinWeek uint64
+	expiringLater      uint64
+	unknownExpiry      uint64
+}
+
 func getOffchainBalance(
 	ctx *cli.Context, explorer Explorer, client arkv1.ArkServiceClient,
 	addr string, computeExpiration bool,
-) (uint64, map[int64]uint64, error) {
+) (offchainBalance, map[int64]uint64, error) {
 	amountByExpiration := make(map[int64]uint64, 0)
 
 	vtxos, err := getVtxos(ctx, explorer, client, addr, computeExpiration)
 	if err != nil {
-		return 0, nil, err
+		return offchainBalance{}, nil, err
+	}
+
+	lockedInRound := make(map[string]struct{})
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return offchainBalance{}, nil, err
+	}
+	if round != nil {
+		for _, in := range round.Inputs {
+			lockedInRound[in] = struct{}{}
+		}
+	}
+
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return offchainBalance{}, nil, err
 	}
-	var balance uint64
+
+	confirmedPoolTxs := make(map[string]bool)
+	balance := offchainBalance{labeled: make([]labeledVtxo, 0)}
 	for _, vtxo := range vtxos {
-		balance += vtxo.amount
+		balance.total += vtxo.amount
+
+		outpoint := fmt.Sprintf("%s:%d", vtxo.txid, vtxo.vout)
+		if label := vtxoLabel(labels, vtxo); len(label) > 0 {
+			balance.labeled = append(balance.labeled, labeledVtxo{
+				Outpoint: outpoint,
+				Amount:   vtxo.amount,
+				Label:    label,
+			})
+		}
+		if _, ok := lockedInRound[outpoint]; ok {
+			balance.lockedInRound += vtxo.amount
+		} else if len(vtxo.redeemTx) > 0 {
+			balance.pending += vtxo.amount
+		} else {
+			confirmed, ok := confirmedPoolTxs[vtxo.poolTxid]
+			if !ok {
+				// nolint:all
+				confirmed, _, _ = getTxBlocktime(ctx, vtxo.poolTxid)
+				confirmedPoolTxs[vtxo.poolTxid] = confirmed
+			}
+			if confirmed {
+				balance.spendable += vtxo.amount
+			} else {
+				balance.unconfirmed += vtxo.amount
+			}
+		}
+
+		switch {
+		case vtxo.expireAt == nil:
+			balance.unknownExpiry += vtxo.amount
+		case time.Until(*vtxo.expireAt) < 24*time.Hour:
+			balance.expiringWithinDay += vtxo.amount
+		case time.Until(*vtxo.expireAt) < 7*24*time.Hour:
+			balance.expiringWithinWeek += vtxo.amount
+		default:
+			balance.expiringLater += vtxo.amount
+		}
 
 		if vtxo.expireAt != nil {
 			expiration := vtxo.expireAt.Unix()
@@ -291,36 +414,11 @@ func getTxBlocktime(ctx *cli.Context, txid string) (confirmed bool, blocktime in
 	if err != nil {
 		return false, 0, err
 	}
-	resp, err := http.Get(fmt.Sprintf("%s/tx/%s", baseUrl, txid))
-	if err != nil {
-		return false, 0, err
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	explorer, err := newExplorer(baseUrl)
 	if err != nil {
 		return false, 0, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return false, 0, fmt.Errorf(string(body))
-	}
-
-	var tx struct {
-		Status struct {
-			Confirmed bool  `json:"confirmed"`
-			Blocktime int64 `json:"block_time"`
-		} `json:"status"`
-	}
-	if err := json.Unmarshal(body, &tx); err != nil {
-		return false, 0, err
-	}
-
-	if !tx.Status.Confirmed {
-		return false, -1, nil
-	}
-
-	return true, tx.Status.Blocktime, nil
-
+	return explorer.GetTxBlocktime(txid)
 }
 
 func getNetwork(ctx *cli.Context) (*common.Network, *network.Network) {
@@ -364,7 +462,12 @@ func getAddress(ctx *cli.Context) (offchainAddr, onchainAddr, redemptionAddr str
 
 	arkNet, liquidNet := getNetwork(ctx)
 
-	arkAddr, err := common.EncodeAddress(arkNet.Addr, userPubkey, aspPubkey)
+	arkAddr, err := common.ArkAddress{
+		HRP:     arkNet.Addr,
+		Version: common.AddressVersion0,
+		AspKey:  aspPubkey,
+		UserKey: userPubkey,
+	}.WithCapabilities(clientCapabilities).Encode()
 	if err != nil {
 		return
 	}
@@ -400,24 +503,63 @@ func getAddress(ctx *cli.Context) (offchainAddr, onchainAddr, redemptionAddr str
 	return
 }
 
+// checkCapabilities returns an error if the counterparty, either the ASP or
+// the receiver of a payment, requires capabilities this client doesn't
+// support. Optional or unknown optional ones are ignored.
+func checkCapabilities(counterparty string, capabilities common.Capabilities) error {
+	missing := capabilities.Missing(clientCapabilities)
+	if len(missing) <= 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(missing))
+	for _, capability := range missing {
+		names = append(names, capability.String())
+	}
+	return fmt.Errorf(
+		"%s requires capabilities not supported by this client: %s, "+
+			"please upgrade", counterparty, strings.Join(names, ", "),
+	)
+}
+
+// printJSON prints the result of a command in the format given with
+// --output, json by default.
 func printJSON(resp interface{}) error {
+	switch outputFormat {
+	case outputTable:
+		return printTable(resp)
+	case outputQuiet:
+		return printQuiet(resp)
+	}
+
 	jsonBytes, err := json.MarshalIndent(resp, "", "\t")
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(string(jsonBytes))
+	fmt.Fprintln(jsonOutput, string(jsonBytes))
 	return nil
 }
 
 func handleRoundStream(
 	ctx *cli.Context, client arkv1.ArkServiceClient, paymentID string,
-	vtxosToSign []vtxo, secKey *secp256k1.PrivateKey, receivers []*arkv1.Output,
+	vtxosToSign []vtxo, signer walletSigner, receivers []*arkv1.Output,
+	ephemeralKey *btcec.PrivateKey,
 ) (poolTxID string, err error) {
+	// the logs of the round are correlated by payment id and, once the round
+	// finalization starts, by round id.
+	log := logger.With("payment_id", paymentID)
+	defer func() {
+		if err != nil {
+			log.Error("round failed", "err", err)
+		}
+	}()
+
 	stream, err := client.GetEventStream(ctx.Context, &arkv1.GetEventStreamRequest{})
 	if err != nil {
 		return "", err
 	}
+	log.Debug("waiting for round events")
 
 	var pingStop func()
 	pingReq := &arkv1.PingRequest{
@@ -429,6 +571,7 @@ func handleRoundStream(
 
 	defer pingStop()
 
+	treeSigner := newTreeSigner(ephemeralKey)
 	for {
 		event, err := stream.Recv()
 		if err == io.EOF {
@@ -440,13 +583,89 @@ func handleRoundStream(
 
 		if e := event.GetRoundFailed(); e != nil {
 			pingStop()
+			log = log.With("round_id", e.GetId())
 			return "", fmt.Errorf("round failed: %s", e.GetReason())
 		}
 
+		if e := event.GetPaymentInputsRejected(); e != nil {
+			if e.GetPaymentId() != paymentID {
+				continue
+			}
+			pingStop()
+			log.Warn("payment inputs rejected", "inputs", len(e.GetInputs()))
+			return "", errInputsRejected{e.GetInputs(), e.GetReason()}
+		}
+
+		if e := event.GetRoundSigning(); e != nil {
+			sent, err := treeSigner.sendNonces(ctx, client, e)
+			if err != nil {
+				return "", fmt.Errorf("failed to send tree nonces: %s", err)
+			}
+			if sent {
+				log.Debug("tree nonces sent", "round_id", e.GetId())
+			}
+			continue
+		}
+
+		if e := event.GetRoundSigningNoncesGenerated(); e != nil {
+			sent, err := treeSigner.sendSignatures(ctx, client, e)
+			if err != nil {
+				return "", fmt.Errorf("failed to send tree signatures: %s", err)
+			}
+			if sent {
+				log.Debug("tree signatures sent", "round_id", e.GetId())
+			}
+			continue
+		}
+
 		if e := event.GetRoundFinalization(); e != nil {
 			// stop pinging as soon as we receive some forfeit txs
 			pingStop()
-			fmt.Println("round finalization started")
+
+			// the pool txs of the covenantless trees are psbts, whose forfeits
+			// are signed with MuSig2 along with the ASP.
+			if poolPsbt, err := psbt.NewFromRawBytes(
+				strings.NewReader(e.GetPoolTx()), true,
+			); err == nil {
+				poolTxid := poolPsbt.UnsignedTx.TxHash().String()
+				log = log.With("round_id", e.GetId(), "pool_txid", poolTxid)
+				log.Info("round finalization started")
+				if err := updatePendingRoundStage(
+					ctx, roundStageSigning, e.GetId(), poolTxid,
+				); err != nil {
+					return "", err
+				}
+
+				log.Debug("signing forfeit txs")
+				signed, err := signCovenantlessForfeits(
+					ctx, client, signer, treeSigner, e, vtxosToSign,
+				)
+				if err != nil {
+					return "", err
+				}
+				if signed == 0 {
+					log.Info("no forfeit txs to sign, waiting for the next round")
+					log = logger.With("payment_id", paymentID)
+					if err := updatePendingRoundStage(
+						ctx, roundStageRegistered, "", "",
+					); err != nil {
+						return "", err
+					}
+					pingStop = nil
+					for pingStop == nil {
+						pingStop = ping(ctx.Context, client, pingReq)
+					}
+					continue
+				}
+
+				if err := updatePendingRoundStage(
+					ctx, roundStageFinalizing, "", "",
+				); err != nil {
+					return "", err
+				}
+				log.Info("signed forfeits submitted", "forfeits", signed)
+				continue
+			}
 
 			poolTx := e.GetPoolTx()
 			ptx, err := psetv2.NewPsetFromBase64(poolTx)
@@ -454,6 +673,18 @@ func handleRoundStream(
 				return "", err
 			}
 
+			utx, err := ptx.UnsignedTx()
+			if err != nil {
+				return "", err
+			}
+			log = log.With("round_id", e.GetId(), "pool_txid", utx.TxHash().String())
+			log.Info("round finalization started")
+			if err := updatePendingRoundStage(
+				ctx, roundStageSigning, e.GetId(), utx.TxHash().String(),
+			); err != nil {
+				return "", err
+			}
+
 			congestionTree, err := toCongestionTree(e.GetCongestionTree())
 			if err != nil {
 				return "", err
@@ -571,14 +802,10 @@ func handleRoundStream(
 				}
 			}
 
-			fmt.Println("congestion tree validated")
+			log.Debug("congestion tree validated", "connectors", len(connectors))
 
 			forfeits := e.GetForfeitTxs()
-			signedForfeits := make([]string, 0)
-
-			fmt.Print("signing forfeit txs... ")
-
-			explorer := NewExplorer(ctx)
+			forfeitsToSign := make([]*psetv2.Pset, 0)
 
 			connectorsTxids := make([]string, 0, len(connectors))
 			for _, connector := range connectors {
@@ -615,24 +842,47 @@ func handleRoundStream(
 								return "", fmt.Errorf("connector txid %s not found in the connectors list", connectorTxid)
 							}
 
-							if err := signPset(ctx, pset, explorer, secKey); err != nil {
-								return "", err
-							}
-
-							signedPset, err := pset.ToBase64()
-							if err != nil {
-								return "", err
-							}
-
-							signedForfeits = append(signedForfeits, signedPset)
+							forfeitsToSign = append(forfeitsToSign, pset)
 						}
 					}
 				}
 			}
 
+			if ctx.Bool(reviewFlag.Name) && len(forfeitsToSign) > 0 {
+				if err := reviewRoundTxs(
+					poolTx, congestionTree, forfeitsToSign,
+				); err != nil {
+					return "", err
+				}
+			}
+
+			log.Debug("signing forfeit txs", "forfeits", len(forfeitsToSign))
+
+			explorer := NewExplorer(ctx)
+
+			signedForfeits := make([]string, 0, len(forfeitsToSign))
+			for _, pset := range forfeitsToSign {
+				if err := signer.signPset(ctx, pset, explorer); err != nil {
+					return "", err
+				}
+
+				signedPset, err := pset.ToBase64()
+				if err != nil {
+					return "", err
+				}
+
+				signedForfeits = append(signedForfeits, signedPset)
+			}
+
 			// if no forfeit txs have been signed, start pinging again and wait for the next round
 			if len(signedForfeits) == 0 {
-				fmt.Printf("\nno forfeit txs to sign, waiting for the next round...\n")
+				log.Info("no forfeit txs to sign, waiting for the next round")
+				log = logger.With("payment_id", paymentID)
+				if err := updatePendingRoundStage(
+					ctx, roundStageRegistered, "", "",
+				); err != nil {
+					return "", err
+				}
 				pingStop = nil
 				for pingStop == nil {
 					pingStop = ping(ctx.Context, client, pingReq)
@@ -640,21 +890,24 @@ func handleRoundStream(
 				continue
 			}
 
-			fmt.Printf("%d signed\n", len(signedForfeits))
-			fmt.Print("finalizing payment... ")
 			_, err = client.FinalizePayment(ctx.Context, &arkv1.FinalizePaymentRequest{
 				SignedForfeitTxs: signedForfeits,
 			})
 			if err != nil {
 				return "", err
 			}
-			fmt.Print("done.\n")
-			fmt.Println("waiting for round finalization...")
+			if err := updatePendingRoundStage(
+				ctx, roundStageFinalizing, "", "",
+			); err != nil {
+				return "", err
+			}
+			log.Info("signed forfeits submitted", "forfeits", len(signedForfeits))
 
 			continue
 		}
 
 		if event.GetRoundFinalized() != nil {
+			log.Info("round finalized")
 			return event.GetRoundFinalized().GetPoolTxid(), nil
 		}
 	}
@@ -795,6 +1048,10 @@ func getRedeemBranches(
 	redeemBranches := make(map[string]*redeemBranch, 0)
 
 	for _, vtxo := range vtxos {
+		// the vtxos received out of round are not leaves of the tree.
+		if len(vtxo.redeemTx) > 0 {
+			continue
+		}
 		if _, ok := congestionTrees[vtxo.poolTxid]; !ok {
 			round, err := client.GetRound(ctx, &arkv1.GetRoundRequest{
 				Txid: vtxo.poolTxid,
@@ -892,82 +1149,134 @@ func coinSelectOnchain(
 	ctx *cli.Context,
 	explorer Explorer, targetAmount uint64, exclude []utxo,
 ) ([]utxo, []utxo, uint64, error) {
-	_, onchainAddr, _, err := getAddress(ctx)
+	spendable, delayedSpendable, err := getSpendableUtxos(ctx, explorer, exclude)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	fromExplorer, err := explorer.GetUtxos(onchainAddr)
+	selector, err := getCoinSelector(ctx)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	utxos := make([]utxo, 0)
+	// the delayed utxos come after the others, to be spent last.
+	candidates := append(append([]utxo{}, spendable...), delayedSpendable...)
+	coins := make([]selectionCoin, 0, len(candidates))
+	for _, utxo := range candidates {
+		coins = append(coins, selectionCoin{
+			outpoint: fmt.Sprintf("%s:%d", utxo.Txid, utxo.Vout),
+			amount:   utxo.Amount,
+		})
+	}
+
+	indexes := selector.selectCoins(coins, targetAmount, 0)
+	if indexes == nil {
+		return nil, nil, 0, fmt.Errorf(
+			"not enough funds to cover amount %d", targetAmount,
+		)
+	}
+
+	utxos, delayedUtxos := make([]utxo, 0), make([]utxo, 0)
 	selectedAmount := uint64(0)
-	for _, utxo := range fromExplorer {
-		if selectedAmount >= targetAmount {
-			break
+	for _, i := range indexes {
+		if i < len(spendable) {
+			utxos = append(utxos, candidates[i])
+		} else {
+			delayedUtxos = append(delayedUtxos, candidates[i])
 		}
+		selectedAmount += candidates[i].Amount
+	}
+
+	return utxos, delayedUtxos, selectedAmount - targetAmount, nil
+}
+
+// getSpendableUtxos returns the utxos of the onchain address and those of
+// the vtxo taproot address whose exit delay has passed, skipping the locked
+// and the excluded ones.
+func getSpendableUtxos(
+	ctx *cli.Context, explorer Explorer, exclude []utxo,
+) ([]utxo, []utxo, error) {
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fromExplorer, err := explorer.GetUtxos(onchainAddr)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isExcluded := func(u utxo) bool {
+		if locked.has(u.Txid, u.Vout) {
+			return true
+		}
 		for _, excluded := range exclude {
-			if utxo.Txid == excluded.Txid && utxo.Vout == excluded.Vout {
-				continue
+			if u.Txid == excluded.Txid && u.Vout == excluded.Vout {
+				return true
 			}
 		}
-
-		utxos = append(utxos, utxo)
-		selectedAmount += utxo.Amount
+		return false
 	}
 
-	if selectedAmount >= targetAmount {
-		return utxos, nil, selectedAmount - targetAmount, nil
+	utxos := make([]utxo, 0)
+	for _, utxo := range fromExplorer {
+		if isExcluded(utxo) {
+			continue
+		}
+		utxos = append(utxos, utxo)
 	}
 
 	userPubkey, err := getWalletPublicKey(ctx)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	aspPubkey, err := getAspPublicKey(ctx)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	unilateralExitDelay, err := getUnilateralExitDelay(ctx)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	vtxoTapKey, _, err := computeVtxoTaprootScript(
 		userPubkey, aspPubkey, uint(unilateralExitDelay),
 	)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	_, net := getNetwork(ctx)
 
 	pay, err := payment.FromTweakedKey(vtxoTapKey, net, nil)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	addr, err := pay.TaprootAddress()
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	fromExplorer, err = explorer.GetUtxos(addr)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, err
 	}
 
 	delayedUtxos := make([]utxo, 0)
 	for _, utxo := range fromExplorer {
-		if selectedAmount >= targetAmount {
-			break
+		// the relative timelock starts once the parent is confirmed, until
+		// then the utxo is stuck. Its parent can be sped up with cpfp.
+		if !utxo.Status.Confirmed {
+			continue
 		}
-
 		availableAt := time.Unix(utxo.Status.Blocktime, 0).Add(
 			time.Duration(unilateralExitDelay) * time.Second,
 		)
@@ -975,23 +1284,14 @@ func coinSelectOnchain(
 			continue
 		}
 
-		for _, excluded := range exclude {
-			if utxo.Txid == excluded.Txid && utxo.Vout == excluded.Vout {
-				continue
-			}
+		if isExcluded(utxo) {
+			continue
 		}
 
 		delayedUtxos = append(delayedUtxos, utxo)
-		selectedAmount += utxo.Amount
 	}
 
-	if selectedAmount < targetAmount {
-		return nil, nil, 0, fmt.Errorf(
-			"not enough funds to cover amount %d", targetAmount,
-		)
-	}
-
-	return utxos, delayedUtxos, selectedAmount - targetAmount, nil
+	return utxos, delayedUtxos, nil
 }
 
 func addInputs(
@@ -1013,6 +1313,9 @@ func addInputs(
 			{
 				Txid:    utxo.Txid,
 				TxIndex: utxo.Vout,
+				// signal replaceability to allow bumping the fee, the delayed
+				// inputs already do with their relative timelock.
+				Sequence: rbfSequence,
 			},
 		}); err != nil {
 			return err
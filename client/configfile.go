@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CONFIG_FILE_ENVVAR = "ARK_CONFIG_FILE"
+	defaultConfigFile  = "~/.ark/config.yaml"
+)
+
+var configFileFlag = &cli.StringFlag{
+	Name:    "config",
+	Usage:   "Specify the path of the optional config file, whose values are overridden by flags",
+	Value:   defaultConfigFile,
+	EnvVars: []string{CONFIG_FILE_ENVVAR},
+}
+
+// fileConfig holds the default values of the flags read from the config file,
+// like:
+//
+//	network: testnet
+//	asp_url: localhost:6000
+//	explorer: https://blockstream.info/liquidtestnet/api
+//	explorers:
+//	  liquid: mempool+https://liquid.network/api
+//	  testnet: ssl://electrum.example.com:50002
+//	  regtest: http://localhost:3001
+//	sat_per_vbyte: 0.1
+//	min_lifetime: 1h
+//	output: json
+//	price_feed: coingecko
+//	fiat_currency: eur
+//	proxy: socks5://127.0.0.1:9050
+//	tls_cert: ~/.ark/asp.pem
+//	tls_client_cert: ~/.ark/client.pem
+//	tls_client_key: ~/.ark/client.key
+//	log_level: info
+//	log_file: ~/.ark/ark.log
+type fileConfig struct {
+	Network  string `yaml:"network"`
+	AspURL   string `yaml:"asp_url"`
+	Explorer string `yaml:"explorer"`
+	// Explorers are the default explorers by network, used by init unless
+	// another is given with explorer or --explorer.
+	Explorers   map[string]string `yaml:"explorers"`
+	SatPerVByte float64           `yaml:"sat_per_vbyte"`
+	MinLifetime string            `yaml:"min_lifetime"`
+	Output      string            `yaml:"output"`
+	PriceFeed   string            `yaml:"price_feed"`
+	Fiat        string            `yaml:"fiat_currency"`
+	Proxy       string            `yaml:"proxy"`
+	LogLevel    string            `yaml:"log_level"`
+	LogFile     string            `yaml:"log_file"`
+
+	// the certificates of the TLS connections to the ASP.
+	TLSCert       string `yaml:"tls_cert"`
+	TLSClientCert string `yaml:"tls_client_cert"`
+	TLSClientKey  string `yaml:"tls_client_key"`
+}
+
+// globalFlagValue returns the value of the given global flag. The global
+// flags are parsed before the config file is loaded, hence their defaults
+// from the config file are taken from the flag definition.
+func globalFlagValue(ctx *cli.Context, flag *cli.StringFlag) string {
+	if ctx.IsSet(flag.Name) {
+		return ctx.String(flag.Name)
+	}
+	return flag.Value
+}
+
+// loadConfigFile reads the config file at the given path, if it exists, and
+// makes its values the defaults of the related flags.
+// It must be called before the flags of the commands are parsed.
+func loadConfigFile(path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %s", err)
+	}
+
+	cfg := fileConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(buf))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %s", path, err)
+	}
+
+	if len(cfg.Network) > 0 {
+		net := strings.ToLower(cfg.Network)
+		if net != "liquid" && net != "testnet" && net != "regtest" {
+			return fmt.Errorf("invalid network %s in config file", cfg.Network)
+		}
+		networkFlag.Value = net
+	}
+	if len(cfg.AspURL) > 0 {
+		urlFlag.Value = cfg.AspURL
+		urlFlag.Required = false
+	}
+	if len(cfg.Explorer) > 0 {
+		if err := validateExplorerURL(cfg.Explorer); err != nil {
+			return fmt.Errorf("invalid explorer in config file: %s", err)
+		}
+		explorerFlag.Value = cfg.Explorer
+	}
+	for net, explorer := range cfg.Explorers {
+		net = strings.ToLower(net)
+		if _, ok := explorerUrl[net]; !ok {
+			return fmt.Errorf("invalid network %s of explorers in config file", net)
+		}
+		if err := validateExplorerURL(explorer); err != nil {
+			return fmt.Errorf(
+				"invalid explorer of network %s in config file: %s", net, err,
+			)
+		}
+		explorerUrl[net] = explorer
+	}
+	if cfg.SatPerVByte > 0 {
+		if cfg.SatPerVByte < minFeeRate {
+			return fmt.Errorf(
+				"invalid sat_per_vbyte in config file, must be at least %.1f",
+				minFeeRate,
+			)
+		}
+		satPerVByteFlag.Value = cfg.SatPerVByte
+	}
+	if len(cfg.MinLifetime) > 0 {
+		minLifetime, err := time.ParseDuration(cfg.MinLifetime)
+		if err != nil || minLifetime < 0 {
+			return fmt.Errorf("invalid min_lifetime %s in config file", cfg.MinLifetime)
+		}
+		minLifetimeFlag.Value = minLifetime
+	}
+	if len(cfg.PriceFeed) > 0 {
+		priceFeedFlag.Value = cfg.PriceFeed
+	}
+	if len(cfg.Fiat) > 0 {
+		fiatCurrencyFlag.Value = strings.ToLower(cfg.Fiat)
+	}
+	if len(cfg.Proxy) > 0 {
+		proxyFlag.Value = cfg.Proxy
+	}
+	if len(cfg.TLSCert) > 0 {
+		tlsCertFlag.Value = cfg.TLSCert
+	}
+	if len(cfg.TLSClientCert) > 0 {
+		tlsClientCertFlag.Value = cfg.TLSClientCert
+	}
+	if len(cfg.TLSClientKey) > 0 {
+		tlsClientKeyFlag.Value = cfg.TLSClientKey
+	}
+	if len(cfg.LogLevel) > 0 {
+		if _, err := parseLogLevel(cfg.LogLevel); err != nil {
+			return fmt.Errorf("%s in config file", err)
+		}
+		logLevelFlag.Value = cfg.LogLevel
+	}
+	if len(cfg.LogFile) > 0 {
+		logFileFlag.Value = cfg.LogFile
+	}
+	if len(cfg.Output) > 0 {
+		if err := validateOutputFormat(cfg.Output); err != nil {
+			return fmt.Errorf("%s in config file", err)
+		}
+		outputFlag.Value = cfg.Output
+	}
+	return nil
+}
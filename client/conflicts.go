@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+// vtxoConflict is a vtxo that this wallet knew as spendable and that has
+// been spent by someone else, ie. another device using the same key.
+type vtxoConflict struct {
+	Txid    string `json:"txid"`
+	Vout    uint32 `json:"vout"`
+	Amount  uint64 `json:"amount"`
+	SpentBy string `json:"spent_by"`
+}
+
+func (c vtxoConflict) String() string {
+	return fmt.Sprintf(
+		"vtxo %s:%d (%d sats) spent by another device in round %s",
+		c.Txid, c.Vout, c.Amount, c.SpentBy,
+	)
+}
+
+type errVtxoConflicts []vtxoConflict
+
+func (e errVtxoConflicts) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, c := range e {
+		msgs = append(msgs, c.String())
+	}
+	return fmt.Sprintf(
+		"conflict detected, %s. The wallet state has been updated, please retry",
+		strings.Join(msgs, ", "),
+	)
+}
+
+func getKnownVtxos(ctx *cli.Context) (map[string]uint64, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	knownVtxos := make(map[string]uint64)
+	if len(state[KNOWN_VTXOS]) <= 0 {
+		return knownVtxos, nil
+	}
+	if err := json.Unmarshal([]byte(state[KNOWN_VTXOS]), &knownVtxos); err != nil {
+		return nil, fmt.Errorf("invalid known vtxos: %s", err)
+	}
+	return knownVtxos, nil
+}
+
+func setKnownVtxos(ctx *cli.Context, knownVtxos map[string]uint64) error {
+	buf, err := json.Marshal(knownVtxos)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{KNOWN_VTXOS: string(buf)})
+}
+
+// forgetSpentVtxos removes from the known vtxos those spent by this wallet,
+// so that they are not reported as conflicts at the next sync.
+func forgetSpentVtxos(ctx *cli.Context, outpoints []string) error {
+	knownVtxos, err := getKnownVtxos(ctx)
+	if err != nil {
+		return err
+	}
+	for _, outpoint := range outpoints {
+		delete(knownVtxos, outpoint)
+	}
+	return setKnownVtxos(ctx, knownVtxos)
+}
+
+// reconcileVtxos compares the vtxos known by the wallet with those returned
+// by the ASP. Any known vtxo that has been spent without this wallet taking
+// part to a round is returned as conflict. The known vtxos are then replaced
+// with the current spendable ones, only if the wallet is locked, otherwise
+// the conflicts are reported again by the next reads.
+func reconcileVtxos(
+	ctx *cli.Context, response *arkv1.ListVtxosResponse,
+) ([]vtxoConflict, error) {
+	knownVtxos, err := getKnownVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the inputs of a pending round might have been spent by this very
+	// wallet, they can't be considered conflicts.
+	ownInputs := make(map[string]struct{})
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if round != nil {
+		for _, in := range round.Inputs {
+			ownInputs[in] = struct{}{}
+		}
+	}
+	// same for those of the pending rounds of the other devices, that have
+	// been notified via sync.
+	remoteLockedVtxos, err := getRemoteLockedVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for in := range remoteLockedVtxos {
+		ownInputs[in] = struct{}{}
+	}
+
+	conflicts := make([]vtxoConflict, 0)
+	for _, v := range response.GetSpentVtxos() {
+		key := fmt.Sprintf("%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout())
+		if _, ok := knownVtxos[key]; !ok {
+			continue
+		}
+		if _, ok := ownInputs[key]; ok {
+			continue
+		}
+		conflicts = append(conflicts, vtxoConflict{
+			Txid:    v.GetOutpoint().GetTxid(),
+			Vout:    v.GetOutpoint().GetVout(),
+			Amount:  v.GetReceiver().GetAmount(),
+			SpentBy: v.GetSpentBy(),
+		})
+	}
+
+	spendableVtxos := make(map[string]uint64)
+	for _, v := range response.GetSpendableVtxos() {
+		if v.GetSwept() {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout())
+		spendableVtxos[key] = v.GetReceiver().GetAmount()
+	}
+
+	if !walletLocked.Load() {
+		return conflicts, nil
+	}
+	if err := setKnownVtxos(ctx, spendableVtxos); err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// checkConflicts syncs with the ASP and returns an errVtxoConflicts error if
+// any of the given coins has been spent by another device.
+func checkConflicts(
+	ctx *cli.Context, client arkv1.ArkServiceClient, coins []vtxo,
+) error {
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.ListVtxos(ctx.Context, &arkv1.ListVtxosRequest{
+		Address: offchainAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	spentVtxos := make(map[string]*arkv1.Vtxo)
+	for _, v := range response.GetSpentVtxos() {
+		key := fmt.Sprintf("%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout())
+		spentVtxos[key] = v
+	}
+
+	// nolint
+	reconcileVtxos(ctx, response)
+
+	conflicts := make(errVtxoConflicts, 0)
+	for _, coin := range coins {
+		v, ok := spentVtxos[fmt.Sprintf("%s:%d", coin.txid, coin.vout)]
+		if !ok {
+			continue
+		}
+		conflicts = append(conflicts, vtxoConflict{
+			Txid:    coin.txid,
+			Vout:    coin.vout,
+			Amount:  coin.amount,
+			SpentBy: v.GetSpentBy(),
+		})
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	consolidateBelowFlag = cli.Uint64Flag{
+		Name:  "below",
+		Usage: "consolidate only the vtxos whose amount is lower than this, in sats",
+	}
+	consolidateDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the vtxos that would be consolidated without joining a round",
+	}
+)
+
+var consolidateCommand = cli.Command{
+	Name:   "consolidate",
+	Usage:  "Gather many small vtxos into a single one with a self-payment in the next round, reducing the forfeits to sign in future payments",
+	Action: withWalletLock(consolidateAction),
+	Flags: []cli.Flag{
+		&consolidateBelowFlag, &consolidateDryRunFlag,
+		&passwordFlag, &roundRetriesFlag, &reviewFlag,
+	},
+}
+
+func consolidateAction(ctx *cli.Context) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	explorer := NewExplorer(ctx)
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+
+	// the frozen vtxos are left untouched like with any automatic selection.
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+
+	below := ctx.Uint64(consolidateBelowFlag.Name)
+	selected := make([]vtxo, 0)
+	outpoints := make([]string, 0)
+	amount := uint64(0)
+	for _, v := range vtxos {
+		if locked.has(v.txid, v.vout) {
+			continue
+		}
+		if below > 0 && v.amount >= below {
+			continue
+		}
+		selected = append(selected, v)
+		outpoints = append(outpoints, fmt.Sprintf("%s:%d", v.txid, v.vout))
+		amount += v.amount
+	}
+	if len(selected) < 2 {
+		return fmt.Errorf("nothing to consolidate, found %d vtxos", len(selected))
+	}
+
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return err
+	}
+	netAmount, err := fees.forSelfPayment(amount)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(consolidateDryRunFlag.Name) {
+		return printJSON(map[string]interface{}{
+			"inputs": outpoints,
+			"amount": netAmount,
+			"fee":    amount - netAmount,
+		})
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("consolidating %d vtxos (%d sats)\n", len(selected), amount)
+
+	poolTxID, err := joinRound(
+		ctx, client, selected, signer,
+		[]*arkv1.Output{{Address: offchainAddr, Amount: netAmount}},
+	)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyConsolidate,
+		Txid:   poolTxID,
+		Amount: netAmount,
+		Fee:    amount - netAmount,
+	})
+
+	return printJSON(map[string]interface{}{
+		"pool_txid": poolTxID,
+		"vtxos":     len(selected),
+		"amount":    netAmount,
+		"fee":       amount - netAmount,
+	})
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ark-network/ark/common"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
+)
+
+// types of the addresses stored in the address book.
+const (
+	contactOffchain = "offchain"
+	contactOnchain  = "onchain"
+)
+
+var (
+	contactNameFlag = cli.StringFlag{
+		Name:     "name",
+		Usage:    "name of the contact, usable as --to of send",
+		Required: true,
+	}
+	contactAddressFlag = cli.StringFlag{
+		Name:     "address",
+		Usage:    "ark or onchain address of the contact",
+		Required: true,
+	}
+)
+
+var contactsCommand = cli.Command{
+	Name:  "contacts",
+	Usage: "Manage the address book of named ark and onchain addresses",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "add",
+			Usage:  "Add a contact or replace the address of an existing one",
+			Action: withWalletLock(contactsAddAction),
+			Flags:  []cli.Flag{&contactNameFlag, &contactAddressFlag},
+		},
+		{
+			Name:   "list",
+			Usage:  "List the contacts, flagging the ones not usable with the connected ASP",
+			Action: contactsListAction,
+		},
+		{
+			Name:   "remove",
+			Usage:  "Remove a contact",
+			Action: withWalletLock(contactsRemoveAction),
+			Flags:  []cli.Flag{&contactNameFlag},
+		},
+	},
+}
+
+type contact struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Type    string `json:"type"`
+}
+
+func contactsAddAction(ctx *cli.Context) error {
+	name := strings.TrimSpace(ctx.String(contactNameFlag.Name))
+	addr := strings.TrimSpace(ctx.String(contactAddressFlag.Name))
+
+	if err := validateContactName(name); err != nil {
+		return err
+	}
+	contactType, err := validateContactAddress(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := getContacts(ctx)
+	if err != nil {
+		return err
+	}
+	contacts[name] = contact{
+		Name:    name,
+		Address: addr,
+		Type:    contactType,
+	}
+	if err := setContacts(ctx, contacts); err != nil {
+		return err
+	}
+
+	return printJSON(contacts[name])
+}
+
+func contactsListAction(ctx *cli.Context) error {
+	contacts, err := getContacts(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(contacts))
+	for name := range contacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		c := contacts[name]
+		entry := map[string]interface{}{
+			"name":    c.Name,
+			"address": c.Address,
+			"type":    c.Type,
+		}
+		// the ASP might have changed since the contact was added.
+		if _, err := validateContactAddress(ctx, c.Address); err != nil {
+			entry["invalid"] = err.Error()
+		}
+		list = append(list, entry)
+	}
+	return printJSON(list)
+}
+
+func contactsRemoveAction(ctx *cli.Context) error {
+	name := strings.TrimSpace(ctx.String(contactNameFlag.Name))
+
+	contacts, err := getContacts(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := contacts[name]; !ok {
+		return fmt.Errorf("contact %s not found", name)
+	}
+	delete(contacts, name)
+
+	return setContacts(ctx, contacts)
+}
+
+// resolveContact returns the address of the contact with the given name, if
+// any, otherwise the given string is returned as is.
+func resolveContact(ctx *cli.Context, nameOrAddr string) (string, error) {
+	contacts, err := getContacts(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c, ok := contacts[nameOrAddr]
+	if !ok {
+		return nameOrAddr, nil
+	}
+	if _, err := validateContactAddress(ctx, c.Address); err != nil {
+		return "", fmt.Errorf("invalid address of contact %s: %s", c.Name, err)
+	}
+	return c.Address, nil
+}
+
+func validateContactName(name string) error {
+	if len(name) <= 0 {
+		return fmt.Errorf("missing contact name")
+	}
+	// names must not be mistaken for addresses or payment uris by send --to.
+	if strings.ContainsAny(name, ": ,") {
+		return fmt.Errorf("invalid contact name, must not contain spaces, commas or colons")
+	}
+	if _, err := common.DecodeArkAddress(name); err == nil {
+		return fmt.Errorf("invalid contact name, must not be an address")
+	}
+	if _, err := address.ToOutputScript(name); err == nil {
+		return fmt.Errorf("invalid contact name, must not be an address")
+	}
+	return nil
+}
+
+// validateContactAddress returns the type of the given address, or an error
+// if it's not valid for the network and the ASPs the wallet is connected to.
+func validateContactAddress(ctx *cli.Context, addr string) (string, error) {
+	_, liquidNet := getNetwork(ctx)
+
+	if _, err := address.ToOutputScript(addr); err == nil {
+		net, err := address.NetworkForAddress(addr)
+		if err != nil {
+			return "", fmt.Errorf("invalid onchain address: unknown network")
+		}
+		if net.Name != liquidNet.Name {
+			return "", fmt.Errorf(
+				"invalid onchain address: must be for %s network", liquidNet.Name,
+			)
+		}
+		return contactOnchain, nil
+	}
+
+	arkAddr, err := common.DecodeArkAddress(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %s", err)
+	}
+	arkNet, _ := getNetwork(ctx)
+	if arkAddr.HRP != arkNet.Addr {
+		return "", fmt.Errorf("invalid ark address: must be for %s network", liquidNet.Name)
+	}
+
+	asp, err := findAsp(ctx, arkAddr.AspKey)
+	if err != nil {
+		return "", err
+	}
+	if asp == nil {
+		return "", fmt.Errorf("ark address not associated with any connected service provider")
+	}
+	return contactOffchain, nil
+}
+
+func getContacts(ctx *cli.Context) (map[string]contact, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make(map[string]contact)
+	if len(state[CONTACTS]) <= 0 {
+		return contacts, nil
+	}
+	if err := json.Unmarshal([]byte(state[CONTACTS]), &contacts); err != nil {
+		return nil, fmt.Errorf("invalid contacts: %s", err)
+	}
+	return contacts, nil
+}
+
+func setContacts(ctx *cli.Context, contacts map[string]contact) error {
+	buf, err := json.Marshal(contacts)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{CONTACTS: string(buf)})
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+var cpfpCommand = cli.Command{
+	Name:      "cpfp",
+	Usage:     "Speed up an unconfirmed onchain tx of the wallet, like a sweep of delayed utxos, with a child tx spending its change and paying the fees of both at the given fee rate",
+	ArgsUsage: "<txid>",
+	Action:    withWalletLock(cpfpAction),
+	Flags:     []cli.Flag{&passwordFlag, &satPerVByteFlag},
+}
+
+func cpfpAction(ctx *cli.Context) error {
+	txid := ctx.Args().First()
+	if len(txid) <= 0 {
+		return fmt.Errorf("missing txid")
+	}
+	if !ctx.IsSet(satPerVByteFlag.Name) {
+		return fmt.Errorf("missing --sat-per-vbyte")
+	}
+	feeRate := ctx.Float64(satPerVByteFlag.Name)
+
+	confirmed, _, err := getTxBlocktime(ctx, txid)
+	if err != nil {
+		return err
+	}
+	if confirmed {
+		return fmt.Errorf("tx %s is already confirmed", txid)
+	}
+
+	explorer := NewExplorer(ctx)
+	txHex, err := explorer.GetTxHex(txid)
+	if err != nil {
+		return err
+	}
+	parent, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return err
+	}
+
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	changeScript, err := address.ToOutputScript(onchainAddr)
+	if err != nil {
+		return err
+	}
+
+	// the child can only spend the outputs of the parent not locked by a
+	// relative timelock, since those can't be spent before it confirms.
+	parentFee := uint64(0)
+	parentUtxos := make([]utxo, 0)
+	amount := uint64(0)
+	for i, out := range parent.Outputs {
+		if out.IsConfidential() {
+			continue
+		}
+		value, err := elementsutil.ValueFromBytes(out.Value)
+		if err != nil {
+			return err
+		}
+		if len(out.Script) <= 0 {
+			parentFee += value
+			continue
+		}
+		if !bytes.Equal(out.Script, changeScript) {
+			continue
+		}
+		parentUtxos = append(parentUtxos, utxo{
+			Txid:   txid,
+			Vout:   uint32(i),
+			Amount: value,
+			Asset:  elementsutil.AssetHashFromBytes(out.Asset),
+		})
+		amount += value
+	}
+	if len(parentUtxos) <= 0 {
+		return fmt.Errorf("tx %s has no output of the wallet to spend", txid)
+	}
+
+	// the fee the parent should pay at the given rate, the child pays for the
+	// difference besides its own.
+	parentTargetFee := uint64(math.Ceil(float64(parent.VirtualSize()) * feeRate))
+	if parentFee >= parentTargetFee {
+		return fmt.Errorf(
+			"tx %s already pays at least %.2f sat/vbyte", txid, feeRate,
+		)
+	}
+	deficit := parentTargetFee - parentFee
+
+	_, net := getNetwork(ctx)
+	pset, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return err
+	}
+	if err := addInputs(ctx, updater, parentUtxos, nil, net); err != nil {
+		return err
+	}
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: amount,
+			Script: changeScript,
+		},
+	}); err != nil {
+		return err
+	}
+
+	selectedUtxos := parentUtxos
+	childFee := uint64(0)
+	for {
+		childFee, err = estimateOnchainFees(updater.Pset, feeRate)
+		if err != nil {
+			return err
+		}
+		if amount >= deficit+childFee+DUST {
+			break
+		}
+
+		// the change of the parent doesn't cover the fees, select more coins
+		// and estimate the fees again since the child got bigger.
+		selected, delayedSelected, newChange, err := coinSelectOnchain(
+			ctx, explorer, deficit+childFee+DUST-amount, selectedUtxos,
+		)
+		if err != nil {
+			return err
+		}
+		if err := addInputs(ctx, updater, selected, delayedSelected, net); err != nil {
+			return err
+		}
+		selectedUtxos = append(selectedUtxos, selected...)
+		selectedUtxos = append(selectedUtxos, delayedSelected...)
+		amount = deficit + childFee + DUST + newChange
+	}
+
+	fee := deficit + childFee
+	updater.Pset.Outputs[0].Value = amount - fee
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: fee,
+		},
+	}); err != nil {
+		return err
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+	if err := signer.signPset(ctx, updater.Pset, explorer); err != nil {
+		return err
+	}
+	if err := psetv2.FinalizeAll(updater.Pset); err != nil {
+		return err
+	}
+
+	b64, err := updater.Pset.ToBase64()
+	if err != nil {
+		return err
+	}
+	childTxid, err := broadcast(ctx, explorer, b64)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:  historyCPFP,
+		Txid:  childTxid,
+		Txids: []string{txid},
+		Fee:   fee,
+	})
+
+	return printJSON(map[string]interface{}{
+		"parent_txid":   txid,
+		"txid":          childTxid,
+		"parent_fee":    parentFee,
+		"fee":           fee,
+		"sat_per_vbyte": feeRate,
+	})
+}
@@ -4,16 +4,86 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"runtime/debug"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/scrypt"
 )
 
-type cypher struct{}
+const (
+	kdfScrypt   = "scrypt"
+	kdfArgon2id = "argon2id"
+)
+
+// kdfParams are the parameters of the function deriving the encryption key
+// from the password. Time, Memory (in KiB) and Threads only apply to
+// argon2id.
+type kdfParams struct {
+	Name    string `json:"name"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+var (
+	// scryptKDF is the one of the keys encrypted before the kdf was
+	// configurable.
+	scryptKDF = kdfParams{Name: kdfScrypt}
+	// defaultArgon2idKDF follows the recommendations of RFC 9106 for memory
+	// constrained environments.
+	defaultArgon2idKDF = kdfParams{
+		Name:    kdfArgon2id,
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+)
+
+func (p kdfParams) validate() error {
+	switch p.Name {
+	case kdfScrypt:
+		return nil
+	case kdfArgon2id:
+		if p.Time <= 0 || p.Memory < 8*uint32(p.Threads) || p.Threads <= 0 {
+			return fmt.Errorf(
+				"invalid argon2id params, time and threads must be positive and " +
+					"memory at least 8KiB per thread",
+			)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown kdf %s", p.Name)
+	}
+}
+
+// parseKDFParams parses the kdf params stored in the state, defaulting to
+// scrypt if missing.
+func parseKDFParams(str string) (kdfParams, error) {
+	if len(str) <= 0 {
+		return scryptKDF, nil
+	}
+	var params kdfParams
+	if err := json.Unmarshal([]byte(str), &params); err != nil {
+		return kdfParams{}, fmt.Errorf("invalid kdf params: %s", err)
+	}
+	if err := params.validate(); err != nil {
+		return kdfParams{}, err
+	}
+	return params, nil
+}
+
+type cypher struct {
+	kdf kdfParams
+}
 
 func newAES128Cypher() *cypher {
-	return &cypher{}
+	return &cypher{scryptKDF}
+}
+
+func newCypherWithKDF(kdf kdfParams) *cypher {
+	return &cypher{kdf}
 }
 
 func (c *cypher) encrypt(privateKey, password []byte) ([]byte, error) {
@@ -29,7 +99,7 @@ func (c *cypher) encrypt(privateKey, password []byte) ([]byte, error) {
 		return nil, fmt.Errorf("missing encryption password")
 	}
 
-	key, salt, err := deriveKey(password, nil)
+	key, salt, err := c.deriveKey(password, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +136,7 @@ func (c *cypher) decrypt(encrypted, password []byte) ([]byte, error) {
 	salt := encrypted[len(encrypted)-32:]
 	data := encrypted[:len(encrypted)-32]
 
-	key, _, err := deriveKey(password, salt)
+	key, _, err := c.deriveKey(password, salt)
 	if err != nil {
 		return nil, err
 	}
@@ -88,13 +158,19 @@ func (c *cypher) decrypt(encrypted, password []byte) ([]byte, error) {
 }
 
 // deriveKey derives a 32 byte array key from a custom passhprase
-func deriveKey(password, salt []byte) ([]byte, []byte, error) {
+func (c *cypher) deriveKey(password, salt []byte) ([]byte, []byte, error) {
 	if salt == nil {
 		salt = make([]byte, 32)
 		if _, err := rand.Read(salt); err != nil {
 			return nil, nil, err
 		}
 	}
+	if c.kdf.Name == kdfArgon2id {
+		key := argon2.IDKey(
+			password, salt, c.kdf.Time, c.kdf.Memory, c.kdf.Threads, 32,
+		)
+		return key, salt, nil
+	}
 	// 2^20 = 1048576 recommended length for key-stretching
 	// check the doc for other recommended values:
 	// https://godoc.org/golang.org/x/crypto/scrypt
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var daemonIntervalFlag = cli.DurationFlag{
+	Name:  "interval",
+	Usage: "how often to check for the scheduled and recurring payments to execute",
+	Value: 30 * time.Second,
+}
+
+var daemonCommand = cli.Command{
+	Name:   "daemon",
+	Usage:  "Unlock the wallet and keep running in the background, executing the payments scheduled with send --at and the standing orders when due, and notifying the confirmations of onboardings and exits",
+	Action: daemonAction,
+	Flags: []cli.Flag{
+		&passwordFlag, &daemonIntervalFlag, &webhookURLFlag, &webhookSecretFlag,
+	},
+}
+
+func daemonAction(ctx *cli.Context) error {
+	interval := ctx.Duration(daemonIntervalFlag.Name)
+
+	// the failed and skipped standing orders, and the progress of the
+	// onboardings and exits, are notified to the webhook.
+	hook, err := getWebhook(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the wallet is unlocked once, like in the repl, for all the payments
+	// executed by the daemon.
+	if err := startSession(ctx); err != nil {
+		return err
+	}
+	defer stopSession()
+
+	globalArgs := getGlobalArgs(ctx)
+	if err := claimAsyncPaymentsOnStartup(ctx, globalArgs); err != nil {
+		logger.Warn("failed to claim the payments received out of round", "err", err)
+	}
+
+	_, onchainAddr, redemptionAddr, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	// the chain is checked at every new block or tx of the wallet's onchain
+	// addresses, if the explorer supports subscriptions.
+	watcher := newChainWatcher(
+		NewExplorer(ctx), []string{onchainAddr, redemptionAddr}, interval,
+	)
+
+	logger.Info("daemon started", "interval", interval)
+	for {
+		if err := runDuePayments(ctx, globalArgs); err != nil {
+			logger.Warn("failed to run scheduled payments", "err", err)
+		}
+		if err := runDueStandingOrders(ctx, globalArgs, hook); err != nil {
+			logger.Warn("failed to run standing orders", "err", err)
+		}
+		if err := withWalletLockWait(ctx, func() error {
+			return checkChainEvents(ctx, hook)
+		}); err != nil {
+			logger.Warn("failed to check onboardings and exits", "err", err)
+		}
+
+		if err := watcher.wait(ctx.Context, interval); err != nil {
+			logger.Info("daemon stopped")
+			return nil
+		}
+	}
+}
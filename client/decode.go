@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+// kinds of the txs recognized by decode.
+const (
+	decodedPoolTx    = "pool"
+	decodedTreeNode  = "tree node"
+	decodedForfeitTx = "forfeit"
+	decodedRedeemTx  = "redeem"
+	decodedOtherTx   = "unknown"
+)
+
+const (
+	sequenceDisableFlag = 1 << 31
+	sequenceTypeFlag    = 1 << 22
+	sequenceMask        = 0x0000ffff
+	// the relative timelocks in seconds have a granularity of 512 seconds.
+	sequenceGranularity = 9
+)
+
+var decodeCommand = cli.Command{
+	Name:      "decode",
+	Usage:     "Decodes a pool tx, a node of a congestion tree, a forfeit or a redeem tx, given as hex or base64 pset, for manual auditing",
+	ArgsUsage: "<hex|base64>",
+	Action:    decodeAction,
+}
+
+type decodedTx struct {
+	Kind     string          `json:"kind"`
+	Txid     string          `json:"txid"`
+	Locktime uint32          `json:"locktime,omitempty"`
+	Inputs   []decodedInput  `json:"inputs"`
+	Outputs  []decodedOutput `json:"outputs"`
+}
+
+type decodedInput struct {
+	Outpoint string `json:"outpoint"`
+	Sequence uint32 `json:"sequence"`
+	// RelativeTimelock is the timelock enforced by the sequence, if any.
+	RelativeTimelock string                 `json:"relative_timelock,omitempty"`
+	Role             string                 `json:"role,omitempty"`
+	Closure          map[string]interface{} `json:"closure,omitempty"`
+
+	leafScript []byte
+}
+
+type decodedOutput struct {
+	Index        int    `json:"index"`
+	Script       string `json:"script"`
+	Amount       uint64 `json:"amount"`
+	Confidential bool   `json:"confidential,omitempty"`
+	Role         string `json:"role,omitempty"`
+	// Vtxo is the outpoint of the vtxo the output is, if any.
+	Vtxo  string `json:"vtxo,omitempty"`
+	Owned bool   `json:"owned,omitempty"`
+
+	script []byte
+}
+
+func decodeAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("missing tx, must be hex or base64 pset")
+	}
+	str := strings.TrimSpace(ctx.Args().First())
+
+	var (
+		decoded *decodedTx
+		err     error
+	)
+	if ptx, perr := psetv2.NewPsetFromBase64(str); perr == nil {
+		decoded, err = decodePset(ptx)
+	} else if tx, terr := transaction.NewTxFromHex(str); terr == nil {
+		decoded, err = decodeRawTx(tx)
+	} else {
+		return fmt.Errorf("invalid tx, must be hex or base64 pset")
+	}
+	if err != nil {
+		return err
+	}
+
+	classifyDecodedTx(decoded)
+	markOwnedOutputs(ctx, decoded)
+	return printJSON(decoded)
+}
+
+func decodePset(ptx *psetv2.Pset) (*decodedTx, error) {
+	utx, err := ptx.UnsignedTx()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := newDecodedTx(utx)
+	for i, in := range ptx.Inputs {
+		if len(in.TapLeafScript) > 0 {
+			decoded.Inputs[i].leafScript = in.TapLeafScript[0].Script
+		}
+	}
+	for i, out := range ptx.Outputs {
+		decoded.Outputs[i].Amount = out.Value
+	}
+	return decoded, nil
+}
+
+func decodeRawTx(tx *transaction.Transaction) (*decodedTx, error) {
+	decoded := newDecodedTx(tx)
+	for i, in := range tx.Inputs {
+		// a script path spend ends with the leaf script and its control block.
+		if n := len(in.Witness); n >= 2 && len(in.Witness[n-1]) > 0 &&
+			in.Witness[n-1][0]&0xfe == 0xc4 {
+			decoded.Inputs[i].leafScript = in.Witness[n-2]
+		}
+	}
+	return decoded, nil
+}
+
+func newDecodedTx(tx *transaction.Transaction) *decodedTx {
+	inputs := make([]decodedInput, 0, len(tx.Inputs))
+	for _, in := range tx.Inputs {
+		inputs = append(inputs, decodedInput{
+			Outpoint: fmt.Sprintf(
+				"%s:%d", chainhash.Hash(in.Hash).String(), in.Index,
+			),
+			Sequence:         in.Sequence,
+			RelativeTimelock: relativeTimelock(in.Sequence),
+		})
+	}
+
+	outputs := make([]decodedOutput, 0, len(tx.Outputs))
+	for i, out := range tx.Outputs {
+		output := decodedOutput{Index: i, script: out.Script, Script: "fee"}
+		if len(out.Script) > 0 {
+			output.Script = disasmScript(out.Script)
+		}
+		if amount, err := elementsutil.ValueFromBytes(out.Value); err == nil {
+			output.Amount = amount
+		} else {
+			output.Confidential = true
+		}
+		outputs = append(outputs, output)
+	}
+
+	return &decodedTx{
+		Txid:     tx.TxHash().String(),
+		Locktime: tx.Locktime,
+		Inputs:   inputs,
+		Outputs:  outputs,
+	}
+}
+
+// classifyDecodedTx guesses the kind of the tx from the closures spent by its
+// inputs and sets the role of its inputs and outputs accordingly.
+func classifyDecodedTx(decoded *decodedTx) {
+	var hasForfeit, hasUnroll, hasLeaves bool
+	for i, in := range decoded.Inputs {
+		if len(in.leafScript) <= 0 {
+			continue
+		}
+		hasLeaves = true
+		closure, err := tree.DecodeClosure(in.leafScript)
+		if err != nil {
+			continue
+		}
+		decoded.Inputs[i].Closure = describeClosure(closure)
+		switch closure.(type) {
+		case *tree.ForfeitClosure:
+			hasForfeit = true
+		case *tree.UnrollClosure:
+			hasUnroll = true
+		}
+	}
+
+	switch {
+	case hasUnroll:
+		decoded.Kind = decodedTreeNode
+		for i := range decoded.Inputs {
+			decoded.Inputs[i].Role = "parent node"
+		}
+	case hasForfeit && len(decoded.Inputs) == 2 &&
+		len(decoded.Inputs[0].leafScript) <= 0:
+		decoded.Kind = decodedForfeitTx
+		decoded.Inputs[0].Role = "connector"
+		decoded.Inputs[1].Role = "vtxo"
+		if len(decoded.Outputs) > 0 {
+			decoded.Outputs[0].Role = "asp"
+		}
+	case hasForfeit:
+		decoded.Kind = decodedRedeemTx
+		for i := range decoded.Inputs {
+			decoded.Inputs[i].Role = "vtxo"
+		}
+		for i, out := range decoded.Outputs {
+			if len(out.script) > 0 {
+				decoded.Outputs[i].Role = "vtxo"
+				decoded.Outputs[i].Vtxo = fmt.Sprintf("%s:%d", decoded.Txid, i)
+			}
+		}
+	case !hasLeaves && len(decoded.Outputs) >= 2 && isTaprootScript(decoded.Outputs[0].script):
+		decoded.Kind = decodedPoolTx
+		decoded.Outputs[0].Role = "congestion tree"
+		decoded.Outputs[1].Role = "connectors"
+		for i := 2; i < len(decoded.Outputs); i++ {
+			if len(decoded.Outputs[i].script) > 0 {
+				decoded.Outputs[i].Role = "onchain"
+			}
+		}
+	default:
+		decoded.Kind = decodedOtherTx
+	}
+
+	for i, out := range decoded.Outputs {
+		if len(out.script) <= 0 {
+			decoded.Outputs[i].Role = "fee"
+		}
+	}
+}
+
+// markOwnedOutputs flags the outputs that are vtxos of the wallet, if it's
+// initialized.
+func markOwnedOutputs(ctx *cli.Context, decoded *decodedTx) {
+	walletScript, err := getWalletVtxoScript(ctx)
+	if err != nil {
+		return
+	}
+
+	for i, out := range decoded.Outputs {
+		if bytes.Equal(out.script, walletScript) {
+			decoded.Outputs[i].Vtxo = fmt.Sprintf("%s:%d", decoded.Txid, i)
+			decoded.Outputs[i].Owned = true
+			if len(out.Role) <= 0 {
+				decoded.Outputs[i].Role = "vtxo"
+			}
+		}
+	}
+}
+
+func describeClosure(closure tree.Closure) map[string]interface{} {
+	switch c := closure.(type) {
+	case *tree.ForfeitClosure:
+		return map[string]interface{}{
+			"type":       "forfeit",
+			"pubkey":     hex.EncodeToString(c.Pubkey.SerializeCompressed()),
+			"asp_pubkey": hex.EncodeToString(c.AspPubkey.SerializeCompressed()),
+		}
+	case *tree.CSVSigClosure:
+		return map[string]interface{}{
+			"type":   "unilateral exit",
+			"pubkey": hex.EncodeToString(c.Pubkey.SerializeCompressed()),
+			"delay":  formatSeconds(int64(c.Seconds)),
+		}
+	case *tree.UnrollClosure:
+		return map[string]interface{}{
+			"type":          "unroll",
+			"left_amount":   c.LeftAmount,
+			"right_amount":  c.RightAmount,
+			"min_relay_fee": c.MinRelayFee,
+		}
+	default:
+		return nil
+	}
+}
+
+// relativeTimelock returns the BIP68 timelock enforced by the given sequence,
+// if any.
+func relativeTimelock(sequence uint32) string {
+	if sequence&sequenceDisableFlag != 0 {
+		return ""
+	}
+	value := int64(sequence & sequenceMask)
+	if value <= 0 {
+		return ""
+	}
+	if sequence&sequenceTypeFlag != 0 {
+		return formatSeconds(value << sequenceGranularity)
+	}
+	return fmt.Sprintf("%d blocks", value)
+}
+
+func isTaprootScript(script []byte) bool {
+	return len(script) == 34 && script[0] == 0x51 && script[1] == 0x20
+}
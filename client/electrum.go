@@ -0,0 +1,405 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+const electrumTimeout = 30 * time.Second
+
+func isElectrumURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "tcp://") ||
+		strings.HasPrefix(rawURL, "ssl://")
+}
+
+// electrumExplorer is the Explorer backed by an Electrum server, like electrs,
+// reached over tcp:// or ssl://. The connection is opened at the first
+// request and kept open.
+type electrumExplorer struct {
+	lock      sync.Mutex
+	serverURL string
+	conn      net.Conn
+	reader    *bufio.Reader
+	nextID    uint64
+	cache     map[string]string
+	// blocktimes are the times of the blocks by height.
+	blocktimes map[int64]int64
+}
+
+func newElectrumExplorer(serverURL string) *electrumExplorer {
+	return &electrumExplorer{
+		serverURL:  serverURL,
+		cache:      make(map[string]string),
+		blocktimes: make(map[int64]int64),
+	}
+}
+
+func (e *electrumExplorer) GetTxHex(txid string) (string, error) {
+	if hex, ok := e.cache[txid]; ok {
+		return hex, nil
+	}
+
+	var txHex string
+	if err := e.call(
+		"blockchain.transaction.get", []interface{}{txid}, &txHex,
+	); err != nil {
+		return "", err
+	}
+
+	e.cache[txid] = txHex
+	return txHex, nil
+}
+
+func (e *electrumExplorer) Broadcast(txStr string) (string, error) {
+	tx, err := transaction.NewTxFromHex(txStr)
+	if err != nil {
+		pset, err := psetv2.NewPsetFromBase64(txStr)
+		if err != nil {
+			return "", err
+		}
+
+		tx, err = psetv2.Extract(pset)
+		if err != nil {
+			return "", err
+		}
+		txStr, _ = tx.ToHex()
+	}
+	txid := tx.TxHash().String()
+	e.cache[txid] = txStr
+
+	if err := e.call(
+		"blockchain.transaction.broadcast", []interface{}{txStr}, &txid,
+	); err != nil {
+		if strings.Contains(
+			strings.ToLower(err.Error()), "transaction already in block chain",
+		) {
+			return tx.TxHash().String(), nil
+		}
+		return "", err
+	}
+	return txid, nil
+}
+
+// GetUtxos returns the unspent outputs of the given address. The server
+// doesn't return their assets, therefore they're read from the txs.
+func (e *electrumExplorer) GetUtxos(addr string) ([]utxo, error) {
+	scripthash, err := addressScripthash(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var unspents []struct {
+		TxHash string `json:"tx_hash"`
+		TxPos  uint32 `json:"tx_pos"`
+		Height int64  `json:"height"`
+	}
+	if err := e.call(
+		"blockchain.scripthash.listunspent", []interface{}{scripthash}, &unspents,
+	); err != nil {
+		return nil, err
+	}
+
+	utxos := make([]utxo, 0, len(unspents))
+	for _, unspent := range unspents {
+		txHex, err := e.GetTxHex(unspent.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		amount, asset, ok, err := getExplicitOutput(txHex, unspent.TxPos)
+		if err != nil {
+			return nil, err
+		}
+		// the confidential outputs can't be spent by this wallet.
+		if !ok {
+			continue
+		}
+
+		u := utxo{
+			Txid:   unspent.TxHash,
+			Vout:   unspent.TxPos,
+			Amount: amount,
+			Asset:  asset,
+		}
+		if unspent.Height > 0 {
+			blocktime, err := e.getBlocktime(unspent.Height)
+			if err != nil {
+				return nil, err
+			}
+			u.Status.Confirmed = true
+			u.Status.BlockHeight = uint32(unspent.Height)
+			u.Status.Blocktime = blocktime
+		}
+		utxos = append(utxos, u)
+	}
+	return utxos, nil
+}
+
+func (e *electrumExplorer) GetBalance(
+	addr, asset string,
+) (confirmed, unconfirmed uint64, err error) {
+	utxos, err := e.GetUtxos(addr)
+	if err != nil {
+		return
+	}
+
+	confirmed, unconfirmed = getUtxosBalance(utxos, asset)
+	return
+}
+
+func (e *electrumExplorer) GetRedeemedVtxosBalance(
+	addr string, unilateralExitDelay int64,
+) (spendableBalance uint64, lockedBalance map[int64]uint64, err error) {
+	utxos, err := e.GetUtxos(addr)
+	if err != nil {
+		return
+	}
+
+	spendableBalance, lockedBalance = getRedeemedVtxosBalance(
+		utxos, unilateralExitDelay,
+	)
+	return
+}
+
+func (e *electrumExplorer) GetTipHeight() (uint32, error) {
+	var tip struct {
+		Height uint32 `json:"height"`
+	}
+	if err := e.call("blockchain.headers.subscribe", nil, &tip); err != nil {
+		return 0, err
+	}
+	return tip.Height, nil
+}
+
+// GetFeeRate returns the fee rate in sats/vbyte estimated to confirm a tx
+// within the next block, zero if the server has no estimation.
+func (e *electrumExplorer) GetFeeRate() (float64, error) {
+	// the server returns the fee rate in coins/kvbyte, or -1.
+	var feeRate float64
+	if err := e.call(
+		"blockchain.estimatefee", []interface{}{1}, &feeRate,
+	); err != nil {
+		return 0, err
+	}
+	if feeRate <= 0 {
+		return 0, nil
+	}
+	return feeRate * 1e8 / 1000, nil
+}
+
+// GetTxBlocktime looks for the tx in the history of the script of one of its
+// outputs to know its block height, if any.
+func (e *electrumExplorer) GetTxBlocktime(txid string) (bool, int64, error) {
+	txHex, err := e.GetTxHex(txid)
+	if err != nil {
+		return false, 0, err
+	}
+	tx, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var script []byte
+	for _, out := range tx.Outputs {
+		// the fee output has no script.
+		if len(out.Script) > 0 {
+			script = out.Script
+			break
+		}
+	}
+	if len(script) <= 0 {
+		return false, 0, fmt.Errorf("tx %s has no output with script", txid)
+	}
+
+	var history []struct {
+		TxHash string `json:"tx_hash"`
+		Height int64  `json:"height"`
+	}
+	if err := e.call(
+		"blockchain.scripthash.get_history",
+		[]interface{}{scriptScripthash(script)}, &history,
+	); err != nil {
+		return false, 0, err
+	}
+
+	for _, entry := range history {
+		if entry.TxHash != txid || entry.Height <= 0 {
+			continue
+		}
+		blocktime, err := e.getBlocktime(entry.Height)
+		if err != nil {
+			return false, 0, err
+		}
+		return true, blocktime, nil
+	}
+	return false, -1, nil
+}
+
+// getBlocktime returns the time of the block at the given height, read from
+// its header.
+func (e *electrumExplorer) getBlocktime(height int64) (int64, error) {
+	if blocktime, ok := e.blocktimes[height]; ok {
+		return blocktime, nil
+	}
+
+	var headerHex string
+	if err := e.call(
+		"blockchain.block.header", []interface{}{height}, &headerHex,
+	); err != nil {
+		return 0, err
+	}
+	header, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return 0, err
+	}
+	// version, previous block hash and merkle root precede the time.
+	if len(header) < 72 {
+		return 0, fmt.Errorf("invalid header of block %d", height)
+	}
+
+	blocktime := int64(binary.LittleEndian.Uint32(header[68:72]))
+	e.blocktimes[height] = blocktime
+	return blocktime, nil
+}
+
+type electrumRequest struct {
+	ID     uint64        `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type electrumResponse struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// call sends a request to the server and decodes its result. The requests
+// are sent one at a time, the notifications received meanwhile are dropped.
+func (e *electrumExplorer) call(
+	method string, params []interface{}, result interface{},
+) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.connect(); err != nil {
+		return err
+	}
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	e.nextID++
+	req := electrumRequest{ID: e.nextID, Method: method, Params: params}
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	// nolint
+	e.conn.SetDeadline(time.Now().Add(electrumTimeout))
+	if _, err := e.conn.Write(append(buf, '\n')); err != nil {
+		e.disconnect()
+		return err
+	}
+
+	for {
+		line, err := e.reader.ReadBytes('\n')
+		if err != nil {
+			e.disconnect()
+			return err
+		}
+
+		resp := electrumResponse{}
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return fmt.Errorf("invalid response from electrum server: %s", err)
+		}
+		if resp.ID == nil || *resp.ID != req.ID {
+			continue
+		}
+
+		if len(resp.Error) > 0 && string(resp.Error) != "null" {
+			var rpcErr struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(resp.Error, &rpcErr); err != nil ||
+				len(rpcErr.Message) <= 0 {
+				return fmt.Errorf("%s", resp.Error)
+			}
+			return fmt.Errorf("%s", rpcErr.Message)
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+	}
+}
+
+// connect opens the connection to the server, through the proxy if any.
+func (e *electrumExplorer) connect() error {
+	if e.conn != nil {
+		return nil
+	}
+
+	serverURL, err := url.Parse(e.serverURL)
+	if err != nil {
+		return fmt.Errorf("invalid electrum server url: %s", err)
+	}
+
+	conn, err := dialTCP(serverURL.Host, electrumTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to electrum server: %s", err)
+	}
+	if serverURL.Scheme == "ssl" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: serverURL.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to connect to electrum server: %s", err)
+		}
+		conn = tlsConn
+	}
+
+	e.conn = conn
+	e.reader = bufio.NewReader(conn)
+	return nil
+}
+
+func (e *electrumExplorer) disconnect() {
+	if e.conn != nil {
+		// nolint
+		e.conn.Close()
+	}
+	e.conn, e.reader = nil, nil
+}
+
+// addressScripthash returns the hash of the output script of the address, by
+// which the electrum server indexes the history.
+func addressScripthash(addr string) (string, error) {
+	script, err := address.ToOutputScript(addr)
+	if err != nil {
+		return "", err
+	}
+	return scriptScripthash(script), nil
+}
+
+func scriptScripthash(script []byte) string {
+	hash := sha256.Sum256(script)
+	// the hash is reversed, like txids.
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:])
+}
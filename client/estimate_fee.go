@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var estimateFeeCommand = cli.Command{
+	Name:   "estimate-fee",
+	Usage:  "Estimates the fees of a send without joining any round: the ones charged by the ASP for the offchain receivers and the share of the onchain tx fee of each onchain receiver",
+	Action: estimateFeeAction,
+	Flags: []cli.Flag{
+		&receiversFlag, &toFlag, &sendAmountFlag, &maxSlippageFlag,
+		&asyncFlag, &satPerVByteFlag, &feePriorityFlag, &subtractFeeFlag,
+	},
+}
+
+func estimateFeeAction(ctx *cli.Context) error {
+	receivers, err := parseEstimateFeeReceivers(ctx)
+	if err != nil {
+		return err
+	}
+
+	onchainReceivers := make([]receiver, 0)
+	offchainReceivers := make([]receiver, 0)
+	for _, r := range receivers {
+		if r.isOnchain() {
+			onchainReceivers = append(onchainReceivers, r)
+		} else {
+			offchainReceivers = append(offchainReceivers, r)
+		}
+	}
+
+	res := make(map[string]interface{})
+	totalFee := uint64(0)
+
+	if len(offchainReceivers) > 0 {
+		if err := selectAspForReceivers(ctx, offchainReceivers); err != nil {
+			return err
+		}
+
+		amount := uint64(0)
+		for _, r := range offchainReceivers {
+			amount += r.Amount
+		}
+
+		offchainAddr, _, _, err := getAddress(ctx)
+		if err != nil {
+			return err
+		}
+		client, close, err := getClientFromState(ctx)
+		if err != nil {
+			return err
+		}
+		defer close()
+
+		// the redeem tx of an async payment pays its own fees, while the
+		// payments of a round pay those charged by the ASP.
+		mode, aspFee := "round", uint64(0)
+		if ctx.Bool(asyncFlag.Name) {
+			info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+			if err != nil {
+				return err
+			}
+			mode, aspFee = "async", uint64(info.GetMinRelayFee())
+		} else {
+			fees, err := getPaymentFees(ctx.Context, client)
+			if err != nil {
+				return err
+			}
+			sentAmount, sentOutputs := uint64(0), 0
+			for _, r := range offchainReceivers {
+				if r.To == offchainAddr {
+					continue
+				}
+				sentAmount += r.Amount
+				sentOutputs++
+			}
+			aspFee = fees.forPayment(sentAmount, sentOutputs)
+		}
+		totalFee += aspFee
+
+		offchain := map[string]interface{}{
+			"mode":      mode,
+			"receivers": len(offchainReceivers),
+			"amount":    amount,
+			"asp_fee":   aspFee,
+		}
+		// with --subtract-fee the receivers get their amount minus their share.
+		if ctx.Bool(subtractFeeFlag.Name) {
+			feeShares, err := prorateOffchainFee(
+				offchainReceivers, offchainAddr, aspFee,
+			)
+			if err != nil {
+				return err
+			}
+			shares := make([]map[string]interface{}, 0, len(offchainReceivers))
+			for i, share := range feeShares {
+				shares = append(shares, map[string]interface{}{
+					"to":     offchainReceivers[i].To,
+					"amount": offchainReceivers[i].Amount,
+					"fee":    share,
+				})
+			}
+			offchain["fee_shares"] = shares
+		}
+		res["offchain"] = offchain
+	}
+
+	if len(onchainReceivers) > 0 {
+		pset, err := buildOnchainTx(ctx, onchainReceivers)
+		if err != nil {
+			return err
+		}
+		b64, err := pset.ToBase64()
+		if err != nil {
+			return err
+		}
+		fee := getPsetFee(b64)
+		totalFee += fee
+
+		amount := uint64(0)
+		for _, r := range onchainReceivers {
+			amount += r.Amount
+		}
+		shares := make([]map[string]interface{}, 0, len(onchainReceivers))
+		for i, share := range prorateFee(onchainReceivers, fee) {
+			shares = append(shares, map[string]interface{}{
+				"to":     onchainReceivers[i].To,
+				"amount": onchainReceivers[i].Amount,
+				"fee":    share,
+			})
+		}
+
+		explorer := NewExplorer(ctx)
+		feeRate, err := getFeeRate(ctx, explorer)
+		if err != nil {
+			return err
+		}
+		onchain := map[string]interface{}{
+			"receivers":     len(onchainReceivers),
+			"amount":        amount,
+			"fee":           fee,
+			"sat_per_vbyte": feeRate,
+			"fee_shares":    shares,
+		}
+		// the fee rates of the other priorities, for comparison.
+		if estimator, ok := explorer.(feeEstimator); ok {
+			if estimates, err := estimator.GetFeeEstimates(); err == nil {
+				onchain["fee_estimates"] = estimates
+			}
+		}
+		res["onchain"] = onchain
+	}
+
+	res["total_fee"] = totalFee
+	return printJSON(res)
+}
+
+// parseEstimateFeeReceivers returns the receivers given either with --to and
+// --amount or with --receivers, like for send.
+func parseEstimateFeeReceivers(ctx *cli.Context) ([]receiver, error) {
+	var receivers []receiver
+	if str := ctx.String(receiversFlag.Name); len(str) > 0 {
+		if ctx.IsSet(toFlag.Name) {
+			return nil, fmt.Errorf("--to can't be used along with --receivers")
+		}
+		if err := json.Unmarshal([]byte(str), &receivers); err != nil {
+			return nil, fmt.Errorf("invalid receivers: %s", err)
+		}
+	} else {
+		to := ctx.String(toFlag.Name)
+		if len(to) <= 0 {
+			return nil, fmt.Errorf("missing destination, either use --to and --amount or --receivers")
+		}
+		amount, _, err := parseSendAmount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		receivers = []receiver{{To: to, Amount: amount}}
+	}
+	if len(receivers) <= 0 {
+		return nil, fmt.Errorf("no receivers specified")
+	}
+
+	for i, r := range receivers {
+		if r.Amount < DUST {
+			return nil, fmt.Errorf("invalid amount (%d), must be at least dust %d", r.Amount, DUST)
+		}
+		addr, err := resolveContact(ctx, r.To)
+		if err != nil {
+			return nil, err
+		}
+		receivers[i].To = addr
+	}
+	return receivers, nil
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+// sources of the events.
+const (
+	eventSourceAsp    = "asp"
+	eventSourceWallet = "wallet"
+)
+
+var eventsCommand = cli.Command{
+	Name:   "events",
+	Usage:  "Stream the events of the ASP and of the wallet (vtxos credited or expiring, rounds joined and finalized) as JSON lines, until interrupted",
+	Action: eventsAction,
+	Flags:  []cli.Flag{&watchIntervalFlag, &expiryThresholdFlag},
+}
+
+// eventStream prints the events as JSON lines, one at a time, and keeps
+// track of the rounds joined by the wallet to report their outcome.
+type eventStream struct {
+	lock sync.Mutex
+	out  io.Writer
+	// walletLock, if any, is held while checking the wallet, so that the
+	// checks don't overlap with the commands run by serve.
+	walletLock sync.Locker
+	// joinedRounds are the pending rounds of the wallet, by payment id.
+	joinedRounds map[string]*pendingRound
+}
+
+func newEventStream(out io.Writer, walletLock sync.Locker) *eventStream {
+	return &eventStream{
+		out:          out,
+		walletLock:   walletLock,
+		joinedRounds: make(map[string]*pendingRound),
+	}
+}
+
+func eventsAction(ctx *cli.Context) error {
+	interval := ctx.Duration(watchIntervalFlag.Name)
+	expiryThreshold := ctx.Duration(expiryThresholdFlag.Name)
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	events := newEventStream(os.Stdout, nil)
+	go events.listenAsp(ctx, client, interval)
+	events.pollWallet(ctx, client, interval, expiryThreshold)
+	return nil
+}
+
+func (s *eventStream) emit(source, kind string, fields map[string]interface{}) {
+	event := map[string]interface{}{
+		"source":     source,
+		"event":      kind,
+		"created_at": time.Now().Unix(),
+	}
+	for k, v := range fields {
+		event[k] = v
+	}
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fmt.Fprintln(s.out, string(buf))
+}
+
+// listenAsp relays the events of the ASP, reconnecting to the stream in case
+// of errors, until the context is done.
+func (s *eventStream) listenAsp(
+	ctx *cli.Context, client arkv1.ArkServiceClient, retryInterval time.Duration,
+) {
+	for {
+		err := s.relayAspEvents(ctx, client)
+		if ctx.Context.Err() != nil {
+			return
+		}
+		logger.Warn("asp event stream interrupted", "err", err)
+
+		select {
+		case <-ctx.Context.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func (s *eventStream) relayAspEvents(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+) error {
+	stream, err := client.GetEventStream(ctx.Context, &arkv1.GetEventStreamRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("stream closed unexpectedly")
+		}
+		if err != nil {
+			return err
+		}
+
+		if e := event.GetRoundFinalization(); e != nil {
+			s.emit(eventSourceAsp, "round_finalization", map[string]interface{}{
+				"round_id": e.GetId(),
+			})
+		}
+		if e := event.GetRoundFinalized(); e != nil {
+			s.emit(eventSourceAsp, "round_finalized", map[string]interface{}{
+				"round_id":  e.GetId(),
+				"pool_txid": e.GetPoolTxid(),
+			})
+			if round := s.joinedRound(e.GetId(), e.GetPoolTxid()); round != nil {
+				s.emit(eventSourceWallet, "round_finalized", map[string]interface{}{
+					"payment_id": round.PaymentID,
+					"round_id":   e.GetId(),
+					"pool_txid":  e.GetPoolTxid(),
+				})
+			}
+		}
+		if e := event.GetRoundFailed(); e != nil {
+			s.emit(eventSourceAsp, "round_failed", map[string]interface{}{
+				"round_id": e.GetId(),
+				"reason":   e.GetReason(),
+			})
+			if round := s.joinedRound(e.GetId(), ""); round != nil {
+				s.emit(eventSourceWallet, "round_failed", map[string]interface{}{
+					"payment_id": round.PaymentID,
+					"round_id":   e.GetId(),
+					"reason":     e.GetReason(),
+				})
+			}
+		}
+		if e := event.GetPaymentInputsRejected(); e != nil {
+			s.emit(eventSourceAsp, "payment_inputs_rejected", map[string]interface{}{
+				"round_id":   e.GetId(),
+				"payment_id": e.GetPaymentId(),
+				"reason":     e.GetReason(),
+			})
+		}
+	}
+}
+
+// joinedRound returns the round joined by the wallet with the given id or
+// pool txid, forgetting it since its outcome is known.
+func (s *eventStream) joinedRound(roundID, poolTxid string) *pendingRound {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for paymentID, round := range s.joinedRounds {
+		if (len(roundID) > 0 && round.RoundID == roundID) ||
+			(len(poolTxid) > 0 && round.PoolTxid == poolTxid) {
+			delete(s.joinedRounds, paymentID)
+			return round
+		}
+	}
+	return nil
+}
+
+// pollWallet periodically checks the wallet for new and expiring vtxos and
+// for the rounds it joins, until the context is done. The vtxos found at the
+// first check are not reported as credited.
+func (s *eventStream) pollWallet(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	interval, expiryThreshold time.Duration,
+) {
+	var seenVtxos map[string]struct{}
+	notifiedExpiries := make(map[string]struct{})
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		vtxos, err := s.checkWallet(ctx, client)
+		if err != nil {
+			logger.Warn("failed to check wallet", "err", err)
+		} else {
+			spendable := make(map[string]struct{}, len(vtxos))
+			for _, v := range vtxos {
+				key := fmt.Sprintf("%s:%d", v.txid, v.vout)
+				spendable[key] = struct{}{}
+
+				if _, ok := seenVtxos[key]; !ok && seenVtxos != nil {
+					s.emit(eventSourceWallet, "vtxo_credited", map[string]interface{}{
+						"outpoint":  key,
+						"amount":    v.amount,
+						"pool_txid": v.poolTxid,
+					})
+				}
+
+				if v.expireAt == nil || time.Until(*v.expireAt) > expiryThreshold {
+					continue
+				}
+				if _, ok := notifiedExpiries[key]; ok {
+					continue
+				}
+				s.emit(eventSourceWallet, "vtxo_expiring", map[string]interface{}{
+					"outpoint":  key,
+					"amount":    v.amount,
+					"expire_at": v.expireAt.Unix(),
+				})
+				notifiedExpiries[key] = struct{}{}
+			}
+
+			for key := range notifiedExpiries {
+				if _, ok := spendable[key]; !ok {
+					delete(notifiedExpiries, key)
+				}
+			}
+			seenVtxos = spendable
+		}
+
+		select {
+		case <-ctx.Context.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkWallet returns the spendable vtxos of the wallet and reports the
+// rounds it joined since the last check.
+func (s *eventStream) checkWallet(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+) ([]vtxo, error) {
+	if s.walletLock != nil {
+		s.walletLock.Lock()
+		defer s.walletLock.Unlock()
+
+		// the state updated while reading the vtxos is written only with the
+		// lock of the wallet held.
+		var vtxos []vtxo
+		err := withWalletLockWait(ctx, func() error {
+			var err error
+			vtxos, err = s.readWallet(ctx, client)
+			return err
+		})
+		return vtxos, err
+	}
+	return s.readWallet(ctx, client)
+}
+
+func (s *eventStream) readWallet(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+) ([]vtxo, error) {
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the payment id is known once the payment is registered.
+	if round != nil && len(round.PaymentID) > 0 {
+		s.lock.Lock()
+		_, known := s.joinedRounds[round.PaymentID]
+		s.joinedRounds[round.PaymentID] = round
+		s.lock.Unlock()
+
+		if !known {
+			s.emit(eventSourceWallet, "round_joined", map[string]interface{}{
+				"payment_id": round.PaymentID,
+				"inputs":     round.Inputs,
+			})
+		}
+	}
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getVtxos(ctx, NewExplorer(ctx), client, offchainAddr, true)
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// exitPollInterval is how often the exit txs are checked, if the explorer
+// doesn't support subscriptions.
+const exitPollInterval = 30 * time.Second
+
+var exitWaitFlag = cli.BoolFlag{
+	Name:  "wait",
+	Usage: "keep running until the exit delay expires and the funds are claimed onchain",
+	Value: false,
+}
+
+var exitCommand = cli.Command{
+	Name: "exit",
+	Usage: "Unilaterally exit the given vtxos (all by default) by broadcasting their redeem branches, " +
+		"then claim the funds onchain once the exit delay expires. Run it again to resume an interrupted exit",
+	Action: withWalletLock(exitAction),
+	Flags:  []cli.Flag{&coinsFlag, &exitWaitFlag, &passwordFlag, &satPerVByteFlag, &feePriorityFlag},
+}
+
+// pendingExit is the unilateral exit in progress, persisted so that it can
+// be resumed after an interruption.
+type pendingExit struct {
+	// Vtxos are the outpoints of the exited vtxos, that are also the
+	// outpoints of the leaves of the redeem branches to claim.
+	Vtxos     []string `json:"vtxos"`
+	Amount    uint64   `json:"amount"`
+	Txids     []string `json:"txids"`
+	StartedAt int64    `json:"started_at"`
+}
+
+func exitAction(ctx *cli.Context) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	explorer := NewExplorer(ctx)
+
+	exit, err := getPendingExit(ctx)
+	if err != nil {
+		return err
+	}
+	if exit != nil && ctx.IsSet(coinsFlag.Name) {
+		return fmt.Errorf(
+			"another exit is in progress, run exit without --coins to resume it",
+		)
+	}
+
+	if exit == nil {
+		exit, err = startExit(ctx, client, explorer)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("resuming exit of %d vtxos\n", len(exit.Vtxos))
+	}
+
+	if err := broadcastExitBranches(ctx, client, explorer, exit); err != nil {
+		return err
+	}
+
+	unilateralExitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, redemptionAddr, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	// the exit txs are checked at every new block, or poll.
+	watcher := newChainWatcher(
+		explorer, []string{redemptionAddr}, exitPollInterval,
+	)
+
+	for {
+		claimableAt, err := getExitClaimableTime(ctx, exit, unilateralExitDelay)
+		if err != nil {
+			return err
+		}
+
+		if claimableAt != nil && !claimableAt.After(time.Now()) {
+			break
+		}
+
+		if !ctx.Bool(exitWaitFlag.Name) {
+			status := map[string]interface{}{
+				"status": "waiting_for_confirmation",
+				"txids":  exit.Txids,
+			}
+			if claimableAt != nil {
+				status["status"] = "waiting_for_exit_delay"
+				status["claimable_at"] = claimableAt.Format("2006-01-02 15:04:05")
+			}
+			return printJSON(status)
+		}
+
+		maxWait := time.Duration(0)
+		if claimableAt != nil {
+			maxWait = time.Until(*claimableAt)
+		}
+		if err := watcher.wait(ctx.Context, maxWait); err != nil {
+			return nil
+		}
+	}
+
+	txid, err := claimExit(ctx, explorer, exit)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyUnilateralExit,
+		Txids:  append(exit.Txids, txid),
+		Amount: exit.Amount,
+	})
+
+	if err := setPendingExit(ctx, nil); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"status":     "claimed",
+		"claim_txid": txid,
+	})
+}
+
+func startExit(
+	ctx *cli.Context, client arkv1.ArkServiceClient, explorer Explorer,
+) (*pendingExit, error) {
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return nil, err
+	}
+	vtxos = withoutAsyncVtxos(vtxos)
+
+	coins, err := getCoins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(coins) > 0 {
+		if vtxos, _, err = selectVtxosByOutpoint(vtxos, coins, 0); err != nil {
+			return nil, err
+		}
+	}
+	if len(vtxos) <= 0 {
+		return nil, fmt.Errorf("no vtxos to exit")
+	}
+
+	exit := &pendingExit{
+		Vtxos:     make([]string, 0, len(vtxos)),
+		Txids:     make([]string, 0),
+		StartedAt: time.Now().Unix(),
+	}
+	for _, v := range vtxos {
+		exit.Vtxos = append(exit.Vtxos, fmt.Sprintf("%s:%d", v.txid, v.vout))
+		exit.Amount += v.amount
+	}
+
+	if len(ctx.String("password")) == 0 {
+		if !askForConfirmation(
+			fmt.Sprintf("unilaterally exit %d sats?", exit.Amount),
+		) {
+			return nil, fmt.Errorf("aborting unilateral exit")
+		}
+	}
+
+	if err := setPendingExit(ctx, exit); err != nil {
+		return nil, err
+	}
+	return exit, nil
+}
+
+// broadcastExitBranches broadcasts the txs of the redeem branches not yet
+// onchain, parents first.
+func broadcastExitBranches(
+	ctx *cli.Context, client arkv1.ArkServiceClient, explorer Explorer,
+	exit *pendingExit,
+) error {
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the vtxos whose branch is fully onchain might not be listed anymore.
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+	exitVtxos := make(map[string]struct{})
+	for _, outpoint := range exit.Vtxos {
+		exitVtxos[outpoint] = struct{}{}
+	}
+	toExit := make([]vtxo, 0)
+	for _, v := range vtxos {
+		if _, ok := exitVtxos[fmt.Sprintf("%s:%d", v.txid, v.vout)]; ok {
+			toExit = append(toExit, v)
+		}
+	}
+	if len(toExit) <= 0 {
+		return nil
+	}
+
+	redeemBranches, err := getRedeemBranches(ctx.Context, explorer, client, toExit)
+	if err != nil {
+		return err
+	}
+
+	transactionsMap := make(map[string]struct{})
+	transactions := make([]string, 0)
+	for _, branch := range redeemBranches {
+		branchTxs, err := branch.redeemPath()
+		if err != nil {
+			return err
+		}
+		for _, tx := range branchTxs {
+			if _, ok := transactionsMap[tx]; !ok {
+				transactions = append(transactions, tx)
+				transactionsMap[tx] = struct{}{}
+			}
+		}
+	}
+
+	for i, tx := range transactions {
+		for {
+			txid, err := broadcast(ctx, explorer, tx)
+			if err != nil {
+				// the parent tx might not be propagated yet.
+				if !strings.Contains(
+					strings.ToLower(err.Error()), "bad-txns-inputs-missingorspent",
+				) {
+					return err
+				}
+				time.Sleep(1 * time.Second)
+				continue
+			}
+
+			logger.Info(
+				"broadcasted tx", "txid", txid,
+				"index", i+1, "total", len(transactions),
+			)
+			exit.Txids = append(exit.Txids, txid)
+			if err := setPendingExit(ctx, exit); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// getExitClaimableTime returns when all the leaves of the exit can be spent,
+// or nil if any of them is not confirmed yet.
+func getExitClaimableTime(
+	ctx *cli.Context, exit *pendingExit, unilateralExitDelay int64,
+) (*time.Time, error) {
+	claimableAt := time.Time{}
+	for _, outpoint := range exit.Vtxos {
+		txid, _, _ := strings.Cut(outpoint, ":")
+		// the explorer fails for txs not propagated yet.
+		confirmed, blocktime, err := getTxBlocktime(ctx, txid)
+		if err != nil || !confirmed {
+			return nil, nil
+		}
+		availableAt := time.Unix(blocktime, 0).Add(
+			time.Duration(unilateralExitDelay) * time.Second,
+		)
+		if availableAt.After(claimableAt) {
+			claimableAt = availableAt
+		}
+	}
+	return &claimableAt, nil
+}
+
+// claimExit sends the funds of the exited vtxos, spendable after the exit
+// delay, to the onchain address of the wallet.
+func claimExit(
+	ctx *cli.Context, explorer Explorer, exit *pendingExit,
+) (string, error) {
+	utxos, delayedUtxos, _, err := selectUtxosByOutpoint(
+		ctx, explorer, exit.Vtxos, 0,
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(utxos) > 0 {
+		return "", fmt.Errorf("unexpected exit outputs owned by the onchain address")
+	}
+
+	amount := uint64(0)
+	for _, u := range delayedUtxos {
+		amount += u.Amount
+	}
+
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	script, err := address.ToOutputScript(onchainAddr)
+	if err != nil {
+		return "", err
+	}
+	_, net := getNetwork(ctx)
+
+	pset, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return "", err
+	}
+	if err := addInputs(ctx, updater, nil, delayedUtxos, net); err != nil {
+		return "", err
+	}
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: amount,
+			Script: script,
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	feeRate, err := getFeeRate(ctx, explorer)
+	if err != nil {
+		return "", err
+	}
+	fee, err := estimateOnchainFees(updater.Pset, feeRate)
+	if err != nil {
+		return "", err
+	}
+	if amount < fee+DUST {
+		return "", fmt.Errorf(
+			"exited amount %d doesn't cover the claim fee %d", amount, fee,
+		)
+	}
+	updater.Pset.Outputs[0].Value = amount - fee
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: fee,
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := signer.signPset(ctx, updater.Pset, explorer); err != nil {
+		return "", err
+	}
+	if err := psetv2.FinalizeAll(updater.Pset); err != nil {
+		return "", err
+	}
+
+	b64, err := updater.Pset.ToBase64()
+	if err != nil {
+		return "", err
+	}
+	return broadcast(ctx, explorer, b64)
+}
+
+func getPendingExit(ctx *cli.Context) (*pendingExit, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(state[PENDING_EXIT]) <= 0 {
+		return nil, nil
+	}
+
+	exit := &pendingExit{}
+	if err := json.Unmarshal([]byte(state[PENDING_EXIT]), exit); err != nil {
+		return nil, fmt.Errorf("invalid pending exit: %s", err)
+	}
+	return exit, nil
+}
+
+func setPendingExit(ctx *cli.Context, exit *pendingExit) error {
+	if exit == nil {
+		return setState(ctx, map[string]string{PENDING_EXIT: ""})
+	}
+
+	buf, err := json.Marshal(exit)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{PENDING_EXIT: string(buf)})
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/elementsutil"
 	"github.com/vulpemventures/go-elements/psetv2"
 	"github.com/vulpemventures/go-elements/transaction"
 )
@@ -20,8 +23,9 @@ type utxo struct {
 	Amount uint64 `json:"value"`
 	Asset  string `json:"asset"`
 	Status struct {
-		Confirmed bool  `json:"confirmed"`
-		Blocktime int64 `json:"block_time"`
+		Confirmed   bool   `json:"confirmed"`
+		BlockHeight uint32 `json:"block_height"`
+		Blocktime   int64  `json:"block_time"`
 	} `json:"status"`
 }
 
@@ -29,23 +33,84 @@ type Explorer interface {
 	GetTxHex(txid string) (string, error)
 	Broadcast(txHex string) (string, error)
 	GetUtxos(addr string) ([]utxo, error)
-	GetBalance(addr, asset string) (uint64, error)
+	GetBalance(addr, asset string) (confirmed, unconfirmed uint64, err error)
 	GetRedeemedVtxosBalance(
 		addr string, unilateralExitDelay int64,
 	) (uint64, map[int64]uint64, error)
+	GetTipHeight() (uint32, error)
+	GetFeeRate() (float64, error)
+	// GetTxBlocktime returns whether the tx is confirmed and, if so, the time
+	// of its block, -1 otherwise.
+	GetTxBlocktime(txid string) (confirmed bool, blocktime int64, err error)
 }
 
+// explorer is the Explorer backed by an Esplora HTTP API.
 type explorer struct {
 	cache   map[string]string
 	baseUrl string
 }
 
+// NewExplorer returns the Explorer for the url in the state: an Electrum
+// server for the tcp:// and ssl:// ones, the RPC of a node for the rpc:// and
+// rpcs:// ones, a mempool.space API for the mempool+https:// ones, an Esplora
+// API otherwise.
 func NewExplorer(ctx *cli.Context) Explorer {
 	baseUrl, err := getBaseURL(ctx)
 	if err != nil {
 		panic(err)
 	}
 
+	explorer, err := newExplorer(baseUrl)
+	if err != nil {
+		panic(err)
+	}
+	return explorer
+}
+
+// newExplorer returns the Explorer for the given url, caching its responses.
+func newExplorer(baseUrl string) (Explorer, error) {
+	var explorer Explorer
+	switch {
+	case isElectrumURL(baseUrl):
+		explorer = newElectrumExplorer(baseUrl)
+	case isNodeRPCURL(baseUrl):
+		nodeExplorer, err := newNodeExplorer(baseUrl)
+		if err != nil {
+			return nil, err
+		}
+		explorer = nodeExplorer
+	case isMempoolURL(baseUrl):
+		explorer = newMempoolExplorer(baseUrl)
+	default:
+		explorer = newEsploraExplorer(baseUrl)
+	}
+	return newCachedExplorer(explorer, baseUrl), nil
+}
+
+// validateExplorerURL checks that the url is the one of a supported
+// explorer.
+func validateExplorerURL(rawURL string) error {
+	explorerURL, err := url.Parse(strings.TrimPrefix(rawURL, mempoolURLPrefix))
+	if err != nil {
+		return err
+	}
+
+	switch explorerURL.Scheme {
+	case "http", "https":
+	case "tcp", "ssl", "rpc", "rpcs":
+		if isMempoolURL(rawURL) {
+			return fmt.Errorf("a mempool.space api must be reached over http")
+		}
+	default:
+		return fmt.Errorf("unsupported scheme %s", explorerURL.Scheme)
+	}
+	if len(explorerURL.Host) <= 0 {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
+
+func newEsploraExplorer(baseUrl string) *explorer {
 	return &explorer{
 		cache:   make(map[string]string),
 		baseUrl: baseUrl,
@@ -99,7 +164,7 @@ func (e *explorer) Broadcast(txStr string) (string, error) {
 }
 
 func (e *explorer) GetUtxos(addr string) ([]utxo, error) {
-	resp, err := http.Get(fmt.Sprintf("%s/address/%s/utxo", e.baseUrl, addr))
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/address/%s/utxo", e.baseUrl, addr))
 	if err != nil {
 		return nil, err
 	}
@@ -120,20 +185,16 @@ func (e *explorer) GetUtxos(addr string) ([]utxo, error) {
 	return payload, nil
 }
 
-func (e *explorer) GetBalance(addr, asset string) (uint64, error) {
-	payload, err := e.GetUtxos(addr)
+func (e *explorer) GetBalance(
+	addr, asset string,
+) (confirmed, unconfirmed uint64, err error) {
+	utxos, err := e.GetUtxos(addr)
 	if err != nil {
-		return 0, err
+		return
 	}
 
-	balance := uint64(0)
-	for _, p := range payload {
-		if p.Asset != asset {
-			continue
-		}
-		balance += p.Amount
-	}
-	return balance, nil
+	confirmed, unconfirmed = getUtxosBalance(utxos, asset)
+	return
 }
 
 func (e *explorer) GetRedeemedVtxosBalance(
@@ -144,32 +205,102 @@ func (e *explorer) GetRedeemedVtxosBalance(
 		return
 	}
 
-	lockedBalance = make(map[int64]uint64, 0)
-	now := time.Now()
-	for _, utxo := range utxos {
-		blocktime := now
-		if utxo.Status.Confirmed {
-			blocktime = time.Unix(utxo.Status.Blocktime, 0)
-		}
+	spendableBalance, lockedBalance = getRedeemedVtxosBalance(
+		utxos, unilateralExitDelay,
+	)
+	return
+}
 
-		delay := time.Duration(unilateralExitDelay) * time.Second
-		availableAt := blocktime.Add(delay)
-		if availableAt.After(now) {
-			if _, ok := lockedBalance[availableAt.Unix()]; !ok {
-				lockedBalance[availableAt.Unix()] = 0
-			}
+func (e *explorer) GetTipHeight() (uint32, error) {
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/blocks/tip/height", e.baseUrl))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
 
-			lockedBalance[availableAt.Unix()] += utxo.Amount
-		} else {
-			spendableBalance += utxo.Amount
-		}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf(string(body))
 	}
 
-	return
+	height, err := strconv.ParseUint(strings.TrimSpace(string(body)), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(height), nil
+}
+
+// GetFeeRate returns the fee rate in sats/vbyte estimated to confirm a tx
+// within the next block. It's zero if the explorer has no estimation, like
+// for an empty mempool.
+func (e *explorer) GetFeeRate() (float64, error) {
+	estimates, err := e.GetFeeEstimates()
+	if err != nil {
+		return 0, err
+	}
+	return estimates.Fastest, nil
+}
+
+func (e *explorer) GetFeeEstimates() (*feeEstimates, error) {
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/fee-estimates", e.baseUrl))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(string(body))
+	}
+
+	// confirmation target in blocks -> fee rate
+	estimates := make(map[string]float64)
+	if err := json.Unmarshal(body, &estimates); err != nil {
+		return nil, err
+	}
+	return feeEstimatesFromTargets(estimates), nil
+}
+
+func (e *explorer) GetTxBlocktime(txid string) (bool, int64, error) {
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/tx/%s", e.baseUrl, txid))
+	if err != nil {
+		return false, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, 0, fmt.Errorf(string(body))
+	}
+
+	var tx struct {
+		Status struct {
+			Confirmed bool  `json:"confirmed"`
+			Blocktime int64 `json:"block_time"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &tx); err != nil {
+		return false, 0, err
+	}
+
+	if !tx.Status.Confirmed {
+		return false, -1, nil
+	}
+
+	return true, tx.Status.Blocktime, nil
 }
 
 func (e *explorer) getTxHex(txid string) (string, error) {
-	resp, err := http.Get(fmt.Sprintf("%s/tx/%s/hex", e.baseUrl, txid))
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/tx/%s/hex", e.baseUrl, txid))
 	if err != nil {
 		return "", err
 	}
@@ -191,7 +322,7 @@ func (e *explorer) getTxHex(txid string) (string, error) {
 func (e *explorer) broadcast(txHex string) (string, error) {
 	body := bytes.NewBuffer([]byte(txHex))
 
-	resp, err := http.Post(fmt.Sprintf("%s/tx", e.baseUrl), "text/plain", body)
+	resp, err := explorerHTTPClient.Post(fmt.Sprintf("%s/tx", e.baseUrl), "text/plain", body)
 	if err != nil {
 		return "", err
 	}
@@ -207,3 +338,73 @@ func (e *explorer) broadcast(txHex string) (string, error) {
 
 	return string(bodyResponse), nil
 }
+
+// getUtxosBalance returns the confirmed and unconfirmed amounts of the given
+// asset in the utxos.
+func getUtxosBalance(utxos []utxo, asset string) (confirmed, unconfirmed uint64) {
+	for _, u := range utxos {
+		if u.Asset != asset {
+			continue
+		}
+		if u.Status.Confirmed {
+			confirmed += u.Amount
+		} else {
+			unconfirmed += u.Amount
+		}
+	}
+	return
+}
+
+// getRedeemedVtxosBalance returns the amount of the given utxos of the vtxo
+// taproot address spendable now, and the locked amounts by the time they
+// become spendable.
+func getRedeemedVtxosBalance(
+	utxos []utxo, unilateralExitDelay int64,
+) (spendableBalance uint64, lockedBalance map[int64]uint64) {
+	lockedBalance = make(map[int64]uint64, 0)
+	now := time.Now()
+	for _, utxo := range utxos {
+		blocktime := now
+		if utxo.Status.Confirmed {
+			blocktime = time.Unix(utxo.Status.Blocktime, 0)
+		}
+
+		delay := time.Duration(unilateralExitDelay) * time.Second
+		availableAt := blocktime.Add(delay)
+		if availableAt.After(now) {
+			if _, ok := lockedBalance[availableAt.Unix()]; !ok {
+				lockedBalance[availableAt.Unix()] = 0
+			}
+
+			lockedBalance[availableAt.Unix()] += utxo.Amount
+		} else {
+			spendableBalance += utxo.Amount
+		}
+	}
+
+	return
+}
+
+// getExplicitOutput returns the amount and the asset of the given output of
+// the tx, ok is false if the output is confidential.
+func getExplicitOutput(
+	txHex string, vout uint32,
+) (amount uint64, asset string, ok bool, err error) {
+	tx, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return 0, "", false, err
+	}
+	if int(vout) >= len(tx.Outputs) {
+		return 0, "", false, fmt.Errorf("output %d not found", vout)
+	}
+
+	out := tx.Outputs[vout]
+	if out.IsConfidential() {
+		return 0, "", false, nil
+	}
+	amount, err = elementsutil.ValueFromBytes(out.Value)
+	if err != nil {
+		return 0, "", false, err
+	}
+	return amount, elementsutil.AssetHashFromBytes(out.Asset), true, nil
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// how long the responses of the explorer are cached, by kind. The txs and
+// the blocktimes of the confirmed ones never change, they're cached for the
+// lifetime of the process.
+const (
+	utxosCacheTTL = 10 * time.Second
+	tipCacheTTL   = 10 * time.Second
+	feesCacheTTL  = time.Minute
+	// maxCachedEntries bounds the size of the cache of a long running
+	// process, like the daemon.
+	maxCachedEntries = 10000
+)
+
+// errNotSupported is returned by the cached explorer for the optional
+// methods not implemented by the explorer it wraps.
+var errNotSupported = errors.New("not supported by the explorer")
+
+var (
+	explorerCachesLock sync.Mutex
+	// explorerCaches are the caches shared by all the explorers with the same
+	// url, so that a command, or a session of the repl or the daemon, doesn't
+	// fetch the same data more than once.
+	explorerCaches = make(map[string]*explorerCache)
+)
+
+type cacheEntry struct {
+	value interface{}
+	// expireAt is zero for the entries never expiring.
+	expireAt time.Time
+}
+
+type explorerCache struct {
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func getExplorerCache(baseUrl string) *explorerCache {
+	explorerCachesLock.Lock()
+	defer explorerCachesLock.Unlock()
+
+	cache, ok := explorerCaches[baseUrl]
+	if !ok {
+		cache = &explorerCache{entries: make(map[string]cacheEntry)}
+		explorerCaches[baseUrl] = cache
+	}
+	return cache
+}
+
+func (c *explorerCache) get(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set caches the value for the given time, forever if ttl is zero.
+func (c *explorerCache) set(key string, value interface{}, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.entries) >= maxCachedEntries {
+		c.evict()
+	}
+
+	entry := cacheEntry{value: value}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+	c.entries[key] = entry
+}
+
+// evict drops the expired entries, or all of them if none is expired.
+func (c *explorerCache) evict() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !entry.expireAt.IsZero() && now.After(entry.expireAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= maxCachedEntries {
+		c.entries = make(map[string]cacheEntry)
+	}
+}
+
+// invalidate drops the entries of the given kinds.
+func (c *explorerCache) invalidate(kinds ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key := range c.entries {
+		for _, kind := range kinds {
+			if strings.HasPrefix(key, kind+":") {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// cachedExplorer is the Explorer caching the responses of the one it wraps.
+// The broadcast of a tx drops the cached utxos and balances, that it changes.
+type cachedExplorer struct {
+	Explorer
+	cache *explorerCache
+}
+
+func newCachedExplorer(explorer Explorer, baseUrl string) *cachedExplorer {
+	return &cachedExplorer{explorer, getExplorerCache(baseUrl)}
+}
+
+func (e *cachedExplorer) GetTxHex(txid string) (string, error) {
+	key := fmt.Sprintf("tx:%s", txid)
+	if txHex, ok := e.cache.get(key); ok {
+		return txHex.(string), nil
+	}
+
+	txHex, err := e.Explorer.GetTxHex(txid)
+	if err != nil {
+		return "", err
+	}
+	e.cache.set(key, txHex, 0)
+	return txHex, nil
+}
+
+func (e *cachedExplorer) Broadcast(txHex string) (string, error) {
+	txid, err := e.Explorer.Broadcast(txHex)
+	if err != nil {
+		return "", err
+	}
+	e.cache.invalidate("utxos", "balance", "redeemed")
+	return txid, nil
+}
+
+func (e *cachedExplorer) GetUtxos(addr string) ([]utxo, error) {
+	key := fmt.Sprintf("utxos:%s", addr)
+	if utxos, ok := e.cache.get(key); ok {
+		return append([]utxo{}, utxos.([]utxo)...), nil
+	}
+
+	utxos, err := e.Explorer.GetUtxos(addr)
+	if err != nil {
+		return nil, err
+	}
+	e.cache.set(key, append([]utxo{}, utxos...), utxosCacheTTL)
+	return utxos, nil
+}
+
+func (e *cachedExplorer) GetBalance(
+	addr, asset string,
+) (uint64, uint64, error) {
+	type balance struct{ confirmed, unconfirmed uint64 }
+
+	key := fmt.Sprintf("balance:%s:%s", addr, asset)
+	if b, ok := e.cache.get(key); ok {
+		return b.(balance).confirmed, b.(balance).unconfirmed, nil
+	}
+
+	confirmed, unconfirmed, err := e.Explorer.GetBalance(addr, asset)
+	if err != nil {
+		return 0, 0, err
+	}
+	e.cache.set(key, balance{confirmed, unconfirmed}, utxosCacheTTL)
+	return confirmed, unconfirmed, nil
+}
+
+func (e *cachedExplorer) GetRedeemedVtxosBalance(
+	addr string, unilateralExitDelay int64,
+) (uint64, map[int64]uint64, error) {
+	type balance struct {
+		spendable uint64
+		locked    map[int64]uint64
+	}
+	copyLocked := func(locked map[int64]uint64) map[int64]uint64 {
+		copied := make(map[int64]uint64, len(locked))
+		for k, v := range locked {
+			copied[k] = v
+		}
+		return copied
+	}
+
+	key := fmt.Sprintf("redeemed:%s:%d", addr, unilateralExitDelay)
+	if b, ok := e.cache.get(key); ok {
+		return b.(balance).spendable, copyLocked(b.(balance).locked), nil
+	}
+
+	spendable, locked, err := e.Explorer.GetRedeemedVtxosBalance(
+		addr, unilateralExitDelay,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+	e.cache.set(key, balance{spendable, copyLocked(locked)}, utxosCacheTTL)
+	return spendable, locked, nil
+}
+
+func (e *cachedExplorer) GetTipHeight() (uint32, error) {
+	if height, ok := e.cache.get("tip:"); ok {
+		return height.(uint32), nil
+	}
+
+	height, err := e.Explorer.GetTipHeight()
+	if err != nil {
+		return 0, err
+	}
+	e.cache.set("tip:", height, tipCacheTTL)
+	return height, nil
+}
+
+func (e *cachedExplorer) GetFeeRate() (float64, error) {
+	if feeRate, ok := e.cache.get("feerate:"); ok {
+		return feeRate.(float64), nil
+	}
+
+	feeRate, err := e.Explorer.GetFeeRate()
+	if err != nil {
+		return 0, err
+	}
+	e.cache.set("feerate:", feeRate, feesCacheTTL)
+	return feeRate, nil
+}
+
+func (e *cachedExplorer) GetFeeEstimates() (*feeEstimates, error) {
+	estimator, ok := e.Explorer.(feeEstimator)
+	if !ok {
+		return nil, errNotSupported
+	}
+
+	if estimates, ok := e.cache.get("fees:"); ok {
+		copied := *estimates.(*feeEstimates)
+		return &copied, nil
+	}
+
+	estimates, err := estimator.GetFeeEstimates()
+	if err != nil {
+		return nil, err
+	}
+	copied := *estimates
+	e.cache.set("fees:", &copied, feesCacheTTL)
+	return estimates, nil
+}
+
+// GetTxBlocktime caches the blocktimes of the confirmed txs for good, the
+// status of the unconfirmed ones for a while.
+func (e *cachedExplorer) GetTxBlocktime(txid string) (bool, int64, error) {
+	type status struct {
+		confirmed bool
+		blocktime int64
+	}
+
+	for _, key := range []string{
+		fmt.Sprintf("blocktime:%s", txid), fmt.Sprintf("unconfirmed:%s", txid),
+	} {
+		if s, ok := e.cache.get(key); ok {
+			return s.(status).confirmed, s.(status).blocktime, nil
+		}
+	}
+
+	confirmed, blocktime, err := e.Explorer.GetTxBlocktime(txid)
+	if err != nil {
+		return false, 0, err
+	}
+	if confirmed {
+		e.cache.set(fmt.Sprintf("blocktime:%s", txid), status{confirmed, blocktime}, 0)
+	} else {
+		e.cache.set(
+			fmt.Sprintf("unconfirmed:%s", txid), status{confirmed, blocktime},
+			tipCacheTTL,
+		)
+	}
+	return confirmed, blocktime, nil
+}
+
+// refresh drops the cached responses that a new block or tx might change.
+func (e *cachedExplorer) refresh() {
+	e.cache.invalidate("utxos", "balance", "redeemed", "tip", "unconfirmed")
+}
+
+func (e *cachedExplorer) SubscribeChain(
+	ctx context.Context, addrs []string,
+) (<-chan struct{}, error) {
+	subscriber, ok := e.Explorer.(chainSubscriber)
+	if !ok {
+		return nil, errNotSupported
+	}
+	return subscriber.SubscribeChain(ctx, addrs)
+}
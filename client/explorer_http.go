@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// maxExplorerBackoff caps the wait before a retry, even if the explorer asks
+// for a longer one.
+const maxExplorerBackoff = time.Minute
+
+var (
+	explorerRateLimitFlag = &cli.Float64Flag{
+		Name:  "explorer-rate-limit",
+		Usage: "Specify the max number of requests per second to the http explorers, 0 for no limit",
+		Value: 5,
+	}
+	explorerRetriesFlag = &cli.UintFlag{
+		Name:  "explorer-retries",
+		Usage: "Specify how many times a request to the http explorers is retried when rate limited or failed by the server",
+		Value: 3,
+	}
+	explorerBackoffFlag = &cli.DurationFlag{
+		Name:  "explorer-backoff",
+		Usage: "Specify the wait before the first retry of a request to the http explorers, doubled at every retry",
+		Value: time.Second,
+	}
+)
+
+// explorerPolicy is the rate limit and retry policy of the requests to the
+// http explorers, not to get banned by the public ones during rescans.
+type explorerPolicy struct {
+	// rateLimit is in requests per second.
+	rateLimit  float64
+	maxRetries uint
+	backoff    time.Duration
+}
+
+// the policy is set from the flags before running the command.
+var explorerHTTPPolicy = explorerPolicy{
+	rateLimit:  5,
+	maxRetries: 3,
+	backoff:    time.Second,
+}
+
+func setExplorerPolicy(ctx *cli.Context) {
+	explorerHTTPPolicy = explorerPolicy{
+		rateLimit:  ctx.Float64(explorerRateLimitFlag.Name),
+		maxRetries: ctx.Uint(explorerRetriesFlag.Name),
+		backoff:    ctx.Duration(explorerBackoffFlag.Name),
+	}
+}
+
+// explorerHTTPClient is the client of all the requests to the http explorers.
+var explorerHTTPClient = &http.Client{Transport: &explorerTransport{}}
+
+// explorerTransport limits the rate of the requests with a token bucket, and
+// retries with exponential backoff those rate limited or failed by the server.
+type explorerTransport struct {
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (t *explorerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := explorerHTTPPolicy
+	backoff := policy.backoff
+	for attempt := uint(0); ; attempt++ {
+		if err := t.wait(req.Context(), policy.rateLimit); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			// the body was consumed by the previous attempt.
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		// the default transport is the one going through the proxy, if any.
+		resp, err := http.DefaultTransport.RoundTrip(attemptReq)
+		if err != nil || !isRetriableStatus(resp.StatusCode) ||
+			attempt >= policy.maxRetries ||
+			(req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		wait := backoff
+		if retryAfter := getRetryAfter(resp); retryAfter > wait {
+			wait = retryAfter
+		}
+		if wait > maxExplorerBackoff {
+			wait = maxExplorerBackoff
+		}
+		// nolint
+		resp.Body.Close()
+		logger.Debug(
+			"explorer request failed, retrying",
+			"url", req.URL.String(), "status", resp.StatusCode, "wait", wait,
+		)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// wait takes a token from the bucket, waiting for it to be refilled if
+// empty. The bucket holds up to a second worth of requests.
+func (t *explorerTransport) wait(ctx context.Context, rateLimit float64) error {
+	if rateLimit <= 0 {
+		return nil
+	}
+	burst := math.Max(1, rateLimit)
+
+	t.lock.Lock()
+	now := time.Now()
+	if t.last.IsZero() {
+		t.tokens = burst
+	} else {
+		t.tokens = math.Min(
+			burst, t.tokens+now.Sub(t.last).Seconds()*rateLimit,
+		)
+	}
+	t.last = now
+	// the token is reserved even if not available yet, so that the waiting
+	// requests are served in order.
+	t.tokens--
+	delay := time.Duration(0)
+	if t.tokens < 0 {
+		delay = time.Duration(-t.tokens / rateLimit * float64(time.Second))
+	}
+	t.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+func isRetriableStatus(status int) bool {
+	return status == http.StatusTooManyRequests ||
+		status >= http.StatusInternalServerError
+}
+
+// getRetryAfter returns the wait requested by the explorer with the
+// Retry-After header, if any.
+func getRetryAfter(resp *http.Response) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if len(header) <= 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// externalSignerTimeout leaves the user enough time to confirm on the device,
+// but not so much that a round moves on while waiting for the forfeits.
+const externalSignerTimeout = 2 * time.Minute
+
+var externalSignerFlag = cli.StringFlag{
+	Name: "external-signer",
+	Usage: "path of an executable holding the wallet key, like a bridge to a hardware wallet, used instead of a password-encrypted key. " +
+		"It's invoked as '<path> getpubkey', printing the hex compressed public key, and as '<path> signpset', " +
+		"reading a base64 pset from stdin and printing it back with the inputs of the key signed, and as '<path> signhash', " +
+		"reading a hex 32-byte hash from stdin and printing its hex BIP340 signature. " +
+		"HWI doesn't support liquid psets, so the bridge must rely on a device-specific tool",
+}
+
+// walletSigner signs the inputs of a pset owned by the wallet, be them onchain
+// utxos, vtxos being redeemed or forfeits.
+type walletSigner interface {
+	signPset(ctx *cli.Context, pset *psetv2.Pset, explorer Explorer) error
+	// signHash returns the BIP340 signature of the given 32-byte hash.
+	signHash(hash []byte) (*schnorr.Signature, error)
+	// signForfeit returns the MuSig2 partial signature of the vtxo input of
+	// the given covenantless forfeit tx.
+	signForfeit(
+		forfeitTx *psbt.Packet, secNonce [97]byte, nonces [][66]byte,
+		keys []*btcec.PublicKey, scriptRoot []byte,
+	) (*musig2.PartialSignature, error)
+}
+
+// getWalletSigner returns the external signer the wallet was initialized
+// with, or the local one after unlocking the key with the password.
+func getWalletSigner(ctx *cli.Context) (walletSigner, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if path := state[EXTERNAL_SIGNER]; len(path) > 0 {
+		return externalSigner{path}, nil
+	}
+
+	prvKey, err := privateKeyFromPassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return localSigner{prvKey}, nil
+}
+
+type localSigner struct {
+	prvKey *secp256k1.PrivateKey
+}
+
+func (s localSigner) signPset(
+	ctx *cli.Context, pset *psetv2.Pset, explorer Explorer,
+) error {
+	return signPset(ctx, pset, explorer, s.prvKey)
+}
+
+func (s localSigner) signHash(hash []byte) (*schnorr.Signature, error) {
+	return schnorr.Sign(s.prvKey, hash)
+}
+
+func (s localSigner) signForfeit(
+	forfeitTx *psbt.Packet, secNonce [97]byte, nonces [][66]byte,
+	keys []*btcec.PublicKey, scriptRoot []byte,
+) (*musig2.PartialSignature, error) {
+	return bitcointree.SignForfeit(
+		forfeitTx, s.prvKey, secNonce, nonces, keys, scriptRoot,
+	)
+}
+
+type externalSigner struct {
+	path string
+}
+
+func (s externalSigner) getPubkey() (*secp256k1.PublicKey, error) {
+	out, err := s.run("getpubkey", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := hex.DecodeString(out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key from external signer: %s", err)
+	}
+	pubkey, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key from external signer: %s", err)
+	}
+	return pubkey, nil
+}
+
+func (s externalSigner) signPset(
+	ctx *cli.Context, pset *psetv2.Pset, explorer Explorer,
+) error {
+	// the device can't fetch the prevouts, add them before handing the pset
+	// over.
+	if err := addWitnessUtxos(pset, explorer); err != nil {
+		return err
+	}
+
+	b64, err := pset.ToBase64()
+	if err != nil {
+		return err
+	}
+
+	out, err := s.run("signpset", []byte(b64))
+	if err != nil {
+		return err
+	}
+
+	signed, err := psetv2.NewPsetFromBase64(out)
+	if err != nil {
+		return fmt.Errorf("invalid pset from external signer: %s", err)
+	}
+
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return err
+	}
+	signedUtx, err := signed.UnsignedTx()
+	if err != nil {
+		return err
+	}
+	if utx.TxHash() != signedUtx.TxHash() {
+		return fmt.Errorf("external signer returned a different tx")
+	}
+
+	for i := range signed.Inputs {
+		if len(signed.Inputs[i].PartialSigs) > 0 {
+			valid, err := signed.ValidateInputSignatures(i)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return fmt.Errorf("invalid signature for input %d", i)
+			}
+		}
+	}
+
+	pubkey, err := getWalletPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	if err := verifyTaprootSigs(ctx, signed, pubkey); err != nil {
+		return err
+	}
+
+	*pset = *signed
+	return nil
+}
+
+// verifyTaprootSigs verifies the taproot signatures of the inputs of the
+// given pset against their sighashes: those of the leaves must be made with
+// the wallet key, those of the key path with the output key.
+func verifyTaprootSigs(
+	ctx *cli.Context, pset *psetv2.Pset, pubkey *secp256k1.PublicKey,
+) error {
+	_, liquidNet := getNetwork(ctx)
+	genesis, err := chainhash.NewHashFromStr(liquidNet.GenesisBlockHash)
+	if err != nil {
+		return err
+	}
+
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return err
+	}
+
+	prevoutsScripts := make([][]byte, 0, len(pset.Inputs))
+	prevoutsValues := make([][]byte, 0, len(pset.Inputs))
+	prevoutsAssets := make([][]byte, 0, len(pset.Inputs))
+	for i, input := range pset.Inputs {
+		if input.WitnessUtxo == nil {
+			return fmt.Errorf("missing witness utxo of input %d", i)
+		}
+		prevoutsScripts = append(prevoutsScripts, input.WitnessUtxo.Script)
+		prevoutsValues = append(prevoutsValues, input.WitnessUtxo.Value)
+		prevoutsAssets = append(prevoutsAssets, input.WitnessUtxo.Asset)
+	}
+
+	verify := func(
+		index int, sigBytes []byte, key *btcec.PublicKey, leafHash *chainhash.Hash,
+	) error {
+		sighashType := txscript.SigHashDefault
+		if len(sigBytes) == 65 {
+			sighashType = txscript.SigHashType(sigBytes[64])
+			sigBytes = sigBytes[:64]
+		}
+		sig, err := schnorr.ParseSignature(sigBytes)
+		if err != nil {
+			return fmt.Errorf("invalid signature for input %d: %s", index, err)
+		}
+
+		preimage := utx.HashForWitnessV1(
+			index, prevoutsScripts, prevoutsAssets, prevoutsValues,
+			sighashType, genesis, leafHash, nil,
+		)
+		if !sig.Verify(preimage[:], key) {
+			return fmt.Errorf("invalid signature for input %d", index)
+		}
+		return nil
+	}
+
+	walletKey := schnorr.SerializePubKey(pubkey)
+	for i, input := range pset.Inputs {
+		for _, tapSig := range input.TapScriptSig {
+			if !bytes.Equal(tapSig.PubKey, walletKey) {
+				return fmt.Errorf("input %d signed with a key not of the wallet", i)
+			}
+
+			leafHash, err := chainhash.NewHash(tapSig.LeafHash)
+			if err != nil {
+				return fmt.Errorf("invalid leaf hash for input %d: %s", i, err)
+			}
+			found := false
+			for _, leaf := range input.TapLeafScript {
+				if leaf.TapHash() == *leafHash {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("input %d signed for an unknown leaf", i)
+			}
+
+			if err := verify(i, tapSig.Signature, pubkey, leafHash); err != nil {
+				return err
+			}
+		}
+
+		if len(input.TapKeySig) > 0 {
+			script := input.WitnessUtxo.Script
+			if len(script) != 34 || script[0] != txscript.OP_1 {
+				return fmt.Errorf("input %d is not a taproot output", i)
+			}
+			outputKey, err := schnorr.ParsePubKey(script[2:])
+			if err != nil {
+				return err
+			}
+			if err := verify(i, input.TapKeySig, outputKey, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s externalSigner) signHash(hash []byte) (*schnorr.Signature, error) {
+	out, err := s.run("signhash", []byte(hex.EncodeToString(hash)))
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := hex.DecodeString(out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from external signer: %s", err)
+	}
+	sig, err := schnorr.ParseSignature(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from external signer: %s", err)
+	}
+
+	pubkey, err := s.getPubkey()
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(hash, pubkey) {
+		return nil, fmt.Errorf("invalid signature from external signer")
+	}
+	return sig, nil
+}
+
+// signForfeit is not supported, the devices can't sign with MuSig2.
+func (s externalSigner) signForfeit(
+	_ *psbt.Packet, _ [97]byte, _ [][66]byte, _ []*btcec.PublicKey, _ []byte,
+) (*musig2.PartialSignature, error) {
+	return nil, fmt.Errorf(
+		"the external signer can't sign the forfeits of covenantless trees",
+	)
+}
+
+func (s externalSigner) run(command string, stdin []byte) (string, error) {
+	fmt.Fprintf(os.Stderr, "waiting for external signer to %s...\n", command)
+
+	// the process is killed once the timeout expires.
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.path, command)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("external signer timed out")
+	}
+	if err != nil {
+		return "", fmt.Errorf("external signer failed to %s: %s", command, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
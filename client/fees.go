@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+// priorities of the onchain txs, by the time they're expected to confirm in.
+const (
+	feePriorityFastest  = "fastest"
+	feePriorityHalfHour = "half-hour"
+	feePriorityHour     = "hour"
+)
+
+// the confirmation targets in blocks of the priorities, a Liquid block being
+// mined every minute.
+const (
+	halfHourTarget = 30
+	hourTarget     = 60
+)
+
+var feePriorityFlag = cli.StringFlag{
+	Name: "fee-priority",
+	Usage: fmt.Sprintf(
+		"how soon the onchain tx should confirm, one of %s, %s or %s, used to estimate the fee rate if --sat-per-vbyte is not set",
+		feePriorityFastest, feePriorityHalfHour, feePriorityHour,
+	),
+	Value: feePriorityFastest,
+}
+
+// feeEstimates are the fee rates in sats/vbyte by priority.
+type feeEstimates struct {
+	Fastest  float64 `json:"fastest"`
+	HalfHour float64 `json:"half_hour"`
+	Hour     float64 `json:"hour"`
+}
+
+func (f *feeEstimates) forPriority(priority string) (float64, error) {
+	switch priority {
+	case "", feePriorityFastest:
+		return f.Fastest, nil
+	case feePriorityHalfHour:
+		return f.HalfHour, nil
+	case feePriorityHour:
+		return f.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid fee priority %s", priority)
+	}
+}
+
+// feeEstimator is implemented by the explorers estimating the fee rates for
+// all the priorities, the others estimate only the fastest one.
+type feeEstimator interface {
+	GetFeeEstimates() (*feeEstimates, error)
+}
+
+// estimateFeeRate returns the fee rate estimated by the explorer for the
+// given priority.
+func estimateFeeRate(explorer Explorer, priority string) (float64, error) {
+	var estimates *feeEstimates
+	estimator, ok := explorer.(feeEstimator)
+	if ok {
+		var err error
+		estimates, err = estimator.GetFeeEstimates()
+		if err != nil && !errors.Is(err, errNotSupported) {
+			return 0, err
+		}
+	}
+	if estimates != nil {
+		return estimates.forPriority(priority)
+	}
+
+	if _, err := (&feeEstimates{}).forPriority(priority); err != nil {
+		return 0, err
+	}
+	if priority != feePriorityFastest {
+		logger.Debug(
+			"explorer can't estimate fee rate by priority, using the fastest",
+			"priority", priority,
+		)
+	}
+	return explorer.GetFeeRate()
+}
+
+// feeEstimatesFromTargets returns the estimates by priority from those by
+// confirmation target in blocks, like those of Esplora. Each priority takes
+// the estimate of the largest target not above its own.
+func feeEstimatesFromTargets(byTarget map[string]float64) *feeEstimates {
+	targets := make([]int, 0, len(byTarget))
+	for target := range byTarget {
+		if n, err := strconv.Atoi(target); err == nil {
+			targets = append(targets, n)
+		}
+	}
+	sort.Ints(targets)
+
+	estimate := func(maxTarget int) float64 {
+		feeRate := 0.0
+		for _, target := range targets {
+			if target > maxTarget {
+				break
+			}
+			feeRate = byTarget[strconv.Itoa(target)]
+		}
+		return feeRate
+	}
+
+	return &feeEstimates{
+		Fastest:  estimate(1),
+		HalfHour: estimate(halfHourTarget),
+		Hour:     estimate(hourTarget),
+	}
+}
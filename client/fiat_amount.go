@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// fiatReferenceMaxAge is the max age of the last fetched price for it to be
+// the reference of the slippage of a fiat amount.
+const fiatReferenceMaxAge = time.Hour
+
+var (
+	sendAmountFlag = cli.StringFlag{
+		Name:  "amount",
+		Usage: "amount to send in sats, or in fiat with the currency as suffix, like 10usd or 5.50eur, converted with the price feed at execution time",
+	}
+	maxSlippageFlag = cli.Float64Flag{
+		Name:  "max-slippage",
+		Usage: "max percentage the price used to convert a fiat amount can differ from the last one fetched by the wallet",
+		Value: 1,
+	}
+)
+
+var fiatAmountRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]{1,2})?)([a-zA-Z]{3})$`)
+
+// fiatAmount is an amount given in fiat and converted to sats.
+type fiatAmount struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+	Rate     float64 `json:"rate"`
+	Sats     uint64  `json:"sats"`
+}
+
+// parseSendAmount returns the amount in sats given with --amount, converting
+// it if given in fiat.
+func parseSendAmount(ctx *cli.Context) (uint64, *fiatAmount, error) {
+	return parseAmount(
+		ctx, ctx.String(sendAmountFlag.Name), ctx.Float64(maxSlippageFlag.Name),
+	)
+}
+
+// parseAmount returns the sats of the given amount, either in sats or in
+// fiat converted with the price feed.
+func parseAmount(
+	ctx *cli.Context, str string, maxSlippage float64,
+) (uint64, *fiatAmount, error) {
+	str = strings.TrimSpace(str)
+	if len(str) <= 0 {
+		return 0, nil, nil
+	}
+	if sats, err := strconv.ParseUint(str, 10, 64); err == nil {
+		return sats, nil, nil
+	}
+
+	matches := fiatAmountRegexp.FindStringSubmatch(str)
+	if matches == nil {
+		return 0, nil, fmt.Errorf(
+			"invalid amount %s, must be in sats or in fiat like 10usd", str,
+		)
+	}
+	// nolint:all
+	amount, _ := strconv.ParseFloat(matches[1], 64)
+	if amount <= 0 {
+		return 0, nil, fmt.Errorf("invalid amount, must be positive")
+	}
+
+	fiat, err := convertFiatAmount(
+		ctx, strings.ToLower(matches[2]), amount, maxSlippage,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+	return fiat.Sats, fiat, nil
+}
+
+func convertFiatAmount(
+	ctx *cli.Context, currency string, amount, maxSlippage float64,
+) (*fiatAmount, error) {
+	if maxSlippage < 0 || maxSlippage > 100 {
+		return nil, fmt.Errorf("invalid max slippage, must be a percentage")
+	}
+
+	prices, _, err := getPriceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if prices == nil {
+		return nil, fmt.Errorf("a price feed is required for fiat amounts, set it with --price-feed")
+	}
+
+	rate, previous, err := prices.getLivePrice(currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the price in %s: %s", currency, err)
+	}
+
+	if previous != nil && previous.Price > 0 &&
+		time.Since(time.Unix(previous.FetchedAt, 0)) < fiatReferenceMaxAge {
+		slippage := math.Abs(rate-previous.Price) / previous.Price * 100
+		if slippage > maxSlippage {
+			return nil, fmt.Errorf(
+				"price in %s moved by %.2f%% (%.2f -> %.2f), more than the max slippage of %.2f%%",
+				currency, slippage, previous.Price, rate, maxSlippage,
+			)
+		}
+	}
+
+	sats := uint64(math.Round(amount / rate * float64(satsPerBTC)))
+	if sats < DUST {
+		return nil, fmt.Errorf(
+			"%.2f %s is %d sats, below the dust amount %d", amount, currency, sats, DUST,
+		)
+	}
+	return &fiatAmount{currency, amount, rate, sats}, nil
+}
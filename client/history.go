@@ -0,0 +1,226 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// kinds of operations recorded in the history.
+const (
+	historyOnchainSend       = "onchain_send"
+	historyOffchainSend      = "offchain_send"
+	historyOnboard           = "onboard"
+	historyCollaborativeExit = "collaborative_exit"
+	historyUnilateralExit    = "unilateral_exit"
+	historyRefresh           = "refresh"
+	historyConsolidate       = "consolidate"
+	historySplit             = "split"
+	historyClaim             = "claim"
+	historyCPFP              = "cpfp"
+	// historyReceive entries are only recovered by rescan, the payments
+	// received are not recorded otherwise.
+	historyReceive = "receive"
+)
+
+var historyCommand = cli.Command{
+	Name:   "history",
+	Usage:  "Shows the onchain sends, offchain payments, onboardings, refreshes, consolidations, splits and exits made by the wallet",
+	Action: historyAction,
+	Subcommands: []*cli.Command{
+		&historyExportCommand,
+	},
+}
+
+type historyEntry struct {
+	// id is the position of the entry in the history table.
+	id   int64
+	Kind string `json:"kind"`
+	// Txid is the id of the onchain tx, or of the pool tx for the operations
+	// settled in a round.
+	Txid string `json:"txid,omitempty"`
+	// Txids are the ids of the txs broadcasted by a unilateral exit, or the
+	// parent sped up by a cpfp.
+	Txids     []string   `json:"txids,omitempty"`
+	Amount    uint64     `json:"amount"`
+	Fee       uint64     `json:"fee,omitempty"`
+	Receivers []receiver `json:"receivers,omitempty"`
+	// Label is the one given to the operation with --label, if any.
+	Label     string `json:"label,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	// Fiat is the value of the amount at the time of the operation, filled
+	// only for display if a price feed is configured.
+	Fiat *fiatValue `json:"fiat,omitempty"`
+}
+
+func historyAction(ctx *cli.Context) error {
+	entries, err := getHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		if label, ok := labels[entry.Txid]; ok {
+			entries[i].Label = label
+		}
+	}
+
+	if err := addFiatValues(ctx, entries); err != nil {
+		return err
+	}
+
+	// most recent first.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return printJSON(entries)
+}
+
+func getHistory(ctx *cli.Context) ([]historyEntry, error) {
+	db, err := getStateDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(
+		"SELECT id, kind, txid, txids, amount, fee, receivers, created_at " +
+			"FROM history ORDER BY id;",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]historyEntry, 0)
+	for rows.Next() {
+		var entry historyEntry
+		var txids, receivers string
+		var amount, fee int64
+		if err := rows.Scan(
+			&entry.id, &entry.Kind, &entry.Txid, &txids, &amount, &fee,
+			&receivers, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entry.Amount, entry.Fee = uint64(amount), uint64(fee)
+		if err := json.Unmarshal([]byte(txids), &entry.Txids); err != nil {
+			return nil, fmt.Errorf("invalid history entry %d: %s", entry.id, err)
+		}
+		if err := json.Unmarshal([]byte(receivers), &entry.Receivers); err != nil {
+			return nil, fmt.Errorf("invalid history entry %d: %s", entry.id, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// addFiatValues fills the fiat value of the given entries at the rate of the
+// day of each operation, if a price feed is configured.
+func addFiatValues(ctx *cli.Context, entries []historyEntry) error {
+	prices, currency, err := getPriceProvider(ctx)
+	if err != nil {
+		return err
+	}
+	if prices == nil {
+		return nil
+	}
+
+	for i, entry := range entries {
+		rate, err := prices.getHistoricalPrice(
+			currency, time.Unix(entry.CreatedAt, 0),
+		)
+		if err != nil {
+			// the history is shown anyway if the price feed is down.
+			logger.Warn("failed to fetch the fiat price", "err", err)
+			return nil
+		}
+		entries[i].Fiat = newFiatValue(currency, rate, entry.Amount)
+	}
+	return nil
+}
+
+// recordHistoryEntry appends the given entry to the history. The operation
+// already took place at this point, therefore a failure is only reported.
+func recordHistoryEntry(ctx *cli.Context, entry historyEntry) {
+	if err := addHistoryEntry(ctx, entry); err != nil {
+		logger.Warn("failed to record history entry", "kind", entry.Kind, "err", err)
+	}
+}
+
+func addHistoryEntry(ctx *cli.Context, entry historyEntry) error {
+	entry.CreatedAt = time.Now().Unix()
+	return withStateTx(ctx, func(tx *sql.Tx) error {
+		return insertHistoryEntry(tx, entry)
+	})
+}
+
+// replaceHistoryTx makes the entry of the given tx, replaced by a fee bump,
+// point to the replacement tx, carrying its label over.
+func replaceHistoryTx(
+	ctx *cli.Context, txid, newTxid string, fee uint64,
+) error {
+	entries, err := getHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withStateTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			"UPDATE history SET txid = ?, fee = ? WHERE txid = ?;",
+			newTxid, int64(fee), txid,
+		); err != nil {
+			return err
+		}
+
+		// the txs of the unilateral exits.
+		for _, entry := range entries {
+			replaced := false
+			for i, id := range entry.Txids {
+				if id == txid {
+					entry.Txids[i], replaced = newTxid, true
+				}
+			}
+			if !replaced {
+				continue
+			}
+			txids, err := json.Marshal(entry.Txids)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(
+				"UPDATE history SET txids = ? WHERE id = ?;", string(txids), entry.id,
+			); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(
+			"INSERT OR IGNORE INTO label (id, label) "+
+				"SELECT ?, label FROM label WHERE id = ?;",
+			newTxid, txid,
+		)
+		return err
+	})
+}
+
+// getPsetFee returns the amount of the fee output of the given pset.
+func getPsetFee(b64 string) uint64 {
+	pset, err := psetv2.NewPsetFromBase64(b64)
+	if err != nil {
+		return 0
+	}
+	for _, out := range pset.Outputs {
+		if len(out.Script) <= 0 {
+			return out.Value
+		}
+	}
+	return 0
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// directions of the history entries in the export.
+const (
+	// funds sent to someone else.
+	directionOut = "out"
+	// funds moved between the onchain and offchain sides of the wallet.
+	directionSelf = "self"
+)
+
+const exportCurrency = "LBTC"
+
+var (
+	historyFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "format of the export (csv, json)",
+		Value: "csv",
+	}
+	historySinceFlag = cli.StringFlag{
+		Name:  "since",
+		Usage: "export only the entries from the given date on (YYYY-MM-DD or RFC3339)",
+	}
+	historyUntilFlag = cli.StringFlag{
+		Name:  "until",
+		Usage: "export only the entries before the given date (YYYY-MM-DD or RFC3339)",
+	}
+)
+
+var historyExportCommand = cli.Command{
+	Name:   "export",
+	Usage:  "Export the history for accounting, with amounts and fees in BTC",
+	Action: historyExportAction,
+	Flags:  []cli.Flag{&historyFormatFlag, &historySinceFlag, &historyUntilFlag},
+}
+
+type historyRecord struct {
+	Date         string `json:"date"`
+	Direction    string `json:"direction"`
+	Amount       string `json:"amount"`
+	Fee          string `json:"fee"`
+	Currency     string `json:"currency"`
+	Counterparty string `json:"counterparty"`
+	Txid         string `json:"txid"`
+	Kind         string `json:"kind"`
+	Label        string `json:"label"`
+	// the fiat columns are empty if no price feed is configured.
+	FiatValue    string `json:"fiat_value"`
+	FiatCurrency string `json:"fiat_currency"`
+}
+
+var historyRecordHeader = []string{
+	"Date", "Direction", "Amount", "Fee", "Currency", "Counterparty", "Txid",
+	"Kind", "Label", "Fiat Value", "Fiat Currency",
+}
+
+func (r historyRecord) toCSV() []string {
+	return []string{
+		r.Date, r.Direction, r.Amount, r.Fee, r.Currency, r.Counterparty, r.Txid,
+		r.Kind, r.Label, r.FiatValue, r.FiatCurrency,
+	}
+}
+
+func historyExportAction(ctx *cli.Context) error {
+	format := strings.ToLower(ctx.String(historyFormatFlag.Name))
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unsupported format %s, must be csv or json", format)
+	}
+
+	var since, until int64
+	if str := ctx.String(historySinceFlag.Name); len(str) > 0 {
+		t, err := parseDate(str)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %s", err)
+		}
+		since = t.Unix()
+	}
+	if str := ctx.String(historyUntilFlag.Name); len(str) > 0 {
+		t, err := parseDate(str)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %s", err)
+		}
+		until = t.Unix()
+	}
+
+	entries, err := getHistory(ctx)
+	if err != nil {
+		return err
+	}
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return err
+	}
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]historyEntry, 0, len(entries))
+	for _, entry := range entries {
+		if since > 0 && entry.CreatedAt < since {
+			continue
+		}
+		if until > 0 && entry.CreatedAt >= until {
+			continue
+		}
+		if label, ok := labels[entry.Txid]; ok {
+			entry.Label = label
+		}
+		filtered = append(filtered, entry)
+	}
+
+	if err := addFiatValues(ctx, filtered); err != nil {
+		return err
+	}
+
+	records := make([]historyRecord, 0, len(filtered))
+	for _, entry := range filtered {
+		records = append(records, toHistoryRecord(entry, onchainAddr))
+	}
+
+	if format == "json" {
+		return printJSON(records)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(historyRecordHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := w.Write(r.toCSV()); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func toHistoryRecord(
+	entry historyEntry, onchainAddr string,
+) historyRecord {
+	direction := directionOut
+	switch entry.Kind {
+	case historyOnboard, historyUnilateralExit, historyRefresh, historyConsolidate,
+		historySplit, historyClaim:
+		direction = directionSelf
+	case historyCollaborativeExit:
+		// the collaborative exit might pay an external address.
+		if len(entry.Receivers) <= 0 || entry.Receivers[0].To == onchainAddr {
+			direction = directionSelf
+		}
+	}
+
+	counterparties := make([]string, 0, len(entry.Receivers))
+	for _, r := range entry.Receivers {
+		if len(r.Label) > 0 {
+			counterparties = append(counterparties, r.Label)
+			continue
+		}
+		counterparties = append(counterparties, r.To)
+	}
+
+	txid := entry.Txid
+	if len(txid) <= 0 {
+		txid = strings.Join(entry.Txids, ";")
+	}
+
+	fiatAmount, fiatCurrency := "", ""
+	if entry.Fiat != nil {
+		fiatAmount = strconv.FormatFloat(entry.Fiat.Amount, 'f', 2, 64)
+		fiatCurrency = strings.ToUpper(entry.Fiat.Currency)
+	}
+
+	return historyRecord{
+		Date:         time.Unix(entry.CreatedAt, 0).UTC().Format("2006-01-02 15:04:05"),
+		Direction:    direction,
+		Amount:       formatBTCAmount(entry.Amount),
+		Fee:          formatBTCAmount(entry.Fee),
+		Currency:     exportCurrency,
+		Counterparty: strings.Join(counterparties, ";"),
+		Txid:         txid,
+		Kind:         entry.Kind,
+		Label:        entry.Label,
+		FiatValue:    fiatAmount,
+		FiatCurrency: fiatCurrency,
+	}
+}
+
+// formatBTCAmount is the inverse of parseBTCAmount.
+func formatBTCAmount(sats uint64) string {
+	return strconv.FormatUint(sats/satsPerBTC, 10) + "." +
+		fmt.Sprintf("%08d", sats%satsPerBTC)
+}
+
+func parseDate(str string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", str); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, str)
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	invoicePrefix  = "arkinv1"
+	invoiceVersion = 1
+	// invoiceSigTag domain-separates the signatures of the invoices.
+	invoiceSigTag = "ark/invoice"
+)
+
+var (
+	invoiceAmountFlag = cli.Uint64Flag{
+		Name:     "amount",
+		Usage:    "amount to invoice in sats",
+		Required: true,
+	}
+	invoiceDescriptionFlag = cli.StringFlag{
+		Name:  "description",
+		Usage: "description of the payment, shown to the payer",
+	}
+	invoiceExpiryFlag = cli.DurationFlag{
+		Name:  "expiry",
+		Usage: "validity of the invoice",
+		Value: 24 * time.Hour,
+	}
+	invoiceFlag = cli.StringFlag{
+		Name:     "invoice",
+		Usage:    "invoice created with invoice create",
+		Required: true,
+	}
+)
+
+var invoiceCommand = cli.Command{
+	Name:  "invoice",
+	Usage: "Create, decode and pay invoices signed by the key of the receiver",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "create",
+			Usage:  "Create an invoice to be paid offchain to the wallet",
+			Action: invoiceCreateAction,
+			Flags: []cli.Flag{
+				&invoiceAmountFlag, &invoiceDescriptionFlag, &invoiceExpiryFlag,
+				&passwordFlag,
+			},
+		},
+		{
+			Name:   "decode",
+			Usage:  "Decode an invoice and verify its signature",
+			Action: invoiceDecodeAction,
+			Flags:  []cli.Flag{&invoiceFlag},
+		},
+		{
+			Name:   "pay",
+			Usage:  "Pay an invoice after verifying its signature and expiry",
+			Action: withWalletLock(invoicePayAction),
+			Flags: []cli.Flag{
+				&invoiceFlag, &passwordFlag, &enableExpiryCoinselectFlag,
+				&coinSelectionFlag, &minLifetimeFlag, &allowExpiringFlag,
+				&roundRetriesFlag, &reviewFlag, &dryRunFlag,
+			},
+		},
+	},
+}
+
+// invoice is a payment request signed by the user key of its ark address,
+// so that the payer can prove whom the payment was requested by. It's JSON
+// and base64url encoded after invoicePrefix.
+type invoice struct {
+	Version     int    `json:"version"`
+	Network     string `json:"network"`
+	Address     string `json:"address"`
+	Amount      uint64 `json:"amount"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+	ExpiresAt   int64  `json:"expires_at"`
+	// Signature is the hex BIP340 signature of sigHash.
+	Signature string `json:"signature,omitempty"`
+}
+
+// sigHash is the tagged hash of the invoice without the signature.
+func (i invoice) sigHash() ([]byte, error) {
+	i.Signature = ""
+	buf, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.TaggedHash([]byte(invoiceSigTag), buf).CloneBytes(), nil
+}
+
+func (i *invoice) encode() (string, error) {
+	buf, err := json.Marshal(i)
+	if err != nil {
+		return "", err
+	}
+	return invoicePrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// verify checks the signature against the user key of the address.
+func (i *invoice) verify() error {
+	addr, err := common.DecodeArkAddress(i.Address)
+	if err != nil {
+		return fmt.Errorf("invalid invoice address: %s", err)
+	}
+
+	buf, err := hex.DecodeString(i.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid invoice signature: %s", err)
+	}
+	sig, err := schnorr.ParseSignature(buf)
+	if err != nil {
+		return fmt.Errorf("invalid invoice signature: %s", err)
+	}
+
+	hash, err := i.sigHash()
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(hash, addr.UserKey) {
+		return fmt.Errorf("invalid invoice signature")
+	}
+	return nil
+}
+
+func (i *invoice) isExpired() bool {
+	return time.Now().Unix() >= i.ExpiresAt
+}
+
+func decodeInvoice(str string) (*invoice, error) {
+	if !strings.HasPrefix(str, invoicePrefix) {
+		return nil, fmt.Errorf("invalid invoice: missing %s prefix", invoicePrefix)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(
+		strings.TrimPrefix(str, invoicePrefix),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice: %s", err)
+	}
+
+	inv := &invoice{}
+	if err := json.Unmarshal(buf, inv); err != nil {
+		return nil, fmt.Errorf("invalid invoice: %s", err)
+	}
+	if inv.Version != invoiceVersion {
+		return nil, fmt.Errorf("unsupported invoice version %d", inv.Version)
+	}
+	if len(inv.Address) <= 0 || inv.Amount <= 0 {
+		return nil, fmt.Errorf("invalid invoice: missing address or amount")
+	}
+	return inv, nil
+}
+
+func invoiceCreateAction(ctx *cli.Context) error {
+	amount := ctx.Uint64(invoiceAmountFlag.Name)
+	if amount < DUST {
+		return fmt.Errorf("invalid amount, must be at least %d", DUST)
+	}
+	expiry := ctx.Duration(invoiceExpiryFlag.Name)
+	if expiry <= 0 {
+		return fmt.Errorf("invalid expiry, must be positive")
+	}
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	_, net := getNetwork(ctx)
+
+	now := time.Now()
+	inv := &invoice{
+		Version:     invoiceVersion,
+		Network:     net.Name,
+		Address:     offchainAddr,
+		Amount:      amount,
+		Description: ctx.String(invoiceDescriptionFlag.Name),
+		CreatedAt:   now.Unix(),
+		ExpiresAt:   now.Add(expiry).Unix(),
+	}
+
+	hash, err := inv.sigHash()
+	if err != nil {
+		return err
+	}
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.signHash(hash)
+	if err != nil {
+		return err
+	}
+	inv.Signature = hex.EncodeToString(sig.Serialize())
+
+	encoded, err := inv.encode()
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"invoice":    encoded,
+		"amount":     inv.Amount,
+		"expires_at": time.Unix(inv.ExpiresAt, 0).Format("2006-01-02 15:04:05"),
+	})
+}
+
+func invoiceDecodeAction(ctx *cli.Context) error {
+	inv, err := decodeInvoice(ctx.String(invoiceFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	res := map[string]interface{}{
+		"network":         inv.Network,
+		"address":         inv.Address,
+		"amount":          inv.Amount,
+		"description":     inv.Description,
+		"created_at":      time.Unix(inv.CreatedAt, 0).Format("2006-01-02 15:04:05"),
+		"expires_at":      time.Unix(inv.ExpiresAt, 0).Format("2006-01-02 15:04:05"),
+		"expired":         inv.isExpired(),
+		"valid_signature": true,
+	}
+	if err := inv.verify(); err != nil {
+		res["valid_signature"] = false
+	}
+	return printJSON(res)
+}
+
+func invoicePayAction(ctx *cli.Context) error {
+	inv, err := decodeInvoice(ctx.String(invoiceFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := inv.verify(); err != nil {
+		return err
+	}
+
+	_, net := getNetwork(ctx)
+	if inv.Network != net.Name {
+		return fmt.Errorf("invoice is for %s network", inv.Network)
+	}
+	if inv.isExpired() {
+		return fmt.Errorf(
+			"invoice expired at %s",
+			time.Unix(inv.ExpiresAt, 0).Format("2006-01-02 15:04:05"),
+		)
+	}
+	if _, err := validateContactAddress(ctx, inv.Address); err != nil {
+		return fmt.Errorf("invalid invoice: %s", err)
+	}
+
+	return sendToReceivers(ctx, []receiver{
+		{
+			To:     inv.Address,
+			Amount: inv.Amount,
+			Label:  inv.Description,
+		},
+	})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"database/sql"
+
+	"github.com/urfave/cli/v2"
+)
+
+var labelFlag = cli.StringFlag{
+	Name:  "label",
+	Usage: "label stored in the wallet along with the resulting txid and shown by history and balance",
+}
+
+// labeledVtxo is a vtxo whose txid, or the one of the round that created it,
+// has a label.
+type labeledVtxo struct {
+	Outpoint string `json:"outpoint"`
+	Amount   uint64 `json:"amount"`
+	Label    string `json:"label"`
+}
+
+// getLabels returns the labels of the wallet by txid, pool txid or address.
+func getLabels(ctx *cli.Context) (map[string]string, error) {
+	db, err := getStateDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT id, label FROM label;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := make(map[string]string)
+	for rows.Next() {
+		var id, label string
+		if err := rows.Scan(&id, &label); err != nil {
+			return nil, err
+		}
+		labels[id] = label
+	}
+	return labels, rows.Err()
+}
+
+func setLabels(ctx *cli.Context, label string, ids ...string) error {
+	labels := make(map[string]string, len(ids))
+	for _, id := range ids {
+		labels[id] = label
+	}
+	return withStateTx(ctx, func(tx *sql.Tx) error {
+		return upsertLabels(tx, labels)
+	})
+}
+
+// recordLabel stores the --label, if any, for the given txid. Like
+// recordHistoryEntry, a failure is only reported.
+func recordLabel(ctx *cli.Context, txid string) {
+	label := ctx.String(labelFlag.Name)
+	if len(label) <= 0 || len(txid) <= 0 {
+		return
+	}
+	if err := setLabels(ctx, label, txid); err != nil {
+		logger.Warn("failed to store label", "txid", txid, "err", err)
+	}
+}
+
+// vtxoLabel returns the label of the vtxo's txid, falling back to the one of
+// its pool tx.
+func vtxoLabel(labels map[string]string, v vtxo) string {
+	if label, ok := labels[v.txid]; ok {
+		return label
+	}
+	return labels[v.poolTxid]
+}
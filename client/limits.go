@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// spendingWindow is the period the daily cap applies to, rolling.
+const spendingWindow = 24 * time.Hour
+
+var (
+	perTxLimitFlag = cli.Uint64Flag{
+		Name:  "per-tx",
+		Usage: "max amount in sats of a single send, 0 to disable the cap",
+	}
+	perDayLimitFlag = cli.Uint64Flag{
+		Name:  "per-day",
+		Usage: "max amount in sats sent in the last 24 hours, 0 to disable the cap",
+	}
+	overrideLimitsFlag = cli.BoolFlag{
+		Name:  "override-limits",
+		Usage: "exceed the spending limits for this send or exit, the password is asked again to confirm",
+	}
+)
+
+var limitsCommand = cli.Command{
+	Name:  "limits",
+	Usage: "Manage the caps on the amounts sent per tx and per day",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "show",
+			Usage:  "Show the spending limits and the amount sent in the last 24 hours",
+			Action: limitsShowAction,
+		},
+		{
+			Name:   "set",
+			Usage:  "Set the spending limits, the password is required",
+			Action: withWalletLock(limitsSetAction),
+			Flags:  []cli.Flag{&perTxLimitFlag, &perDayLimitFlag, &passwordFlag},
+		},
+	},
+}
+
+// spendingLimits are the caps enforced by send, a zero value disables the
+// corresponding cap.
+type spendingLimits struct {
+	PerTx  uint64 `json:"per_tx"`
+	PerDay uint64 `json:"per_day"`
+}
+
+func limitsShowAction(ctx *cli.Context) error {
+	limits, err := getSpendingLimits(ctx)
+	if err != nil {
+		return err
+	}
+	spent, err := getSpentInWindow(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"per_tx":         limits.PerTx,
+		"per_day":        limits.PerDay,
+		"spent_last_day": spent,
+	})
+}
+
+func limitsSetAction(ctx *cli.Context) error {
+	if !ctx.IsSet(perTxLimitFlag.Name) && !ctx.IsSet(perDayLimitFlag.Name) {
+		return fmt.Errorf("missing limits, set --per-tx and/or --per-day")
+	}
+
+	// the limits protect an unlocked or unattended wallet, they can't be
+	// changed without the password.
+	if _, err := readPassword(ctx, true); err != nil {
+		return err
+	}
+
+	limits, err := getSpendingLimits(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet(perTxLimitFlag.Name) {
+		limits.PerTx = ctx.Uint64(perTxLimitFlag.Name)
+	}
+	if ctx.IsSet(perDayLimitFlag.Name) {
+		limits.PerDay = ctx.Uint64(perDayLimitFlag.Name)
+	}
+
+	buf, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+	if err := setState(ctx, map[string]string{SPENDING_LIMITS: string(buf)}); err != nil {
+		return err
+	}
+	return printJSON(limits)
+}
+
+// checkSpendingLimits returns an error if sending to the given receivers
+// exceeds the spending limits, unless overridden with --override-limits
+// and the password entered again.
+func checkSpendingLimits(ctx *cli.Context, receivers []receiver) error {
+	limits, err := getSpendingLimits(ctx)
+	if err != nil {
+		return err
+	}
+	if limits.PerTx <= 0 && limits.PerDay <= 0 {
+		return nil
+	}
+
+	amount := uint64(0)
+	for _, r := range receivers {
+		amount += r.Amount
+	}
+
+	var exceeded error
+	if limits.PerTx > 0 && amount > limits.PerTx {
+		exceeded = fmt.Errorf(
+			"amount %d exceeds the per tx limit %d", amount, limits.PerTx,
+		)
+	}
+	if exceeded == nil && limits.PerDay > 0 {
+		spent, err := getSpentInWindow(ctx)
+		if err != nil {
+			return err
+		}
+		if spent+amount > limits.PerDay {
+			exceeded = fmt.Errorf(
+				"amount %d exceeds the per day limit %d, %d already sent in the "+
+					"last 24 hours", amount, limits.PerDay, spent,
+			)
+		}
+	}
+	if exceeded == nil {
+		return nil
+	}
+
+	if !ctx.Bool(overrideLimitsFlag.Name) {
+		return fmt.Errorf("%s, use --override-limits to send anyway", exceeded)
+	}
+	logger.Warn("overriding spending limits", "reason", exceeded)
+	return confirmPassword(ctx)
+}
+
+// confirmPassword asks the password again, even if given with --password or
+// if the wallet is unlocked already, like in the repl.
+func confirmPassword(ctx *cli.Context) error {
+	fmt.Fprint(os.Stderr, "confirm with your password: ")
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr) // new line
+	if err != nil {
+		return err
+	}
+	return verifyPassword(ctx, password)
+}
+
+func getSpendingLimits(ctx *cli.Context) (*spendingLimits, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := &spendingLimits{}
+	if len(state[SPENDING_LIMITS]) <= 0 {
+		return limits, nil
+	}
+	if err := json.Unmarshal([]byte(state[SPENDING_LIMITS]), limits); err != nil {
+		return nil, fmt.Errorf("invalid spending limits: %s", err)
+	}
+	return limits, nil
+}
+
+// getSpentInWindow returns the amount sent, onchain and offchain, in the last
+// 24 hours according to the history. The collaborative exits count along with
+// their fees, like when checked against the limits.
+func getSpentInWindow(ctx *cli.Context) (uint64, error) {
+	entries, err := getHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	since := time.Now().Add(-spendingWindow).Unix()
+	spent := uint64(0)
+	for _, entry := range entries {
+		if entry.CreatedAt < since {
+			continue
+		}
+		switch entry.Kind {
+		case historyOnchainSend, historyOffchainSend:
+			spent += entry.Amount
+		case historyCollaborativeExit:
+			spent += entry.Amount + entry.Fee
+		}
+	}
+	return spent, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var listVtxosCommand = cli.Command{
+	Name:   "list-vtxos",
+	Usage:  "List the spendable vtxos with their expiry and whether they're locked in a round or being redeemed unilaterally",
+	Action: listVtxosAction,
+}
+
+type vtxoStatus struct {
+	Outpoint string `json:"outpoint"`
+	Amount   uint64 `json:"amount"`
+	PoolTxid string `json:"pool_txid"`
+	// PoolTxConfirmed is false for vtxos of a round not final yet.
+	PoolTxConfirmed bool   `json:"pool_tx_confirmed"`
+	ExpireAt        int64  `json:"expire_at,omitempty"`
+	ExpiryTime      string `json:"expiry_time,omitempty"`
+	// InPendingRound is true for vtxos spent by a payment registered for a
+	// round not completed yet.
+	InPendingRound bool `json:"in_pending_round"`
+	// Frozen is true for vtxos excluded from the coin selection with coin
+	// lock.
+	Frozen bool `json:"frozen"`
+	// PublishedBranchTxs is the number of txs of the redeem branch, from the
+	// pool tx to the vtxo, already onchain, out of BranchTxs.
+	PublishedBranchTxs int    `json:"published_branch_txs"`
+	BranchTxs          int    `json:"branch_txs"`
+	Label              string `json:"label,omitempty"`
+}
+
+func listVtxosAction(ctx *cli.Context) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	explorer := NewExplorer(ctx)
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+
+	lockedInRound := make(map[string]struct{})
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+	if round != nil {
+		for _, in := range round.Inputs {
+			lockedInRound[in] = struct{}{}
+		}
+	}
+
+	frozen, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	redeemBranches, err := getRedeemBranches(ctx.Context, explorer, client, vtxos)
+	if err != nil {
+		return err
+	}
+
+	confirmedPoolTxs := make(map[string]bool)
+	list := make([]vtxoStatus, 0, len(vtxos))
+	for _, v := range vtxos {
+		outpoint := fmt.Sprintf("%s:%d", v.txid, v.vout)
+		status := vtxoStatus{
+			Outpoint: outpoint,
+			Amount:   v.amount,
+			PoolTxid: v.poolTxid,
+			Label:    vtxoLabel(labels, v),
+		}
+
+		confirmed, ok := confirmedPoolTxs[v.poolTxid]
+		if !ok {
+			// nolint:all
+			confirmed, _, _ = getTxBlocktime(ctx, v.poolTxid)
+			confirmedPoolTxs[v.poolTxid] = confirmed
+		}
+		status.PoolTxConfirmed = confirmed
+
+		if v.expireAt != nil {
+			status.ExpireAt = v.expireAt.Unix()
+			status.ExpiryTime = v.expireAt.Format("2006-01-02 15:04:05")
+		}
+		if _, ok := lockedInRound[outpoint]; ok {
+			status.InPendingRound = true
+		}
+		status.Frozen = frozen.has(v.txid, v.vout)
+
+		if branch, ok := redeemBranches[v.txid]; ok {
+			offchainPath, err := branch.offchainPath()
+			if err != nil {
+				return err
+			}
+			status.BranchTxs = len(branch.branch)
+			status.PublishedBranchTxs = len(branch.branch) - len(offchainPath)
+		}
+
+		list = append(list, status)
+	}
+
+	return printJSON(list)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// walletLockRetryInterval is how often the long-running commands retry to
+// acquire the wallet lock while held by another command.
+const walletLockRetryInterval = time.Second
+
+var errWalletBusy = errors.New("wallet is busy")
+
+// walletLocked tells whether this process holds the wallet lock. The state
+// derived from the ASP while reading the vtxos is written only if so, not to
+// race with the commands spending them.
+var walletLocked atomic.Bool
+
+type walletLock struct {
+	Operation string `json:"operation"`
+	Pid       int    `json:"pid"`
+	StartedAt int64  `json:"started_at"`
+}
+
+// withWalletLock makes the given action hold an exclusive advisory lock on
+// the wallet's datadir while it runs, so that concurrent invocations can't
+// select the same vtxos or interleave their writes to the state.
+func withWalletLock(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		unlock, err := lockWallet(ctx, ctx.Command.Name)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// make sure to know the outcome of any operation left pending by a
+		// previous invocation before doing anything else.
+		if err := reconcileOutbox(ctx); err != nil {
+			logger.Warn("failed to reconcile pending operations", "err", err)
+		}
+
+		return action(ctx)
+	}
+}
+
+func lockWallet(ctx *cli.Context, operation string) (func(), error) {
+	datadir := ctx.String("datadir")
+	lockFilePath := filepath.Join(datadir, LOCK_FILE)
+
+	file, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %s", err)
+	}
+
+	if err := syscall.Flock(
+		int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB,
+	); err != nil {
+		defer file.Close()
+
+		if err != syscall.EWOULDBLOCK {
+			return nil, fmt.Errorf("failed to lock wallet: %s", err)
+		}
+
+		holder := &walletLock{}
+		buf, _ := os.ReadFile(lockFilePath)
+		if err := json.Unmarshal(buf, holder); err != nil {
+			return nil, fmt.Errorf("%w with another operation", errWalletBusy)
+		}
+
+		return nil, fmt.Errorf(
+			"%w with operation '%s' (pid %d, started at %s)", errWalletBusy,
+			holder.Operation, holder.Pid,
+			time.Unix(holder.StartedAt, 0).Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	buf, err := json.Marshal(walletLock{
+		Operation: operation,
+		Pid:       os.Getpid(),
+		StartedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(0); err != nil {
+		return nil, err
+	}
+	if _, err := file.WriteAt(buf, 0); err != nil {
+		return nil, err
+	}
+
+	walletLocked.Store(true)
+	unlock := func() {
+		walletLocked.Store(false)
+		// nolint
+		file.Truncate(0)
+		// nolint
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}
+
+	return unlock, nil
+}
+
+// withWalletLockWait runs the given function holding the wallet lock, waiting
+// for it while held by another command. The long-running commands, like
+// watch, serve and daemon, can't hold the lock for their whole life, they
+// hold it instead every time they update the state.
+func withWalletLockWait(ctx *cli.Context, fn func() error) error {
+	for {
+		unlock, err := lockWallet(ctx, ctx.Command.Name)
+		if err == nil {
+			defer unlock()
+			return fn()
+		}
+		if !errors.Is(err, errWalletBusy) {
+			return err
+		}
+
+		select {
+		case <-ctx.Context.Done():
+			return ctx.Context.Err()
+		case <-time.After(walletLockRetryInterval):
+		}
+	}
+}
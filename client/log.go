@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	LOG_LEVEL_ENVVAR = "ARK_LOG_LEVEL"
+	LOG_FILE_ENVVAR  = "ARK_LOG_FILE"
+	defaultLogLevel  = "warn"
+)
+
+var (
+	logLevelFlag = &cli.StringFlag{
+		Name:    "log-level",
+		Usage:   "Specify the level of the logs, one of debug, info, warn and error",
+		Value:   defaultLogLevel,
+		EnvVars: []string{LOG_LEVEL_ENVVAR},
+	}
+	logFileFlag = &cli.StringFlag{
+		Name:    "log-file",
+		Usage:   "Specify the file the logs are appended to instead of being written to stderr",
+		EnvVars: []string{LOG_FILE_ENVVAR},
+	}
+)
+
+// logger is the structured logger of the client, the diagnostics go through
+// it while the results of the commands are printed to stdout.
+var logger = newLogger(os.Stderr, slog.LevelWarn)
+
+// logFile is the file opened with --log-file, if any.
+var logFile *os.File
+
+func newLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+func parseLogLevel(str string) (slog.Level, error) {
+	switch strings.ToLower(str) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf(
+			"invalid log level %s, must be one of debug, info, warn and error", str,
+		)
+	}
+}
+
+// setupLogger makes the logger write the logs of the given level, or above,
+// to the given file or, if empty, to stderr.
+func setupLogger(levelStr, path string) error {
+	level, err := parseLogLevel(levelStr)
+	if err != nil {
+		return err
+	}
+
+	// the repl sets up the logger again before every command.
+	closeLogFile()
+
+	var w io.Writer = os.Stderr
+	if len(path) > 0 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %s", err)
+		}
+		logFile = f
+		w = f
+	}
+	logger = newLogger(w, level)
+	return nil
+}
+
+func closeLogFile() {
+	if logFile == nil {
+		return
+	}
+	// nolint
+	logFile.Close()
+	logFile = nil
+	logger = newLogger(os.Stderr, slog.LevelWarn)
+}
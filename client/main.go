@@ -1,6 +1,9 @@
 package main
 
 import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,8 +19,12 @@ import (
 const (
 	DATADIR_ENVVAR = "ARK_WALLET_DATADIR"
 
-	STATE_FILE     = "state.json"
-	defaultNetwork = "liquid"
+	// STATE_FILE is the json state of older versions, migrated to
+	// STATE_DB_FILE.
+	STATE_FILE      = "state.json"
+	LOCK_FILE       = "wallet.lock"
+	BACKUP_FILE_EXT = ".bak"
+	defaultNetwork  = "liquid"
 
 	ASP_URL               = "asp_url"
 	ASP_PUBKEY            = "asp_public_key"
@@ -28,6 +35,30 @@ const (
 	PUBKEY                = "public_key"
 	NETWORK               = "network"
 	EXPLORER              = "explorer"
+	PENDING_ROUND         = "pending_round"
+	STATE_CHECKSUM        = "checksum"
+	KNOWN_VTXOS           = "known_vtxos"
+	DEVICE_ID             = "device_id"
+	SYNC_CURSOR           = "sync_cursor"
+	SYNC_EPOCH            = "sync_epoch"
+	SYNC_LAST_UPDATE      = "sync_last_update"
+	REMOTE_PENDING_ROUNDS = "remote_pending_rounds"
+	REMOTE_OUTBOXES       = "remote_outboxes"
+	WATCH_CHECKPOINT      = "watch_checkpoint"
+	OUTBOX                = "outbox"
+	HISTORY               = "history"
+	LABELS                = "labels"
+	CONTACTS              = "contacts"
+	EXTERNAL_SIGNER       = "external_signer"
+	PRVKEY_KDF            = "private_key_kdf"
+	PENDING_EXIT          = "pending_exit"
+	ASPS                  = "asps"
+	LOCKED_COINS          = "locked_coins"
+	SCHEDULED_PAYMENTS    = "scheduled_payments"
+	STANDING_ORDERS       = "standing_orders"
+	SPENDING_LIMITS       = "spending_limits"
+	VTXO_CLUSTERS         = "vtxo_clusters"
+	CHAIN_EVENTS          = "chain_events"
 )
 
 var (
@@ -35,6 +66,14 @@ var (
 
 	defaultDatadir = common.AppDataDir("ark-cli", false)
 
+	// clientCapabilities are the protocol features supported by this client,
+	// advertised by its addresses.
+	clientCapabilities = common.Capabilities(0).
+				Set(common.CapabilityCovenantTree, false).
+				Set(common.CapabilityCovenantlessTree, false)
+
+	// explorerUrl are the default explorers by network, overridden by the
+	// explorers of the config file.
 	explorerUrl = map[string]string{
 		network.Liquid.Name:  "https://blockstream.info/liquid/api",
 		network.Testnet.Name: "https://blockstream.info/liquidtestnet/api",
@@ -77,9 +116,63 @@ func main() {
 		&redeemCommand,
 		&sendCommand,
 		&onboardCommand,
+		&syncCommand,
+		&watchCommand,
+		&outboxCommand,
+		&historyCommand,
+		&contactsCommand,
+		&signCommand,
+		&broadcastCommand,
+		&backupCommand,
+		&changePasswordCommand,
+		&listVtxosCommand,
+		&refreshCommand,
+		&exitCommand,
+		&invoiceCommand,
+		&bumpFeeCommand,
+		&replCommand,
+		&aspCommand,
+		&aspInfoCommand,
+		&resumeCommand,
+		&coinCommand,
+		&consolidateCommand,
+		&splitCommand,
+		&claimCommand,
+		&signMessageCommand,
+		&verifyMessageCommand,
+		&estimateFeeCommand,
+		&decodeCommand,
+		&treeCommand,
+		&receiptCommand,
+		&rescanCommand,
+		&eventsCommand,
+		&cpfpCommand,
+		&pendingCommand,
+		&recurringCommand,
+		&daemonCommand,
+		&limitsCommand,
+		&serveCommand,
 	)
 	app.Flags = []cli.Flag{
 		datadirFlag,
+		configFileFlag,
+		priceFeedFlag,
+		fiatCurrencyFlag,
+		outputFlag,
+		proxyFlag,
+		tlsCertFlag,
+		tlsClientCertFlag,
+		tlsClientKeyFlag,
+		insecureFlag,
+		rpcTimeoutFlag,
+		rpcRetriesFlag,
+		rpcBackoffFlag,
+		explorerRateLimitFlag,
+		explorerRetriesFlag,
+		explorerBackoffFlag,
+		aspFlag,
+		logLevelFlag,
+		logFileFlag,
 	}
 
 	app.Before = func(ctx *cli.Context) error {
@@ -89,9 +182,50 @@ func main() {
 			return err
 		}
 
+		if err := loadConfigFile(
+			cleanAndExpandPath(ctx.String(configFileFlag.Name)),
+		); err != nil {
+			return err
+		}
+
+		outputFormat = globalFlagValue(ctx, outputFlag)
+		if err := validateOutputFormat(outputFormat); err != nil {
+			return err
+		}
+
+		if err := setupLogger(
+			globalFlagValue(ctx, logLevelFlag),
+			cleanAndExpandPath(globalFlagValue(ctx, logFileFlag)),
+		); err != nil {
+			return err
+		}
+
+		if err := setupProxy(globalFlagValue(ctx, proxyFlag)); err != nil {
+			return err
+		}
+		if err := setupTLS(ctx); err != nil {
+			return err
+		}
+		setRPCPolicy(ctx)
+		setExplorerPolicy(ctx)
+
 		if _, err := os.Stat(datadir); os.IsNotExist(err) {
-			return os.Mkdir(datadir, os.ModeDir|0755)
+			if err := os.Mkdir(datadir, os.ModeDir|0755); err != nil {
+				return err
+			}
 		}
+
+		return setSelectedAsp(ctx)
+	}
+
+	app.After = func(ctx *cli.Context) error {
+		// the commands run by a session share the state db and the log file
+		// with the session itself, that closes them once over.
+		if replSession != nil {
+			return nil
+		}
+		closeStateDB()
+		closeLogFile()
 		return nil
 	}
 
@@ -129,57 +263,100 @@ func cleanAndExpandPath(path string) string {
 }
 
 func getState(ctx *cli.Context) (map[string]string, error) {
-	datadir := ctx.String("datadir")
-	stateFilePath := filepath.Join(datadir, STATE_FILE)
-	file, err := os.ReadFile(stateFilePath)
+	db, err := getStateDB(ctx)
 	if err != nil {
-		if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT key, value FROM state;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
 			return nil, err
 		}
-		if err := setInitialState(stateFilePath); err != nil {
-			return nil, err
+		data[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if selectedAsp != nil {
+			for key, value := range selectedAsp.stateEntries() {
+				data[key] = value
+			}
 		}
-		return initialState, nil
+		return data, nil
 	}
 
-	data := map[string]string{}
-	if err := json.Unmarshal(file, &data); err != nil {
+	if err := setInitialState(ctx); err != nil {
 		return nil, err
 	}
+	return merge(initialState), nil
+}
 
-	return data, nil
+func setInitialState(ctx *cli.Context) error {
+	return withStateTx(ctx, func(tx *sql.Tx) error {
+		return upsertState(tx, initialState)
+	})
 }
 
-func setInitialState(stateFilePath string) error {
-	jsonString, err := json.Marshal(initialState)
-	if err != nil {
-		return err
+// setState updates the given entries of the state in a single transaction,
+// so that a crash mid-write can never leave the state partially updated.
+func setState(ctx *cli.Context, data map[string]string) error {
+	if err := withStateTx(ctx, func(tx *sql.Tx) error {
+		return upsertState(tx, data)
+	}); err != nil {
+		return fmt.Errorf("writing state: %w", err)
 	}
-	return os.WriteFile(stateFilePath, jsonString, 0755)
+	return nil
 }
 
-func setState(ctx *cli.Context, data map[string]string) error {
-	currentData, err := getState(ctx)
+// readStateFile reads the json state file of older versions at the given
+// path and validates its checksum. State files written before the
+// introduction of the checksum are accepted as they are.
+func readStateFile(path string) (map[string]string, error) {
+	file, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	mergedData := merge(currentData, data)
+	data := map[string]string{}
+	if err := json.Unmarshal(file, &data); err != nil {
+		return nil, err
+	}
+
+	checksum, ok := data[STATE_CHECKSUM]
+	if !ok {
+		return data, nil
+	}
+	delete(data, STATE_CHECKSUM)
 
-	jsonString, err := json.Marshal(mergedData)
+	expectedChecksum, err := stateChecksum(data)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if checksum != expectedChecksum {
+		return nil, fmt.Errorf("checksum mismatch")
 	}
 
-	datadir := ctx.String("datadir")
-	statePath := filepath.Join(datadir, STATE_FILE)
+	return data, nil
+}
 
-	err = os.WriteFile(statePath, jsonString, 0755)
+func stateChecksum(data map[string]string) (string, error) {
+	// json encoding of maps is sorted by key, hence deterministic.
+	buf, err := json.Marshal(data)
 	if err != nil {
-		return fmt.Errorf("writing to file: %w", err)
+		return "", err
 	}
-
-	return nil
+	checksum := sha256.Sum256(buf)
+	return hex.EncodeToString(checksum[:]), nil
 }
 
 func merge(maps ...map[string]string) map[string]string {
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const mempoolURLPrefix = "mempool+"
+
+func isMempoolURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, mempoolURLPrefix)
+}
+
+// mempoolExplorer is the Explorer backed by a mempool.space API, like
+// mempool+https://liquid.network/api. It's Esplora compatible, with its own
+// fee recommendations.
+type mempoolExplorer struct {
+	*explorer
+}
+
+func newMempoolExplorer(rawURL string) *mempoolExplorer {
+	return &mempoolExplorer{
+		newEsploraExplorer(strings.TrimPrefix(rawURL, mempoolURLPrefix)),
+	}
+}
+
+func (e *mempoolExplorer) GetFeeRate() (float64, error) {
+	estimates, err := e.GetFeeEstimates()
+	if err != nil {
+		return 0, err
+	}
+	return estimates.Fastest, nil
+}
+
+func (e *mempoolExplorer) GetFeeEstimates() (*feeEstimates, error) {
+	resp, err := explorerHTTPClient.Get(fmt.Sprintf("%s/v1/fees/recommended", e.baseUrl))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(string(body))
+	}
+
+	var fees struct {
+		FastestFee  float64 `json:"fastestFee"`
+		HalfHourFee float64 `json:"halfHourFee"`
+		HourFee     float64 `json:"hourFee"`
+	}
+	if err := json.Unmarshal(body, &fees); err != nil {
+		return nil, err
+	}
+	return &feeEstimates{
+		Fastest:  fees.FastestFee,
+		HalfHour: fees.HalfHourFee,
+		Hour:     fees.HourFee,
+	}, nil
+}
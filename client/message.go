@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/urfave/cli/v2"
+)
+
+// messageSigTag domain-separates the signatures of the messages, so that
+// they can't be replayed as signatures of txs or invoices.
+const messageSigTag = "ark/message"
+
+var (
+	messageFlag = cli.StringFlag{
+		Name:     "message",
+		Usage:    "message to sign or verify",
+		Required: true,
+	}
+	messageAddressFlag = cli.StringFlag{
+		Name:     "address",
+		Usage:    "ark address the message is signed by",
+		Required: true,
+	}
+	messageSignatureFlag = cli.StringFlag{
+		Name:     "signature",
+		Usage:    "hex signature returned by signmessage",
+		Required: true,
+	}
+)
+
+var signMessageCommand = cli.Command{
+	Name:   "signmessage",
+	Usage:  "Sign a message with the key of the offchain address, to prove its ownership to third parties",
+	Action: signMessageAction,
+	Flags:  []cli.Flag{&messageFlag, &passwordFlag},
+}
+
+var verifyMessageCommand = cli.Command{
+	Name:   "verifymessage",
+	Usage:  "Verify a message signed with signmessage by the owner of an ark address",
+	Action: verifyMessageAction,
+	Flags:  []cli.Flag{&messageAddressFlag, &messageFlag, &messageSignatureFlag},
+}
+
+// messageSigHash is the tagged hash of the message signed with the user key
+// of an ark address.
+func messageSigHash(message string) []byte {
+	return chainhash.TaggedHash([]byte(messageSigTag), []byte(message)).CloneBytes()
+}
+
+func signMessageAction(ctx *cli.Context) error {
+	message := ctx.String(messageFlag.Name)
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.signHash(messageSigHash(message))
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"address":   offchainAddr,
+		"message":   message,
+		"signature": hex.EncodeToString(sig.Serialize()),
+	})
+}
+
+func verifyMessageAction(ctx *cli.Context) error {
+	address := ctx.String(messageAddressFlag.Name)
+	message := ctx.String(messageFlag.Name)
+
+	addr, err := common.DecodeArkAddress(address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %s", err)
+	}
+
+	buf, err := hex.DecodeString(ctx.String(messageSignatureFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+	sig, err := schnorr.ParseSignature(buf)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+
+	return printJSON(map[string]interface{}{
+		"address": address,
+		"message": message,
+		"valid":   sig.Verify(messageSigHash(message), addr.UserKey),
+	})
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+var (
+	exportUnsignedFlag = cli.StringFlag{
+		Name:  "export-unsigned",
+		Usage: "write the unsigned pset of the onchain send to the given file instead of signing and broadcasting it, see the sign and broadcast commands",
+	}
+	importFlag = cli.StringFlag{
+		Name:     "import",
+		Usage:    "file containing the base64 encoded pset",
+		Required: true,
+	}
+	signedFileFlag = cli.StringFlag{
+		Name:  "output-file",
+		Usage: "file where to write the signed pset, defaults to the imported one with the .signed extension",
+	}
+)
+
+var signCommand = cli.Command{
+	Name: "sign",
+	Usage: "Sign the inputs of a pset owned by the wallet, like the one exported with send --export-unsigned. " +
+		"It only needs the wallet's state and no connection, so it can run on an offline machine. " +
+		"Forfeit txs must be signed while the round is ongoing instead, so they can't be signed offline",
+	Action: withWalletLock(signAction),
+	Flags:  []cli.Flag{&importFlag, &signedFileFlag, &passwordFlag},
+}
+
+var broadcastCommand = cli.Command{
+	Name:   "broadcast",
+	Usage:  "Broadcast a pset signed with the sign command",
+	Action: withWalletLock(broadcastAction),
+	Flags:  []cli.Flag{&importFlag},
+}
+
+func signAction(ctx *cli.Context) error {
+	path := ctx.String(importFlag.Name)
+	pset, err := readPsetFile(path)
+	if err != nil {
+		return err
+	}
+
+	for i, in := range pset.Inputs {
+		if in.WitnessUtxo == nil {
+			return fmt.Errorf("missing witness utxo of input %d", i)
+		}
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	// all the witness utxos are known, the explorer is never used.
+	if err := signer.signPset(ctx, pset, NewExplorer(ctx)); err != nil {
+		return err
+	}
+
+	// the pset might contain inputs signed by other parties later.
+	complete := psetv2.FinalizeAll(pset) == nil
+
+	b64, err := pset.ToBase64()
+	if err != nil {
+		return err
+	}
+
+	outputPath := ctx.String(signedFileFlag.Name)
+	if len(outputPath) <= 0 {
+		outputPath = path + ".signed"
+	}
+	if err := os.WriteFile(outputPath, []byte(b64), 0600); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"signed_pset_file": outputPath,
+		"complete":         complete,
+	})
+}
+
+func broadcastAction(ctx *cli.Context) error {
+	pset, err := readPsetFile(ctx.String(importFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	if err := psetv2.MaybeFinalizeAll(pset); err != nil {
+		return fmt.Errorf("pset is not fully signed: %s", err)
+	}
+
+	b64, err := pset.ToBase64()
+	if err != nil {
+		return err
+	}
+
+	explorer := NewExplorer(ctx)
+	txid, err := broadcast(ctx, explorer, b64)
+	if err != nil {
+		return err
+	}
+
+	amount, err := getPsetSentAmount(ctx, pset)
+	if err != nil {
+		return err
+	}
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyOnchainSend,
+		Txid:   txid,
+		Amount: amount,
+		Fee:    getPsetFee(b64),
+	})
+
+	return printJSON(map[string]interface{}{
+		"txid": txid,
+	})
+}
+
+// exportUnsignedPset writes the given pset to the file set with
+// --export-unsigned.
+func exportUnsignedPset(ctx *cli.Context, b64 string) error {
+	path := ctx.String(exportUnsignedFlag.Name)
+	if err := os.WriteFile(path, []byte(b64), 0600); err != nil {
+		return err
+	}
+
+	txid, err := getTxid(b64)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"unsigned_pset_file": path,
+		"txid":               txid,
+	})
+}
+
+func readPsetFile(path string) (*psetv2.Pset, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pset, err := psetv2.NewPsetFromBase64(strings.TrimSpace(string(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pset in %s: %s", path, err)
+	}
+	return pset, nil
+}
+
+// getPsetSentAmount returns the sum of the outputs of the pset that neither
+// pay the fees nor send the change back to the wallet.
+func getPsetSentAmount(ctx *cli.Context, pset *psetv2.Pset) (uint64, error) {
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return 0, err
+	}
+	changeScript, err := address.ToOutputScript(onchainAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := uint64(0)
+	for _, out := range pset.Outputs {
+		if len(out.Script) <= 0 || bytes.Equal(out.Script, changeScript) {
+			continue
+		}
+		amount += out.Value
+	}
+	return amount, nil
+}
@@ -1,18 +1,23 @@
 package main
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/address"
 	"github.com/vulpemventures/go-elements/payment"
 	"github.com/vulpemventures/go-elements/psetv2"
 )
 
 const (
 	minRelayFee = 30
+	// how often the boarding tx and the resulting vtxo are checked.
+	onboardPollInterval = 10 * time.Second
 )
 
 var (
@@ -24,13 +29,17 @@ var (
 		Name:  "trusted",
 		Usage: "trusted onboard",
 	}
+	noWaitOnboardFlag = cli.BoolFlag{
+		Name:  "no-wait",
+		Usage: "return once the boarding tx is submitted, without waiting for it to confirm and for the vtxo to be credited",
+	}
 )
 
 var onboardCommand = cli.Command{
 	Name:   "onboard",
-	Usage:  "Onboard the Ark by lifting your funds",
-	Action: onboardAction,
-	Flags:  []cli.Flag{&amountOnboardFlag, &trustedOnboardFlag, &passwordFlag},
+	Usage:  "Onboard the Ark by lifting your funds, waiting for the boarding tx to confirm and the vtxo to be credited",
+	Action: withWalletLock(onboardAction),
+	Flags:  []cli.Flag{&amountOnboardFlag, &trustedOnboardFlag, &passwordFlag, &noWaitOnboardFlag},
 }
 
 func onboardAction(ctx *cli.Context) error {
@@ -111,7 +120,23 @@ func onboardAction(ctx *cli.Context) error {
 		Amount: sharedOutputAmount,
 	}
 
-	pset, err := sendOnchain(ctx, []receiver{onchainReceiver})
+	// the ASP pays the onchain fees of the onboardings below its sponsorship
+	// threshold.
+	info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+	sponsored := amount < uint64(info.GetFeeSponsorshipThreshold())
+
+	var pset string
+	if sponsored {
+		pset, err = buildSponsoredBoardingTx(
+			ctx, client, onchainReceiver, amount,
+			hex.EncodeToString(userPubKey.SerializeCompressed()),
+		)
+	} else {
+		pset, err = sendOnchain(ctx, []receiver{onchainReceiver})
+	}
 	if err != nil {
 		return err
 	}
@@ -129,16 +154,242 @@ func onboardAction(ctx *cli.Context) error {
 		return err
 	}
 
+	// the boarding tx is broadcasted by the ASP, track it until it's onchain.
+	if err := recordOutboxEntry(
+		ctx, outboxOnboard, txid, outboxPending, nil,
+	); err != nil {
+		return err
+	}
+
 	_, err = client.Onboard(ctx.Context, &arkv1.OnboardRequest{
 		BoardingTx:     pset,
 		CongestionTree: castCongestionTree(congestionTree),
 		UserPubkey:     hex.EncodeToString(userPubKey.SerializeCompressed()),
 	})
 	if err != nil {
+		// nolint
+		recordOutboxEntry(ctx, outboxOnboard, txid, outboxPending, err)
 		return err
 	}
 
-	fmt.Println("onboard_txid:", txid)
+	fee := getPsetFee(pset)
+	if sponsored {
+		fee = 0
+	}
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyOnboard,
+		Txid:   txid,
+		Amount: amount,
+		Fee:    fee,
+	})
+
+	if ctx.Bool(noWaitOnboardFlag.Name) {
+		return printJSON(map[string]interface{}{
+			"onboard_txid":  txid,
+			"fee_sponsored": sponsored,
+		})
+	}
+
+	logger.Info("boarding tx submitted, waiting for confirmation", "txid", txid)
+	if err := waitOnboardConfirmation(ctx, txid); err != nil {
+		return err
+	}
+	// nolint
+	recordOutboxEntry(ctx, outboxOnboard, txid, outboxDone, nil)
+
+	logger.Info("boarding tx confirmed, waiting for the vtxo to be credited", "txid", txid)
+	vtxo, err := waitOnboardVtxo(ctx, client, txid)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"onboard_txid":  txid,
+		"outpoint":      fmt.Sprintf("%s:%d", vtxo.txid, vtxo.vout),
+		"amount":        vtxo.amount,
+		"fee_sponsored": sponsored,
+	})
+}
+
+// buildSponsoredBoardingTx returns the boarding tx paying the given shared
+// output, funded by the ASP with the coins paying its fees and those of the
+// congestion tree, and signed by the wallet. The coins of the wallet cover
+// only the onboarded amount.
+func buildSponsoredBoardingTx(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	sharedOutput receiver, amount uint64, userPubkey string,
+) (string, error) {
+	_, net := getNetwork(ctx)
+	explorer := NewExplorer(ctx)
+
+	sharedOutputScript, err := address.ToOutputScript(sharedOutput.To)
+	if err != nil {
+		return "", err
+	}
+	ptx, err := psetv2.New(nil, []psetv2.OutputArgs{
+		{
+			Asset:  net.AssetID,
+			Amount: sharedOutput.Amount,
+			Script: sharedOutputScript,
+		},
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	updater, err := psetv2.NewUpdater(ptx)
+	if err != nil {
+		return "", err
+	}
+
+	utxos, delayedUtxos, change, err := coinSelectOnchain(
+		ctx, explorer, amount, nil,
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := addInputs(ctx, updater, utxos, delayedUtxos, net); err != nil {
+		return "", err
+	}
+
+	_, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	onchainScript, err := address.ToOutputScript(onchainAddr)
+	if err != nil {
+		return "", err
+	}
+	// a change below dust is left to the fees.
+	if change >= DUST {
+		if err := updater.AddOutputs([]psetv2.OutputArgs{
+			{
+				Asset:  net.AssetID,
+				Amount: change,
+				Script: onchainScript,
+			},
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	unsignedTx, err := ptx.ToBase64()
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.SponsorOnboarding(
+		ctx.Context, &arkv1.SponsorOnboardingRequest{
+			BoardingTx: unsignedTx,
+			UserPubkey: userPubkey,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get onboarding sponsored: %s", err)
+	}
+
+	fundedTx, err := psetv2.NewPsetFromBase64(resp.GetBoardingTx())
+	if err != nil {
+		return "", err
+	}
+	if err := checkSponsoredBoardingTx(ptx, fundedTx, onchainScript); err != nil {
+		return "", err
+	}
 
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := signer.signPset(ctx, fundedTx, explorer); err != nil {
+		return "", err
+	}
+	// the inputs of the ASP might be finalized already.
+	for i, in := range fundedTx.Inputs {
+		if len(in.FinalScriptWitness) > 0 {
+			continue
+		}
+		if err := psetv2.Finalize(fundedTx, i); err != nil {
+			return "", err
+		}
+	}
+
+	return fundedTx.ToBase64()
+}
+
+// checkSponsoredBoardingTx returns an error if the ASP changed the inputs or
+// the outputs of the boarding tx, or added any coin of the wallet, instead of
+// just adding its own ones.
+func checkSponsoredBoardingTx(
+	unsignedTx, fundedTx *psetv2.Pset, onchainScript []byte,
+) error {
+	if len(fundedTx.Inputs) < len(unsignedTx.Inputs) ||
+		len(fundedTx.Outputs) < len(unsignedTx.Outputs) {
+		return fmt.Errorf("sponsored boarding tx misses inputs or outputs")
+	}
+	for i, in := range fundedTx.Inputs {
+		if i < len(unsignedTx.Inputs) {
+			prev := unsignedTx.Inputs[i]
+			if !bytes.Equal(in.PreviousTxid, prev.PreviousTxid) ||
+				in.PreviousTxIndex != prev.PreviousTxIndex {
+				return fmt.Errorf("sponsored boarding tx input %d changed", i)
+			}
+			continue
+		}
+		if in.WitnessUtxo == nil || len(in.TapLeafScript) > 0 ||
+			bytes.Equal(in.WitnessUtxo.Script, onchainScript) {
+			return fmt.Errorf("sponsored boarding tx input %d is invalid", i)
+		}
+	}
+	for i, out := range unsignedTx.Outputs {
+		funded := fundedTx.Outputs[i]
+		if !bytes.Equal(funded.Script, out.Script) ||
+			!bytes.Equal(funded.Asset, out.Asset) || funded.Value != out.Value {
+			return fmt.Errorf("sponsored boarding tx output %d changed", i)
+		}
+	}
 	return nil
 }
+
+// waitOnboardConfirmation waits for the boarding tx to confirm, checking it
+// at every new block if the explorer supports subscriptions.
+func waitOnboardConfirmation(ctx *cli.Context, txid string) error {
+	watcher := newChainWatcher(NewExplorer(ctx), nil, onboardPollInterval)
+	for {
+		// the tx might not be propagated yet, errors are just retried.
+		if confirmed, _, err := getTxBlocktime(ctx, txid); err == nil && confirmed {
+			return nil
+		}
+
+		if err := watcher.wait(ctx.Context, 0); err != nil {
+			return err
+		}
+	}
+}
+
+// waitOnboardVtxo waits for the ASP to credit the vtxo of the given boarding
+// tx.
+func waitOnboardVtxo(
+	ctx *cli.Context, client arkv1.ArkServiceClient, txid string,
+) (*vtxo, error) {
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	explorer := NewExplorer(ctx)
+
+	for {
+		vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vtxos {
+			if v.poolTxid == txid {
+				return &v, nil
+			}
+		}
+
+		select {
+		case <-ctx.Context.Done():
+			return nil, ctx.Context.Err()
+		case <-time.After(onboardPollInterval):
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+// kinds of operations with external side effects tracked in the outbox.
+const (
+	outboxBroadcast       = "broadcast"
+	outboxRegisterPayment = "register_payment"
+	outboxClaimPayment    = "claim_payment"
+	outboxOnboard         = "onboard"
+	// outboxPayment entries track the payments executed by the daemon for the
+	// scheduled payments and the standing orders, settled by the daemon
+	// itself.
+	outboxPayment = "payment"
+)
+
+// statuses of an outbox entry.
+const (
+	// the operation has been attempted but its outcome is not known yet.
+	outboxPending = "pending"
+	// the effects of the operation landed.
+	outboxDone = "done"
+	// the operation has no effects.
+	outboxFailed = "failed"
+)
+
+// maxSettledOutboxEntries is the number of done or failed entries kept in the
+// outbox as record of the latest operations.
+const maxSettledOutboxEntries = 50
+
+var outboxCommand = cli.Command{
+	Name:   "outbox",
+	Usage:  "Shows the status of the latest operations with external side effects",
+	Action: withWalletLock(outboxAction),
+}
+
+type outboxEntry struct {
+	Kind      string `json:"kind"`
+	Ref       string `json:"ref"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	// Device is set only for display, for the operations of the other
+	// devices received with sync.
+	Device string `json:"device,omitempty"`
+}
+
+func outboxAction(ctx *cli.Context) error {
+	entries, err := getOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteOutboxes, err := getRemoteOutboxes(ctx)
+	if err != nil {
+		return err
+	}
+	devices := make([]string, 0, len(remoteOutboxes))
+	for device := range remoteOutboxes {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+	for _, device := range devices {
+		for _, e := range remoteOutboxes[device] {
+			e.Device = device
+			entries = append(entries, e)
+		}
+	}
+	return printJSON(entries)
+}
+
+func getOutbox(ctx *cli.Context) ([]outboxEntry, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]outboxEntry, 0)
+	if len(state[OUTBOX]) <= 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(state[OUTBOX]), &entries); err != nil {
+		return nil, fmt.Errorf("invalid outbox: %s", err)
+	}
+	return entries, nil
+}
+
+func setOutbox(ctx *cli.Context, entries []outboxEntry) error {
+	// drop the oldest settled entries.
+	settled := 0
+	for _, e := range entries {
+		if e.Status != outboxPending {
+			settled++
+		}
+	}
+	pruned := make([]outboxEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Status != outboxPending && settled > maxSettledOutboxEntries {
+			settled--
+			continue
+		}
+		pruned = append(pruned, e)
+	}
+
+	buf, err := json.Marshal(pruned)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{OUTBOX: string(buf)})
+}
+
+// recordOutboxEntry adds or updates the entry for the given operation.
+func recordOutboxEntry(ctx *cli.Context, kind, ref, status string, opErr error) error {
+	entries, err := getOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	errMsg := ""
+	if opErr != nil {
+		errMsg = opErr.Error()
+	}
+
+	for i, e := range entries {
+		if e.Kind == kind && e.Ref == ref {
+			entries[i].Status = status
+			entries[i].Error = errMsg
+			entries[i].UpdatedAt = now
+			return setOutbox(ctx, entries)
+		}
+	}
+
+	entries = append(entries, outboxEntry{
+		Kind:      kind,
+		Ref:       ref,
+		Status:    status,
+		Error:     errMsg,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	return setOutbox(ctx, entries)
+}
+
+// broadcast broadcasts the given tx, either hex or pset, keeping track of the
+// operation in the outbox. If the broadcast fails, the entry is left pending
+// because the tx might have reached the network anyway.
+func broadcast(ctx *cli.Context, explorer Explorer, txStr string) (string, error) {
+	txid, err := getTxid(txStr)
+	if err != nil {
+		return "", err
+	}
+
+	if err := recordOutboxEntry(
+		ctx, outboxBroadcast, txid, outboxPending, nil,
+	); err != nil {
+		return "", err
+	}
+
+	if _, err := explorer.Broadcast(txStr); err != nil {
+		// nolint
+		recordOutboxEntry(ctx, outboxBroadcast, txid, outboxPending, err)
+		return "", err
+	}
+
+	if err := recordOutboxEntry(
+		ctx, outboxBroadcast, txid, outboxDone, nil,
+	); err != nil {
+		return "", err
+	}
+	return txid, nil
+}
+
+// trackPayment runs the given payment recording its outcome in the outbox,
+// with the given ref. The entry is left pending if the payment is
+// interrupted, its outcome being unknown.
+func trackPayment(ctx *cli.Context, ref string, pay func() error) error {
+	if err := recordOutboxEntry(
+		ctx, outboxPayment, ref, outboxPending, nil,
+	); err != nil {
+		return err
+	}
+
+	if err := pay(); err != nil {
+		// nolint
+		recordOutboxEntry(ctx, outboxPayment, ref, outboxFailed, err)
+		return err
+	}
+	return recordOutboxEntry(ctx, outboxPayment, ref, outboxDone, nil)
+}
+
+// getPaymentOutcome returns the entry of the payment with the given ref, if
+// any.
+func getPaymentOutcome(ctx *cli.Context, ref string) (*outboxEntry, error) {
+	entries, err := getOutbox(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Kind == outboxPayment && e.Ref == ref {
+			entry := e
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// reconcileOutbox checks the outcome of the operations left pending by
+// previous invocations.
+// Broadcasts and onboardings are settled by looking for the tx onchain, while
+// payment registrations and claims are settled by the outcome of the round
+// they were part of.
+func reconcileOutbox(ctx *cli.Context) error {
+	entries, err := getOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	pendingRound, err := getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+
+	var explorer Explorer
+	changed := false
+	for i, e := range entries {
+		if e.Status != outboxPending {
+			continue
+		}
+
+		switch e.Kind {
+		case outboxBroadcast, outboxOnboard:
+			if explorer == nil {
+				explorer = NewExplorer(ctx)
+			}
+			if _, err := explorer.GetTxHex(e.Ref); err != nil {
+				// the tx might still be in flight, give up only after a while.
+				if time.Since(time.Unix(e.UpdatedAt, 0)) < time.Hour {
+					continue
+				}
+				entries[i].Status = outboxFailed
+				entries[i].Error = "tx not found"
+			} else {
+				entries[i].Status = outboxDone
+				entries[i].Error = ""
+			}
+		case outboxRegisterPayment, outboxClaimPayment:
+			if pendingRound == nil || pendingRound.PaymentID != e.Ref {
+				entries[i].Status = outboxFailed
+				entries[i].Error = "payment not part of any pending round"
+				break
+			}
+			poolTxid, err := resolvePendingRound(ctx, pendingRound)
+			if err != nil {
+				// the outcome of the round is still unknown.
+				continue
+			}
+			if len(poolTxid) > 0 {
+				entries[i].Status = outboxDone
+			} else {
+				entries[i].Status = outboxFailed
+				entries[i].Error = "round failed"
+			}
+		default:
+			continue
+		}
+
+		entries[i].UpdatedAt = time.Now().Unix()
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return setOutbox(ctx, entries)
+}
+
+func getTxid(txStr string) (string, error) {
+	tx, err := transaction.NewTxFromHex(txStr)
+	if err != nil {
+		pset, err := psetv2.NewPsetFromBase64(txStr)
+		if err != nil {
+			return "", err
+		}
+		utx, err := pset.UnsignedTx()
+		if err != nil {
+			return "", err
+		}
+		return utx.TxHash().String(), nil
+	}
+	return tx.TxHash().String(), nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// formats of the output of the commands.
+const (
+	outputJSON = "json"
+	// outputTable renders objects as key/value pairs and lists of objects as
+	// tables, for humans.
+	outputTable = "table"
+	// outputQuiet prints only the primary identifier of the result, for
+	// piping.
+	outputQuiet = "quiet"
+)
+
+var outputFlag = &cli.StringFlag{
+	Name:  "output",
+	Usage: "Specify the output format of the commands (json, table, quiet)",
+	Value: outputJSON,
+}
+
+// outputFormat is set from the --output flag before running the command.
+var outputFormat = outputJSON
+
+// jsonOutput is where the json results are printed, serve captures them to
+// reply to the API calls.
+var jsonOutput io.Writer = os.Stdout
+
+// primaryKeys are the fields identifying the result of a command, in order of
+// precedence, printed by the quiet output.
+var primaryKeys = []string{
+	"txid", "pool_txid", "claim_txid", "invoice", "payment_request",
+	"offchain_address", "address", "outpoint", "backup_file", "name",
+	"onboard_txid",
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputJSON, outputTable, outputQuiet:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %s", format)
+	}
+}
+
+func printTable(resp interface{}) error {
+	value, err := toGenericJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			fmt.Fprintf(w, "%s\t%s\n", strings.ToUpper(key), formatCell(v[key]))
+		}
+	case []interface{}:
+		columns := make(map[string]struct{})
+		for _, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok {
+				for key := range obj {
+					columns[key] = struct{}{}
+				}
+			}
+		}
+		if len(columns) <= 0 {
+			for _, item := range v {
+				fmt.Fprintln(w, formatCell(item))
+			}
+			break
+		}
+
+		header := make([]string, 0, len(columns))
+		for key := range columns {
+			header = append(header, key)
+		}
+		sort.Strings(header)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+		for _, item := range v {
+			obj, _ := item.(map[string]interface{})
+			row := make([]string, 0, len(header))
+			for _, key := range header {
+				row = append(row, formatCell(obj[key]))
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	default:
+		fmt.Fprintln(w, formatCell(v))
+	}
+	return w.Flush()
+}
+
+func printQuiet(resp interface{}) error {
+	value, err := toGenericJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			fmt.Println(formatCell(item))
+			continue
+		}
+		for _, key := range primaryKeys {
+			if id, ok := obj[key]; ok && id != nil && id != "" {
+				fmt.Println(formatCell(id))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// toGenericJSON converts the given value to maps, slices and scalars, as
+// decoded from its json encoding.
+func toGenericJSON(resp interface{}) (interface{}, error) {
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	// keep the amounts as they are instead of turning them into floats.
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		// nested values are kept on a single line.
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(buf)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+)
+
+const ppmDenominator = 1_000_000
+
+// paymentFees are the fees charged by the ASP for each payment of a round, on
+// top of the exit fee. The outputs to self, like the change, are charged only
+// the flat fee.
+type paymentFees struct {
+	flat            uint64
+	proportionalPpm uint64
+	perOutput       uint64
+}
+
+func getPaymentFees(
+	ctx context.Context, client arkv1.ArkServiceClient,
+) (*paymentFees, error) {
+	info, err := client.GetInfo(ctx, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	policy := info.GetFeePolicy()
+	return &paymentFees{
+		flat:            policy.GetFlatFee(),
+		proportionalPpm: policy.GetProportionalFeePpm(),
+		perOutput:       policy.GetOutputFee(),
+	}, nil
+}
+
+// forPayment returns the fees of a payment sending the given amount to
+// others with the given number of outputs, the proportional fee rounded up
+// like the ASP does.
+func (f paymentFees) forPayment(sentAmount uint64, sentOutputs int) uint64 {
+	proportionalFee := sentAmount/ppmDenominator*f.proportionalPpm +
+		(sentAmount%ppmDenominator*f.proportionalPpm+ppmDenominator-1)/
+			ppmDenominator
+
+	return f.flat + f.perOutput*uint64(sentOutputs) + proportionalFee
+}
+
+// forSelfPayment returns what's left of the given amount sent to self, that
+// is charged only the flat fee.
+func (f paymentFees) forSelfPayment(amount uint64) (uint64, error) {
+	if amount < f.flat+DUST {
+		return 0, fmt.Errorf(
+			"amount of %d sats doesn't cover the fee of %d sats charged by the ASP",
+			amount, f.flat,
+		)
+	}
+	return amount - f.flat, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// paymentRequestPrefix makes the payment requests easy to tell apart from
+	// addresses and payment uris.
+	paymentRequestPrefix  = "arkreq1"
+	paymentRequestVersion = 1
+)
+
+var (
+	requestAmountFlag = cli.Uint64Flag{
+		Name:  "amount",
+		Usage: "amount to request in sats, emits a payment request to be paid with send --request",
+	}
+	requestExpiryFlag = cli.DurationFlag{
+		Name:  "expiry",
+		Usage: "validity of the payment request, never expires if not set",
+	}
+	requestFlag = cli.StringFlag{
+		Name:  "request",
+		Usage: "payment request generated by receive --amount, embedding address and amount",
+	}
+)
+
+// paymentRequest is what the payer needs to pay the receiver offchain, JSON
+// and base64url encoded after paymentRequestPrefix.
+type paymentRequest struct {
+	Version int    `json:"version"`
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+	// AspPubkey is the hex compressed key of the ASP of the receiver, the
+	// payer must be connected to the same one.
+	AspPubkey string `json:"asp_pubkey"`
+	Label     string `json:"label,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	// ExpiresAt is zero if the request never expires.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+func (r *paymentRequest) encode() (string, error) {
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return paymentRequestPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func decodePaymentRequest(str string) (*paymentRequest, error) {
+	if !strings.HasPrefix(str, paymentRequestPrefix) {
+		return nil, fmt.Errorf("invalid payment request: missing %s prefix", paymentRequestPrefix)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(
+		strings.TrimPrefix(str, paymentRequestPrefix),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment request: %s", err)
+	}
+
+	req := &paymentRequest{}
+	if err := json.Unmarshal(buf, req); err != nil {
+		return nil, fmt.Errorf("invalid payment request: %s", err)
+	}
+	if req.Version != paymentRequestVersion {
+		return nil, fmt.Errorf("unsupported payment request version %d", req.Version)
+	}
+	if len(req.Address) <= 0 || req.Amount <= 0 {
+		return nil, fmt.Errorf("invalid payment request: missing address or amount")
+	}
+	return req, nil
+}
+
+func newPaymentRequest(
+	ctx *cli.Context, offchainAddr, label string,
+) (*paymentRequest, error) {
+	amount := ctx.Uint64(requestAmountFlag.Name)
+	if amount < DUST {
+		return nil, fmt.Errorf("invalid amount, must be at least %d", DUST)
+	}
+
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, net := getNetwork(ctx)
+
+	now := time.Now()
+	req := &paymentRequest{
+		Version:   paymentRequestVersion,
+		Network:   net.Name,
+		Address:   offchainAddr,
+		Amount:    amount,
+		AspPubkey: hex.EncodeToString(aspPubkey.SerializeCompressed()),
+		Label:     label,
+		CreatedAt: now.Unix(),
+	}
+	if expiry := ctx.Duration(requestExpiryFlag.Name); expiry != 0 {
+		if expiry < 0 {
+			return nil, fmt.Errorf("invalid expiry, must be positive")
+		}
+		req.ExpiresAt = now.Add(expiry).Unix()
+	}
+	return req, nil
+}
+
+// validatePaymentRequest makes sure the given request can be paid by the
+// wallet.
+func validatePaymentRequest(ctx *cli.Context, req *paymentRequest) error {
+	_, net := getNetwork(ctx)
+	if req.Network != net.Name {
+		return fmt.Errorf("payment request is for %s network", req.Network)
+	}
+	if req.ExpiresAt > 0 && time.Now().Unix() >= req.ExpiresAt {
+		return fmt.Errorf(
+			"payment request expired at %s",
+			time.Unix(req.ExpiresAt, 0).Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	reqAspPubkey, err := hex.DecodeString(req.AspPubkey)
+	if err != nil {
+		return fmt.Errorf("invalid payment request asp pubkey: %s", err)
+	}
+	aspPubkey, err := secp256k1.ParsePubKey(reqAspPubkey)
+	if err != nil {
+		return fmt.Errorf("invalid payment request asp pubkey: %s", err)
+	}
+	asp, err := findAsp(ctx, aspPubkey)
+	if err != nil {
+		return err
+	}
+	if asp == nil {
+		return fmt.Errorf("payment request not associated with any connected service provider")
+	}
+
+	if _, err := validateContactAddress(ctx, req.Address); err != nil {
+		return fmt.Errorf("invalid payment request: %s", err)
+	}
+	return nil
+}
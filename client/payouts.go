@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ark-network/ark/common"
+	"github.com/urfave/cli/v2"
+)
+
+var fromFileFlag = cli.StringFlag{
+	Name:  "from-file",
+	Usage: "CSV file of the offchain receivers to pay with a single round payment, one 'address,amount[,label]' per line",
+}
+
+// payoutStatus is the outcome of the payment of a receiver of --from-file.
+type payoutStatus struct {
+	To     string `json:"to"`
+	Amount uint64 `json:"amount"`
+	Label  string `json:"label,omitempty"`
+	Status string `json:"status"`
+}
+
+// readReceiversFile returns the receivers of the given CSV file, optionally
+// starting with an 'address,amount,label' header. Every line is validated so
+// that all the invalid ones are reported at once, and nothing is paid unless
+// all are valid.
+func readReceiversFile(ctx *cli.Context, path string) ([]receiver, error) {
+	f, err := os.Open(cleanAndExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open receivers file: %s", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	receivers := make([]receiver, 0)
+	invalid := make([]string, 0)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid receivers file: %s", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first && isReceiversHeader(record) {
+			continue
+		}
+
+		r, err := parseReceiverRecord(ctx, record)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("line %d: %s", line, err))
+			continue
+		}
+		receivers = append(receivers, *r)
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf(
+			"invalid receivers file:\n%s", strings.Join(invalid, "\n"),
+		)
+	}
+	if len(receivers) <= 0 {
+		return nil, fmt.Errorf("no receivers in file %s", path)
+	}
+	return receivers, nil
+}
+
+func isReceiversHeader(record []string) bool {
+	if len(record) < 2 {
+		return false
+	}
+	_, err := strconv.ParseUint(record[1], 10, 64)
+	return err != nil && strings.EqualFold(record[0], "address")
+}
+
+func parseReceiverRecord(ctx *cli.Context, record []string) (*receiver, error) {
+	if len(record) < 2 || len(record) > 3 {
+		return nil, fmt.Errorf("expected address,amount[,label], got %d fields", len(record))
+	}
+
+	addr, err := resolveContact(ctx, strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := common.DecodeArkAddress(addr); err != nil {
+		return nil, fmt.Errorf("invalid offchain address %s", record[0])
+	}
+
+	amount, err := strconv.ParseUint(strings.TrimSpace(record[1]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %s", record[1])
+	}
+	if amount < DUST {
+		return nil, fmt.Errorf("invalid amount %d, must be at least %d", amount, DUST)
+	}
+
+	r := &receiver{To: addr, Amount: amount}
+	if len(record) > 2 {
+		r.Label = strings.TrimSpace(record[2])
+	}
+	// the contact name labels the payment unless given another one.
+	if addr != record[0] && len(r.Label) <= 0 {
+		r.Label = record[0]
+	}
+	return r, nil
+}
+
+func newPayoutStatuses(receivers []receiver, status string) []payoutStatus {
+	statuses := make([]payoutStatus, 0, len(receivers))
+	for _, r := range receivers {
+		statuses = append(statuses, payoutStatus{r.To, r.Amount, r.Label, status})
+	}
+	return statuses
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	PRICE_FEED_ENVVAR = "ARK_PRICE_FEED"
+	PRICE_CACHE_FILE  = "prices.json"
+	// priceFeedCoingecko selects the public CoinGecko API as price feed.
+	priceFeedCoingecko = "coingecko"
+	coingeckoURL       = "https://api.coingecko.com/api/v3"
+	defaultFiat        = "usd"
+	// the current price is fetched again only after this time.
+	currentPriceTTL = 5 * time.Minute
+)
+
+var (
+	priceFeedFlag = &cli.StringFlag{
+		Name: "price-feed",
+		Usage: "Specify the price feed used to show fiat values, either coingecko or the URL of " +
+			"an endpoint answering GET <url>?currency=<fiat>[&timestamp=<unix>] with {\"price\": <btc price>}",
+		EnvVars: []string{PRICE_FEED_ENVVAR},
+	}
+	fiatCurrencyFlag = &cli.StringFlag{
+		Name:  "fiat-currency",
+		Usage: "Specify the currency of the fiat values",
+		Value: defaultFiat,
+	}
+)
+
+// priceProvider returns the price of 1 BTC in the given fiat currency.
+type priceProvider interface {
+	getCurrentPrice(currency string) (float64, error)
+	// getHistoricalPrice returns the price of the day of the given time.
+	getHistoricalPrice(currency string, at time.Time) (float64, error)
+}
+
+// fiatValue is the value of an amount in fiat currency.
+type fiatValue struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+	Amount   float64 `json:"amount"`
+}
+
+func newFiatValue(currency string, rate float64, sats uint64) *fiatValue {
+	amount := float64(sats) / float64(satsPerBTC) * rate
+	return &fiatValue{
+		Currency: currency,
+		Rate:     rate,
+		// cents are enough.
+		Amount: float64(int64(amount*100+0.5)) / 100,
+	}
+}
+
+// getPriceProvider returns the configured price provider wrapped by the
+// local cache, or nil if no price feed is configured.
+func getPriceProvider(ctx *cli.Context) (*cachedPriceProvider, string, error) {
+	feed := globalFlagValue(ctx, priceFeedFlag)
+	if len(feed) <= 0 {
+		return nil, "", nil
+	}
+	currency := strings.ToLower(globalFlagValue(ctx, fiatCurrencyFlag))
+	if len(currency) <= 0 {
+		return nil, "", fmt.Errorf("missing fiat currency")
+	}
+
+	var provider priceProvider
+	if feed == priceFeedCoingecko {
+		provider = &coingeckoPriceProvider{coingeckoURL}
+	} else {
+		if _, err := url.ParseRequestURI(feed); err != nil {
+			return nil, "", fmt.Errorf("invalid price feed %s: %s", feed, err)
+		}
+		provider = &httpPriceProvider{feed}
+	}
+
+	cachePath := filepath.Join(ctx.String("datadir"), PRICE_CACHE_FILE)
+	return newCachedPriceProvider(provider, cachePath), currency, nil
+}
+
+// httpPriceProvider fetches the prices from a generic endpoint.
+type httpPriceProvider struct {
+	url string
+}
+
+func (p *httpPriceProvider) getCurrentPrice(currency string) (float64, error) {
+	return p.getPrice(url.Values{"currency": {currency}})
+}
+
+func (p *httpPriceProvider) getHistoricalPrice(
+	currency string, at time.Time,
+) (float64, error) {
+	return p.getPrice(url.Values{
+		"currency":  {currency},
+		"timestamp": {strconv.FormatInt(at.Unix(), 10)},
+	})
+}
+
+func (p *httpPriceProvider) getPrice(query url.Values) (float64, error) {
+	endpoint, err := url.Parse(p.url)
+	if err != nil {
+		return 0, err
+	}
+	values := endpoint.Query()
+	for k, v := range query {
+		values[k] = v
+	}
+	endpoint.RawQuery = values.Encode()
+
+	payload := struct {
+		Price float64 `json:"price"`
+	}{}
+	if err := getPriceJSON(endpoint.String(), &payload); err != nil {
+		return 0, err
+	}
+	if payload.Price <= 0 {
+		return 0, fmt.Errorf("invalid price %f from price feed", payload.Price)
+	}
+	return payload.Price, nil
+}
+
+// coingeckoPriceProvider fetches the prices from the public CoinGecko API.
+type coingeckoPriceProvider struct {
+	baseUrl string
+}
+
+func (p *coingeckoPriceProvider) getCurrentPrice(currency string) (float64, error) {
+	payload := map[string]map[string]float64{}
+	if err := getPriceJSON(fmt.Sprintf(
+		"%s/simple/price?ids=bitcoin&vs_currencies=%s",
+		p.baseUrl, url.QueryEscape(currency),
+	), &payload); err != nil {
+		return 0, err
+	}
+
+	price, ok := payload["bitcoin"][currency]
+	if !ok || price <= 0 {
+		return 0, fmt.Errorf("price in %s not found", currency)
+	}
+	return price, nil
+}
+
+func (p *coingeckoPriceProvider) getHistoricalPrice(
+	currency string, at time.Time,
+) (float64, error) {
+	payload := struct {
+		MarketData struct {
+			CurrentPrice map[string]float64 `json:"current_price"`
+		} `json:"market_data"`
+	}{}
+	if err := getPriceJSON(fmt.Sprintf(
+		"%s/coins/bitcoin/history?date=%s&localization=false",
+		p.baseUrl, at.UTC().Format("02-01-2006"),
+	), &payload); err != nil {
+		return 0, err
+	}
+
+	price, ok := payload.MarketData.CurrentPrice[currency]
+	if !ok || price <= 0 {
+		return 0, fmt.Errorf(
+			"price in %s of %s not found", currency, at.UTC().Format("2006-01-02"),
+		)
+	}
+	return price, nil
+}
+
+func getPriceJSON(endpoint string, payload interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("price feed: %s", string(body))
+	}
+	return json.Unmarshal(body, payload)
+}
+
+type cachedPrice struct {
+	Price     float64 `json:"price"`
+	FetchedAt int64   `json:"fetched_at"`
+}
+
+// cachedPriceProvider keeps the fetched prices in a file of the datadir. The
+// historical prices never change, so they're cached forever, while the current
+// one is fetched again once expired.
+type cachedPriceProvider struct {
+	provider priceProvider
+	path     string
+	prices   map[string]cachedPrice
+}
+
+func newCachedPriceProvider(provider priceProvider, path string) *cachedPriceProvider {
+	prices := make(map[string]cachedPrice)
+	// a missing or corrupted cache is just rebuilt.
+	if buf, err := os.ReadFile(path); err == nil {
+		// nolint:all
+		json.Unmarshal(buf, &prices)
+	}
+	return &cachedPriceProvider{provider, path, prices}
+}
+
+func (p *cachedPriceProvider) getCurrentPrice(currency string) (float64, error) {
+	key := fmt.Sprintf("%s:current", currency)
+	if cached, ok := p.prices[key]; ok &&
+		time.Since(time.Unix(cached.FetchedAt, 0)) < currentPriceTTL {
+		return cached.Price, nil
+	}
+
+	price, err := p.provider.getCurrentPrice(currency)
+	if err != nil {
+		return 0, err
+	}
+	p.store(key, price)
+	return price, nil
+}
+
+// getLivePrice fetches the current price regardless of the cache, and returns
+// it along with the previously fetched one, if any.
+func (p *cachedPriceProvider) getLivePrice(
+	currency string,
+) (float64, *cachedPrice, error) {
+	key := fmt.Sprintf("%s:current", currency)
+	var previous *cachedPrice
+	if cached, ok := p.prices[key]; ok {
+		previous = &cached
+	}
+
+	price, err := p.provider.getCurrentPrice(currency)
+	if err != nil {
+		return 0, nil, err
+	}
+	p.store(key, price)
+	return price, previous, nil
+}
+
+func (p *cachedPriceProvider) getHistoricalPrice(
+	currency string, at time.Time,
+) (float64, error) {
+	day := at.UTC().Format("2006-01-02")
+	// the price of today is not final yet.
+	if day == time.Now().UTC().Format("2006-01-02") {
+		return p.getCurrentPrice(currency)
+	}
+
+	key := fmt.Sprintf("%s:%s", currency, day)
+	if cached, ok := p.prices[key]; ok {
+		return cached.Price, nil
+	}
+
+	price, err := p.provider.getHistoricalPrice(currency, at)
+	if err != nil {
+		return 0, err
+	}
+	p.store(key, price)
+	return price, nil
+}
+
+// store caches the given price, failing to persist it only costs a new
+// request the next time.
+func (p *cachedPriceProvider) store(key string, price float64) {
+	p.prices[key] = cachedPrice{price, time.Now().Unix()}
+	if buf, err := json.Marshal(p.prices); err == nil {
+		// nolint:all
+		os.WriteFile(p.path, buf, 0600)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+)
+
+const PROXY_ENVVAR = "ARK_PROXY"
+
+var proxyFlag = &cli.StringFlag{
+	Name: "proxy",
+	Usage: "Specify a SOCKS5 proxy, like socks5://127.0.0.1:9050 for Tor, to route the connections " +
+		"to the ASP and to the explorer through. The host names are resolved by the proxy, so that " +
+		".onion ASPs can be reached",
+	EnvVars: []string{PROXY_ENVVAR},
+}
+
+// socksProxy is the proxy set with --proxy, if any.
+var socksProxy *url.URL
+
+// setupProxy makes all the http requests, and the gRPC connections opened
+// afterwards, go through the given SOCKS5 proxy.
+func setupProxy(rawURL string) error {
+	if len(rawURL) <= 0 {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy: %s", err)
+	}
+	if proxyURL.Scheme != "socks5" && proxyURL.Scheme != "socks5h" {
+		return fmt.Errorf("invalid proxy: only socks5 is supported")
+	}
+	if len(proxyURL.Host) <= 0 {
+		return fmt.Errorf("invalid proxy: missing host")
+	}
+
+	// the socks5 client of the http package always lets the proxy resolve
+	// the host names, like socks5h.
+	httpProxyURL := *proxyURL
+	httpProxyURL.Scheme = "socks5"
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("failed to set up proxy")
+	}
+	transport.Proxy = http.ProxyURL(&httpProxyURL)
+
+	socksProxy = proxyURL
+	return nil
+}
+
+// proxyDialOptions returns the options to make a gRPC connection go through
+// the proxy, if any.
+func proxyDialOptions() ([]grpc.DialOption, error) {
+	if socksProxy == nil {
+		return nil, nil
+	}
+
+	dialer, err := proxy.FromURL(socksProxy, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %s", err)
+	}
+
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			if d, ok := dialer.(proxy.ContextDialer); ok {
+				return d.DialContext(ctx, "tcp", addr)
+			}
+			return dialer.Dial("tcp", addr)
+		}),
+	}, nil
+}
+
+// dialTCP opens a tcp connection to the given address, through the proxy if
+// any.
+func dialTCP(addr string, timeout time.Duration) (net.Conn, error) {
+	var dialer proxy.Dialer = &net.Dialer{Timeout: timeout}
+	if socksProxy != nil {
+		var err error
+		if dialer, err = proxy.FromURL(socksProxy, proxy.Direct); err != nil {
+			return nil, fmt.Errorf("invalid proxy: %s", err)
+		}
+	}
+	return dialer.Dial("tcp", addr)
+}
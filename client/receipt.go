@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+var (
+	receiptFlag = cli.StringFlag{
+		Name:     "receipt",
+		Usage:    "receipt to verify, as returned by receipt get",
+		Required: true,
+	}
+	receiptAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "optional ark address the receipt must be paid to",
+	}
+	receiptNetworkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: "network of the receipt, defaults to the one of the wallet",
+	}
+)
+
+var receiptCommand = cli.Command{
+	Name:  "receipt",
+	Usage: "Get and verify the receipts, signed by the ASP, of the vtxos created by the rounds",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "Gets the receipt of a vtxo created by a finalized round, to be shared with third parties as proof of payment",
+			ArgsUsage: "<txid:vout>",
+			Action:    receiptGetAction,
+		},
+		{
+			Name: "verify",
+			Usage: "Verifies a receipt without trusting the payer nor the ASP: checks the signature of the ASP, the receiver, " +
+				"that the vtxo is a leaf of the congestion tree of the round and that the pool tx is onchain. Fails if any check can't be done",
+			Action: receiptVerifyAction,
+			Flags:  []cli.Flag{&receiptFlag, &receiptAddressFlag, &receiptNetworkFlag},
+		},
+	},
+}
+
+func receiptGetAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("missing vtxo outpoint")
+	}
+	outpoint, err := parseOutpoint(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	txid, voutStr, _ := strings.Cut(outpoint, ":")
+	vout, _ := strconv.ParseUint(voutStr, 10, 32)
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	resp, err := client.GetReceipt(ctx.Context, &arkv1.GetReceiptRequest{
+		Outpoint: &arkv1.Input{Txid: txid, Vout: uint32(vout)},
+	})
+	if err != nil {
+		return err
+	}
+	r := resp.GetReceipt()
+	receipt := common.Receipt{
+		PoolTxid:  r.GetPoolTxid(),
+		VtxoTxid:  r.GetVtxoTxid(),
+		Vout:      r.GetVout(),
+		Amount:    r.GetAmount(),
+		Pubkey:    r.GetPubkey(),
+		AspPubkey: r.GetAspPubkey(),
+		CreatedAt: r.GetCreatedAt(),
+		Signature: r.GetSignature(),
+	}
+
+	// don't hand out receipts that third parties would reject.
+	_, net := getNetwork(ctx)
+	if err := receipt.Verify(*net); err != nil {
+		return fmt.Errorf("the asp returned an invalid receipt: %s", err)
+	}
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	if receipt.AspPubkey != hex.EncodeToString(aspPubkey.SerializeCompressed()) {
+		return fmt.Errorf("the receipt is signed by another asp")
+	}
+
+	encoded, err := encodeReceipt(receipt)
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"receipt": receipt,
+		"encoded": encoded,
+	})
+}
+
+func receiptVerifyAction(ctx *cli.Context) error {
+	receipt, err := decodeReceipt(ctx.String(receiptFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	_, net := getNetwork(ctx)
+	if name := ctx.String(receiptNetworkFlag.Name); len(name) > 0 {
+		_, net = networkFromString(name)
+	}
+
+	res := map[string]interface{}{
+		"receipt": receipt,
+		"valid":   false,
+	}
+	// the receipts that can't be verified are reported as invalid, and the
+	// command fails.
+	invalid := func(reason string) error {
+		res["error"] = reason
+		if err := printJSON(res); err != nil {
+			return err
+		}
+		return fmt.Errorf("invalid receipt: %s", reason)
+	}
+
+	if err := receipt.Verify(*net); err != nil {
+		return invalid(err.Error())
+	}
+
+	if address := ctx.String(receiptAddressFlag.Name); len(address) > 0 {
+		addr, err := common.DecodeArkAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid address: %s", err)
+		}
+		pubkey, _ := hex.DecodeString(receipt.Pubkey)
+		aspPubkey, _ := hex.DecodeString(receipt.AspPubkey)
+		if !bytes.Equal(pubkey, addr.UserKey.SerializeCompressed()) {
+			return invalid("the receipt is not paid to the given address")
+		}
+		if !bytes.Equal(aspPubkey, addr.AspKey.SerializeCompressed()) {
+			return invalid("the receipt is signed by another asp than the one of the given address")
+		}
+	}
+
+	// the signature of the ASP alone doesn't prove that the round happened:
+	// the vtxo must be a leaf of the congestion tree of the round, and the
+	// pool tx must be onchain. This requires an initialized wallet, with the
+	// same ASP as the receipt and an explorer.
+	if err := verifyReceiptRound(ctx, receipt); err != nil {
+		return invalid(err.Error())
+	}
+
+	confirmed, blocktime, err := getTxBlocktime(ctx, receipt.PoolTxid)
+	if err != nil {
+		return invalid(fmt.Sprintf("pool tx not found: %s", err))
+	}
+	res["pool_tx_confirmed"] = confirmed
+	if confirmed {
+		res["pool_tx_blocktime"] = blocktime
+	}
+
+	res["valid"] = true
+	return printJSON(res)
+}
+
+// verifyReceiptRound checks that the vtxo of the receipt is a leaf of the
+// congestion tree of the round, of the given amount and paid to the pubkey of
+// the receipt, and that the tree is the one of the pool tx.
+func verifyReceiptRound(ctx *cli.Context, receipt *common.Receipt) error {
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return fmt.Errorf("can't check the round without an initialized wallet: %s", err)
+	}
+	if receipt.AspPubkey != hex.EncodeToString(aspPubkey.SerializeCompressed()) {
+		return fmt.Errorf("can't check the round of a receipt signed by another asp than the one of the wallet")
+	}
+	if baseURL, err := getBaseURL(ctx); err != nil || len(baseURL) <= 0 {
+		return fmt.Errorf("can't check the pool tx without an explorer")
+	}
+	roundLifetime, err := getRoundLifetime(ctx)
+	if err != nil {
+		return err
+	}
+	exitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return fmt.Errorf("can't check the round: %s", err)
+	}
+	defer close()
+
+	resp, err := client.GetRound(ctx.Context, &arkv1.GetRoundRequest{
+		Txid: receipt.PoolTxid,
+	})
+	if err != nil {
+		return fmt.Errorf("can't get the round of the pool tx: %s", err)
+	}
+	poolTx := resp.GetRound().GetPoolTx()
+	ptx, err := psetv2.NewPsetFromBase64(poolTx)
+	if err != nil {
+		return fmt.Errorf("invalid pool tx: %s", err)
+	}
+	utx, err := ptx.UnsignedTx()
+	if err != nil {
+		return fmt.Errorf("invalid pool tx: %s", err)
+	}
+	if txid := utx.TxHash().String(); txid != receipt.PoolTxid {
+		return fmt.Errorf("the asp returned another pool tx %s", txid)
+	}
+
+	congestionTree, err := toCongestionTree(resp.GetRound().GetCongestionTree())
+	if err != nil {
+		return err
+	}
+	if err := tree.ValidateCongestionTree(
+		congestionTree, poolTx, aspPubkey, roundLifetime,
+	); err != nil {
+		return fmt.Errorf("invalid congestion tree: %s", err)
+	}
+
+	buf, err := hex.DecodeString(receipt.Pubkey)
+	if err != nil {
+		return fmt.Errorf("invalid receipt pubkey: %s", err)
+	}
+	userPubkey, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return fmt.Errorf("invalid receipt pubkey: %s", err)
+	}
+	tapKey, _, err := computeVtxoTaprootScript(
+		userPubkey, aspPubkey, uint(exitDelay),
+	)
+	if err != nil {
+		return err
+	}
+	vtxoScript := append([]byte{0x51, 0x20}, schnorr.SerializePubKey(tapKey)...)
+
+	for _, leaf := range congestionTree.Leaves() {
+		if leaf.Txid != receipt.VtxoTxid {
+			continue
+		}
+		leafTx, err := psetv2.NewPsetFromBase64(leaf.Tx)
+		if err != nil {
+			return fmt.Errorf("invalid leaf tx: %s", err)
+		}
+		if int(receipt.Vout) >= len(leafTx.Outputs) {
+			return fmt.Errorf("vtxo output not found in leaf tx")
+		}
+		output := leafTx.Outputs[receipt.Vout]
+		if output.Value != receipt.Amount {
+			return fmt.Errorf(
+				"invalid vtxo amount: got %d, want %d", output.Value, receipt.Amount,
+			)
+		}
+		if !bytes.Equal(output.Script, vtxoScript) {
+			return fmt.Errorf("the vtxo is not paid to the pubkey of the receipt")
+		}
+		return nil
+	}
+	return fmt.Errorf("the vtxo is not a leaf of the congestion tree of the round")
+}
+
+// encodeReceipt returns the base64 of the json of the receipt, to be shared
+// as a single string.
+func encodeReceipt(receipt common.Receipt) (string, error) {
+	buf, err := json.Marshal(receipt)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// decodeReceipt parses a receipt given either as json or as base64 json.
+func decodeReceipt(str string) (*common.Receipt, error) {
+	str = strings.TrimSpace(str)
+	buf := []byte(str)
+	if !strings.HasPrefix(str, "{") {
+		decoded, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid receipt, must be json or base64")
+		}
+		buf = decoded
+	}
+
+	var receipt common.Receipt
+	if err := json.Unmarshal(buf, &receipt); err != nil {
+		return nil, fmt.Errorf("invalid receipt: %s", err)
+	}
+	return &receipt, nil
+}
@@ -1,13 +1,26 @@
 package main
 
 import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/mdp/qrterminal/v3"
 	"github.com/urfave/cli/v2"
 )
 
+var qrFlag = cli.BoolFlag{
+	Name:  "qr",
+	Usage: "render the addresses as QR codes in the terminal",
+	Value: false,
+}
+
 var receiveCommand = cli.Command{
 	Name:   "receive",
-	Usage:  "Shows both onchain and offchain addresses",
-	Action: receiveAction,
+	Usage:  "Shows both onchain and offchain addresses, or a payment request for the given amount",
+	Action: withWalletLock(receiveAction),
+	Flags:  []cli.Flag{&labelFlag, &qrFlag, &requestAmountFlag, &requestExpiryFlag},
 }
 
 func receiveAction(ctx *cli.Context) error {
@@ -16,8 +29,79 @@ func receiveAction(ctx *cli.Context) error {
 		return err
 	}
 
-	return printJSON(map[string]interface{}{
+	if label := ctx.String(labelFlag.Name); len(label) > 0 {
+		if err := setLabels(ctx, label, offchainAddr, onchainAddr); err != nil {
+			return err
+		}
+	}
+
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	res := map[string]interface{}{
 		"offchain_address": offchainAddr,
 		"onchain_address":  onchainAddr,
+	}
+	offchainURI := ""
+	if label, ok := labels[offchainAddr]; ok {
+		offchainURI = fmt.Sprintf(
+			"ark:%s?%s", offchainAddr, url.Values{"label": {label}}.Encode(),
+		)
+		res["label"] = label
+		res["offchain_uri"] = offchainURI
+	}
+
+	paymentRequest := ""
+	if ctx.IsSet(requestAmountFlag.Name) {
+		req, err := newPaymentRequest(ctx, offchainAddr, labels[offchainAddr])
+		if err != nil {
+			return err
+		}
+		if paymentRequest, err = req.encode(); err != nil {
+			return err
+		}
+		res["amount"] = req.Amount
+		if req.ExpiresAt > 0 {
+			res["expires_at"] = time.Unix(req.ExpiresAt, 0).Format("2006-01-02 15:04:05")
+		}
+		res["payment_request"] = paymentRequest
+	} else if ctx.IsSet(requestExpiryFlag.Name) {
+		return fmt.Errorf("--expiry requires --amount")
+	}
+	if err := printJSON(res); err != nil {
+		return err
+	}
+
+	if ctx.Bool(qrFlag.Name) {
+		if len(paymentRequest) > 0 {
+			fmt.Println("\npayment request:")
+			printQRCode(paymentRequest)
+			return nil
+		}
+		if len(offchainURI) <= 0 {
+			offchainURI = offchainAddr
+		}
+		fmt.Println("\noffchain address:")
+		printQRCode(offchainURI)
+		fmt.Println("\nonchain address:")
+		printQRCode(onchainAddr)
+	}
+	return nil
+}
+
+// printQRCode renders the given string as a QR code made of half blocks, so
+// that it fits in standard terminals.
+func printQRCode(str string) {
+	qrterminal.GenerateWithConfig(str, qrterminal.Config{
+		Level:          qrterminal.L,
+		Writer:         os.Stdout,
+		HalfBlocks:     true,
+		BlackChar:      qrterminal.BLACK_BLACK,
+		WhiteBlackChar: qrterminal.WHITE_BLACK,
+		WhiteChar:      qrterminal.WHITE_WHITE,
+		BlackWhiteChar: qrterminal.BLACK_WHITE,
+		QuietZone:      2,
 	})
 }
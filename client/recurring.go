@@ -0,0 +1,424 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// outcomes of the executions of the standing orders.
+const (
+	recurringPaid    = "paid"
+	recurringSkipped = "skipped"
+	recurringFailed  = "failed"
+	// recurringExecuting is the status of an order while its payment is
+	// being sent, tracked in the outbox with the LastRef of the order.
+	recurringExecuting = "executing"
+)
+
+var (
+	everyFlag = cli.StringFlag{
+		Name:     "every",
+		Usage:    "interval between the payments, like 1w, 2d or 12h",
+		Required: true,
+	}
+	startFlag = cli.StringFlag{
+		Name:  "start",
+		Usage: "time of the first payment, RFC3339 or unix timestamp, defaults to now",
+	}
+)
+
+var recurringCommand = cli.Command{
+	Name:  "recurring",
+	Usage: "Manage the standing orders, the recurring payments executed by the daemon",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "add",
+			Usage:  "Add a standing order paying the given amount every given interval. A fiat amount is converted at every payment, and the payments not covered by the balance are skipped",
+			Action: withWalletLock(recurringAddAction),
+			Flags: append([]cli.Flag{
+				&toFlag, &sendAmountFlag, &maxSlippageFlag, &everyFlag, &startFlag,
+			}, scheduledSendFlags...),
+		},
+		{
+			Name:   "list",
+			Usage:  "List the standing orders",
+			Action: recurringListAction,
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a standing order",
+			ArgsUsage: "<id>",
+			Action:    withWalletLock(recurringRemoveAction),
+		},
+	},
+}
+
+type standingOrder struct {
+	ID string `json:"id"`
+	To string `json:"to"`
+	// Amount is in sats or in fiat, in the format of send --amount.
+	Amount      string  `json:"amount"`
+	MaxSlippage float64 `json:"max_slippage,omitempty"`
+	Every       string  `json:"every"`
+	// Flags are the values of the scheduledSendFlags set for the payments.
+	Flags     map[string]string `json:"flags,omitempty"`
+	NextAt    int64             `json:"next_at"`
+	LastRunAt int64             `json:"last_run_at,omitempty"`
+	// LastStatus is the outcome of the last execution, if any.
+	LastStatus string `json:"last_status,omitempty"`
+	LastError  string `json:"last_error,omitempty"`
+	// LastRef identifies the last payment of the order in the outbox.
+	LastRef   string `json:"last_ref,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// advance moves the next execution of the order after the current time,
+// skipping those missed while the daemon was not running.
+func (o *standingOrder) advance(now time.Time) error {
+	every, err := parseEvery(o.Every)
+	if err != nil {
+		return err
+	}
+	next := time.Unix(o.NextAt, 0)
+	for !next.After(now) {
+		next = next.Add(every)
+	}
+	o.NextAt = next.Unix()
+	return nil
+}
+
+func recurringAddAction(ctx *cli.Context) error {
+	to := ctx.String(toFlag.Name)
+	if len(to) <= 0 {
+		return fmt.Errorf("missing --to")
+	}
+	addr, err := resolveContact(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	// the amount is validated now, but converted at every payment.
+	amountStr := ctx.String(sendAmountFlag.Name)
+	amount, _, err := parseSendAmount(ctx)
+	if err != nil {
+		return err
+	}
+	if amount < DUST {
+		return fmt.Errorf("invalid amount (%d), must be at least dust %d", amount, DUST)
+	}
+
+	every := ctx.String(everyFlag.Name)
+	if _, err := parseEvery(every); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if str := ctx.String(startFlag.Name); len(str) > 0 {
+		if start, err = parseScheduleTime(str); err != nil {
+			return err
+		}
+	}
+
+	flags := make(map[string]string)
+	for _, flag := range scheduledSendFlags {
+		name := flag.Names()[0]
+		if ctx.IsSet(name) {
+			flags[name] = fmt.Sprintf("%v", ctx.Value(name))
+		}
+	}
+	// the contact name labels the payments unless given another one.
+	if _, ok := flags[labelFlag.Name]; !ok && addr != to {
+		flags[labelFlag.Name] = to
+	}
+
+	id, err := newScheduledPaymentID()
+	if err != nil {
+		return err
+	}
+	order := standingOrder{
+		ID:          id,
+		To:          addr,
+		Amount:      strings.TrimSpace(amountStr),
+		MaxSlippage: ctx.Float64(maxSlippageFlag.Name),
+		Every:       every,
+		Flags:       flags,
+		NextAt:      start.Unix(),
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return err
+	}
+	orders[order.ID] = order
+	if err := setStandingOrders(ctx, orders); err != nil {
+		return err
+	}
+
+	return printJSON(order)
+}
+
+func recurringListAction(ctx *cli.Context) error {
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	list := make([]standingOrder, 0, len(orders))
+	for _, order := range orders {
+		list = append(list, order)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].NextAt < list[j].NextAt
+	})
+	return printJSON(list)
+}
+
+func recurringRemoveAction(ctx *cli.Context) error {
+	id := ctx.Args().First()
+	if len(id) <= 0 {
+		return fmt.Errorf("missing standing order id")
+	}
+
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := orders[id]; !ok {
+		return fmt.Errorf("standing order %s not found", id)
+	}
+	delete(orders, id)
+	if err := setStandingOrders(ctx, orders); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"id":      id,
+		"removed": true,
+	})
+}
+
+// runDueStandingOrders executes the payments of the standing orders whose
+// time has come. A payment not covered by the balance is skipped, and both
+// the skipped and the failed ones are notified.
+//
+// The next execution of an order is persisted, along with the executing
+// status, before paying, so that a payment interrupted by a crash of the
+// daemon is never sent twice: its outcome is looked up in the outbox at the
+// next run instead.
+func runDueStandingOrders(
+	ctx *cli.Context, globalArgs []string, hook *webhook,
+) error {
+	if err := withWalletLockWait(ctx, func() error {
+		return reconcileStandingOrders(ctx, hook)
+	}); err != nil {
+		return err
+	}
+
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, order := range orders {
+		if order.NextAt > now.Unix() {
+			continue
+		}
+
+		order.LastRef = fmt.Sprintf("%s:%d", order.ID, order.NextAt)
+		order.LastRunAt, order.LastStatus, order.LastError =
+			now.Unix(), recurringExecuting, ""
+		if err := order.advance(now); err != nil {
+			return err
+		}
+		stored := false
+		if err := withWalletLockWait(ctx, func() error {
+			stored, err = updateStandingOrder(ctx, order)
+			return err
+		}); err != nil {
+			return err
+		}
+		if !stored {
+			continue
+		}
+
+		status, runErr := runStandingOrder(ctx, globalArgs, order)
+		if err := withWalletLockWait(ctx, func() error {
+			return settleStandingOrder(ctx, hook, order, status, runErr)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileStandingOrders settles the orders left executing by a daemon that
+// was interrupted. The interrupted payments are failed, not retried, as they
+// might have been registered for a round already.
+func reconcileStandingOrders(ctx *cli.Context, hook *webhook) error {
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if order.LastStatus != recurringExecuting {
+			continue
+		}
+
+		outcome, err := getPaymentOutcome(ctx, order.LastRef)
+		if err != nil {
+			return err
+		}
+		status, runErr := recurringFailed, fmt.Errorf(
+			"interrupted while executing, check the outbox and the history",
+		)
+		switch {
+		case outcome == nil:
+			runErr = fmt.Errorf("interrupted before sending the payment")
+		case outcome.Status == outboxDone:
+			status, runErr = recurringPaid, nil
+		case outcome.Status == outboxFailed:
+			runErr = fmt.Errorf("%s", outcome.Error)
+		}
+		if err := settleStandingOrder(
+			ctx, hook, order, status, runErr,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settleStandingOrder records the outcome of the last payment of the order,
+// notifying it if not paid.
+func settleStandingOrder(
+	ctx *cli.Context, hook *webhook, order standingOrder,
+	status string, runErr error,
+) error {
+	event := map[string]interface{}{
+		"event":  "recurring_payment_" + status,
+		"id":     order.ID,
+		"to":     order.To,
+		"amount": order.Amount,
+	}
+	if runErr != nil {
+		event["error"] = runErr.Error()
+	}
+	if status != recurringPaid {
+		notifyEvent(hook, event)
+	}
+
+	order.LastStatus, order.LastError = status, ""
+	if runErr != nil {
+		order.LastError = runErr.Error()
+	}
+	_, err := updateStandingOrder(ctx, order)
+	return err
+}
+
+// updateStandingOrder stores the given order. It returns whether the order
+// still exists, not removed meanwhile.
+func updateStandingOrder(ctx *cli.Context, order standingOrder) (bool, error) {
+	orders, err := getStandingOrders(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := orders[order.ID]; !ok {
+		return false, nil
+	}
+	orders[order.ID] = order
+	return true, setStandingOrders(ctx, orders)
+}
+
+// runStandingOrder pays the amount of the order, if covered by the balance,
+// and returns the outcome.
+func runStandingOrder(
+	ctx *cli.Context, globalArgs []string, order standingOrder,
+) (string, error) {
+	amount, _, err := parseAmount(ctx, order.Amount, order.MaxSlippage)
+	if err != nil {
+		return recurringFailed, err
+	}
+
+	r := receiver{To: order.To, Amount: amount}
+	balance, err := getSendAllAmount(ctx, r)
+	if err != nil || balance < amount {
+		return recurringSkipped, fmt.Errorf(
+			"insufficient balance to pay %d sats", amount,
+		)
+	}
+
+	args := []string{
+		sendCommand.Name, "--to", order.To,
+		"--amount", strconv.FormatUint(amount, 10),
+	}
+	for name, value := range order.Flags {
+		args = append(args, fmt.Sprintf("--%s=%s", name, value))
+	}
+	args = append(args, fmt.Sprintf("--%s=%s", paymentRefFlag.Name, order.LastRef))
+
+	logger.Info("executing standing order", "id", order.ID, "amount", amount)
+	if err := ctx.App.Run(append(globalArgs, args...)); err != nil {
+		logger.Error("standing order failed", "id", order.ID, "err", err)
+		return recurringFailed, err
+	}
+	return recurringPaid, nil
+}
+
+// parseEvery parses the interval of a standing order, either a duration or
+// a number of days or weeks, like 2d or 1w.
+func parseEvery(str string) (time.Duration, error) {
+	var every time.Duration
+	switch {
+	case strings.HasSuffix(str, "d") || strings.HasSuffix(str, "w"):
+		n, err := strconv.ParseUint(str[:len(str)-1], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %s", str)
+		}
+		every = time.Duration(n) * 24 * time.Hour
+		if strings.HasSuffix(str, "w") {
+			every *= 7
+		}
+	default:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %s, must be like 1w, 2d or 12h", str)
+		}
+		every = d
+	}
+	if every < time.Minute {
+		return 0, fmt.Errorf("invalid interval %s, must be at least 1m", str)
+	}
+	return every, nil
+}
+
+func getStandingOrders(ctx *cli.Context) (map[string]standingOrder, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make(map[string]standingOrder)
+	if len(state[STANDING_ORDERS]) <= 0 {
+		return orders, nil
+	}
+	if err := json.Unmarshal([]byte(state[STANDING_ORDERS]), &orders); err != nil {
+		return nil, fmt.Errorf("invalid standing orders: %s", err)
+	}
+	return orders, nil
+}
+
+func setStandingOrders(ctx *cli.Context, orders map[string]standingOrder) error {
+	buf, err := json.Marshal(orders)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{STANDING_ORDERS: string(buf)})
+}
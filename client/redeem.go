@@ -16,7 +16,7 @@ import (
 var (
 	addressFlag = cli.StringFlag{
 		Name:     "address",
-		Usage:    "main chain address receiving the redeeemed VTXO",
+		Usage:    "main chain address receiving the redeeemed VTXO, defaults to the onchain address of the wallet",
 		Value:    "",
 		Required: false,
 	}
@@ -39,8 +39,8 @@ var (
 var redeemCommand = cli.Command{
 	Name:   "redeem",
 	Usage:  "Redeem your offchain funds, either collaboratively or unilaterally",
-	Flags:  []cli.Flag{&addressFlag, &amountToRedeemFlag, &forceFlag, &passwordFlag, &enableExpiryCoinselectFlag},
-	Action: redeemAction,
+	Flags:  []cli.Flag{&addressFlag, &amountToRedeemFlag, &forceFlag, &passwordFlag, &enableExpiryCoinselectFlag, &coinSelectionFlag, &minLifetimeFlag, &allowExpiringFlag, &roundRetriesFlag, &reviewFlag, &overrideLimitsFlag},
+	Action: withWalletLock(redeemAction),
 }
 
 func redeemAction(ctx *cli.Context) error {
@@ -48,10 +48,6 @@ func redeemAction(ctx *cli.Context) error {
 	amount := ctx.Uint64("amount")
 	force := ctx.Bool("force")
 
-	if len(addr) <= 0 && !force {
-		return fmt.Errorf("missing address flag (--address)")
-	}
-
 	if !force && amount <= 0 {
 		return fmt.Errorf("missing amount flag (--amount)")
 	}
@@ -62,9 +58,17 @@ func redeemAction(ctx *cli.Context) error {
 	}
 	defer clean()
 
+	if !force && len(addr) <= 0 {
+		_, onchainAddr, _, err := getAddress(ctx)
+		if err != nil {
+			return err
+		}
+		addr = onchainAddr
+	}
+
 	if force {
 		if amount > 0 {
-			fmt.Printf("WARNING: unilateral exit (--force) ignores --amount flag, it will redeem all your VTXOs\n")
+			logger.Warn("unilateral exit (--force) ignores --amount flag, it will redeem all your VTXOs")
 		}
 
 		return unilateralRedeem(ctx, client)
@@ -76,7 +80,10 @@ func redeemAction(ctx *cli.Context) error {
 func collaborativeRedeem(
 	ctx *cli.Context, client arkv1.ArkServiceClient, addr string, amount uint64,
 ) error {
-	withExpiryCoinselect := ctx.Bool("enable-expiry-coinselect")
+	selector, err := getCoinSelector(ctx)
+	if err != nil {
+		return err
+	}
 
 	if _, err := address.ToOutputScript(addr); err != nil {
 		return fmt.Errorf("invalid onchain address")
@@ -96,6 +103,10 @@ func collaborativeRedeem(
 		addr = info.Address
 	}
 
+	if amount < DUST {
+		return fmt.Errorf("invalid amount (%d), must be greater than dust %d", amount, DUST)
+	}
+
 	offchainAddr, _, _, err := getAddress(ctx)
 	if err != nil {
 		return err
@@ -108,18 +119,46 @@ func collaborativeRedeem(
 		},
 	}
 
+	exitFee, sponsored, err := getExitFee(ctx, client, amount)
+	if err != nil {
+		return err
+	}
+	if sponsored {
+		logger.Info("the onchain fees of this exit are sponsored by the ASP")
+	}
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return err
+	}
+	fee := exitFee + fees.forPayment(amount, 1)
+
 	explorer := NewExplorer(ctx)
 
-	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, withExpiryCoinselect)
+	vtxos, err := getVtxos(
+		ctx, explorer, client, offchainAddr, needsExpiration(selector),
+	)
 	if err != nil {
 		return err
 	}
 
-	selectedCoins, changeAmount, err := coinSelect(vtxos, amount, withExpiryCoinselect)
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+	selectedCoins, changeAmount, err := coinSelectVtxos(
+		ctx, vtxos, amount+fee, selector, locked,
+	)
 	if err != nil {
 		return err
 	}
 
+	// a change below dust can't be an output of the round, it's left to the
+	// ASP along with the fees.
+	if changeAmount > 0 && changeAmount < DUST {
+		fee += changeAmount
+		changeAmount = 0
+	}
+
 	if changeAmount > 0 {
 		receivers = append(receivers, &arkv1.Output{
 			Address: offchainAddr,
@@ -127,49 +166,40 @@ func collaborativeRedeem(
 		})
 	}
 
-	inputs := make([]*arkv1.Input, 0, len(selectedCoins))
-
-	for _, coin := range selectedCoins {
-		inputs = append(inputs, &arkv1.Input{
-			Txid: coin.txid,
-			Vout: coin.vout,
-		})
-	}
-
-	secKey, err := privateKeyFromPassword(ctx)
-	if err != nil {
-		return err
-	}
-
-	registerResponse, err := client.RegisterPayment(ctx.Context, &arkv1.RegisterPaymentRequest{
-		Inputs: inputs,
-	})
-	if err != nil {
+	// the fees leave the wallet along with the redeemed amount.
+	if err := checkSpendingLimits(
+		ctx, []receiver{{To: addr, Amount: amount + fee}},
+	); err != nil {
 		return err
 	}
 
-	_, err = client.ClaimPayment(ctx.Context, &arkv1.ClaimPaymentRequest{
-		Id:      registerResponse.GetId(),
-		Outputs: receivers,
-	})
+	signer, err := getWalletSigner(ctx)
 	if err != nil {
 		return err
 	}
 
-	poolTxID, err := handleRoundStream(
-		ctx,
-		client,
-		registerResponse.GetId(),
-		selectedCoins,
-		secKey,
-		receivers,
+	poolTxID, err := joinRound(
+		ctx, client, selectedCoins, signer, receivers,
 	)
 	if err != nil {
 		return err
 	}
 
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:      historyCollaborativeExit,
+		Txid:      poolTxID,
+		Amount:    amount,
+		Fee:       fee,
+		Receivers: []receiver{{To: addr, Amount: amount}},
+	})
+
 	if err := printJSON(map[string]interface{}{
-		"pool_txid": poolTxID,
+		"pool_txid":     poolTxID,
+		"address":       addr,
+		"amount":        amount,
+		"change":        changeAmount,
+		"fee":           fee,
+		"fee_sponsored": sponsored,
 	}); err != nil {
 		return err
 	}
@@ -177,6 +207,26 @@ func collaborativeRedeem(
 	return nil
 }
 
+// getExitFee returns the fee charged by the ASP for a cooperative exit of the
+// given amount, and whether the ASP sponsors it, in which case it's zero.
+func getExitFee(
+	ctx *cli.Context, client arkv1.ArkServiceClient, amount uint64,
+) (uint64, bool, error) {
+	info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return 0, false, err
+	}
+
+	exitFee := uint64(info.GetExitFee())
+	if exitFee <= 0 {
+		return 0, false, nil
+	}
+	if amount < uint64(info.GetFeeSponsorshipThreshold()) {
+		return 0, true, nil
+	}
+	return exitFee, false, nil
+}
+
 func unilateralRedeem(ctx *cli.Context, client arkv1.ArkServiceClient) error {
 	offchainAddr, _, _, err := getAddress(ctx)
 	if err != nil {
@@ -188,6 +238,7 @@ func unilateralRedeem(ctx *cli.Context, client arkv1.ArkServiceClient) error {
 	if err != nil {
 		return err
 	}
+	vtxos = withoutAsyncVtxos(vtxos)
 
 	totalVtxosAmount := uint64(0)
 
@@ -225,9 +276,10 @@ func unilateralRedeem(ctx *cli.Context, client arkv1.ArkServiceClient) error {
 		}
 	}
 
+	txids := make([]string, 0, len(transactions))
 	for i, txHex := range transactions {
 		for {
-			txid, err := explorer.Broadcast(txHex)
+			txid, err := broadcast(ctx, explorer, txHex)
 			if err != nil {
 				if strings.Contains(strings.ToLower(err.Error()), "bad-txns-inputs-missingorspent") {
 					time.Sleep(1 * time.Second)
@@ -237,12 +289,22 @@ func unilateralRedeem(ctx *cli.Context, client arkv1.ArkServiceClient) error {
 			}
 
 			if len(txid) > 0 {
-				fmt.Printf("(%d/%d) broadcasted tx %s\n", i+1, len(transactions), txid)
+				logger.Info(
+					"broadcasted tx", "txid", txid,
+					"index", i+1, "total", len(transactions),
+				)
+				txids = append(txids, txid)
 				break
 			}
 		}
 	}
 
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyUnilateralExit,
+		Txids:  txids,
+		Amount: totalVtxosAmount,
+	})
+
 	return nil
 }
 
@@ -252,7 +314,7 @@ func askForConfirmation(s string) bool {
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
-		fmt.Printf("%s [y/n]: ", s)
+		fmt.Fprintf(os.Stderr, "%s [y/n]: ", s)
 
 		response, err := reader.ReadString('\n')
 		if err != nil {
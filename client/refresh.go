@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	refreshThresholdFlag = cli.DurationFlag{
+		Name:  "threshold",
+		Usage: "refresh the vtxos expiring within this time",
+		Value: 24 * time.Hour,
+	}
+	refreshAutoFlag = cli.BoolFlag{
+		Name:  "auto",
+		Usage: "keep running and refresh the vtxos as soon as they get within the threshold of expiry",
+		Value: false,
+	}
+	refreshIntervalFlag = cli.DurationFlag{
+		Name:  "interval",
+		Usage: "how often to check the expiry of the vtxos in auto mode",
+		Value: 10 * time.Minute,
+	}
+)
+
+var refreshCommand = cli.Command{
+	Name:   "refresh",
+	Usage:  "Send the vtxos about to expire to yourself in a new round, so that they don't get swept by the ASP",
+	Action: refreshAction,
+	Flags: []cli.Flag{
+		&refreshThresholdFlag, &refreshAutoFlag, &refreshIntervalFlag,
+		&passwordFlag, &roundRetriesFlag, &reviewFlag,
+	},
+}
+
+func refreshAction(ctx *cli.Context) error {
+	threshold := ctx.Duration(refreshThresholdFlag.Name)
+	if threshold <= 0 {
+		return fmt.Errorf("invalid threshold, must be positive")
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	roundLifetime, err := getRoundLifetime(ctx)
+	if err != nil {
+		return err
+	}
+	if threshold >= time.Duration(roundLifetime)*time.Second {
+		// every vtxo would be refreshed over and over.
+		return fmt.Errorf(
+			"invalid threshold, must be lower than the round lifetime %s",
+			time.Duration(roundLifetime)*time.Second,
+		)
+	}
+
+	// unlock the wallet once, it's needed for every refresh in auto mode.
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(refreshAutoFlag.Name) {
+		return refreshOnce(ctx, client, signer, threshold)
+	}
+
+	ticker := time.NewTicker(ctx.Duration(refreshIntervalFlag.Name))
+	defer ticker.Stop()
+
+	for {
+		if err := refreshOnce(ctx, client, signer, threshold); err != nil {
+			logger.Warn("refresh failed", "err", err)
+		}
+
+		select {
+		case <-ctx.Context.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshOnce joins a round to self-send the vtxos expiring within the given
+// threshold, if any. The wallet is locked only for the time of the round, so
+// that other commands can run in the meanwhile in auto mode.
+func refreshOnce(
+	ctx *cli.Context, client arkv1.ArkServiceClient, signer walletSigner,
+	threshold time.Duration,
+) error {
+	unlock, err := lockWallet(ctx, "refresh")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := reconcileOutbox(ctx); err != nil {
+		logger.Warn("failed to reconcile pending operations", "err", err)
+	}
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	explorer := NewExplorer(ctx)
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+
+	expiring := make([]vtxo, 0)
+	amount := uint64(0)
+	for _, v := range vtxos {
+		if v.expireAt == nil || time.Until(*v.expireAt) > threshold {
+			continue
+		}
+		expiring = append(expiring, v)
+		amount += v.amount
+	}
+	if len(expiring) <= 0 {
+		return nil
+	}
+
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return err
+	}
+	netAmount, err := fees.forSelfPayment(amount)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("refreshing %d vtxos (%d sats)\n", len(expiring), amount)
+
+	poolTxID, err := joinRound(
+		ctx, client, expiring, signer,
+		[]*arkv1.Output{{Address: offchainAddr, Amount: netAmount}},
+	)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historyRefresh,
+		Txid:   poolTxID,
+		Amount: netAmount,
+		Fee:    amount - netAmount,
+	})
+
+	return printJSON(map[string]interface{}{
+		"event":     "vtxos_refreshed",
+		"pool_txid": poolTxID,
+		"vtxos":     len(expiring),
+		"amount":    netAmount,
+		"fee":       amount - netAmount,
+	})
+}
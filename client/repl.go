@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+var replCommand = cli.Command{
+	Name:   "repl",
+	Usage:  "Unlock the wallet once and run commands interactively, reusing the key and the ASP connection for the whole session",
+	Action: replAction,
+	Flags:  []cli.Flag{&passwordFlag},
+}
+
+// replSession holds what the commands run by the repl share, it's nil outside
+// of the repl.
+var replSession *session
+
+type session struct {
+	prvKey *secp256k1.PrivateKey
+	// the connections to the ASPs, by url.
+	clients map[string]arkv1.ArkServiceClient
+	closers []func()
+}
+
+// getClient returns the connection to the given ASP opened by a previous
+// command, or a new one kept open until the end of the session.
+func (s *session) getClient(addr string) (arkv1.ArkServiceClient, func(), error) {
+	if client, ok := s.clients[addr]; ok {
+		return client, func() {}, nil
+	}
+
+	client, close, err := getClient(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.clients[addr] = client
+	s.closers = append(s.closers, close)
+	return client, func() {}, nil
+}
+
+func (s *session) close() {
+	for _, close := range s.closers {
+		close()
+	}
+	if s.prvKey != nil {
+		s.prvKey.Zero()
+	}
+}
+
+func replAction(ctx *cli.Context) error {
+	if err := startSession(ctx); err != nil {
+		return err
+	}
+	defer stopSession()
+
+	globalArgs := getGlobalArgs(ctx)
+
+	// the payments received out of round while the wallet was offline are
+	// claimed right away, they'd be lost if left to expire.
+	if err := claimAsyncPaymentsOnStartup(ctx, globalArgs); err != nil {
+		logger.Warn("failed to claim the payments received out of round", "err", err)
+	}
+
+	fmt.Println("type 'help' to list the commands, 'quit' or ctrl-d to leave")
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("ark> ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				fmt.Println()
+				return nil
+			}
+			return err
+		}
+
+		args, err := splitArgs(line)
+		if err != nil {
+			fmt.Printf("error: %s\n", err)
+			continue
+		}
+		if len(args) <= 0 {
+			continue
+		}
+		if args[0] == "quit" {
+			return nil
+		}
+		if args[0] == "repl" {
+			fmt.Println("error: already in a repl session")
+			continue
+		}
+
+		if err := ctx.App.Run(append(globalArgs, args...)); err != nil {
+			fmt.Printf("error: %s\n", err)
+		}
+	}
+}
+
+// startSession unlocks the wallet once for all the commands run by the repl
+// or by the daemon, until stopSession is called.
+func startSession(ctx *cli.Context) error {
+	if replSession != nil {
+		return fmt.Errorf("already in a repl session")
+	}
+
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(state[PUBKEY]) <= 0 {
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	s := &session{clients: make(map[string]arkv1.ArkServiceClient)}
+	// the external signer holds the key, there's nothing to unlock.
+	if len(state[EXTERNAL_SIGNER]) <= 0 {
+		if s.prvKey, err = privateKeyFromPassword(ctx); err != nil {
+			return err
+		}
+	}
+	replSession = s
+	return nil
+}
+
+func stopSession() {
+	if replSession == nil {
+		return
+	}
+	replSession.close()
+	replSession = nil
+}
+
+// getGlobalArgs returns the arguments to run the commands of a session with
+// the same global flags of the session itself.
+func getGlobalArgs(ctx *cli.Context) []string {
+	globalArgs := []string{ctx.App.Name}
+	for _, flag := range ctx.App.Flags {
+		name := flag.Names()[0]
+		if ctx.IsSet(name) {
+			globalArgs = append(globalArgs, fmt.Sprintf("--%s=%v", name, ctx.Value(name)))
+		}
+	}
+	return globalArgs
+}
+
+// splitArgs splits the given line into arguments like a shell would,
+// honoring single and double quotes and backslash escapes.
+func splitArgs(line string) ([]string, error) {
+	args := make([]string, 0)
+	var current strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+
+	for _, r := range strings.TrimSpace(line) {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var rescanCommand = cli.Command{
+	Name:   "rescan",
+	Usage:  "Recover the vtxos and the rounds of the wallet from the ASP and rebuild the local state, to be run after restoring a wallet from its key",
+	Action: withWalletLock(rescanAction),
+}
+
+// rescannedRound is the activity of the wallet in a round, or in an async
+// payment, recovered from its vtxos.
+type rescannedRound struct {
+	txid      string
+	received  uint64
+	spent     uint64
+	createdAt int64
+}
+
+func rescanAction(ctx *cli.Context) error {
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	// the address depends on the parameters of the ASP, which are refreshed
+	// first in case the restored state is outdated.
+	info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if err := setState(ctx, map[string]string{
+		ASP_PUBKEY:            info.GetPubkey(),
+		ROUND_LIFETIME:        strconv.Itoa(int(info.GetRoundLifetime())),
+		UNILATERAL_EXIT_DELAY: strconv.Itoa(int(info.GetUnilateralExitDelay())),
+	}); err != nil {
+		return err
+	}
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.ListVtxos(ctx.Context, &arkv1.ListVtxosRequest{
+		Address: offchainAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	spendableVtxos := make(map[string]uint64)
+	balance := uint64(0)
+	for _, v := range response.GetSpendableVtxos() {
+		if v.GetSwept() {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout())
+		spendableVtxos[key] = v.GetReceiver().GetAmount()
+		balance += v.GetReceiver().GetAmount()
+	}
+	if err := setKnownVtxos(ctx, spendableVtxos); err != nil {
+		return err
+	}
+
+	// the locked vtxos that have been spent meanwhile are dropped.
+	lockedCoins, err := getLockedCoins(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range response.GetSpentVtxos() {
+		delete(lockedCoins, fmt.Sprintf(
+			"%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout(),
+		))
+	}
+	if err := setLockedCoins(ctx, lockedCoins); err != nil {
+		return err
+	}
+
+	rounds, err := rescanRounds(ctx, client, response)
+	if err != nil {
+		return err
+	}
+	recovered, err := recoverHistory(ctx, rounds)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"address":           offchainAddr,
+		"spendable_vtxos":   len(spendableVtxos),
+		"spent_vtxos":       len(response.GetSpentVtxos()),
+		"balance":           balance,
+		"rounds":            len(rounds),
+		"recovered_history": recovered,
+	})
+}
+
+// rescanRounds groups the vtxos of the wallet by the round, or the redeem tx,
+// that created or spent them.
+func rescanRounds(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	response *arkv1.ListVtxosResponse,
+) ([]*rescannedRound, error) {
+	rounds := make(map[string]*rescannedRound)
+	getRound := func(txid string) *rescannedRound {
+		if _, ok := rounds[txid]; !ok {
+			rounds[txid] = &rescannedRound{txid: txid}
+		}
+		return rounds[txid]
+	}
+
+	vtxos := make([]*arkv1.Vtxo, 0, len(response.GetSpendableVtxos())+len(response.GetSpentVtxos()))
+	vtxos = append(vtxos, response.GetSpendableVtxos()...)
+	vtxos = append(vtxos, response.GetSpentVtxos()...)
+	for _, v := range vtxos {
+		// the vtxos received out of round are created by their redeem tx.
+		txid := v.GetPoolTxid()
+		if len(v.GetRedeemTx()) > 0 {
+			txid = v.GetOutpoint().GetTxid()
+		}
+		getRound(txid).received += v.GetReceiver().GetAmount()
+
+		if len(v.GetSpentBy()) > 0 {
+			getRound(v.GetSpentBy()).spent += v.GetReceiver().GetAmount()
+		}
+	}
+
+	// the redeem txs have no round of their own, they're dated with the one
+	// of the vtxos they create.
+	poolTxids := make(map[string]string)
+	for _, v := range vtxos {
+		if len(v.GetRedeemTx()) > 0 {
+			poolTxids[v.GetOutpoint().GetTxid()] = v.GetPoolTxid()
+		}
+	}
+
+	timestamps := make(map[string]int64)
+	list := make([]*rescannedRound, 0, len(rounds))
+	for txid, round := range rounds {
+		poolTxid := txid
+		if id, ok := poolTxids[txid]; ok {
+			poolTxid = id
+		}
+		if _, ok := timestamps[poolTxid]; !ok {
+			resp, err := client.GetRound(ctx.Context, &arkv1.GetRoundRequest{
+				Txid: poolTxid,
+			})
+			if err != nil {
+				logger.Warn("failed to fetch round", "pool_txid", poolTxid, "err", err)
+			}
+			timestamps[poolTxid] = resp.GetRound().GetEnd()
+		}
+		round.createdAt = timestamps[poolTxid]
+		list = append(list, round)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].createdAt < list[j].createdAt
+	})
+	return list, nil
+}
+
+// recoverHistory adds to the history an entry for every rescanned round not
+// already in there, and returns how many have been added. The entries of the
+// operations made by this wallet are richer than those recovered and are
+// kept as they are.
+func recoverHistory(ctx *cli.Context, rounds []*rescannedRound) (int, error) {
+	entries, err := getHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+	known := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		known[entry.Txid] = struct{}{}
+	}
+
+	recovered := make([]historyEntry, 0)
+	for _, round := range rounds {
+		if _, ok := known[round.txid]; ok {
+			continue
+		}
+		entry := historyEntry{Txid: round.txid, CreatedAt: round.createdAt}
+		switch {
+		case round.spent <= 0:
+			entry.Kind, entry.Amount = historyReceive, round.received
+		case round.received >= round.spent:
+			entry.Kind, entry.Amount = historyRefresh, round.received
+		default:
+			entry.Kind, entry.Amount = historyOffchainSend, round.spent-round.received
+		}
+		recovered = append(recovered, entry)
+	}
+	if len(recovered) <= 0 {
+		return 0, nil
+	}
+
+	if err := withStateTx(ctx, func(tx *sql.Tx) error {
+		for _, entry := range recovered {
+			if err := insertHistoryEntry(tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return len(recovered), nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+var resumeCommand = cli.Command{
+	Name:   "resume",
+	Usage:  "Resumes the round participation interrupted by a crash or a connection loss, or abandons it if nothing can have been broadcasted",
+	Action: withWalletLock(resumeAction),
+	Flags:  []cli.Flag{&passwordFlag, &reviewFlag},
+}
+
+func resumeAction(ctx *cli.Context) error {
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		logger.Warn("no round to resume")
+		return nil
+	}
+
+	// the payment must be resumed with the ASP it was registered with.
+	if len(round.AspPubkey) > 0 {
+		if err := selectPendingRoundAsp(ctx, round.AspPubkey); err != nil {
+			return err
+		}
+	}
+
+	// the round might have been finalized while the client was away.
+	if len(round.PoolTxid) > 0 {
+		if _, err := NewExplorer(ctx).GetTxHex(round.PoolTxid); err == nil {
+			return printResumedRound(ctx, round, round.PoolTxid)
+		}
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	if round.Stage == roundStageFinalizing {
+		return waitPendingRound(ctx, client, round)
+	}
+
+	// the payment was never claimed, so it can't be part of any round: the
+	// next send registers the same coins with the same client payment id.
+	if round.Stage == roundStageRegistering {
+		logger.Warn(
+			"payment registration not completed, nothing to resume",
+			"client_payment_id", round.ClientPaymentID,
+		)
+		return nil
+	}
+
+	// the ASP forgets the payments of the clients that stop pinging, in that
+	// case there's nothing to rejoin and, since no signed forfeit was ever
+	// submitted, the coins can be released.
+	if _, err := client.Ping(ctx.Context, &arkv1.PingRequest{
+		PaymentId: round.PaymentID,
+	}); err != nil {
+		if isRetriable(err) {
+			return err
+		}
+		// the forfeits might have been signed already, in which case the
+		// coins are released only if the ASP confirms the round failed.
+		if round.Stage == roundStageSigning {
+			poolTxid, resolveErr := resolvePendingRound(ctx, round)
+			if resolveErr != nil {
+				return fmt.Errorf("%s: %s", err, resolveErr)
+			}
+			if len(poolTxid) > 0 {
+				return printResumedRound(ctx, round, poolTxid)
+			}
+		}
+		return abandonPendingRound(ctx, round, err)
+	}
+
+	explorer := NewExplorer(ctx)
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+	coins, _, err := selectVtxosByOutpoint(vtxos, round.Inputs, 0)
+	if err != nil {
+		return fmt.Errorf("cannot resume payment %s: %s", round.PaymentID, err)
+	}
+
+	receivers := make([]*arkv1.Output, 0, len(round.Receivers))
+	for _, r := range round.Receivers {
+		receivers = append(receivers, &arkv1.Output{
+			Address: r.To,
+			Amount:  r.Amount,
+		})
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	ephemeralKey, err := parseEphemeralKey(round.EphemeralKey)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("rejoining round", "payment_id", round.PaymentID)
+	poolTxid, roundErr := handleRoundStream(
+		ctx, client, round.PaymentID, coins, signer, receivers, ephemeralKey,
+	)
+	if roundErr == nil {
+		return printResumedRound(ctx, round, poolTxid)
+	}
+
+	// same as for a round joined by send, the coins are released only if
+	// no signed forfeit was submitted in the meanwhile.
+	round, err = getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		return roundErr
+	}
+	poolTxid, err = resolvePendingRound(ctx, round)
+	if err != nil {
+		return fmt.Errorf("%s: %s", roundErr, err)
+	}
+	if len(poolTxid) > 0 {
+		return printResumedRound(ctx, round, poolTxid)
+	}
+	if err := settlePaymentOutbox(
+		ctx, round.PaymentID, outboxFailed, roundErr,
+	); err != nil {
+		return err
+	}
+	logger.Warn(
+		"round failed, nothing has been broadcasted, coins released",
+		"payment_id", round.PaymentID, "err", roundErr,
+	)
+	return nil
+}
+
+// waitPendingRound waits for the outcome of the round the signed forfeits of
+// the payment were submitted to.
+func waitPendingRound(
+	ctx *cli.Context, client arkv1.ArkServiceClient, round *pendingRound,
+) error {
+	stream, err := client.GetEventStream(ctx.Context, &arkv1.GetEventStreamRequest{})
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"waiting for round finalization",
+		"payment_id", round.PaymentID, "round_id", round.RoundID,
+	)
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("stream closed unexpectedly")
+		}
+		if err != nil {
+			return err
+		}
+
+		if e := event.GetRoundFinalized(); e != nil {
+			if e.GetId() == round.RoundID || e.GetPoolTxid() == round.PoolTxid {
+				return printResumedRound(ctx, round, e.GetPoolTxid())
+			}
+		}
+
+		if e := event.GetRoundFailed(); e != nil && e.GetId() == round.RoundID {
+			// the ASP gave up the round, so its pool tx won't be broadcasted
+			// and the submitted forfeits are useless.
+			return abandonPendingRound(ctx, round, fmt.Errorf("%s", e.GetReason()))
+		}
+	}
+}
+
+// abandonPendingRound releases the coins of a payment whose round can't
+// have been broadcasted.
+func abandonPendingRound(
+	ctx *cli.Context, round *pendingRound, reason error,
+) error {
+	if err := settlePaymentOutbox(
+		ctx, round.PaymentID, outboxFailed, reason,
+	); err != nil {
+		return err
+	}
+	if err := setPendingRound(ctx, nil); err != nil {
+		return err
+	}
+	logger.Warn(
+		"payment abandoned, nothing has been broadcasted, coins released",
+		"payment_id", round.PaymentID, "err", reason,
+	)
+	return nil
+}
+
+func printResumedRound(
+	ctx *cli.Context, round *pendingRound, poolTxid string,
+) error {
+	if err := settleRound(ctx, round.PaymentID, poolTxid, round.Inputs); err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"payment_id": round.PaymentID,
+		"pool_txid":  poolTxid,
+		"receivers":  round.Receivers,
+	})
+}
+
+func selectPendingRoundAsp(ctx *cli.Context, pubkey string) error {
+	buf, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return fmt.Errorf("invalid pending round asp pubkey: %s", err)
+	}
+	key, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return fmt.Errorf("invalid pending round asp pubkey: %s", err)
+	}
+
+	asp, err := findAsp(ctx, key)
+	if err != nil {
+		return err
+	}
+	if asp == nil {
+		return fmt.Errorf("payment registered with ASP %s the wallet is no longer connected to", pubkey)
+	}
+	return useAsp(ctx, asp)
+}
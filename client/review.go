@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"golang.org/x/term"
+)
+
+var reviewFlag = cli.BoolFlag{
+	Name:  "review",
+	Usage: "show a decoded summary of the txs of the round before signing the forfeits and, if interactive, ask for confirmation",
+	Value: false,
+}
+
+type txSummary struct {
+	Txid     string          `json:"txid"`
+	Locktime uint32          `json:"locktime,omitempty"`
+	Inputs   []inputSummary  `json:"inputs"`
+	Outputs  []outputSummary `json:"outputs"`
+}
+
+type inputSummary struct {
+	Outpoint    string   `json:"outpoint"`
+	Sequence    uint32   `json:"sequence"`
+	LeafScripts []string `json:"leaf_scripts,omitempty"`
+}
+
+type outputSummary struct {
+	Script string `json:"script"`
+	Amount uint64 `json:"amount"`
+}
+
+// reviewRoundTxs prints what the round asks to sign: the forfeit txs spending
+// the wallet's coins, the pool tx and the nodes of the congestion tree whose
+// leaves are the new vtxos.
+// In interactive mode, it returns an error unless the user confirms.
+func reviewRoundTxs(
+	poolTx string, congestionTree tree.CongestionTree,
+	forfeits []*psetv2.Pset,
+) error {
+	forfeitSummaries := make([]txSummary, 0, len(forfeits))
+	for _, forfeit := range forfeits {
+		summary, err := summarizePset(forfeit)
+		if err != nil {
+			return err
+		}
+		forfeitSummaries = append(forfeitSummaries, summary)
+	}
+
+	ptx, err := psetv2.NewPsetFromBase64(poolTx)
+	if err != nil {
+		return err
+	}
+	poolTxSummary, err := summarizePset(ptx)
+	if err != nil {
+		return err
+	}
+
+	treeSummary := make([]map[string]interface{}, 0, congestionTree.NumberOfNodes())
+	for depth, level := range congestionTree {
+		for _, node := range level {
+			pset, err := psetv2.NewPsetFromBase64(node.Tx)
+			if err != nil {
+				return err
+			}
+			summary, err := summarizePset(pset)
+			if err != nil {
+				return err
+			}
+			treeSummary = append(treeSummary, map[string]interface{}{
+				"depth":       depth,
+				"parent_txid": node.ParentTxid,
+				"leaf":        node.Leaf,
+				"tx":          summary,
+			})
+		}
+	}
+
+	fmt.Println()
+	if err := printJSON(map[string]interface{}{
+		"forfeit_txs":     forfeitSummaries,
+		"pool_tx":         poolTxSummary,
+		"congestion_tree": treeSummary,
+	}); err != nil {
+		return err
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	if !askForConfirmation(
+		fmt.Sprintf("sign %d forfeit txs?", len(forfeits)),
+	) {
+		return fmt.Errorf("signing of forfeit txs rejected")
+	}
+	return nil
+}
+
+func summarizePset(pset *psetv2.Pset) (txSummary, error) {
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return txSummary{}, err
+	}
+
+	inputs := make([]inputSummary, 0, len(pset.Inputs))
+	for i, in := range pset.Inputs {
+		leafScripts := make([]string, 0, len(in.TapLeafScript))
+		for _, leaf := range in.TapLeafScript {
+			leafScripts = append(leafScripts, disasmScript(leaf.Script))
+		}
+		inputs = append(inputs, inputSummary{
+			Outpoint: fmt.Sprintf(
+				"%s:%d", chainhash.Hash(in.PreviousTxid).String(), in.PreviousTxIndex,
+			),
+			Sequence:    utx.Inputs[i].Sequence,
+			LeafScripts: leafScripts,
+		})
+	}
+
+	outputs := make([]outputSummary, 0, len(pset.Outputs))
+	for _, out := range pset.Outputs {
+		script := "fee"
+		if len(out.Script) > 0 {
+			script = disasmScript(out.Script)
+		}
+		outputs = append(outputs, outputSummary{
+			Script: script,
+			Amount: out.Value,
+		})
+	}
+
+	return txSummary{
+		Txid:     utx.TxHash().String(),
+		Locktime: utx.Locktime,
+		Inputs:   inputs,
+		Outputs:  outputs,
+	}, nil
+}
+
+// disasmScript returns the human readable form of the given script, or its hex
+// encoding if it contains opcodes unknown to bitcoin.
+func disasmScript(script []byte) string {
+	disasm, err := txscript.DisasmString(script)
+	if err != nil {
+		return hex.EncodeToString(script)
+	}
+	return disasm
+}
@@ -0,0 +1,660 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stages a payment goes through while taking part to a round.
+// The current one is persisted in the state so that, whatever happens to the
+// round, the client knows whether its funds might have moved or not.
+const (
+	// the payment is being registered, the ASP might not know about it yet.
+	roundStageRegistering = "registering"
+	// the payment is registered, nothing has been signed yet.
+	roundStageRegistered = "registered"
+	// the round finalization started, forfeits are being signed.
+	roundStageSigning = "signing"
+	// the signed forfeits have been submitted to the ASP.
+	roundStageFinalizing = "finalizing"
+)
+
+// staleRegistrationTimeout is after how long the ASP surely forgot a payment
+// registered but never claimed, and so never pinged.
+const staleRegistrationTimeout = 10 * time.Minute
+
+// maxCoinsReplacements is how many times the coins rejected by the ASP are
+// replaced before giving up the payment.
+const maxCoinsReplacements = 3
+
+var roundRetriesFlag = cli.IntFlag{
+	Name:  "round-retries",
+	Usage: "number of times to automatically join the next round if the current one fails before the payment is finalized",
+	Value: 0,
+}
+
+type pendingRound struct {
+	// ClientPaymentID is generated before registering the payment, so that
+	// the ASP recognizes a retried registration of the same inputs.
+	ClientPaymentID string   `json:"client_payment_id,omitempty"`
+	PaymentID       string   `json:"payment_id"`
+	Stage           string   `json:"stage"`
+	Inputs          []string `json:"inputs"`
+	// the receivers and the ASP of the payment are needed to resume it.
+	Receivers []receiver `json:"receivers,omitempty"`
+	AspPubkey string     `json:"asp_pubkey,omitempty"`
+	RoundID   string     `json:"round_id,omitempty"`
+	PoolTxid  string     `json:"pool_txid,omitempty"`
+	UpdatedAt int64      `json:"updated_at"`
+
+	// EphemeralKey cosigns the congestion tree of the round, if the ASP
+	// requires it. It's meaningless once the round is over.
+	EphemeralKey string `json:"ephemeral_key,omitempty"`
+}
+
+// isPendingRegistration returns whether the payment is being registered and
+// might still be known by the ASP.
+func (r *pendingRound) isPendingRegistration() bool {
+	return r.Stage == roundStageRegistering &&
+		time.Since(time.Unix(r.UpdatedAt, 0)) < staleRegistrationTimeout
+}
+
+func getPendingRound(ctx *cli.Context) (*pendingRound, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingRoundStr := state[PENDING_ROUND]
+	if len(pendingRoundStr) <= 0 {
+		return nil, nil
+	}
+
+	round := &pendingRound{}
+	if err := json.Unmarshal([]byte(pendingRoundStr), round); err != nil {
+		return nil, fmt.Errorf("invalid pending round: %s", err)
+	}
+	return round, nil
+}
+
+func setPendingRound(ctx *cli.Context, round *pendingRound) error {
+	if round == nil {
+		return setState(ctx, map[string]string{PENDING_ROUND: ""})
+	}
+
+	round.UpdatedAt = time.Now().Unix()
+	buf, err := json.Marshal(round)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{PENDING_ROUND: string(buf)})
+}
+
+func updatePendingRoundStage(
+	ctx *cli.Context, stage, roundID, poolTxid string,
+) error {
+	round, err := getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		return nil
+	}
+
+	round.Stage = stage
+	if len(roundID) > 0 {
+		round.RoundID = roundID
+	}
+	if len(poolTxid) > 0 {
+		round.PoolTxid = poolTxid
+	}
+	return setPendingRound(ctx, round)
+}
+
+// resolvePendingRound checks the outcome of a round the client was taking
+// part to. It returns the pool txid if the round's pool tx has been
+// broadcasted, meaning that the payment went through.
+// If it hasn't, the coins are released only if no forfeit was ever signed,
+// or if the ASP confirms that the round failed. Otherwise the pending round
+// is kept, since the pool tx might still be broadcasted, so that its outcome
+// can be checked again later.
+// A registration whose outcome is unknown is kept as well, until the ASP
+// forgets it, so that it's retried with the same client payment id.
+func resolvePendingRound(ctx *cli.Context, round *pendingRound) (string, error) {
+	if round.Stage == roundStageRegistering {
+		if round.isPendingRegistration() {
+			return "", nil
+		}
+		return "", setPendingRound(ctx, nil)
+	}
+
+	if len(round.PoolTxid) > 0 {
+		explorer := NewExplorer(ctx)
+		if _, err := explorer.GetTxHex(round.PoolTxid); err == nil {
+			if err := forgetSpentVtxos(ctx, round.Inputs); err != nil {
+				return "", err
+			}
+			if err := mergeVtxoClusters(
+				ctx, round.PoolTxid, round.Inputs,
+			); err != nil {
+				return "", err
+			}
+			if err := setPendingRound(ctx, nil); err != nil {
+				return "", err
+			}
+			return round.PoolTxid, nil
+		}
+	}
+
+	if round.Stage == roundStageSigning || round.Stage == roundStageFinalizing {
+		failed, err := isRoundFailed(ctx, round.RoundID)
+		if err != nil {
+			logger.Warn(
+				"failed to check the outcome of the round",
+				"round_id", round.RoundID, "err", err,
+			)
+		}
+		if !failed {
+			return "", fmt.Errorf(
+				"round %s has not been broadcasted yet but the forfeits of "+
+					"payment %s might have been signed, retry later to check its outcome",
+				round.PoolTxid, round.PaymentID,
+			)
+		}
+	}
+
+	return "", setPendingRound(ctx, nil)
+}
+
+// isRoundFailed returns whether the ASP reports the round with the given id
+// as failed, in which case its pool tx won't ever be broadcasted.
+func isRoundFailed(ctx *cli.Context, roundID string) (bool, error) {
+	if len(roundID) <= 0 {
+		return false, nil
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer close()
+
+	resp, err := client.GetRoundById(ctx.Context, &arkv1.GetRoundByIdRequest{
+		Id: roundID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetRound().GetStage() == arkv1.RoundStage_ROUND_STAGE_FAILED, nil
+}
+
+// joinRound registers a payment for the given coins and receivers and waits
+// for the round it takes part to to be finalized.
+// If the round fails before the signed forfeits are submitted nothing could
+// have been broadcasted: the coins are released and, if requested with
+// --round-retries, the payment is registered again for the next round.
+func joinRound(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	selectedCoins []vtxo, signer walletSigner,
+	receivers []*arkv1.Output,
+) (string, error) {
+	// check the outcome of any round left pending by a previous invocation
+	// before spending the same coins again.
+	prevRound, err := getPendingRound(ctx)
+	if err != nil {
+		return "", err
+	}
+	var prevRegistration *pendingRound
+	if prevRound != nil {
+		poolTxid, err := resolvePendingRound(ctx, prevRound)
+		if err != nil {
+			return "", err
+		}
+		if len(poolTxid) > 0 {
+			return "", fmt.Errorf(
+				"a previous payment (%s) was finalized in round %s, check your balance before retrying",
+				prevRound.PaymentID, poolTxid,
+			)
+		}
+		// a registration not stale yet is kept by resolvePendingRound.
+		if prevRound.isPendingRegistration() {
+			prevRegistration = prevRound
+		}
+	}
+
+	// all the coins ever rejected by the ASP are excluded from the
+	// replacements.
+	rejectedInputs := make([]*arkv1.Input, 0)
+	replacements := 0
+
+	retries := ctx.Int(roundRetriesFlag.Name)
+	for attempt := 0; ; attempt++ {
+		inputs := make([]*arkv1.Input, 0, len(selectedCoins))
+		inputsStr := make([]string, 0, len(selectedCoins))
+		for _, coin := range selectedCoins {
+			inputs = append(inputs, &arkv1.Input{
+				Txid: coin.txid,
+				Vout: coin.vout,
+			})
+			inputsStr = append(inputsStr, fmt.Sprintf("%s:%d", coin.txid, coin.vout))
+		}
+
+		// a registration interrupted before the ASP answered is retried with
+		// the same id, so that the ASP doesn't register the inputs twice.
+		var clientPaymentID string
+		if attempt == 0 && prevRegistration != nil &&
+			sameOutpoints(prevRegistration.Inputs, inputsStr) {
+			clientPaymentID = prevRegistration.ClientPaymentID
+		} else {
+			clientPaymentID, err = newClientPaymentID()
+			if err != nil {
+				return "", err
+			}
+		}
+
+		log := logger.With("client_payment_id", clientPaymentID, "attempt", attempt)
+
+		// all the coins are owned by the wallet key, that proves it to the
+		// ASP by signing the registration.
+		sig, err := signer.signHash(
+			common.RegistrationHash(clientPaymentID, inputsStr),
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign payment registration: %s", err)
+		}
+		signatures := make([]string, 0, len(inputs))
+		for range inputs {
+			signatures = append(signatures, hex.EncodeToString(sig.Serialize()))
+		}
+
+		aspPubkey, err := getAspPublicKey(ctx)
+		if err != nil {
+			return "", err
+		}
+		pendingReceivers := make([]receiver, 0, len(receivers))
+		for _, r := range receivers {
+			pendingReceivers = append(pendingReceivers, receiver{
+				To: r.GetAddress(), Amount: r.GetAmount(),
+			})
+		}
+		ephemeralKey, err := btcec.NewPrivateKey()
+		if err != nil {
+			return "", err
+		}
+		round := &pendingRound{
+			ClientPaymentID: clientPaymentID,
+			Stage:           roundStageRegistering,
+			Inputs:          inputsStr,
+			Receivers:       pendingReceivers,
+			AspPubkey:       hex.EncodeToString(aspPubkey.SerializeCompressed()),
+			EphemeralKey:    hex.EncodeToString(ephemeralKey.Serialize()),
+		}
+		if err := setPendingRound(ctx, round); err != nil {
+			return "", err
+		}
+
+		registerResponse, err := client.RegisterPayment(
+			ctx.Context, &arkv1.RegisterPaymentRequest{
+				Inputs:          inputs,
+				ClientPaymentId: clientPaymentID,
+				Signatures:      signatures,
+			},
+		)
+		if err != nil {
+			log.Error("payment registration failed", "err", err)
+			// nothing can have been signed yet, but the payment might have
+			// been registered anyway if the ASP didn't answer: in that case
+			// the pending round is kept to retry with the same id.
+			if isRegistrationRejected(err) {
+				// nolint
+				setPendingRound(ctx, nil)
+			}
+			// the registration fails if some coins have been spent in the
+			// meanwhile, likely by another device using the same wallet.
+			if conflictErr := checkConflicts(
+				ctx, client, selectedCoins,
+			); conflictErr != nil {
+				return "", conflictErr
+			}
+			return "", err
+		}
+		paymentID := registerResponse.GetId()
+		log = log.With("payment_id", paymentID)
+		log.Info("payment registered", "inputs", len(inputs))
+
+		if err := recordOutboxEntry(
+			ctx, outboxRegisterPayment, paymentID, outboxPending, nil,
+		); err != nil {
+			return "", err
+		}
+
+		if _, err := client.ClaimPayment(ctx.Context, &arkv1.ClaimPaymentRequest{
+			Id:      paymentID,
+			Outputs: receivers,
+			EphemeralPubkey: hex.EncodeToString(
+				ephemeralKey.PubKey().SerializeCompressed(),
+			),
+		}); err != nil {
+			log.Error("payment claim failed", "err", err)
+			// nolint
+			recordOutboxEntry(ctx, outboxRegisterPayment, paymentID, outboxFailed, err)
+			return "", err
+		}
+
+		if err := recordOutboxEntry(
+			ctx, outboxClaimPayment, paymentID, outboxPending, nil,
+		); err != nil {
+			return "", err
+		}
+
+		round.PaymentID = paymentID
+		round.Stage = roundStageRegistered
+		if err := setPendingRound(ctx, round); err != nil {
+			return "", err
+		}
+
+		poolTxID, roundErr := handleRoundStream(
+			ctx, client, paymentID,
+			selectedCoins, signer, receivers, ephemeralKey,
+		)
+		if roundErr == nil {
+			if err := settleRound(ctx, paymentID, poolTxID, inputsStr); err != nil {
+				return "", err
+			}
+			return poolTxID, nil
+		}
+
+		round, err = getPendingRound(ctx)
+		if err != nil {
+			return "", err
+		}
+		if round == nil {
+			return "", roundErr
+		}
+
+		stage := round.Stage
+		log.Info("checking the outcome of the failed round", "stage", stage)
+		poolTxID, err = resolvePendingRound(ctx, round)
+		if err != nil {
+			return "", fmt.Errorf("%s: %s", roundErr, err)
+		}
+		if len(poolTxID) > 0 {
+			if err := settlePaymentOutbox(ctx, paymentID, outboxDone, nil); err != nil {
+				return "", err
+			}
+			return poolTxID, nil
+		}
+		if err := settlePaymentOutbox(
+			ctx, paymentID, outboxFailed, roundErr,
+		); err != nil {
+			return "", err
+		}
+
+		// some of the coins have been rejected by the ASP, replace them and
+		// register the payment again without waiting for the next round.
+		rejectedErr := errInputsRejected{}
+		if errors.As(roundErr, &rejectedErr) {
+			if replacements >= maxCoinsReplacements {
+				return "", fmt.Errorf(
+					"%s: rejected coins already replaced %d times, giving up",
+					roundErr, replacements,
+				)
+			}
+			replacements++
+			rejectedInputs = append(rejectedInputs, rejectedErr.inputs...)
+
+			log.Warn(
+				"replacing rejected coins",
+				"err", roundErr, "replacement", replacements,
+			)
+			selectedCoins, receivers, err = replaceRejectedCoins(
+				ctx, client, selectedCoins, receivers, rejectedInputs,
+			)
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", roundErr, err)
+			}
+			attempt--
+			continue
+		}
+
+		log.Warn(
+			"round failed, nothing has been broadcasted, coins released",
+			"stage", stage, "err", roundErr,
+		)
+		if attempt >= retries {
+			return "", roundErr
+		}
+		log.Info("joining next round", "retry", attempt+1, "retries", retries)
+	}
+}
+
+// isRegistrationRejected returns whether the ASP surely didn't register the
+// payment, either because the call never reached it or because it answered
+// with an error. Deadlines, cancellations and transport errors leave the
+// outcome unknown.
+func isRegistrationRejected(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch s.Code() {
+	case codes.DeadlineExceeded, codes.Canceled, codes.Internal:
+		return false
+	default:
+		return true
+	}
+}
+
+type errInputsRejected struct {
+	inputs []*arkv1.Input
+	reason string
+}
+
+func (e errInputsRejected) Error() string {
+	return fmt.Sprintf("payment inputs rejected by the ASP: %s", e.reason)
+}
+
+// replaceRejectedCoins drops the rejected coins, any of the given ones, from
+// the selected ones and selects new ones to cover for the missing amount. Any
+// change is added to the one sent back to the wallet's offchain address, and
+// the fees charged by the ASP are recomputed for the new outputs.
+func replaceRejectedCoins(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	selectedCoins []vtxo, receivers []*arkv1.Output, rejected []*arkv1.Input,
+) ([]vtxo, []*arkv1.Output, error) {
+	isRejected := func(txid string, vout uint32) bool {
+		for _, in := range rejected {
+			if in.GetTxid() == txid && in.GetVout() == vout {
+				return true
+			}
+		}
+		return false
+	}
+	isSelected := func(txid string, vout uint32) bool {
+		for _, coin := range selectedCoins {
+			if coin.txid == txid && coin.vout == vout {
+				return true
+			}
+		}
+		return false
+	}
+
+	keptCoins := make([]vtxo, 0, len(selectedCoins))
+	missingAmount := uint64(0)
+	for _, coin := range selectedCoins {
+		if isRejected(coin.txid, coin.vout) {
+			missingAmount += coin.amount
+			continue
+		}
+		keptCoins = append(keptCoins, coin)
+	}
+	if missingAmount == 0 {
+		return selectedCoins, receivers, nil
+	}
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selector, err := getCoinSelector(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	explorer := NewExplorer(ctx)
+	vtxos, err := getVtxos(
+		ctx, explorer, client, offchainAddr, needsExpiration(selector),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	availableCoins := make([]vtxo, 0, len(vtxos))
+	for _, coin := range vtxos {
+		if isRejected(coin.txid, coin.vout) || isSelected(coin.txid, coin.vout) {
+			continue
+		}
+		availableCoins = append(availableCoins, coin)
+	}
+
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	replacements, changeAmount, err := coinSelectVtxos(
+		ctx, availableCoins, missingAmount, selector, locked,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to replace rejected coins: %s", err)
+	}
+
+	// the change is merged into the one of the payment, if any.
+	prevFee := paymentFeeOf(fees, receivers, offchainAddr)
+	newReceivers := make([]*arkv1.Output, 0, len(receivers)+1)
+	changeIndex := -1
+	for _, r := range receivers {
+		if r.GetAddress() == offchainAddr {
+			changeIndex = len(newReceivers)
+		}
+		newReceivers = append(newReceivers, &arkv1.Output{
+			Address: r.GetAddress(),
+			Amount:  r.GetAmount(),
+		})
+	}
+	if changeAmount > 0 {
+		if changeIndex < 0 {
+			changeIndex = len(newReceivers)
+			newReceivers = append(newReceivers, &arkv1.Output{
+				Address: offchainAddr,
+			})
+		}
+		newReceivers[changeIndex].Amount += changeAmount
+	}
+
+	// any increase of the fees charged by the ASP for the new outputs is
+	// paid with the change.
+	if newFee := paymentFeeOf(fees, newReceivers, offchainAddr); newFee > prevFee {
+		extraFee := newFee - prevFee
+		if changeIndex < 0 ||
+			newReceivers[changeIndex].GetAmount() < extraFee+DUST {
+			return nil, nil, fmt.Errorf(
+				"failed to replace rejected coins: change doesn't cover the "+
+					"additional fee of %d sats", extraFee,
+			)
+		}
+		newReceivers[changeIndex].Amount -= extraFee
+	}
+
+	return append(keptCoins, replacements...), newReceivers, nil
+}
+
+// paymentFeeOf returns the fees charged by the ASP for a payment to the given
+// receivers, those sent to the given address of the wallet excluded.
+func paymentFeeOf(
+	fees *paymentFees, receivers []*arkv1.Output, selfAddr string,
+) uint64 {
+	sentAmount, sentOutputs := uint64(0), 0
+	for _, r := range receivers {
+		if r.GetAddress() == selfAddr {
+			continue
+		}
+		sentAmount += r.GetAmount()
+		sentOutputs++
+	}
+	return fees.forPayment(sentAmount, sentOutputs)
+}
+
+// newClientPaymentID returns a random id for a payment to register.
+func newClientPaymentID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// sameOutpoints returns whether the given lists contain the same outpoints,
+// whatever their order.
+func sameOutpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[string]int)
+	for _, outpoint := range a {
+		count[outpoint]++
+	}
+	for _, outpoint := range b {
+		if count[outpoint] <= 0 {
+			return false
+		}
+		count[outpoint]--
+	}
+	return true
+}
+
+// settleRound marks the payment as done and the coins it spent as spent,
+// once its round has been finalized.
+func settleRound(
+	ctx *cli.Context, paymentID, poolTxid string, inputs []string,
+) error {
+	if err := settlePaymentOutbox(ctx, paymentID, outboxDone, nil); err != nil {
+		return err
+	}
+	if err := forgetSpentVtxos(ctx, inputs); err != nil {
+		return err
+	}
+	if err := mergeVtxoClusters(ctx, poolTxid, inputs); err != nil {
+		return err
+	}
+	return setPendingRound(ctx, nil)
+}
+
+func settlePaymentOutbox(
+	ctx *cli.Context, paymentID, status string, roundErr error,
+) error {
+	if err := recordOutboxEntry(
+		ctx, outboxRegisterPayment, paymentID, status, roundErr,
+	); err != nil {
+		return err
+	}
+	return recordOutboxEntry(
+		ctx, outboxClaimPayment, paymentID, status, roundErr,
+	)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcTimeoutFlag = &cli.DurationFlag{
+		Name:  "rpc-timeout",
+		Usage: "Specify the deadline of each call to the ASP, the event stream excluded",
+		Value: 30 * time.Second,
+	}
+	rpcRetriesFlag = &cli.UintFlag{
+		Name:  "rpc-retries",
+		Usage: "Specify how many times a call to the ASP is retried when the ASP is unreachable or busy",
+		Value: 3,
+	}
+	rpcBackoffFlag = &cli.DurationFlag{
+		Name:  "rpc-backoff",
+		Usage: "Specify the wait before the first retry of a call to the ASP, doubled at every retry",
+		Value: time.Second,
+	}
+)
+
+// rpcPolicy is the deadline and retry policy of the unary calls to the ASP.
+type rpcPolicy struct {
+	timeout    time.Duration
+	maxRetries uint
+	backoff    time.Duration
+}
+
+// the policy is set from the flags before running the command.
+var aspRPCPolicy = rpcPolicy{
+	timeout:    30 * time.Second,
+	maxRetries: 3,
+	backoff:    time.Second,
+}
+
+func setRPCPolicy(ctx *cli.Context) {
+	aspRPCPolicy = rpcPolicy{
+		timeout:    ctx.Duration(rpcTimeoutFlag.Name),
+		maxRetries: ctx.Uint(rpcRetriesFlag.Name),
+		backoff:    ctx.Duration(rpcBackoffFlag.Name),
+	}
+}
+
+// isRetriable returns whether the call surely didn't reach the ASP or was
+// rejected before being processed, so that it's safe to retry even the ones
+// that aren't idempotent, like RegisterPayment.
+func isRetriable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// unaryInterceptor applies the deadline to every attempt of a call, unless
+// the caller set a shorter one, and retries it with exponential backoff.
+func (p rpcPolicy) unaryInterceptor(
+	ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	backoff := p.backoff
+	for attempt := uint(0); ; attempt++ {
+		err := p.invoke(ctx, method, req, reply, cc, invoker, opts...)
+		if err == nil || !isRetriable(err) || attempt >= p.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (p rpcPolicy) invoke(
+	ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// statuses of the scheduled payments, those executed are removed.
+const (
+	scheduledPending = "pending"
+	// scheduledExecuting payments are being sent by the daemon, their outcome
+	// is tracked in the outbox with the payment id as ref.
+	scheduledExecuting = "executing"
+	scheduledFailed    = "failed"
+)
+
+var atFlag = cli.StringFlag{
+	Name:  "at",
+	Usage: "schedule the send at the given time, RFC3339 or unix timestamp. It's executed by the daemon, joining the first round after that time",
+}
+
+// scheduledSendFlags are the flags of send carried over to the execution of
+// the scheduled payments.
+var scheduledSendFlags = []cli.Flag{
+	&asyncFlag, &subtractFeeFlag, &satPerVByteFlag, &feePriorityFlag,
+	&enableExpiryCoinselectFlag, &coinSelectionFlag, &minLifetimeFlag,
+	&allowExpiringFlag, &roundRetriesFlag, &labelFlag,
+}
+
+var pendingCommand = cli.Command{
+	Name:  "pending",
+	Usage: "Manage the payments scheduled with send --at",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the scheduled payments, pending, executing or failed",
+			Action: pendingListAction,
+		},
+		{
+			Name:      "cancel",
+			Usage:     "Cancel a scheduled payment",
+			ArgsUsage: "<id>",
+			Action:    withWalletLock(pendingCancelAction),
+		},
+	},
+}
+
+type scheduledPayment struct {
+	ID        string     `json:"id"`
+	Receivers []receiver `json:"receivers"`
+	// Flags are the values of the scheduledSendFlags set for the send.
+	Flags     map[string]string `json:"flags,omitempty"`
+	ExecuteAt int64             `json:"execute_at"`
+	Status    string            `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	CreatedAt int64             `json:"created_at"`
+}
+
+// sendArgs returns the arguments to run the payment with send.
+func (p scheduledPayment) sendArgs() ([]string, error) {
+	receivers, err := json.Marshal(p.Receivers)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{sendCommand.Name, "--receivers", string(receivers)}
+	for name, value := range p.Flags {
+		args = append(args, fmt.Sprintf("--%s=%s", name, value))
+	}
+	return args, nil
+}
+
+// schedulePayment persists the payment to the given receivers, to be
+// executed by the daemon at the time given with --at.
+func schedulePayment(ctx *cli.Context, receivers []receiver) error {
+	if ctx.Bool(sendAllFlag.Name) {
+		return fmt.Errorf("--all can't be used along with --at")
+	}
+	if ctx.Bool(dryRunFlag.Name) || ctx.IsSet(exportUnsignedFlag.Name) {
+		return fmt.Errorf("--at can't be used along with --dry-run or --export-unsigned")
+	}
+	if ctx.IsSet(coinsFlag.Name) {
+		return fmt.Errorf("--coins can't be used along with --at, they might be spent meanwhile")
+	}
+	executeAt, err := parseScheduleTime(ctx.String(atFlag.Name))
+	if err != nil {
+		return err
+	}
+	if !executeAt.After(time.Now()) {
+		return fmt.Errorf("invalid --at, must be in the future")
+	}
+
+	flags := make(map[string]string)
+	for _, flag := range scheduledSendFlags {
+		name := flag.Names()[0]
+		if ctx.IsSet(name) {
+			flags[name] = fmt.Sprintf("%v", ctx.Value(name))
+		}
+	}
+
+	id, err := newScheduledPaymentID()
+	if err != nil {
+		return err
+	}
+	payment := scheduledPayment{
+		ID:        id,
+		Receivers: receivers,
+		Flags:     flags,
+		ExecuteAt: executeAt.Unix(),
+		Status:    scheduledPending,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return err
+	}
+	payments[payment.ID] = payment
+	if err := setScheduledPayments(ctx, payments); err != nil {
+		return err
+	}
+
+	return printJSON(payment)
+}
+
+// newScheduledPaymentID returns a random id, short enough to be typed to
+// cancel the payment.
+func newScheduledPaymentID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func parseScheduleTime(str string) (time.Time, error) {
+	if timestamp, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return time.Unix(timestamp, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"invalid time %s, must be RFC3339 or unix timestamp", str,
+		)
+	}
+	return t, nil
+}
+
+func pendingListAction(ctx *cli.Context) error {
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return err
+	}
+
+	list := make([]scheduledPayment, 0, len(payments))
+	for _, payment := range payments {
+		list = append(list, payment)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].ExecuteAt < list[j].ExecuteAt
+	})
+	return printJSON(list)
+}
+
+func pendingCancelAction(ctx *cli.Context) error {
+	id := ctx.Args().First()
+	if len(id) <= 0 {
+		return fmt.Errorf("missing scheduled payment id")
+	}
+
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return err
+	}
+	payment, ok := payments[id]
+	if !ok {
+		return fmt.Errorf("scheduled payment %s not found", id)
+	}
+	if payment.Status == scheduledExecuting {
+		return fmt.Errorf("scheduled payment %s is being executed", id)
+	}
+	delete(payments, id)
+	if err := setScheduledPayments(ctx, payments); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"id":       payment.ID,
+		"canceled": true,
+	})
+}
+
+// runDuePayments executes the scheduled payments whose time has come, one at
+// a time. The failed ones are kept, and not retried, to be inspected with
+// pending list.
+//
+// Every payment is marked executing before being sent, so that a payment
+// interrupted by a crash of the daemon is never sent twice: its outcome is
+// looked up in the outbox at the next run instead.
+func runDuePayments(ctx *cli.Context, globalArgs []string) error {
+	if err := withWalletLockWait(ctx, func() error {
+		return reconcileScheduledPayments(ctx)
+	}); err != nil {
+		return err
+	}
+
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return err
+	}
+
+	due := make([]scheduledPayment, 0)
+	for _, payment := range payments {
+		if payment.Status == scheduledPending &&
+			payment.ExecuteAt <= time.Now().Unix() {
+			due = append(due, payment)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].ExecuteAt < due[j].ExecuteAt
+	})
+
+	for _, payment := range due {
+		args, err := payment.sendArgs()
+		if err != nil {
+			return err
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", paymentRefFlag.Name, payment.ID))
+
+		// the payment might have been canceled meanwhile.
+		payment.Status = scheduledExecuting
+		stored := false
+		if err := withWalletLockWait(ctx, func() error {
+			stored, err = updateScheduledPayment(ctx, payment, false)
+			return err
+		}); err != nil {
+			return err
+		}
+		if !stored {
+			continue
+		}
+
+		logger.Info("executing scheduled payment", "id", payment.ID)
+		runErr := ctx.App.Run(append(globalArgs, args...))
+		if runErr != nil {
+			logger.Error("scheduled payment failed", "id", payment.ID, "err", runErr)
+		}
+
+		if err := withWalletLockWait(ctx, func() error {
+			return settleScheduledPayment(ctx, payment, runErr)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileScheduledPayments settles the payments left executing by a
+// daemon that was interrupted.
+func reconcileScheduledPayments(ctx *cli.Context) error {
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		if payment.Status != scheduledExecuting {
+			continue
+		}
+		if err := settleScheduledPayment(ctx, payment, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settleScheduledPayment records the outcome of the executed payment, given
+// by its outbox entry. Without any entry the payment was never sent, and it's
+// failed with runErr, or back to pending if the send couldn't even start.
+func settleScheduledPayment(
+	ctx *cli.Context, payment scheduledPayment, runErr error,
+) error {
+	outcome, err := getPaymentOutcome(ctx, payment.ID)
+	if err != nil {
+		return err
+	}
+
+	done := false
+	switch {
+	case outcome == nil:
+		if runErr != nil && !errors.Is(runErr, errWalletBusy) {
+			payment.Status, payment.Error = scheduledFailed, runErr.Error()
+		} else {
+			payment.Status = scheduledPending
+		}
+	case outcome.Status == outboxDone:
+		done = true
+	case outcome.Status == outboxFailed:
+		payment.Status, payment.Error = scheduledFailed, outcome.Error
+	default:
+		// the send was interrupted, the payment might have been registered
+		// for a round already.
+		payment.Status = scheduledFailed
+		payment.Error = "interrupted while executing, check the outbox and " +
+			"the history before scheduling it again"
+	}
+
+	_, err = updateScheduledPayment(ctx, payment, done)
+	return err
+}
+
+// updateScheduledPayment stores the given payment, removed if done. It
+// returns whether the payment is still scheduled, not canceled meanwhile.
+func updateScheduledPayment(
+	ctx *cli.Context, payment scheduledPayment, done bool,
+) (bool, error) {
+	payments, err := getScheduledPayments(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := payments[payment.ID]; !ok {
+		return false, nil
+	}
+	if done {
+		delete(payments, payment.ID)
+	} else {
+		payments[payment.ID] = payment
+	}
+	return true, setScheduledPayments(ctx, payments)
+}
+
+func getScheduledPayments(ctx *cli.Context) (map[string]scheduledPayment, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make(map[string]scheduledPayment)
+	if len(state[SCHEDULED_PAYMENTS]) <= 0 {
+		return payments, nil
+	}
+	if err := json.Unmarshal(
+		[]byte(state[SCHEDULED_PAYMENTS]), &payments,
+	); err != nil {
+		return nil, fmt.Errorf("invalid scheduled payments: %s", err)
+	}
+	return payments, nil
+}
+
+func setScheduledPayments(
+	ctx *cli.Context, payments map[string]scheduledPayment,
+) error {
+	buf, err := json.Marshal(payments)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{SCHEDULED_PAYMENTS: string(buf)})
+}
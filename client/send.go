@@ -2,20 +2,32 @@ package main
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/urfave/cli/v2"
 	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/elementsutil"
 	"github.com/vulpemventures/go-elements/psetv2"
 )
 
+const (
+	// minFeeRate is the min relay fee rate of Liquid in sats/vbyte.
+	minFeeRate = 0.1
+	// feeOutputSize is the size of an explicit fee output, that is asset,
+	// value, empty nonce and empty script.
+	feeOutputSize = 33 + 9 + 1 + 1
+)
+
 type receiver struct {
 	To     string `json:"to"`
 	Amount uint64 `json:"amount"`
+	Label  string `json:"label,omitempty"`
 }
 
 func (r *receiver) isOnchain() bool {
@@ -30,7 +42,7 @@ var (
 	}
 	toFlag = cli.StringFlag{
 		Name:  "to",
-		Usage: "address of the recipient",
+		Usage: "address or contact name of the recipient, or a BIP21-style payment uri (ark:, liquidnetwork:, ...) embedding address, amount and label",
 	}
 	amountFlag = cli.Uint64Flag{
 		Name:  "amount",
@@ -41,33 +53,137 @@ var (
 		Usage: "select vtxos that are about to expire first",
 		Value: false,
 	}
+	sendAllFlag = cli.BoolFlag{
+		Name:  "all",
+		Usage: "send all the onchain or offchain funds, depending on the type of the --to address, with the fees deducted from the amount",
+		Value: false,
+	}
+	satPerVByteFlag = cli.Float64Flag{
+		Name:  "sat-per-vbyte",
+		Usage: "fee rate of onchain sends, estimated by the explorer if not set",
+	}
+	dryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "select coins and build the outputs without broadcasting or joining a round, printing the selected inputs, change and fees",
+		Value: false,
+	}
+	// paymentRefFlag is set by the daemon for the payments it executes.
+	paymentRefFlag = cli.StringFlag{
+		Name:   "ref",
+		Usage:  "reference of the scheduled payment or standing order paid, to track its outcome in the outbox",
+		Hidden: true,
+	}
+	subtractFeeFlag = cli.BoolFlag{
+		Name:  "subtract-fee",
+		Usage: "deduct the fees from the amounts of the receivers, prorated by amount, instead of adding them on top: the network fees of onchain sends, the ones charged by the ASP of offchain sends",
+		Value: false,
+	}
 )
 
 var sendCommand = cli.Command{
 	Name:   "send",
 	Usage:  "Send your onchain or offchain funds to one or many receivers",
-	Action: sendAction,
-	Flags:  []cli.Flag{&receiversFlag, &toFlag, &amountFlag, &passwordFlag, &enableExpiryCoinselectFlag},
+	Action: withWalletLock(sendAction),
+	Flags:  []cli.Flag{&receiversFlag, &toFlag, &sendAmountFlag, &maxSlippageFlag, &passwordFlag, &enableExpiryCoinselectFlag, &coinSelectionFlag, &minLifetimeFlag, &allowExpiringFlag, &roundRetriesFlag, &subtractFeeFlag, &reviewFlag, &dryRunFlag, &satPerVByteFlag, &feePriorityFlag, &sendAllFlag, &coinsFlag, &labelFlag, &exportUnsignedFlag, &requestFlag, &asyncFlag, &fromFileFlag, &atFlag, &overrideLimitsFlag, &paymentRefFlag},
 }
 
 func sendAction(ctx *cli.Context) error {
-	if !ctx.IsSet("receivers") && !ctx.IsSet("to") && !ctx.IsSet("amount") {
-		return fmt.Errorf("missing destination, either use --to and --amount to send or --receivers to send to many")
+	// the payments executed by the daemon are tracked, so that their outcome
+	// is known even if the daemon is interrupted meanwhile.
+	if ref := ctx.String(paymentRefFlag.Name); len(ref) > 0 {
+		return trackPayment(ctx, ref, func() error {
+			return send(ctx)
+		})
+	}
+	return send(ctx)
+}
+
+func send(ctx *cli.Context) error {
+	if !ctx.IsSet("receivers") && !ctx.IsSet("to") && !ctx.IsSet("amount") &&
+		!ctx.IsSet(requestFlag.Name) && !ctx.IsSet(fromFileFlag.Name) {
+		return fmt.Errorf("missing destination, either use --to and --amount to send, --request to pay a payment request, --receivers or --from-file to send to many")
+	}
+	if ctx.IsSet(requestFlag.Name) && (ctx.IsSet("receivers") || ctx.IsSet("to")) {
+		return fmt.Errorf("--request can't be used along with --to or --receivers")
+	}
+	if ctx.IsSet(fromFileFlag.Name) {
+		if ctx.IsSet("receivers") || ctx.IsSet("to") || ctx.IsSet("amount") ||
+			ctx.IsSet(requestFlag.Name) || ctx.Bool(sendAllFlag.Name) {
+			return fmt.Errorf("--from-file can't be used along with other destinations or amounts")
+		}
+		receiversJSON, err := readReceiversFile(ctx, ctx.String(fromFileFlag.Name))
+		if err != nil {
+			return err
+		}
+		if ctx.IsSet(atFlag.Name) {
+			return schedulePayment(ctx, receiversJSON)
+		}
+		return sendToReceivers(ctx, receiversJSON)
 	}
 	receivers := ctx.String("receivers")
 	to := ctx.String("to")
-	amount := ctx.Uint64("amount")
+	amount, fiat, err := parseSendAmount(ctx)
+	if err != nil {
+		return err
+	}
+	if fiat != nil {
+		logger.Info(
+			"fiat amount converted", "amount", fiat.Amount,
+			"currency", fiat.Currency, "sats", fiat.Sats, "rate", fiat.Rate,
+		)
+	}
 
 	var receiversJSON []receiver
-	if len(receivers) > 0 {
+	if ctx.IsSet(requestFlag.Name) {
+		req, err := decodePaymentRequest(ctx.String(requestFlag.Name))
+		if err != nil {
+			return err
+		}
+		if err := validatePaymentRequest(ctx, req); err != nil {
+			return err
+		}
+		if amount > 0 && amount != req.Amount {
+			return fmt.Errorf(
+				"amount %d doesn't match the one of the payment request %d",
+				amount, req.Amount,
+			)
+		}
+		receiversJSON = []receiver{
+			{
+				To:     req.Address,
+				Amount: req.Amount,
+				Label:  req.Label,
+			},
+		}
+	} else if len(receivers) > 0 {
 		if err := json.Unmarshal([]byte(receivers), &receiversJSON); err != nil {
 			return fmt.Errorf("invalid receivers: %s", err)
 		}
 	} else {
+		label := ""
+		if isPaymentURI(to) {
+			_, net := getNetwork(ctx)
+			uri, err := parsePaymentURI(to, net.AssetID)
+			if err != nil {
+				return err
+			}
+			if uri.amount > 0 {
+				if amount > 0 && amount != uri.amount {
+					return fmt.Errorf(
+						"amount %d doesn't match the one of the payment uri %d",
+						amount, uri.amount,
+					)
+				}
+				amount = uri.amount
+			}
+			to, label = uri.address, uri.memo()
+		}
+
 		receiversJSON = []receiver{
 			{
 				To:     to,
 				Amount: amount,
+				Label:  label,
 			},
 		}
 	}
@@ -76,6 +192,45 @@ func sendAction(ctx *cli.Context) error {
 		return fmt.Errorf("no receivers specified")
 	}
 
+	for i, r := range receiversJSON {
+		addr, err := resolveContact(ctx, r.To)
+		if err != nil {
+			return err
+		}
+		// the contact name labels the payment unless given another one.
+		if addr != r.To && len(r.Label) <= 0 {
+			receiversJSON[i].Label = r.To
+		}
+		receiversJSON[i].To = addr
+	}
+
+	if ctx.IsSet(atFlag.Name) {
+		return schedulePayment(ctx, receiversJSON)
+	}
+
+	if ctx.Bool(sendAllFlag.Name) {
+		if ctx.IsSet(requestFlag.Name) {
+			return fmt.Errorf("--all can't be used along with a payment request")
+		}
+		if len(receivers) > 0 {
+			return fmt.Errorf("--all requires a single destination, use --to instead of --receivers")
+		}
+		if amount > 0 {
+			return fmt.Errorf("--all can't be used along with an amount")
+		}
+		amount, err := getSendAllAmount(ctx, receiversJSON[0])
+		if err != nil {
+			return err
+		}
+		receiversJSON[0].Amount = amount
+	}
+
+	return sendToReceivers(ctx, receiversJSON)
+}
+
+// sendToReceivers pays the given receivers, offchain in a round and onchain
+// with a single tx.
+func sendToReceivers(ctx *cli.Context, receiversJSON []receiver) error {
 	onchainReceivers := make([]receiver, 0)
 	offchainReceivers := make([]receiver, 0)
 
@@ -87,63 +242,141 @@ func sendAction(ctx *cli.Context) error {
 		}
 	}
 
+	if ctx.IsSet(exportUnsignedFlag.Name) && len(offchainReceivers) > 0 {
+		return fmt.Errorf("--export-unsigned is only supported for onchain sends")
+	}
+
+	isMixed := len(onchainReceivers) > 0 && len(offchainReceivers) > 0
+	if isMixed && ctx.IsSet(coinsFlag.Name) {
+		return fmt.Errorf("--coins is not supported when sending both onchain and offchain")
+	}
+
+	// nothing is sent with --dry-run, the limits are checked otherwise even
+	// when exporting the unsigned tx since it's meant to be broadcasted.
+	if !ctx.Bool(dryRunFlag.Name) {
+		if err := checkSpendingLimits(ctx, receiversJSON); err != nil {
+			return err
+		}
+	}
+
+	// the offchain receivers are paid with the vtxos of their ASP.
+	if err := selectAspForReceivers(ctx, offchainReceivers); err != nil {
+		return err
+	}
+
 	explorer := NewExplorer(ctx)
 
+	// the onchain tx is built and signed first, but broadcasted only after
+	// the offchain payment settles, so that nothing happens if any of the
+	// two can't be funded.
+	var pset string
 	if len(onchainReceivers) > 0 {
-		pset, err := sendOnchain(ctx, onchainReceivers)
+		var err error
+		pset, err = sendOnchain(ctx, onchainReceivers)
 		if err != nil {
 			return err
 		}
 
-		txid, err := explorer.Broadcast(pset)
+		if ctx.Bool(dryRunFlag.Name) {
+			if err := printOnchainDryRun(pset, len(onchainReceivers)); err != nil {
+				return err
+			}
+		} else if ctx.IsSet(exportUnsignedFlag.Name) {
+			return exportUnsignedPset(ctx, pset)
+		}
+	}
+
+	res := make(map[string]interface{})
+	if len(offchainReceivers) > 0 {
+		if isMixed {
+			if err := checkOffchainFunds(ctx, explorer, offchainReceivers); err != nil {
+				return err
+			}
+		}
+
+		txid, err := sendOffchain(ctx, offchainReceivers)
 		if err != nil {
 			return err
 		}
+		if ctx.Bool(asyncFlag.Name) {
+			res["redeem_txid"] = txid
+		} else {
+			res["pool_txid"] = txid
+		}
+		if ctx.IsSet(fromFileFlag.Name) {
+			res["receivers"] = newPayoutStatuses(offchainReceivers, "paid")
+		}
+	}
 
-		return printJSON(map[string]interface{}{
-			"txid": txid,
-		})
+	if ctx.Bool(dryRunFlag.Name) {
+		return nil
 	}
 
-	if len(offchainReceivers) > 0 {
-		if err := sendOffchain(ctx, offchainReceivers); err != nil {
+	if len(onchainReceivers) > 0 {
+		txid, err := broadcast(ctx, explorer, pset)
+		if err != nil {
+			if isMixed {
+				return fmt.Errorf(
+					"offchain payment settled in round %s, but failed to broadcast "+
+						"onchain tx: %s", res["pool_txid"], err,
+				)
+			}
 			return err
 		}
+
+		amount := uint64(0)
+		for _, r := range onchainReceivers {
+			amount += r.Amount
+		}
+		recordLabel(ctx, txid)
+		recordHistoryEntry(ctx, historyEntry{
+			Kind:      historyOnchainSend,
+			Txid:      txid,
+			Amount:    amount,
+			Fee:       getPsetFee(pset),
+			Receivers: onchainReceivers,
+		})
+		res["txid"] = txid
 	}
 
-	return nil
+	return printJSON(res)
 }
 
-func sendOffchain(ctx *cli.Context, receivers []receiver) error {
-	withExpiryCoinselect := ctx.Bool("enable-expiry-coinselect")
-
+func sendOffchain(ctx *cli.Context, receivers []receiver) (string, error) {
 	offchainAddr, _, _, err := getAddress(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, _, aspPubKey, err := common.DecodeAddress(offchainAddr)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	receiversOutput := make([]*arkv1.Output, 0)
 	sumOfReceivers := uint64(0)
 
 	for _, receiver := range receivers {
-		_, _, aspKey, err := common.DecodeAddress(receiver.To)
+		receiverAddr, err := common.DecodeArkAddress(receiver.To)
 		if err != nil {
-			return fmt.Errorf("invalid receiver address: %s", err)
+			return "", fmt.Errorf("invalid receiver address: %s", err)
+		}
+		aspKey := receiverAddr.AspKey
+
+		if err := checkCapabilities(
+			fmt.Sprintf("receiver %s", receiver.To), receiverAddr.Capabilities(),
+		); err != nil {
+			return "", err
 		}
 
 		if !bytes.Equal(
 			aspPubKey.SerializeCompressed(), aspKey.SerializeCompressed(),
 		) {
-			return fmt.Errorf("invalid receiver address '%s': must be associated with the connected service provider", receiver.To)
+			return "", fmt.Errorf("invalid receiver address '%s': must be associated with the connected service provider", receiver.To)
 		}
 
 		if receiver.Amount < DUST {
-			return fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount, DUST)
+			return "", fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount, DUST)
 		}
 
 		receiversOutput = append(receiversOutput, &arkv1.Output{
@@ -154,21 +387,63 @@ func sendOffchain(ctx *cli.Context, receivers []receiver) error {
 	}
 	client, close, err := getClientFromState(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer close()
 
 	explorer := NewExplorer(ctx)
 
-	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, withExpiryCoinselect)
-	if err != nil {
-		return err
+	// the redeem tx of an async payment pays its own fees, while the payments
+	// of a round pay those charged by the ASP.
+	async := ctx.Bool(asyncFlag.Name)
+	fee := uint64(0)
+	if async {
+		info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+		if err != nil {
+			return "", err
+		}
+		fee = uint64(info.GetMinRelayFee())
+	} else {
+		fees, err := getPaymentFees(ctx.Context, client)
+		if err != nil {
+			return "", err
+		}
+		sentAmount, sentOutputs := uint64(0), 0
+		for _, r := range receivers {
+			if r.To == offchainAddr {
+				continue
+			}
+			sentAmount += r.Amount
+			sentOutputs++
+		}
+		fee = fees.forPayment(sentAmount, sentOutputs)
 	}
-	selectedCoins, changeAmount, err := coinSelect(vtxos, sumOfReceivers, withExpiryCoinselect)
+
+	if ctx.Bool(subtractFeeFlag.Name) || ctx.Bool(sendAllFlag.Name) {
+		shares, err := prorateOffchainFee(receivers, offchainAddr, fee)
+		if err != nil {
+			return "", err
+		}
+		// the outputs of the receivers are the first ones.
+		for i, share := range shares {
+			receivers[i].Amount -= share
+			receiversOutput[i].Amount -= share
+		}
+		sumOfReceivers -= fee
+	}
+
+	selectedCoins, changeAmount, err := selectOffchainCoins(
+		ctx, explorer, client, offchainAddr, sumOfReceivers+fee,
+	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	// a dust change can't be a vtxo, it's left to fees.
+	if changeAmount < DUST {
+		fee += changeAmount
+		changeAmount = 0
+	}
 	if changeAmount > 0 {
 		changeReceiver := &arkv1.Output{
 			Address: offchainAddr,
@@ -177,70 +452,409 @@ func sendOffchain(ctx *cli.Context, receivers []receiver) error {
 		receiversOutput = append(receiversOutput, changeReceiver)
 	}
 
-	inputs := make([]*arkv1.Input, 0, len(selectedCoins))
-
-	for _, coin := range selectedCoins {
-		inputs = append(inputs, &arkv1.Input{
-			Txid: coin.txid,
-			Vout: coin.vout,
+	if ctx.Bool(dryRunFlag.Name) {
+		inputs := make([]map[string]interface{}, 0, len(selectedCoins))
+		for _, coin := range selectedCoins {
+			inputs = append(inputs, map[string]interface{}{
+				"outpoint": fmt.Sprintf("%s:%d", coin.txid, coin.vout),
+				"amount":   coin.amount,
+			})
+		}
+		return "", printJSON(map[string]interface{}{
+			"inputs":    inputs,
+			"receivers": receivers,
+			"change":    changeAmount,
+			"fee":       fee,
 		})
 	}
 
-	secKey, err := privateKeyFromPassword(ctx)
+	signer, err := getWalletSigner(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	registerResponse, err := client.RegisterPayment(
-		ctx.Context, &arkv1.RegisterPaymentRequest{Inputs: inputs},
+	if async {
+		redeemTxid, err := sendAsync(
+			ctx, client, selectedCoins, signer, receiversOutput,
+		)
+		if err != nil {
+			return "", err
+		}
+
+		recordLabel(ctx, redeemTxid)
+		recordHistoryEntry(ctx, historyEntry{
+			Kind:      historyOffchainSend,
+			Txid:      redeemTxid,
+			Amount:    sumOfReceivers,
+			Fee:       fee,
+			Receivers: receivers,
+		})
+		return redeemTxid, nil
+	}
+
+	poolTxID, err := joinRound(
+		ctx, client, selectedCoins, signer, receiversOutput,
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = client.ClaimPayment(ctx.Context, &arkv1.ClaimPaymentRequest{
-		Id:      registerResponse.GetId(),
-		Outputs: receiversOutput,
+	recordLabel(ctx, poolTxID)
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:      historyOffchainSend,
+		Txid:      poolTxID,
+		Amount:    sumOfReceivers,
+		Fee:       fee,
+		Receivers: receivers,
 	})
+
+	return poolTxID, nil
+}
+
+// printOnchainDryRun prints the inputs and outputs of the given unsigned pset,
+// whose first outputs are the ones of the receivers, followed by the change,
+// if any, and by the fee output.
+func printOnchainDryRun(b64 string, numOfReceivers int) error {
+	pset, err := psetv2.NewPsetFromBase64(b64)
 	if err != nil {
 		return err
 	}
-
-	poolTxID, err := handleRoundStream(
-		ctx, client, registerResponse.GetId(),
-		selectedCoins, secKey, receiversOutput,
-	)
+	utx, err := pset.UnsignedTx()
 	if err != nil {
 		return err
 	}
 
+	inputs := make([]map[string]interface{}, 0, len(utx.Inputs))
+	for i, in := range utx.Inputs {
+		input := map[string]interface{}{
+			"outpoint": fmt.Sprintf(
+				"%s:%d", chainhash.Hash(in.Hash).String(), in.Index,
+			),
+		}
+		if prevout := pset.Inputs[i].WitnessUtxo; prevout != nil {
+			if amount, err := elementsutil.ValueFromBytes(prevout.Value); err == nil {
+				input["amount"] = amount
+			}
+		}
+		inputs = append(inputs, input)
+	}
+
+	outputs := make([]map[string]interface{}, 0, numOfReceivers)
+	change, fee := uint64(0), uint64(0)
+	for i, out := range pset.Outputs {
+		switch {
+		case len(out.Script) <= 0:
+			fee = out.Value
+		case i < numOfReceivers:
+			outputs = append(outputs, map[string]interface{}{
+				"script": hex.EncodeToString(out.Script),
+				"amount": out.Value,
+			})
+		default:
+			change += out.Value
+		}
+	}
+
 	return printJSON(map[string]interface{}{
-		"pool_txid": poolTxID,
+		"inputs":  inputs,
+		"outputs": outputs,
+		"change":  change,
+		"fee":     fee,
 	})
 }
 
+// checkOffchainFunds returns an error if the offchain balance doesn't cover
+// the given receivers.
+func checkOffchainFunds(
+	ctx *cli.Context, explorer Explorer, receivers []receiver,
+) error {
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	amount := uint64(0)
+	for _, r := range receivers {
+		amount += r.Amount
+	}
+	_, _, err = selectOffchainCoins(ctx, explorer, client, offchainAddr, amount)
+	return err
+}
+
+// selectOffchainCoins selects the vtxos given with --coins, or automatically,
+// to cover the given amount, and returns them along with the change.
+func selectOffchainCoins(
+	ctx *cli.Context, explorer Explorer, client arkv1.ArkServiceClient,
+	offchainAddr string, amount uint64,
+) ([]vtxo, uint64, error) {
+	selector, err := getCoinSelector(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	coins, err := getCoins(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	vtxos, err := getVtxos(
+		ctx, explorer, client, offchainAddr, needsExpiration(selector),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(coins) > 0 {
+		return selectVtxosByOutpoint(vtxos, coins, amount)
+	}
+
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return coinSelectVtxos(ctx, vtxos, amount, selector, locked)
+}
+
+// getSendAllAmount returns the whole spendable onchain or offchain balance,
+// depending on the type of the given receiver, or the total of the coins
+// given with --coins.
+func getSendAllAmount(ctx *cli.Context, r receiver) (uint64, error) {
+	offchainAddr, onchainAddr, redemptionAddr, err := getAddress(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	explorer := NewExplorer(ctx)
+	amount := uint64(0)
+
+	coins, err := getCoins(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.isOnchain() && len(coins) > 0 {
+		utxos, delayedUtxos, _, err := selectUtxosByOutpoint(
+			ctx, explorer, coins, 0,
+		)
+		if err != nil {
+			return 0, err
+		}
+		for _, u := range append(utxos, delayedUtxos...) {
+			amount += u.Amount
+		}
+	} else if r.isOnchain() {
+		utxos, err := explorer.GetUtxos(onchainAddr)
+		if err != nil {
+			return 0, err
+		}
+		for _, u := range utxos {
+			amount += u.Amount
+		}
+
+		unilateralExitDelay, err := getUnilateralExitDelay(ctx)
+		if err != nil {
+			return 0, err
+		}
+		redeemed, _, err := explorer.GetRedeemedVtxosBalance(
+			redemptionAddr, unilateralExitDelay,
+		)
+		if err != nil {
+			return 0, err
+		}
+		amount += redeemed
+	} else {
+		client, close, err := getClientFromState(ctx)
+		if err != nil {
+			return 0, err
+		}
+		defer close()
+
+		vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+		if err != nil {
+			return 0, err
+		}
+		if len(coins) > 0 {
+			if vtxos, _, err = selectVtxosByOutpoint(vtxos, coins, 0); err != nil {
+				return 0, err
+			}
+		}
+		for _, v := range vtxos {
+			amount += v.amount
+		}
+	}
+
+	if amount <= 0 {
+		return 0, fmt.Errorf("no funds to send")
+	}
+	return amount, nil
+}
+
+// getFeeRate returns the fee rate in sats/vbyte set with --sat-per-vbyte, or
+// the one estimated by the explorer for the --fee-priority.
+func getFeeRate(ctx *cli.Context, explorer Explorer) (float64, error) {
+	// the fee rate might also be set in the config file.
+	if feeRate := ctx.Float64(satPerVByteFlag.Name); feeRate > 0 ||
+		ctx.IsSet(satPerVByteFlag.Name) {
+		if feeRate < minFeeRate {
+			return 0, fmt.Errorf(
+				"invalid fee rate %f, must be at least %.1f sat/vbyte",
+				feeRate, minFeeRate,
+			)
+		}
+		return feeRate, nil
+	}
+
+	feeRate, err := estimateFeeRate(explorer, ctx.String(feePriorityFlag.Name))
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to estimate fee rate, use --sat-per-vbyte to set one: %s", err,
+		)
+	}
+	return math.Max(feeRate, minFeeRate), nil
+}
+
+// estimateOnchainFees estimates the fees of the given unsigned pset, once
+// signed and with the fee output added, at the given rate in sats/vbyte.
+func estimateOnchainFees(pset *psetv2.Pset, feeRate float64) (uint64, error) {
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return 0, err
+	}
+
+	// the empty issuance and pegin witnesses of each input and the empty
+	// proofs of each output are part of the witness in elements.
+	witnessSize := 3*len(pset.Inputs) + 2*(len(pset.Outputs)+1)
+	for _, in := range pset.Inputs {
+		if len(in.TapLeafScript) > 0 {
+			leaf := in.TapLeafScript[0]
+			controlBlock, err := leaf.ControlBlock.ToBytes()
+			if err != nil {
+				return 0, err
+			}
+			// signature + leaf script + control block
+			witnessSize += 1 + 1 + 64 + 1 + len(leaf.Script) + 1 + len(controlBlock)
+			continue
+		}
+		// p2wpkh: signature + pubkey
+		witnessSize += 1 + 1 + 72 + 1 + 33
+	}
+
+	vsize := utx.VirtualSize() + feeOutputSize + (witnessSize+3)/4
+	return uint64(math.Ceil(float64(vsize) * feeRate)), nil
+}
+
+// prorateFee splits the given fee among the receivers proportionally to their
+// amounts. The remainder of the division is charged to the first receiver.
+func prorateFee(receivers []receiver, fee uint64) []uint64 {
+	total := uint64(0)
+	for _, r := range receivers {
+		total += r.Amount
+	}
+
+	shares := make([]uint64, len(receivers))
+	charged := uint64(0)
+	for i, r := range receivers {
+		shares[i] = fee * r.Amount / total
+		charged += shares[i]
+	}
+	shares[0] += fee - charged
+	return shares
+}
+
+// prorateOffchainFee splits the given fee among the offchain receivers other
+// than self, or among all of them if sending only to self, and checks that
+// what's left of their amounts is not dust.
+func prorateOffchainFee(
+	receivers []receiver, offchainAddr string, fee uint64,
+) ([]uint64, error) {
+	payers := make([]receiver, 0, len(receivers))
+	indexes := make([]int, 0, len(receivers))
+	for i, r := range receivers {
+		if r.To == offchainAddr {
+			continue
+		}
+		payers = append(payers, r)
+		indexes = append(indexes, i)
+	}
+	if len(payers) <= 0 {
+		payers = receivers
+		indexes = indexes[:0]
+		for i := range receivers {
+			indexes = append(indexes, i)
+		}
+	}
+
+	shares := make([]uint64, len(receivers))
+	for i, share := range prorateFee(payers, fee) {
+		amount := payers[i].Amount
+		if amount < share+DUST {
+			return nil, fmt.Errorf(
+				"invalid amount (%d), must be greater than dust %d after "+
+					"subtracting fee %d", amount, DUST, share,
+			)
+		}
+		shares[indexes[i]] = share
+	}
+	return shares, nil
+}
+
 func sendOnchain(ctx *cli.Context, receivers []receiver) (string, error) {
-	pset, err := psetv2.New(nil, nil, nil)
+	pset, err := buildOnchainTx(ctx, receivers)
 	if err != nil {
 		return "", err
 	}
-	updater, err := psetv2.NewUpdater(pset)
+
+	// the pset is returned unsigned, it won't be broadcasted anyway.
+	if ctx.Bool(dryRunFlag.Name) || ctx.IsSet(exportUnsignedFlag.Name) {
+		return pset.ToBase64()
+	}
+
+	signer, err := getWalletSigner(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	if err := signer.signPset(ctx, pset, NewExplorer(ctx)); err != nil {
+		return "", err
+	}
+
+	if err := psetv2.FinalizeAll(pset); err != nil {
+		return "", err
+	}
+
+	return pset.ToBase64()
+}
+
+// buildOnchainTx returns the unsigned tx paying the given receivers with the
+// onchain coins of the wallet, its fee output included.
+func buildOnchainTx(ctx *cli.Context, receivers []receiver) (*psetv2.Pset, error) {
+	pset, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return nil, err
+	}
+
 	_, net := getNetwork(ctx)
 
 	targetAmount := uint64(0)
 	for _, receiver := range receivers {
 		targetAmount += receiver.Amount
 		if receiver.Amount < DUST {
-			return "", fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount, DUST)
+			return nil, fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount, DUST)
 		}
 
 		script, err := address.ToOutputScript(receiver.To)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if err := updater.AddOutputs([]psetv2.OutputArgs{
@@ -250,93 +864,156 @@ func sendOnchain(ctx *cli.Context, receivers []receiver) (string, error) {
 				Script: script,
 			},
 		}); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
 	explorer := NewExplorer(ctx)
 
-	utxos, delayedUtxos, change, err := coinSelectOnchain(
-		ctx, explorer, targetAmount, nil,
-	)
+	coins, err := getCoins(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err := addInputs(ctx, updater, utxos, delayedUtxos, net); err != nil {
-		return "", err
+	feeRate, err := getFeeRate(ctx, explorer)
+	if err != nil {
+		return nil, err
 	}
 
-	if change > 0 {
-		_, changeAddr, _, err := getAddress(ctx)
-		if err != nil {
-			return "", err
-		}
+	subtractFee := ctx.Bool(subtractFeeFlag.Name) || ctx.Bool(sendAllFlag.Name)
 
-		changeScript, err := address.ToOutputScript(changeAddr)
-		if err != nil {
-			return "", err
+	var utxos, delayedUtxos []utxo
+	var change uint64
+	// changeless is true if the selected coins cover amount and fees without
+	// change output, the excess going to the fees.
+	changeless := false
+	selector, err := getCoinSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(coins) > 0 {
+		utxos, delayedUtxos, change, err = selectUtxosByOutpoint(
+			ctx, explorer, coins, targetAmount,
+		)
+	} else {
+		if _, ok := selector.(bnbSelector); ok && !subtractFee {
+			utxos, delayedUtxos, change, err = coinSelectOnchainChangeless(
+				ctx, explorer, updater.Pset, targetAmount, feeRate,
+			)
+			if err != nil {
+				return nil, err
+			}
+			changeless = len(utxos)+len(delayedUtxos) > 0
+		}
+		if !changeless {
+			utxos, delayedUtxos, change, err = coinSelectOnchain(
+				ctx, explorer, targetAmount, nil,
+			)
 		}
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		if err := updater.AddOutputs([]psetv2.OutputArgs{
+	if err := addInputs(ctx, updater, utxos, delayedUtxos, net); err != nil {
+		return nil, err
+	}
+
+	_, changeAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changeScript, err := address.ToOutputScript(changeAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the change output, if any, follows the receivers' ones.
+	changeIndex := len(receivers)
+	hasChangeOutput := false
+	addChangeOutput := func() error {
+		if hasChangeOutput {
+			return nil
+		}
+		hasChangeOutput = true
+		return updater.AddOutputs([]psetv2.OutputArgs{
 			{
 				Asset:  net.AssetID,
 				Amount: change,
 				Script: changeScript,
 			},
-		}); err != nil {
-			return "", err
-		}
+		})
 	}
 
-	utx, err := pset.UnsignedTx()
-	if err != nil {
-		return "", err
+	if change > 0 && !changeless {
+		if err := addChangeOutput(); err != nil {
+			return nil, err
+		}
 	}
 
-	vBytes := utx.VirtualSize()
-	feeAmount := uint64(math.Ceil(float64(vBytes) * 0.5))
-
-	if change > feeAmount {
-		updater.Pset.Outputs[len(updater.Pset.Outputs)-1].Value = change - feeAmount
-	} else if change == feeAmount {
-		updater.Pset.Outputs = updater.Pset.Outputs[:len(updater.Pset.Outputs)-1]
-	} else { // change < feeAmount
-		if change > 0 {
-			updater.Pset.Outputs = updater.Pset.Outputs[:len(updater.Pset.Outputs)-1]
+	selectedUtxos := append(utxos, delayedUtxos...)
+	feeAmount := uint64(0)
+	for {
+		feeAmount, err = estimateOnchainFees(updater.Pset, feeRate)
+		if err != nil {
+			return nil, err
+		}
+		if subtractFee || change >= feeAmount {
+			break
+		}
+		if len(coins) > 0 {
+			return nil, fmt.Errorf(
+				"selected coins don't cover amount plus fees %d", feeAmount,
+			)
 		}
-		// reselect the difference
+
+		// the change doesn't cover the fees, select more coins and estimate
+		// the fees again since the tx got bigger.
 		selected, delayedSelected, newChange, err := coinSelectOnchain(
-			ctx, explorer, feeAmount-change, append(utxos, delayedUtxos...),
+			ctx, explorer, feeAmount-change, selectedUtxos,
 		)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if err := addInputs(ctx, updater, selected, delayedSelected, net); err != nil {
-			return "", err
+			return nil, err
 		}
+		selectedUtxos = append(selectedUtxos, selected...)
+		selectedUtxos = append(selectedUtxos, delayedSelected...)
 
-		if newChange > 0 {
-			_, changeAddr, _, err := getAddress(ctx)
-			if err != nil {
-				return "", err
-			}
+		change = feeAmount + newChange
+		changeless = false
+		if err := addChangeOutput(); err != nil {
+			return nil, err
+		}
+	}
 
-			changeScript, err := address.ToOutputScript(changeAddr)
-			if err != nil {
-				return "", err
-			}
+	if changeless {
+		feeAmount = change
+	}
 
-			if err := updater.AddOutputs([]psetv2.OutputArgs{
-				{
-					Asset:  net.AssetID,
-					Amount: newChange,
-					Script: changeScript,
-				},
-			}); err != nil {
-				return "", err
+	if subtractFee {
+		// the receivers' outputs are the first ones of the pset.
+		for i, fee := range prorateFee(receivers, feeAmount) {
+			amount := receivers[i].Amount
+			if amount < fee+DUST {
+				return nil, fmt.Errorf(
+					"invalid amount (%d), must be greater than dust %d after "+
+						"subtracting fee %d", amount, DUST, fee,
+				)
 			}
+			updater.Pset.Outputs[i].Value = amount - fee
+		}
+	} else if hasChangeOutput {
+		if change > feeAmount {
+			updater.Pset.Outputs[changeIndex].Value = change - feeAmount
+		} else {
+			updater.Pset.Outputs = append(
+				updater.Pset.Outputs[:changeIndex],
+				updater.Pset.Outputs[changeIndex+1:]...,
+			)
 		}
 	}
 
@@ -346,21 +1023,8 @@ func sendOnchain(ctx *cli.Context, receivers []receiver) (string, error) {
 			Amount: feeAmount,
 		},
 	}); err != nil {
-		return "", err
-	}
-
-	prvKey, err := privateKeyFromPassword(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	if err := signPset(ctx, updater.Pset, explorer, prvKey); err != nil {
-		return "", err
-	}
-
-	if err := psetv2.FinalizeAll(updater.Pset); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return updater.Pset.ToBase64()
+	return updater.Pset, nil
 }
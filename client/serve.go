@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const SERVE_TOKEN_ENVVAR = "ARK_SERVE_TOKEN"
+
+var (
+	serveListenFlag = cli.StringFlag{
+		Name:  "listen",
+		Usage: "localhost address the API listens on",
+		Value: "127.0.0.1:7070",
+	}
+	serveTokenFlag = cli.StringFlag{
+		Name:    "token",
+		Usage:   "token the API calls must carry in the Authorization: Bearer header, a random one is generated and printed if not set",
+		EnvVars: []string{SERVE_TOKEN_ENVVAR},
+	}
+)
+
+var serveCommand = cli.Command{
+	Name:   "serve",
+	Usage:  "Unlock the wallet and expose balance, receive, send, history and events over a localhost HTTP JSON API, authenticated with a token",
+	Action: serveAction,
+	Flags:  []cli.Flag{&passwordFlag, &serveListenFlag, &serveTokenFlag},
+}
+
+// sendRequest is the body of POST /v1/send, mapped to the flags of send.
+type sendRequest struct {
+	To          string     `json:"to"`
+	Amount      string     `json:"amount"`
+	Receivers   []receiver `json:"receivers"`
+	Label       string     `json:"label"`
+	Async       bool       `json:"async"`
+	SubtractFee bool       `json:"subtract_fee"`
+	SatPerVByte float64    `json:"sat_per_vbyte"`
+}
+
+func (r sendRequest) args() ([]string, error) {
+	args := []string{sendCommand.Name}
+	switch {
+	case len(r.Receivers) > 0:
+		if len(r.To) > 0 {
+			return nil, fmt.Errorf("to can't be used along with receivers")
+		}
+		receivers, err := json.Marshal(r.Receivers)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--receivers", string(receivers))
+	case len(r.To) > 0:
+		args = append(args, "--to", r.To, "--amount", r.Amount)
+	default:
+		return nil, fmt.Errorf("missing destination, either set to and amount or receivers")
+	}
+	if len(r.Label) > 0 {
+		args = append(args, "--label", r.Label)
+	}
+	if r.Async {
+		args = append(args, "--async")
+	}
+	if r.SubtractFee {
+		args = append(args, "--subtract-fee")
+	}
+	if r.SatPerVByte > 0 {
+		args = append(args, fmt.Sprintf("--sat-per-vbyte=%v", r.SatPerVByte))
+	}
+	return args, nil
+}
+
+// apiServer runs the commands for the API calls one at a time, in the
+// session of the unlocked wallet.
+type apiServer struct {
+	ctx        *cli.Context
+	globalArgs []string
+	token      string
+	lock       sync.Mutex
+	events     *eventHub
+}
+
+func serveAction(ctx *cli.Context) error {
+	listen := ctx.String(serveListenFlag.Name)
+	if err := checkLoopbackAddress(listen); err != nil {
+		return err
+	}
+
+	token := ctx.String(serveTokenFlag.Name)
+	if len(token) <= 0 {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return err
+		}
+		token = hex.EncodeToString(buf)
+		fmt.Printf("api token: %s\n", token)
+	}
+
+	if err := startSession(ctx); err != nil {
+		return err
+	}
+	defer stopSession()
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	s := &apiServer{
+		ctx: ctx,
+		// the results are always captured as json, whatever the output of the
+		// session.
+		globalArgs: append(getGlobalArgs(ctx), "--output="+outputJSON),
+		token:      token,
+		events:     newEventHub(),
+	}
+
+	stream := newEventStream(s.events, &s.lock)
+	go stream.listenAsp(ctx, client, watchIntervalFlag.Value)
+	go stream.pollWallet(
+		ctx, client, watchIntervalFlag.Value, expiryThresholdFlag.Value,
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/balance", s.handleCommand(http.MethodGet, balanceCommand.Name))
+	mux.HandleFunc("/v1/receive", s.handleCommand(http.MethodGet, receiveCommand.Name))
+	mux.HandleFunc("/v1/history", s.handleCommand(http.MethodGet, historyCommand.Name))
+	mux.HandleFunc("/v1/send", s.handleSend)
+	mux.HandleFunc("/v1/events", s.handleEvents)
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           s.authenticate(mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Context.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// nolint
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving wallet api", "listen", listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// checkLoopbackAddress makes sure the API is only reachable from localhost.
+func checkLoopbackAddress(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address: %s", err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("invalid listen address %s, must be a localhost one", addr)
+	}
+	return nil
+}
+
+func (s *apiServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, fmt.Errorf("invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *apiServer) handleCommand(method string, args ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+			return
+		}
+		s.reply(w, args)
+	}
+}
+
+func (s *apiServer) handleSend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+		return
+	}
+
+	var req sendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %s", err))
+		return
+	}
+	args, err := req.args()
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.reply(w, args)
+}
+
+// handleEvents streams the events as JSON lines until the client leaves.
+func (s *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	ch, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-ch:
+			if _, err := w.Write(line); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// reply runs the command with the given args and replies with its json
+// result.
+func (s *apiServer) reply(w http.ResponseWriter, args []string) {
+	out, err := s.run(args)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if len(bytes.TrimSpace(out)) <= 0 {
+		out = []byte("{}")
+	}
+	// nolint
+	w.Write(out)
+}
+
+func (s *apiServer) run(args []string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	buf := &bytes.Buffer{}
+	prevOutput := jsonOutput
+	jsonOutput = buf
+	defer func() { jsonOutput = prevOutput }()
+
+	if err := s.ctx.App.Run(append(s.globalArgs, args...)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeAPIError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	// nolint
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// eventHub fans out the events written by an eventStream to the clients of
+// the events endpoint. Slow clients miss the events instead of blocking the
+// stream.
+type eventHub struct {
+	lock        sync.Mutex
+	subscribers map[chan []byte]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan []byte]struct{})}
+}
+
+func (h *eventHub) Write(p []byte) (int, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for ch := range h.subscribers {
+		line := append([]byte{}, p...)
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+func (h *eventHub) subscribe() (<-chan []byte, func()) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	ch := make(chan []byte, 100)
+	h.subscribers[ch] = struct{}{}
+	return ch, func() {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+		delete(h.subscribers, ch)
+	}
+}
@@ -20,47 +20,11 @@ import (
 func signPset(
 	ctx *cli.Context, pset *psetv2.Pset, explorer Explorer, prvKey *secp256k1.PrivateKey,
 ) error {
-	updater, err := psetv2.NewUpdater(pset)
-	if err != nil {
+	if err := addWitnessUtxos(pset, explorer); err != nil {
 		return err
 	}
 
-	for i, input := range pset.Inputs {
-		if input.WitnessUtxo != nil {
-			continue
-		}
-
-		prevoutTxHex, err := explorer.GetTxHex(chainhash.Hash(input.PreviousTxid).String())
-		if err != nil {
-			return err
-		}
-
-		prevoutTx, err := transaction.NewTxFromHex(prevoutTxHex)
-		if err != nil {
-			return err
-		}
-
-		utxo := prevoutTx.Outputs[input.PreviousTxIndex]
-		if utxo == nil {
-			return fmt.Errorf("witness utxo not found")
-		}
-
-		if err := updater.AddInWitnessUtxo(i, utxo); err != nil {
-			return err
-		}
-
-		sighashType := txscript.SigHashAll
-
-		if utxo.Script[0] == txscript.OP_1 {
-			sighashType = txscript.SigHashDefault
-		}
-
-		if err := updater.AddInSighashType(i, sighashType); err != nil {
-			return err
-		}
-	}
-
-	signer, err := psetv2.NewSigner(updater.Pset)
+	signer, err := psetv2.NewSigner(pset)
 	if err != nil {
 		return err
 	}
@@ -197,3 +161,48 @@ func signPset(
 
 	return nil
 }
+
+// addWitnessUtxos fetches the prevouts of the inputs missing them.
+func addWitnessUtxos(pset *psetv2.Pset, explorer Explorer) error {
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return err
+	}
+
+	for i, input := range pset.Inputs {
+		if input.WitnessUtxo != nil {
+			continue
+		}
+
+		prevoutTxHex, err := explorer.GetTxHex(chainhash.Hash(input.PreviousTxid).String())
+		if err != nil {
+			return err
+		}
+
+		prevoutTx, err := transaction.NewTxFromHex(prevoutTxHex)
+		if err != nil {
+			return err
+		}
+
+		utxo := prevoutTx.Outputs[input.PreviousTxIndex]
+		if utxo == nil {
+			return fmt.Errorf("witness utxo not found")
+		}
+
+		if err := updater.AddInWitnessUtxo(i, utxo); err != nil {
+			return err
+		}
+
+		sighashType := txscript.SigHashAll
+
+		if utxo.Script[0] == txscript.OP_1 {
+			sighashType = txscript.SigHashDefault
+		}
+
+		if err := updater.AddInSighashType(i, sighashType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	splitCoinFlag = cli.StringFlag{
+		Name:  "coin",
+		Usage: "vtxo to split (txid:vout), the largest one not frozen by default",
+	}
+	splitDenominationFlag = cli.Uint64Flag{
+		Name:     "denomination",
+		Usage:    "amount in sats of each of the new vtxos",
+		Required: true,
+	}
+	splitCountFlag = cli.IntFlag{
+		Name:  "count",
+		Usage: "number of vtxos of the given denomination to create, as many as the vtxo covers by default",
+	}
+)
+
+var splitCommand = cli.Command{
+	Name:   "split",
+	Usage:  "Split a vtxo into many ones of the same amount with a self-payment in the next round, the rest being sent back as change",
+	Action: withWalletLock(splitAction),
+	Flags: []cli.Flag{
+		&splitCoinFlag, &splitDenominationFlag, &splitCountFlag,
+		&passwordFlag, &roundRetriesFlag, &reviewFlag,
+	},
+}
+
+func splitAction(ctx *cli.Context) error {
+	denomination := ctx.Uint64(splitDenominationFlag.Name)
+	if denomination < DUST {
+		return fmt.Errorf("invalid denomination, must be at least %d", DUST)
+	}
+	count := ctx.Int(splitCountFlag.Name)
+	if count < 0 {
+		return fmt.Errorf("invalid count, must be positive")
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	offchainAddr, _, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	explorer := NewExplorer(ctx)
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+
+	coin, err := selectVtxoToSplit(ctx, vtxos)
+	if err != nil {
+		return err
+	}
+
+	// the split is a payment to self, charged only the flat fee by the ASP.
+	fees, err := getPaymentFees(ctx.Context, client)
+	if err != nil {
+		return err
+	}
+	if coin.amount < fees.flat {
+		return fmt.Errorf(
+			"vtxo of %d sats doesn't cover the fee of %d sats charged by the ASP",
+			coin.amount, fees.flat,
+		)
+	}
+	available := coin.amount - fees.flat
+
+	// without an explicit count, the last denomination is dropped if the
+	// change would be dust otherwise.
+	if count == 0 {
+		count = int(available / denomination)
+		if change := available % denomination; change > 0 && change < DUST {
+			count--
+		}
+	}
+	if count < 2 {
+		return fmt.Errorf(
+			"vtxo of %d sats can't be split in denominations of %d", coin.amount, denomination,
+		)
+	}
+
+	total := denomination * uint64(count)
+	if total > available {
+		return fmt.Errorf(
+			"vtxo of %d sats doesn't cover %d denominations of %d and a fee of %d",
+			coin.amount, count, denomination, fees.flat,
+		)
+	}
+	change := available - total
+	if change > 0 && change < DUST {
+		return fmt.Errorf(
+			"change of %d sats would be dust, use a different count", change,
+		)
+	}
+
+	receivers := make([]*arkv1.Output, 0, count+1)
+	for i := 0; i < count; i++ {
+		receivers = append(receivers, &arkv1.Output{
+			Address: offchainAddr, Amount: denomination,
+		})
+	}
+	if change > 0 {
+		receivers = append(receivers, &arkv1.Output{
+			Address: offchainAddr, Amount: change,
+		})
+	}
+
+	signer, err := getWalletSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"splitting vtxo of %d sats into %d x %d sats\n", coin.amount, count, denomination,
+	)
+
+	poolTxID, err := joinRound(ctx, client, []vtxo{*coin}, signer, receivers)
+	if err != nil {
+		return err
+	}
+
+	recordHistoryEntry(ctx, historyEntry{
+		Kind:   historySplit,
+		Txid:   poolTxID,
+		Amount: available,
+		Fee:    fees.flat,
+	})
+
+	return printJSON(map[string]interface{}{
+		"pool_txid":    poolTxID,
+		"denomination": denomination,
+		"count":        count,
+		"change":       change,
+		"fee":          fees.flat,
+	})
+}
+
+// selectVtxoToSplit returns the vtxo given with --coin, or the largest one not
+// frozen.
+func selectVtxoToSplit(ctx *cli.Context, vtxos []vtxo) (*vtxo, error) {
+	if str := ctx.String(splitCoinFlag.Name); len(str) > 0 {
+		outpoint, err := parseOutpoint(str)
+		if err != nil {
+			return nil, err
+		}
+		selected, _, err := selectVtxosByOutpoint(vtxos, []string{outpoint}, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &selected[0], nil
+	}
+
+	locked, err := getLockedCoins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]vtxo, 0, len(vtxos))
+	for _, v := range vtxos {
+		if !locked.has(v.txid, v.vout) {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) <= 0 {
+		return nil, fmt.Errorf("no vtxo to split")
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].amount > candidates[j].amount
+	})
+	return &candidates[0], nil
+}
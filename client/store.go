@@ -0,0 +1,262 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	STATE_DB_FILE = "state.db"
+	// legacyStateExt is appended to the json state files once migrated.
+	legacyStateExt = ".migrated"
+
+	createStateTable = `
+CREATE TABLE IF NOT EXISTS state (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+	// the history and the labels have their own tables, the HISTORY and LABELS
+	// keys are only found in the json state of older versions and in backups.
+	createHistoryTable = `
+CREATE TABLE IF NOT EXISTS history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind TEXT NOT NULL,
+	txid TEXT NOT NULL,
+	txids TEXT NOT NULL,
+	amount INTEGER NOT NULL,
+	fee INTEGER NOT NULL,
+	receivers TEXT NOT NULL,
+	created_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS history_txid ON history (txid);
+`
+	createLabelTable = `
+CREATE TABLE IF NOT EXISTS label (
+	id TEXT PRIMARY KEY,
+	label TEXT NOT NULL
+);
+`
+)
+
+var (
+	stateDB     *sql.DB
+	stateDBPath string
+	stateDBMtx  sync.Mutex
+)
+
+// getStateDB returns the database holding the wallet state in the datadir,
+// opening it at the first call. The state of older versions, kept in a json
+// file, is migrated to the database the first time it's opened.
+func getStateDB(ctx *cli.Context) (*sql.DB, error) {
+	stateDBMtx.Lock()
+	defer stateDBMtx.Unlock()
+
+	datadir := ctx.String("datadir")
+	dbPath := filepath.Join(datadir, STATE_DB_FILE)
+	if stateDB != nil && stateDBPath == dbPath {
+		return stateDB, nil
+	}
+
+	// concurrent invocations wait for each other's transactions instead of
+	// failing right away.
+	db, err := sql.Open("sqlite", fmt.Sprintf(
+		"file:%s?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"+
+			"&_pragma=synchronous(FULL)&_txlock=immediate",
+		dbPath,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state db: %s", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range []string{
+		createStateTable, createHistoryTable, createLabelTable,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create state db: %s", err)
+		}
+	}
+	// nolint:all
+	os.Chmod(dbPath, 0600)
+
+	if err := migrateStateFile(db, filepath.Join(datadir, STATE_FILE)); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if stateDB != nil {
+		stateDB.Close()
+	}
+	stateDB, stateDBPath = db, dbPath
+	return stateDB, nil
+}
+
+func closeStateDB() {
+	stateDBMtx.Lock()
+	defer stateDBMtx.Unlock()
+
+	if stateDB != nil {
+		stateDB.Close()
+		stateDB, stateDBPath = nil, ""
+	}
+}
+
+// migrateStateFile moves the json state file at the given path, or its
+// backup if corrupted, to the empty database. The history and the labels get
+// their own tables.
+func migrateStateFile(db *sql.DB, path string) error {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM state;").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	data, err := readStateFile(path)
+	if err != nil {
+		if backup, backupErr := readStateFile(path + BACKUP_FILE_EXT); backupErr == nil {
+			data, err = backup, nil
+		}
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("invalid state file: %s", err)
+	}
+
+	history := make([]historyEntry, 0)
+	if len(data[HISTORY]) > 0 {
+		if err := json.Unmarshal([]byte(data[HISTORY]), &history); err != nil {
+			return fmt.Errorf("invalid history: %s", err)
+		}
+	}
+	labels := make(map[string]string)
+	if len(data[LABELS]) > 0 {
+		if err := json.Unmarshal([]byte(data[LABELS]), &labels); err != nil {
+			return fmt.Errorf("invalid labels: %s", err)
+		}
+	}
+	delete(data, HISTORY)
+	delete(data, LABELS)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := upsertState(tx, data); err != nil {
+		// nolint:all
+		tx.Rollback()
+		return err
+	}
+	for _, entry := range history {
+		if err := insertHistoryEntry(tx, entry); err != nil {
+			// nolint:all
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := upsertLabels(tx, labels); err != nil {
+		// nolint:all
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to migrate state file: %s", err)
+	}
+
+	// keep the old files around, but out of the way.
+	for _, p := range []string{path, path + BACKUP_FILE_EXT} {
+		if err := os.Rename(p, p+legacyStateExt); err != nil && !os.IsNotExist(err) {
+			logger.Warn("failed to rename migrated state file", "path", p, "err", err)
+		}
+	}
+	return nil
+}
+
+func upsertState(tx *sql.Tx, data map[string]string) error {
+	stmt, err := tx.Prepare(
+		"INSERT INTO state (key, value) VALUES (?, ?) " +
+			"ON CONFLICT(key) DO UPDATE SET value = excluded.value;",
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for key, value := range data {
+		if _, err := stmt.Exec(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertHistoryEntry(tx *sql.Tx, entry historyEntry) error {
+	txids, err := json.Marshal(entry.Txids)
+	if err != nil {
+		return err
+	}
+	receivers, err := json.Marshal(entry.Receivers)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO history (kind, txid, txids, amount, fee, receivers, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?);",
+		entry.Kind, entry.Txid, string(txids), int64(entry.Amount),
+		int64(entry.Fee), string(receivers), entry.CreatedAt,
+	)
+	return err
+}
+
+func upsertLabels(tx *sql.Tx, labels map[string]string) error {
+	stmt, err := tx.Prepare(
+		"INSERT INTO label (id, label) VALUES (?, ?) " +
+			"ON CONFLICT(id) DO UPDATE SET label = excluded.label;",
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, label := range labels {
+		if _, err := stmt.Exec(id, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withStateTx runs the given function in a database transaction, committed
+// only if it succeeds.
+func withStateTx(ctx *cli.Context, f func(tx *sql.Tx) error) error {
+	db, err := getStateDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return fmt.Errorf("%s, and failed to rollback: %s", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// subscribedPollInterval is how often the chain is checked anyway while
+	// subscribed, in case a notification got lost.
+	subscribedPollInterval = 5 * time.Minute
+	// resubscribeInterval is how long to wait before subscribing again after
+	// a failure, polling in the meanwhile.
+	resubscribeInterval = time.Minute
+	// keepAliveInterval is how often the subscriptions are pinged not to be
+	// closed by the servers for inactivity.
+	keepAliveInterval = time.Minute
+)
+
+// chainSubscriber is implemented by the explorers able to push the changes
+// of the chain, instead of being polled for them.
+type chainSubscriber interface {
+	// SubscribeChain returns a channel receiving a notification at every new
+	// block and at every new tx of the given addresses. The channel is closed
+	// once the subscription ends, either for the context being done or for
+	// the connection being lost.
+	SubscribeChain(ctx context.Context, addrs []string) (<-chan struct{}, error)
+}
+
+// chainWatcher waits for the chain to change, either by subscribing to the
+// explorer or, if not supported, by polling it.
+type chainWatcher struct {
+	explorer      Explorer
+	addrs         []string
+	pollInterval  time.Duration
+	notifications <-chan struct{}
+	lastAttempt   time.Time
+	// unsupported is set if the explorer doesn't support subscriptions.
+	unsupported bool
+}
+
+func newChainWatcher(
+	explorer Explorer, addrs []string, pollInterval time.Duration,
+) *chainWatcher {
+	return &chainWatcher{
+		explorer:     explorer,
+		addrs:        addrs,
+		pollInterval: pollInterval,
+	}
+}
+
+// wait returns at the next notification of the explorer or, if not
+// subscribed, after the poll interval. It returns after maxWait anyway, if
+// positive.
+func (w *chainWatcher) wait(ctx context.Context, maxWait time.Duration) error {
+	w.subscribe(ctx)
+
+	timeout := w.pollInterval
+	if w.notifications != nil {
+		timeout = subscribedPollInterval
+	}
+	if maxWait > 0 && maxWait < timeout {
+		timeout = maxWait
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	case _, ok := <-w.notifications:
+		if !ok {
+			logger.Debug("chain subscription closed, polling the explorer")
+			w.notifications = nil
+			break
+		}
+		// the cached responses are outdated by the notified change.
+		if cached, ok := w.explorer.(*cachedExplorer); ok {
+			cached.refresh()
+		}
+	}
+	return nil
+}
+
+func (w *chainWatcher) subscribe(ctx context.Context) {
+	if w.unsupported || w.notifications != nil ||
+		time.Since(w.lastAttempt) < resubscribeInterval {
+		return
+	}
+	subscriber, ok := w.explorer.(chainSubscriber)
+	if !ok {
+		w.unsupported = true
+		return
+	}
+
+	w.lastAttempt = time.Now()
+	notifications, err := subscriber.SubscribeChain(ctx, w.addrs)
+	if errors.Is(err, errNotSupported) {
+		w.unsupported = true
+		return
+	}
+	if err != nil {
+		logger.Debug(
+			"failed to subscribe to the explorer, polling it", "err", err,
+		)
+		return
+	}
+	w.notifications = notifications
+}
+
+// notify signals a change of the chain, unless one is already pending.
+func notify(notifications chan struct{}) {
+	select {
+	case notifications <- struct{}{}:
+	default:
+	}
+}
+
+// SubscribeChain subscribes to the headers and to the status of the scripts
+// of the given addresses, over a connection dedicated to the notifications.
+func (e *electrumExplorer) SubscribeChain(
+	ctx context.Context, addrs []string,
+) (<-chan struct{}, error) {
+	sub := newElectrumExplorer(e.serverURL)
+	if err := sub.connect(); err != nil {
+		return nil, err
+	}
+	conn, reader := sub.conn, sub.reader
+
+	requests := []electrumRequest{
+		{Method: "blockchain.headers.subscribe", Params: []interface{}{}},
+	}
+	for _, addr := range addrs {
+		scripthash, err := addressScripthash(addr)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		requests = append(requests, electrumRequest{
+			Method: "blockchain.scripthash.subscribe",
+			Params: []interface{}{scripthash},
+		})
+	}
+
+	send := func(req electrumRequest) error {
+		sub.nextID++
+		req.ID = sub.nextID
+		buf, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		// nolint
+		conn.SetWriteDeadline(time.Now().Add(electrumTimeout))
+		_, err = conn.Write(append(buf, '\n'))
+		return err
+	}
+	for _, req := range requests {
+		if err := send(req); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	notifications := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(notifications)
+		defer close(done)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			// the notifications are the messages with a method, the
+			// others are the responses to the requests.
+			var msg struct {
+				Method string `json:"method"`
+			}
+			if err := json.Unmarshal(line, &msg); err == nil &&
+				len(msg.Method) > 0 {
+				notify(notifications)
+			}
+		}
+	}()
+	go keepAlive(ctx, done, conn, func() error {
+		return send(electrumRequest{
+			Method: "server.ping", Params: []interface{}{},
+		})
+	})
+
+	return notifications, nil
+}
+
+// SubscribeChain subscribes to the blocks and to the txs of the given
+// addresses through the websocket of the mempool.space API.
+func (e *mempoolExplorer) SubscribeChain(
+	ctx context.Context, addrs []string,
+) (<-chan struct{}, error) {
+	baseURL, err := url.Parse(e.baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid explorer url: %s", err)
+	}
+
+	wsURL := *baseURL
+	wsURL.Path = strings.TrimSuffix(baseURL.Path, "/") + "/v1/ws"
+	defaultPort := "80"
+	switch baseURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+		defaultPort = "443"
+	case "http":
+		wsURL.Scheme = "ws"
+	default:
+		return nil, fmt.Errorf("invalid explorer url scheme %s", baseURL.Scheme)
+	}
+
+	config, err := websocket.NewConfig(wsURL.String(), baseURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	host := baseURL.Host
+	if len(baseURL.Port()) <= 0 {
+		host = net.JoinHostPort(baseURL.Hostname(), defaultPort)
+	}
+	conn, err := dialTCP(host, electrumTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to explorer: %s", err)
+	}
+	if wsURL.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: baseURL.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to explorer: %s", err)
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to explorer: %s", err)
+	}
+
+	requests := []interface{}{
+		map[string]interface{}{"action": "want", "data": []string{"blocks"}},
+	}
+	if len(addrs) > 0 {
+		requests = append(
+			requests, map[string]interface{}{"track-addresses": addrs},
+		)
+	}
+	for _, req := range requests {
+		if err := websocket.JSON.Send(ws, req); err != nil {
+			ws.Close()
+			return nil, err
+		}
+	}
+
+	notifications := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(notifications)
+		defer close(done)
+		for {
+			var msg map[string]json.RawMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				return
+			}
+			for _, key := range []string{
+				"block", "multi-address-transactions",
+			} {
+				if _, ok := msg[key]; ok {
+					notify(notifications)
+					break
+				}
+			}
+		}
+	}()
+	go keepAlive(ctx, done, ws, func() error {
+		return websocket.JSON.Send(ws, map[string]string{"action": "ping"})
+	})
+
+	return notifications, nil
+}
+
+// keepAlive pings the subscription until it ends, then closes its connection
+// once the context is done.
+func keepAlive(
+	ctx context.Context, done <-chan struct{}, conn net.Conn, ping func() error,
+) {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	// nolint
+	defer conn.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := ping(); err != nil {
+				return
+			}
+		}
+	}
+}
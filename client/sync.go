@@ -0,0 +1,496 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+// remotePendingRoundTTL is the time after which the pending round of another
+// device is considered stale and its coins are released.
+const remotePendingRoundTTL = 10 * time.Minute
+
+// syncedStateKeys are the entries of the state exchanged between devices,
+// the others are either static or strictly related to the local device. The
+// history and the labels have their own tables, and are exchanged in the
+// json format of the backups.
+var syncedStateKeys = []string{KNOWN_VTXOS, LABELS, HISTORY}
+
+var syncCommand = cli.Command{
+	Name:   "sync",
+	Usage:  "Exchange state updates with the other devices sharing the same wallet, via the ASP",
+	Action: withWalletLock(syncAction),
+	Flags:  []cli.Flag{&passwordFlag},
+}
+
+// syncMessage is the plaintext of the messages exchanged between devices.
+type syncMessage struct {
+	DeviceID     string            `json:"device_id"`
+	Timestamp    int64             `json:"timestamp"`
+	Entries      map[string]string `json:"entries"`
+	PendingRound *pendingRound     `json:"pending_round,omitempty"`
+	// Outbox are the latest operations of the device, shown by the others.
+	Outbox []outboxEntry `json:"outbox,omitempty"`
+}
+
+func syncAction(ctx *cli.Context) error {
+	// the devices sync through the ASP of init.
+	selectAsp(nil)
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	secKey, err := privateKeyFromPassword(ctx)
+	if err != nil {
+		return err
+	}
+
+	deviceID, err := getDeviceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	syncKey := deriveSyncKey(secKey)
+	mailboxID := deriveMailboxID(syncKey)
+
+	// pull the updates of the other devices first.
+	cursor, _ := strconv.ParseUint(state[SYNC_CURSOR], 10, 64)
+	epoch, _ := strconv.ParseUint(state[SYNC_EPOCH], 10, 64)
+	resp, err := client.GetSyncMessages(ctx.Context, &arkv1.GetSyncMessagesRequest{
+		MailboxId:     mailboxID,
+		AfterSequence: cursor,
+		Epoch:         epoch,
+	})
+	if err != nil {
+		return err
+	}
+	// the sequence restarts with the ASP, that returns all the messages if
+	// the cursor is of a previous epoch.
+	if resp.GetEpoch() != epoch {
+		epoch = resp.GetEpoch()
+		cursor = 0
+	}
+
+	received := 0
+	for _, msg := range resp.GetMessages() {
+		if msg.GetSequence() > cursor {
+			cursor = msg.GetSequence()
+		}
+
+		buf, err := base64.StdEncoding.DecodeString(msg.GetPayload())
+		if err != nil {
+			continue
+		}
+		plaintext, err := decryptSyncMessage(syncKey, buf)
+		if err != nil {
+			// not for us or tampered, either way skip it.
+			continue
+		}
+		update := syncMessage{}
+		if err := json.Unmarshal(plaintext, &update); err != nil {
+			continue
+		}
+		if update.DeviceID == deviceID {
+			continue
+		}
+
+		if err := applySyncMessage(ctx, update); err != nil {
+			return err
+		}
+		received++
+	}
+
+	// then push the local state.
+	localRound, err := getPendingRound(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := getSyncedEntries(ctx)
+	if err != nil {
+		return err
+	}
+	outbox, err := getOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(syncMessage{
+		DeviceID:     deviceID,
+		Timestamp:    time.Now().Unix(),
+		Entries:      entries,
+		PendingRound: localRound,
+		Outbox:       outbox,
+	})
+	if err != nil {
+		return err
+	}
+	encrypted, err := encryptSyncMessage(syncKey, plaintext)
+	if err != nil {
+		return err
+	}
+
+	pushResp, err := client.PushSyncMessage(ctx.Context, &arkv1.PushSyncMessageRequest{
+		MailboxId: mailboxID,
+		Payload:   base64.StdEncoding.EncodeToString(encrypted),
+	})
+	if err != nil {
+		return err
+	}
+	// the cursor is not moved past the pushed message, not to skip those of
+	// the other devices pushed in the meantime. Ours is skipped by device id.
+	if pushResp.GetEpoch() != epoch {
+		epoch = pushResp.GetEpoch()
+		cursor = 0
+	}
+
+	if err := setState(ctx, map[string]string{
+		SYNC_CURSOR: strconv.FormatUint(cursor, 10),
+		SYNC_EPOCH:  strconv.FormatUint(epoch, 10),
+	}); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"device_id":         deviceID,
+		"received_updates":  received,
+		"last_sync_message": cursor,
+	})
+}
+
+// getSyncedEntries returns the entries of the local state sent to the other
+// devices.
+func getSyncedEntries(ctx *cli.Context) (map[string]string, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := getLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history, err := getHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return nil, err
+	}
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string)
+	for _, key := range syncedStateKeys {
+		entries[key] = state[key]
+	}
+	entries[LABELS] = string(labelsJSON)
+	entries[HISTORY] = string(historyJSON)
+	return entries, nil
+}
+
+// applySyncMessage merges the update of another device into the local state.
+// The known vtxos are merged so that the next sync with the ASP detects the
+// ones spent by any device, and the history entries missing locally are
+// added. The labels missing locally are added too, while those differing are
+// overwritten only if the update is more recent than the last one applied.
+// The pending round and the outbox of the other device are stored so that
+// its coins are not selected by this one, and its operations are shown.
+// The entries of the state unknown to this version are ignored.
+func applySyncMessage(ctx *cli.Context, update syncMessage) error {
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	lastUpdate, _ := strconv.ParseInt(state[SYNC_LAST_UPDATE], 10, 64)
+	isNewer := update.Timestamp >= lastUpdate
+
+	remoteRounds, err := getRemotePendingRounds(ctx)
+	if err != nil {
+		return err
+	}
+	if update.PendingRound != nil {
+		remoteRounds[update.DeviceID] = *update.PendingRound
+	} else {
+		delete(remoteRounds, update.DeviceID)
+	}
+	roundsJSON, err := json.Marshal(remoteRounds)
+	if err != nil {
+		return err
+	}
+
+	remoteOutboxes, err := getRemoteOutboxes(ctx)
+	if err != nil {
+		return err
+	}
+	if len(update.Outbox) > 0 {
+		remoteOutboxes[update.DeviceID] = update.Outbox
+	} else {
+		delete(remoteOutboxes, update.DeviceID)
+	}
+	outboxesJSON, err := json.Marshal(remoteOutboxes)
+	if err != nil {
+		return err
+	}
+
+	changes := map[string]string{
+		REMOTE_PENDING_ROUNDS: string(roundsJSON),
+		REMOTE_OUTBOXES:       string(outboxesJSON),
+	}
+	newLabels := make(map[string]string)
+	newHistory := make([]historyEntry, 0)
+
+	for key, value := range update.Entries {
+		if len(value) <= 0 {
+			continue
+		}
+
+		switch key {
+		case KNOWN_VTXOS:
+			knownVtxos, err := getKnownVtxos(ctx)
+			if err != nil {
+				return err
+			}
+			remoteVtxos := make(map[string]uint64)
+			if err := json.Unmarshal([]byte(value), &remoteVtxos); err != nil {
+				return fmt.Errorf("invalid known vtxos from device %s", update.DeviceID)
+			}
+			buf, err := json.Marshal(mergeKnownVtxos(knownVtxos, remoteVtxos))
+			if err != nil {
+				return err
+			}
+			changes[key] = string(buf)
+		case LABELS:
+			labels, err := getLabels(ctx)
+			if err != nil {
+				return err
+			}
+			remoteLabels := make(map[string]string)
+			if err := json.Unmarshal([]byte(value), &remoteLabels); err != nil {
+				return fmt.Errorf("invalid labels from device %s", update.DeviceID)
+			}
+			newLabels = mergeLabels(labels, remoteLabels, isNewer)
+		case HISTORY:
+			history, err := getHistory(ctx)
+			if err != nil {
+				return err
+			}
+			remoteHistory := make([]historyEntry, 0)
+			if err := json.Unmarshal([]byte(value), &remoteHistory); err != nil {
+				return fmt.Errorf("invalid history from device %s", update.DeviceID)
+			}
+			newHistory = mergeHistory(history, remoteHistory)
+		}
+	}
+	if update.Timestamp > lastUpdate {
+		changes[SYNC_LAST_UPDATE] = strconv.FormatInt(update.Timestamp, 10)
+	}
+
+	return withStateTx(ctx, func(tx *sql.Tx) error {
+		if err := upsertState(tx, changes); err != nil {
+			return err
+		}
+		if err := upsertLabels(tx, newLabels); err != nil {
+			return err
+		}
+		for _, entry := range newHistory {
+			if err := insertHistoryEntry(tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// mergeKnownVtxos returns the union of the known vtxos of both devices.
+func mergeKnownVtxos(local, remote map[string]uint64) map[string]uint64 {
+	merged := make(map[string]uint64, len(local)+len(remote))
+	for outpoint, amount := range local {
+		merged[outpoint] = amount
+	}
+	for outpoint, amount := range remote {
+		merged[outpoint] = amount
+	}
+	return merged
+}
+
+// mergeLabels returns the remote labels to store locally, those missing, and
+// those differing if the remote ones are newer.
+func mergeLabels(local, remote map[string]string, isNewer bool) map[string]string {
+	changes := make(map[string]string)
+	for id, label := range remote {
+		localLabel, ok := local[id]
+		if !ok || (isNewer && localLabel != label) {
+			changes[id] = label
+		}
+	}
+	return changes
+}
+
+// mergeHistory returns the remote history entries missing locally, oldest
+// first. The entries are told apart by kind, amount and time of creation,
+// since their txid changes with a fee bump.
+func mergeHistory(local, remote []historyEntry) []historyEntry {
+	key := func(e historyEntry) string {
+		return fmt.Sprintf("%s:%d:%d", e.Kind, e.Amount, e.CreatedAt)
+	}
+
+	known := make(map[string]struct{}, len(local))
+	for _, entry := range local {
+		known[key(entry)] = struct{}{}
+	}
+
+	missing := make([]historyEntry, 0)
+	for _, entry := range remote {
+		if _, ok := known[key(entry)]; ok {
+			continue
+		}
+		known[key(entry)] = struct{}{}
+		missing = append(missing, entry)
+	}
+	sort.SliceStable(missing, func(i, j int) bool {
+		return missing[i].CreatedAt < missing[j].CreatedAt
+	})
+	return missing
+}
+
+func getRemoteOutboxes(ctx *cli.Context) (map[string][]outboxEntry, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxes := make(map[string][]outboxEntry)
+	if len(state[REMOTE_OUTBOXES]) <= 0 {
+		return outboxes, nil
+	}
+	if err := json.Unmarshal([]byte(state[REMOTE_OUTBOXES]), &outboxes); err != nil {
+		return nil, fmt.Errorf("invalid remote outboxes: %s", err)
+	}
+	return outboxes, nil
+}
+
+func getRemotePendingRounds(ctx *cli.Context) (map[string]pendingRound, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make(map[string]pendingRound)
+	if len(state[REMOTE_PENDING_ROUNDS]) <= 0 {
+		return rounds, nil
+	}
+	if err := json.Unmarshal([]byte(state[REMOTE_PENDING_ROUNDS]), &rounds); err != nil {
+		return nil, fmt.Errorf("invalid remote pending rounds: %s", err)
+	}
+	return rounds, nil
+}
+
+// getRemoteLockedVtxos returns the coins used by the not yet stale pending
+// rounds of the other devices.
+func getRemoteLockedVtxos(ctx *cli.Context) (map[string]struct{}, error) {
+	rounds, err := getRemotePendingRounds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[string]struct{})
+	now := time.Now()
+	for _, round := range rounds {
+		if now.Sub(time.Unix(round.UpdatedAt, 0)) > remotePendingRoundTTL {
+			continue
+		}
+		for _, in := range round.Inputs {
+			locked[in] = struct{}{}
+		}
+	}
+	return locked, nil
+}
+
+func getDeviceID(ctx *cli.Context) (string, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return "", err
+	}
+	if id := state[DEVICE_ID]; len(id) > 0 {
+		return id, nil
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+	if err := setState(ctx, map[string]string{DEVICE_ID: id}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// deriveSyncKey derives the key used to encrypt the sync messages from the
+// wallet private key, so that any device sharing it can decrypt them.
+func deriveSyncKey(secKey *secp256k1.PrivateKey) []byte {
+	key := sha256.Sum256(append(secKey.Serialize(), []byte("ark-sync-key")...))
+	return key[:]
+}
+
+// deriveMailboxID derives the id of the mailbox from the sync key, it does
+// not reveal anything about the wallet to the ASP.
+func deriveMailboxID(syncKey []byte) string {
+	id := sha256.Sum256(append(syncKey, []byte("ark-sync-mailbox")...))
+	return hex.EncodeToString(id[:])
+}
+
+func encryptSyncMessage(key, plaintext []byte) ([]byte, error) {
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(blockCipher)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decryptSyncMessage(key, encrypted []byte) ([]byte, error) {
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(blockCipher)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid message")
+	}
+	nonce, text := encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, text, nil)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeKnownVtxos(t *testing.T) {
+	local := map[string]uint64{"a:0": 1000, "b:0": 2000}
+	remote := map[string]uint64{"b:0": 2000, "c:1": 3000}
+
+	merged := mergeKnownVtxos(local, remote)
+	expected := map[string]uint64{"a:0": 1000, "b:0": 2000, "c:1": 3000}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+	if len(local) != 2 {
+		t.Fatalf("local known vtxos must not be modified")
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	local := map[string]string{"tx1": "rent", "tx2": "salary"}
+	remote := map[string]string{"tx1": "rent", "tx2": "bonus", "tx3": "coffee"}
+
+	fixtures := []struct {
+		name     string
+		isNewer  bool
+		expected map[string]string
+	}{
+		{
+			name:     "older_update",
+			isNewer:  false,
+			expected: map[string]string{"tx3": "coffee"},
+		},
+		{
+			name:     "newer_update",
+			isNewer:  true,
+			expected: map[string]string{"tx2": "bonus", "tx3": "coffee"},
+		},
+	}
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			changes := mergeLabels(local, remote, f.isNewer)
+			if !reflect.DeepEqual(changes, f.expected) {
+				t.Fatalf("expected %v, got %v", f.expected, changes)
+			}
+		})
+	}
+}
+
+func TestMergeHistory(t *testing.T) {
+	local := []historyEntry{
+		{Kind: "send", Txid: "tx1", Amount: 1000, CreatedAt: 10},
+		{Kind: "refresh", Txid: "tx2", Amount: 5000, CreatedAt: 20},
+	}
+	remote := []historyEntry{
+		// already known, with the txid of a fee bump.
+		{Kind: "send", Txid: "tx1-bumped", Amount: 1000, CreatedAt: 10},
+		{Kind: "exit", Txid: "tx4", Amount: 700, CreatedAt: 40},
+		{Kind: "send", Txid: "tx3", Amount: 2000, CreatedAt: 30},
+		// duplicated in the remote history.
+		{Kind: "send", Txid: "tx3", Amount: 2000, CreatedAt: 30},
+	}
+
+	missing := mergeHistory(local, remote)
+	if len(missing) != 2 {
+		t.Fatalf("expected 2 missing entries, got %d", len(missing))
+	}
+	if missing[0].Txid != "tx3" || missing[1].Txid != "tx4" {
+		t.Fatalf("expected missing entries oldest first, got %v", missing)
+	}
+
+	if missing := mergeHistory(append(local, missing...), remote); len(missing) != 0 {
+		t.Fatalf("expected no missing entries once merged, got %d", len(missing))
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	TLS_CERT_ENVVAR        = "ARK_TLS_CERT"
+	TLS_CLIENT_CERT_ENVVAR = "ARK_TLS_CLIENT_CERT"
+	TLS_CLIENT_KEY_ENVVAR  = "ARK_TLS_CLIENT_KEY"
+	INSECURE_ENVVAR        = "ARK_INSECURE"
+)
+
+var (
+	tlsCertFlag = &cli.StringFlag{
+		Name: "tls-cert",
+		Usage: "Specify the path of the PEM certificate of the ASP, or of its CA, to verify the TLS " +
+			"connection with, like for a self-signed one. The connection uses TLS even if the ASP url " +
+			"has no https:// scheme",
+		EnvVars: []string{TLS_CERT_ENVVAR},
+	}
+	tlsClientCertFlag = &cli.StringFlag{
+		Name:    "tls-client-cert",
+		Usage:   "Specify the path of the PEM certificate to authenticate with to the ASPs requiring it (mTLS)",
+		EnvVars: []string{TLS_CLIENT_CERT_ENVVAR},
+	}
+	tlsClientKeyFlag = &cli.StringFlag{
+		Name:    "tls-client-key",
+		Usage:   "Specify the path of the PEM key of the client certificate",
+		EnvVars: []string{TLS_CLIENT_KEY_ENVVAR},
+	}
+	insecureFlag = &cli.BoolFlag{
+		Name:    "insecure",
+		Usage:   "Connect to the ASP in plaintext, even if its url has the https:// scheme",
+		EnvVars: []string{INSECURE_ENVVAR},
+	}
+)
+
+var (
+	// aspTLSConfig is the TLS config set with the --tls-* flags, if any.
+	aspTLSConfig *tls.Config
+	// insecureConn is set with --insecure.
+	insecureConn bool
+)
+
+// setupTLS loads the certificates to open the TLS connections to the ASP
+// with, if any.
+func setupTLS(ctx *cli.Context) error {
+	certPath := cleanAndExpandPath(globalFlagValue(ctx, tlsCertFlag))
+	clientCertPath := cleanAndExpandPath(globalFlagValue(ctx, tlsClientCertFlag))
+	clientKeyPath := cleanAndExpandPath(globalFlagValue(ctx, tlsClientKeyFlag))
+	insecureConn = ctx.Bool(insecureFlag.Name)
+
+	if len(certPath) <= 0 && len(clientCertPath) <= 0 && len(clientKeyPath) <= 0 {
+		return nil
+	}
+	if insecureConn {
+		return fmt.Errorf("--insecure can't be used with the --tls-* flags")
+	}
+	if (len(clientCertPath) > 0) != (len(clientKeyPath) > 0) {
+		return fmt.Errorf("--tls-client-cert and --tls-client-key must be set together")
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(certPath) > 0 {
+		buf, err := os.ReadFile(certPath)
+		if err != nil {
+			return fmt.Errorf("failed to read tls certificate: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return fmt.Errorf("invalid tls certificate: no pem certificate")
+		}
+		config.RootCAs = pool
+	}
+	if len(clientCertPath) > 0 {
+		cert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return fmt.Errorf("invalid tls client certificate or key: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	aspTLSConfig = config
+	return nil
+}
+
+// aspTransportCredentials returns the credentials of the gRPC connection to
+// the ASP at the given url, and its address with the default port if none.
+// The connection uses TLS if the url has the https:// scheme or the --tls-*
+// flags are set, unless --insecure.
+func aspTransportCredentials(
+	url string,
+) (credentials.TransportCredentials, string) {
+	useTLS := aspTLSConfig != nil
+	addr := url
+	if strings.HasPrefix(addr, "https://") {
+		addr = strings.TrimPrefix(addr, "https://")
+		useTLS = true
+	}
+	if insecureConn {
+		useTLS = false
+	}
+
+	port := 80
+	if useTLS {
+		port = 443
+	}
+	if !strings.Contains(addr, ":") {
+		addr = fmt.Sprintf("%s:%d", addr, port)
+	}
+
+	if !useTLS {
+		return insecure.NewCredentials(), addr
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if aspTLSConfig != nil {
+		config = aspTLSConfig.Clone()
+	}
+	return credentials.NewTLS(config), addr
+}
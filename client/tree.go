@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/urfave/cli/v2"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+var treeCommand = cli.Command{
+	Name:  "tree",
+	Usage: "Inspect the congestion trees of the rounds",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "show",
+			Usage:     "Shows the congestion tree of a round, given its id or pool txid, highlighting the leaves of the wallet. With --output table it's drawn as a tree",
+			ArgsUsage: "<round_id|pool_txid>",
+			Action:    treeShowAction,
+		},
+	},
+}
+
+type treeNodeSummary struct {
+	Depth      int    `json:"depth"`
+	Txid       string `json:"txid"`
+	ParentTxid string `json:"parent_txid"`
+	Leaf       bool   `json:"leaf"`
+	Amount     uint64 `json:"amount"`
+	// Vtxos are the outpoints of the vtxos of the wallet created by the leaf.
+	Vtxos []string `json:"vtxos,omitempty"`
+}
+
+func treeShowAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("missing round id or pool txid")
+	}
+	id := ctx.Args().First()
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	var round *arkv1.Round
+	// the pool txids are 32 bytes hex, the round ids are uuids.
+	if len(id) == 64 {
+		resp, err := client.GetRound(ctx.Context, &arkv1.GetRoundRequest{Txid: id})
+		if err != nil {
+			return err
+		}
+		round = resp.GetRound()
+	} else {
+		resp, err := client.GetRoundById(ctx.Context, &arkv1.GetRoundByIdRequest{Id: id})
+		if err != nil {
+			return err
+		}
+		round = resp.GetRound()
+	}
+
+	congestionTree, err := toCongestionTree(round.GetCongestionTree())
+	if err != nil {
+		return err
+	}
+	if len(congestionTree) <= 0 {
+		return fmt.Errorf("round %s has no congestion tree", round.GetId())
+	}
+
+	walletScript, err := getWalletVtxoScript(ctx)
+	if err != nil {
+		return err
+	}
+
+	nodes := make([]treeNodeSummary, 0, congestionTree.NumberOfNodes())
+	for depth, level := range congestionTree {
+		for _, node := range level {
+			summary, err := summarizeTreeNode(node, depth, walletScript)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, summary)
+		}
+	}
+
+	if outputFormat == outputTable {
+		printTree(congestionTree, nodes)
+		return nil
+	}
+	return printJSON(map[string]interface{}{
+		"round_id": round.GetId(),
+		"depth":    len(congestionTree),
+		"nodes":    nodes,
+	})
+}
+
+// getWalletVtxoScript returns the output script of the vtxos of the wallet.
+func getWalletVtxoScript(ctx *cli.Context) ([]byte, error) {
+	userPubkey, err := getWalletPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tapKey, _, err := computeVtxoTaprootScript(userPubkey, aspPubkey, uint(exitDelay))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0x51, 0x20}, schnorr.SerializePubKey(tapKey)...), nil
+}
+
+func summarizeTreeNode(
+	node tree.Node, depth int, walletScript []byte,
+) (treeNodeSummary, error) {
+	pset, err := psetv2.NewPsetFromBase64(node.Tx)
+	if err != nil {
+		return treeNodeSummary{}, err
+	}
+
+	summary := treeNodeSummary{
+		Depth:      depth,
+		Txid:       node.Txid,
+		ParentTxid: node.ParentTxid,
+		Leaf:       node.Leaf,
+	}
+	for i, out := range pset.Outputs {
+		// the fee output is not part of the tree.
+		if len(out.Script) <= 0 {
+			continue
+		}
+		summary.Amount += out.Value
+		if node.Leaf && bytes.Equal(out.Script, walletScript) {
+			summary.Vtxos = append(summary.Vtxos, fmt.Sprintf("%s:%d", node.Txid, i))
+		}
+	}
+	return summary, nil
+}
+
+// printTree draws the tree from its root, marking the leaves of the wallet.
+func printTree(congestionTree tree.CongestionTree, nodes []treeNodeSummary) {
+	byTxid := make(map[string]treeNodeSummary, len(nodes))
+	for _, node := range nodes {
+		byTxid[node.Txid] = node
+	}
+
+	var draw func(txid, prefix string, last bool, depth int)
+	draw = func(txid, prefix string, last bool, depth int) {
+		node := byTxid[txid]
+		branch, childPrefix := "├── ", "│   "
+		if last {
+			branch, childPrefix = "└── ", "    "
+		}
+		if depth == 0 {
+			branch, childPrefix = "", ""
+		}
+
+		line := fmt.Sprintf("%s%s%s %d sats", prefix, branch, node.Txid, node.Amount)
+		if len(node.Vtxos) > 0 {
+			line += fmt.Sprintf("  <- yours (%s)", strings.Join(node.Vtxos, ", "))
+		}
+		fmt.Println(line)
+
+		children := congestionTree.Children(txid)
+		for i, child := range children {
+			draw(child.Txid, prefix+childPrefix, i == len(children)-1, depth+1)
+		}
+	}
+
+	root, err := congestionTree.Root()
+	if err != nil {
+		return
+	}
+	draw(root.Txid, "", true, 0)
+}
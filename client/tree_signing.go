@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/urfave/cli/v2"
+)
+
+// treeSigner cosigns the congestion tree of a round, with MuSig2, using the
+// ephemeral key sent to the ASP when claiming the payment.
+type treeSigner struct {
+	key     *btcec.PrivateKey
+	roundID string
+	tree    tree.CongestionTree
+	session bitcointree.SignerSession
+	// cosigners are the keys aggregated in the outputs of the tree txs.
+	cosigners []*secp256k1.PublicKey
+}
+
+func newTreeSigner(key *btcec.PrivateKey) *treeSigner {
+	return &treeSigner{key: key}
+}
+
+// parseEphemeralKey returns the ephemeral key stored in the pending round,
+// nil if none.
+func parseEphemeralKey(key string) (*btcec.PrivateKey, error) {
+	if len(key) <= 0 {
+		return nil, nil
+	}
+	buf, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %s", err)
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(buf)
+	return privkey, nil
+}
+
+// sendNonces generates the nonces for the unsigned tree of the round and
+// sends them to the ASP. The round is skipped if the ephemeral key is not
+// among its cosigners.
+func (s *treeSigner) sendNonces(
+	ctx *cli.Context, client arkv1.ArkServiceClient, e *arkv1.RoundSigningEvent,
+) (bool, error) {
+	if s.key == nil {
+		return false, nil
+	}
+
+	pubkey := hex.EncodeToString(s.key.PubKey().SerializeCompressed())
+	cosigners := make([]*secp256k1.PublicKey, 0, len(e.GetCosignersPubkeys()))
+	found := false
+	for _, key := range e.GetCosignersPubkeys() {
+		buf, err := hex.DecodeString(key)
+		if err != nil {
+			return false, fmt.Errorf("invalid cosigner pubkey: %s", err)
+		}
+		cosigner, err := secp256k1.ParsePubKey(buf)
+		if err != nil {
+			return false, fmt.Errorf("invalid cosigner pubkey: %s", err)
+		}
+		cosigners = append(cosigners, cosigner)
+		if key == pubkey {
+			found = true
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	congestionTree, err := toCongestionTree(e.GetUnsignedTree())
+	if err != nil {
+		return false, err
+	}
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	roundLifetime, err := getRoundLifetime(ctx)
+	if err != nil {
+		return false, err
+	}
+	sweepRoot, err := bitcointree.SweepScriptRoot(aspPubkey, roundLifetime)
+	if err != nil {
+		return false, err
+	}
+	info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+	if err != nil {
+		return false, err
+	}
+
+	session := bitcointree.NewTreeSignerSession(
+		congestionTree, info.GetMinRelayFee(), sweepRoot,
+	)
+	nonces, err := session.GetNonces(s.key.PubKey())
+	if err != nil {
+		return false, fmt.Errorf("failed to generate tree nonces: %s", err)
+	}
+	encodedNonces, err := bitcointree.EncodeNonces(nonces)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := client.SendTreeNonces(ctx.Context, &arkv1.SendTreeNoncesRequest{
+		RoundId:    e.GetId(),
+		PublicKey:  pubkey,
+		TreeNonces: encodedNonces,
+	}); err != nil {
+		return false, err
+	}
+
+	s.roundID = e.GetId()
+	s.tree = congestionTree
+	s.session = session
+	s.cosigners = cosigners
+	return true, nil
+}
+
+// sendSignatures signs the tree with the nonces aggregated by the ASP and
+// sends the partial signatures back.
+func (s *treeSigner) sendSignatures(
+	ctx *cli.Context, client arkv1.ArkServiceClient,
+	e *arkv1.RoundSigningNoncesGeneratedEvent,
+) (bool, error) {
+	if s.session == nil || s.roundID != e.GetId() {
+		return false, nil
+	}
+
+	nonces, err := bitcointree.DecodeNonces(e.GetTreeNonces(), s.tree)
+	if err != nil {
+		return false, fmt.Errorf("invalid tree nonces: %s", err)
+	}
+	if err := s.session.SetKeys(s.cosigners, nonces); err != nil {
+		return false, err
+	}
+	sigs, err := s.session.Sign(s.key)
+	if err != nil {
+		return false, fmt.Errorf("failed to sign tree: %s", err)
+	}
+	encodedSigs, err := bitcointree.EncodeSignatures(sigs)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := client.SendTreeSignatures(
+		ctx.Context, &arkv1.SendTreeSignaturesRequest{
+			RoundId:        e.GetId(),
+			PublicKey:      hex.EncodeToString(s.key.PubKey().SerializeCompressed()),
+			TreeSignatures: encodedSigs,
+		},
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// signCovenantlessForfeits validates the covenantless tree of the round and
+// signs, with MuSig2 along with the ASP, the forfeit txs spending the given
+// vtxos. It returns the number of signed forfeits.
+func signCovenantlessForfeits(
+	ctx *cli.Context, client arkv1.ArkServiceClient, signer walletSigner,
+	treeSigner *treeSigner, e *arkv1.RoundFinalizationEvent,
+	vtxosToSign []vtxo,
+) (int, error) {
+	aspPubkey, err := getAspPublicKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	walletPubkey, err := getWalletPublicKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	unilateralExitDelay, err := getUnilateralExitDelay(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	congestionTree, err := toCongestionTree(e.GetCongestionTree())
+	if err != nil {
+		return 0, err
+	}
+	if len(congestionTree) > 0 {
+		if treeSigner.roundID != e.GetId() {
+			return 0, fmt.Errorf("congestion tree not cosigned by this client")
+		}
+		roundLifetime, err := getRoundLifetime(ctx)
+		if err != nil {
+			return 0, err
+		}
+		info, err := client.GetInfo(ctx.Context, &arkv1.GetInfoRequest{})
+		if err != nil {
+			return 0, err
+		}
+		if err := bitcointree.ValidateCongestionTree(
+			congestionTree, e.GetPoolTx(), aspPubkey, roundLifetime,
+			treeSigner.cosigners, info.GetMinRelayFee(),
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	scriptRoot, err := bitcointree.VtxoScriptRoot(
+		walletPubkey, uint(unilateralExitDelay),
+	)
+	if err != nil {
+		return 0, err
+	}
+	keys := bitcointree.VtxoKeys(walletPubkey, aspPubkey)
+
+	forfeits := make(map[string]*psbt.Packet)
+	nonces := make(map[string]*musig2.Nonces)
+	req := &arkv1.SendForfeitNoncesRequest{RoundId: e.GetId()}
+	for _, b64 := range e.GetForfeitTxs() {
+		forfeitTx, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+		if err != nil {
+			return 0, err
+		}
+		if len(forfeitTx.UnsignedTx.TxIn) <= bitcointree.ForfeitVtxoInputIndex {
+			return 0, fmt.Errorf("missing vtxo input of forfeit tx")
+		}
+		outpoint := forfeitTx.UnsignedTx.TxIn[bitcointree.ForfeitVtxoInputIndex].PreviousOutPoint
+
+		for _, coin := range vtxosToSign {
+			if outpoint.Hash.String() != coin.txid || outpoint.Index != coin.vout {
+				continue
+			}
+			nonce, err := musig2.GenNonces(musig2.WithPublicKey(walletPubkey))
+			if err != nil {
+				return 0, err
+			}
+			txid := forfeitTx.UnsignedTx.TxHash().String()
+			forfeits[txid] = forfeitTx
+			nonces[txid] = nonce
+			req.Nonces = append(req.Nonces, &arkv1.ForfeitNonce{
+				ForfeitTxid: txid,
+				Nonce:       bitcointree.EncodeNonce(nonce.PubNonce),
+			})
+			break
+		}
+	}
+	if len(forfeits) <= 0 {
+		return 0, nil
+	}
+
+	resp, err := client.SendForfeitNonces(ctx.Context, req)
+	if err != nil {
+		return 0, err
+	}
+
+	sigsReq := &arkv1.SendForfeitSignaturesRequest{RoundId: e.GetId()}
+	for _, aspNonce := range resp.GetNonces() {
+		txid := aspNonce.GetForfeitTxid()
+		forfeitTx, ok := forfeits[txid]
+		if !ok {
+			return 0, fmt.Errorf("unexpected nonce for forfeit tx %s", txid)
+		}
+		nonce, err := bitcointree.DecodeNonce(aspNonce.GetNonce())
+		if err != nil {
+			return 0, err
+		}
+
+		sig, err := signer.signForfeit(
+			forfeitTx, nonces[txid].SecNonce,
+			[][66]byte{nonces[txid].PubNonce, nonce}, keys, scriptRoot,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to sign forfeit tx %s: %s", txid, err)
+		}
+		encodedSig, err := bitcointree.EncodeSignature(sig)
+		if err != nil {
+			return 0, err
+		}
+		sigsReq.Signatures = append(sigsReq.Signatures, &arkv1.ForfeitSignature{
+			ForfeitTxid: txid,
+			Signature:   encodedSig,
+		})
+	}
+	if len(sigsReq.Signatures) != len(forfeits) {
+		return 0, fmt.Errorf("missing nonces of the asp for the forfeit txs")
+	}
+
+	if _, err := client.SendForfeitSignatures(ctx.Context, sigsReq); err != nil {
+		return 0, err
+	}
+	return len(forfeits), nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const satsPerBTC = uint64(1e8)
+
+// schemes of the payment URIs accepted by send --to.
+var paymentURISchemes = map[string]struct{}{
+	"ark":           {},
+	"liquidnetwork": {},
+	"liquidtestnet": {},
+	"bitcoin":       {},
+}
+
+// paymentURI is a BIP21-style payment request like
+// ark:<address>?amount=<btc>&label=<label>&message=<message>.
+type paymentURI struct {
+	address string
+	// amount in sats, zero if not specified.
+	amount  uint64
+	label   string
+	message string
+}
+
+// memo returns the label of the URI, or its message if missing.
+func (u paymentURI) memo() string {
+	if len(u.label) > 0 {
+		return u.label
+	}
+	return u.message
+}
+
+// isPaymentURI returns whether the given string starts with one of the
+// supported URI schemes.
+func isPaymentURI(str string) bool {
+	scheme, _, found := strings.Cut(str, ":")
+	if !found {
+		return false
+	}
+	_, ok := paymentURISchemes[strings.ToLower(scheme)]
+	return ok
+}
+
+// parsePaymentURI parses a BIP21-style payment URI. The amount is expressed
+// in BTC, as per BIP21. Unknown required parameters (req-*) and assets other
+// than the given one make the URI invalid.
+func parsePaymentURI(str, assetID string) (*paymentURI, error) {
+	scheme, rest, found := strings.Cut(str, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid payment uri: missing scheme")
+	}
+	if _, ok := paymentURISchemes[strings.ToLower(scheme)]; !ok {
+		return nil, fmt.Errorf("invalid payment uri: unsupported scheme %s", scheme)
+	}
+
+	addr, rawQuery, _ := strings.Cut(rest, "?")
+	if len(addr) <= 0 {
+		return nil, fmt.Errorf("invalid payment uri: missing address")
+	}
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri: %s", err)
+	}
+
+	uri := &paymentURI{address: addr}
+	for key, values := range params {
+		value := values[0]
+		switch key {
+		case "amount":
+			amount, err := parseBTCAmount(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid payment uri amount: %s", err)
+			}
+			uri.amount = amount
+		case "label":
+			uri.label = value
+		case "message":
+			uri.message = value
+		case "assetid":
+			if value != assetID {
+				return nil, fmt.Errorf(
+					"invalid payment uri: unsupported asset %s", value,
+				)
+			}
+		default:
+			if strings.HasPrefix(key, "req-") {
+				return nil, fmt.Errorf(
+					"invalid payment uri: unsupported required parameter %s", key,
+				)
+			}
+		}
+	}
+
+	return uri, nil
+}
+
+// parseBTCAmount converts a decimal amount in BTC to sats without going
+// through floating point arithmetics.
+func parseBTCAmount(str string) (uint64, error) {
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if len(intPart) <= 0 && len(fracPart) <= 0 {
+		return 0, fmt.Errorf("empty amount")
+	}
+	if len(fracPart) > 8 {
+		return 0, fmt.Errorf("too many decimals")
+	}
+
+	btc := uint64(0)
+	if len(intPart) > 0 {
+		n, err := strconv.ParseUint(intPart, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n > math.MaxUint64/satsPerBTC {
+			return 0, fmt.Errorf("amount too big")
+		}
+		btc = n
+	}
+
+	sats := uint64(0)
+	if len(fracPart) > 0 {
+		fracPart += strings.Repeat("0", 8-len(fracPart))
+		n, err := strconv.ParseUint(fracPart, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		sats = n
+	}
+
+	return btc*satsPerBTC + sats, nil
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	watchIntervalFlag = cli.DurationFlag{
+		Name:  "interval",
+		Usage: "how often to check the ASP and the explorer for updates",
+		Value: 30 * time.Second,
+	}
+	expiryThresholdFlag = cli.DurationFlag{
+		Name:  "expiry-threshold",
+		Usage: "notify about vtxos expiring within this time",
+		Value: 24 * time.Hour,
+	}
+)
+
+var watchCommand = cli.Command{
+	Name:   "watch",
+	Usage:  "Keep watching the wallet for incoming payments, finalized rounds and expiring vtxos",
+	Action: watchAction,
+	Flags: []cli.Flag{
+		&watchIntervalFlag, &expiryThresholdFlag, &webhookURLFlag, &webhookSecretFlag,
+	},
+}
+
+// watchCheckpoint is the position the watcher reached with both the ASP and
+// the explorer. It's persisted after every check, so that after a restart the
+// watcher resumes from it and reports any event occurred in the meanwhile.
+// The ASP has no event sequence, its position is the set of vtxos already
+// seen, spent ones included, that it keeps listing. The explorer position is
+// the last block checked: the utxos confirmed after it are the new ones, the
+// unconfirmed ones are tracked until they get confirmed.
+type watchCheckpoint struct {
+	LastBlockHeight  uint32              `json:"last_block_height"`
+	SeenVtxos        map[string]struct{} `json:"seen_vtxos"`
+	SeenUtxos        map[string]struct{} `json:"seen_utxos"`
+	NotifiedExpiries map[string]struct{} `json:"notified_expiries"`
+	// FinalizedRounds are the pool txs of the vtxos already seen confirmed.
+	FinalizedRounds map[string]struct{} `json:"finalized_rounds"`
+}
+
+func watchAction(ctx *cli.Context) error {
+	interval := ctx.Duration(watchIntervalFlag.Name)
+	expiryThreshold := ctx.Duration(expiryThresholdFlag.Name)
+
+	hook, err := getWebhook(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, close, err := getClientFromState(ctx)
+	if err != nil {
+		return err
+	}
+	defer close()
+
+	checkpoint, err := getWatchCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpoint.LastBlockHeight > 0 {
+		fmt.Printf("resuming from block %d\n", checkpoint.LastBlockHeight)
+	} else {
+		fmt.Println("first run, the coins already owned are not notified")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := withWalletLockWait(ctx, func() error {
+			return watchOnce(ctx, client, hook, checkpoint, expiryThreshold)
+		}); err != nil {
+			logger.Warn("watch failed", "err", err)
+		}
+
+		select {
+		case <-ctx.Context.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func watchOnce(
+	ctx *cli.Context, client arkv1.ArkServiceClient, hook *webhook,
+	checkpoint *watchCheckpoint, expiryThreshold time.Duration,
+) error {
+	offchainAddr, onchainAddr, _, err := getAddress(ctx)
+	if err != nil {
+		return err
+	}
+	explorer := NewExplorer(ctx)
+
+	height, err := explorer.GetTipHeight()
+	if err != nil {
+		return err
+	}
+
+	// the vtxos received and spent while not watching are listed as spent.
+	response, err := client.ListVtxos(ctx.Context, &arkv1.ListVtxosRequest{
+		Address: offchainAddr,
+	})
+	if err != nil {
+		return err
+	}
+	vtxos, err := getVtxos(ctx, explorer, client, offchainAddr, false)
+	if err != nil {
+		return err
+	}
+
+	utxos, err := explorer.GetUtxos(onchainAddr)
+	if err != nil {
+		return err
+	}
+
+	// at the first run, the coins already owned are just recorded.
+	firstRun := checkpoint.LastBlockHeight == 0
+	notify := func(event map[string]interface{}) {
+		if firstRun {
+			return
+		}
+		notifyEvent(hook, event)
+	}
+
+	listed := make(map[string]struct{})
+	allVtxos := make([]*arkv1.Vtxo, 0, len(response.GetSpendableVtxos())+len(response.GetSpentVtxos()))
+	allVtxos = append(allVtxos, response.GetSpendableVtxos()...)
+	allVtxos = append(allVtxos, response.GetSpentVtxos()...)
+	for _, v := range allVtxos {
+		key := fmt.Sprintf("%s:%d", v.GetOutpoint().GetTxid(), v.GetOutpoint().GetVout())
+		listed[key] = struct{}{}
+		if _, ok := checkpoint.SeenVtxos[key]; ok {
+			continue
+		}
+		notify(map[string]interface{}{
+			"event":    "offchain_payment_received",
+			"outpoint": key,
+			"amount":   v.GetReceiver().GetAmount(),
+		})
+		checkpoint.SeenVtxos[key] = struct{}{}
+	}
+
+	for _, v := range vtxos {
+		key := fmt.Sprintf("%s:%d", v.txid, v.vout)
+		if v.expireAt == nil || time.Until(*v.expireAt) > expiryThreshold {
+			continue
+		}
+		if _, ok := checkpoint.NotifiedExpiries[key]; ok {
+			continue
+		}
+		notifyEvent(hook, map[string]interface{}{
+			"event":     "vtxo_expiring",
+			"outpoint":  key,
+			"amount":    v.amount,
+			"expire_at": v.expireAt.Format("2006-01-02 15:04:05"),
+		})
+		checkpoint.NotifiedExpiries[key] = struct{}{}
+	}
+
+	// the utxos confirmed up to the last block checked are already notified,
+	// the others unless seen unconfirmed.
+	for _, u := range utxos {
+		if u.Status.Confirmed && u.Status.BlockHeight > 0 &&
+			u.Status.BlockHeight <= checkpoint.LastBlockHeight {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", u.Txid, u.Vout)
+		if _, ok := checkpoint.SeenUtxos[key]; ok {
+			continue
+		}
+		notify(map[string]interface{}{
+			"event":     "onchain_payment_received",
+			"outpoint":  key,
+			"amount":    u.Amount,
+			"confirmed": u.Status.Confirmed,
+		})
+		checkpoint.SeenUtxos[key] = struct{}{}
+	}
+
+	// a round is final once its pool tx is confirmed.
+	rounds := make(map[string]uint64)
+	for _, v := range vtxos {
+		rounds[v.poolTxid] += v.amount
+	}
+	for poolTxid, amount := range rounds {
+		if _, ok := checkpoint.FinalizedRounds[poolTxid]; ok {
+			continue
+		}
+		// nolint:all
+		confirmed, blocktime, _ := getTxBlocktime(ctx, poolTxid)
+		if !confirmed {
+			continue
+		}
+		notify(map[string]interface{}{
+			"event":        "round_finalized",
+			"pool_txid":    poolTxid,
+			"amount":       amount,
+			"confirmed_at": time.Unix(blocktime, 0).Format("2006-01-02 15:04:05"),
+		})
+		checkpoint.FinalizedRounds[poolTxid] = struct{}{}
+	}
+
+	// forget the coins not listed anymore to keep the checkpoint small.
+	spendable := make(map[string]struct{})
+	for _, v := range vtxos {
+		spendable[fmt.Sprintf("%s:%d", v.txid, v.vout)] = struct{}{}
+	}
+	for poolTxid := range checkpoint.FinalizedRounds {
+		if _, ok := rounds[poolTxid]; !ok {
+			delete(checkpoint.FinalizedRounds, poolTxid)
+		}
+	}
+	for key := range checkpoint.SeenVtxos {
+		if _, ok := listed[key]; !ok {
+			delete(checkpoint.SeenVtxos, key)
+		}
+	}
+	for key := range checkpoint.NotifiedExpiries {
+		if _, ok := spendable[key]; !ok {
+			delete(checkpoint.NotifiedExpiries, key)
+		}
+	}
+	// the utxos confirmed up to the tip are covered by the block height.
+	unconfirmed := make(map[string]struct{})
+	for _, u := range utxos {
+		if !u.Status.Confirmed || u.Status.BlockHeight == 0 ||
+			u.Status.BlockHeight > height {
+			unconfirmed[fmt.Sprintf("%s:%d", u.Txid, u.Vout)] = struct{}{}
+		}
+	}
+	for key := range checkpoint.SeenUtxos {
+		if _, ok := unconfirmed[key]; !ok {
+			delete(checkpoint.SeenUtxos, key)
+		}
+	}
+
+	checkpoint.LastBlockHeight = height
+	return setWatchCheckpoint(ctx, checkpoint)
+}
+
+// notifyEvent prints the given event and delivers it to the webhook, if any.
+func notifyEvent(hook *webhook, event map[string]interface{}) {
+	event["created_at"] = time.Now().Unix()
+	// nolint
+	printJSON(event)
+
+	if hook == nil {
+		return
+	}
+	if err := hook.notify(event); err != nil {
+		logger.Warn("failed to notify webhook", "err", err)
+	}
+}
+
+func getWatchCheckpoint(ctx *cli.Context) (*watchCheckpoint, error) {
+	state, err := getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &watchCheckpoint{}
+	if len(state[WATCH_CHECKPOINT]) > 0 {
+		if err := json.Unmarshal(
+			[]byte(state[WATCH_CHECKPOINT]), checkpoint,
+		); err != nil {
+			return nil, fmt.Errorf("invalid watch checkpoint: %s", err)
+		}
+	}
+	if checkpoint.SeenVtxos == nil {
+		checkpoint.SeenVtxos = make(map[string]struct{})
+	}
+	if checkpoint.SeenUtxos == nil {
+		checkpoint.SeenUtxos = make(map[string]struct{})
+	}
+	if checkpoint.NotifiedExpiries == nil {
+		checkpoint.NotifiedExpiries = make(map[string]struct{})
+	}
+	if checkpoint.FinalizedRounds == nil {
+		checkpoint.FinalizedRounds = make(map[string]struct{})
+	}
+	return checkpoint, nil
+}
+
+func setWatchCheckpoint(ctx *cli.Context, checkpoint *watchCheckpoint) error {
+	buf, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return setState(ctx, map[string]string{WATCH_CHECKPOINT: string(buf)})
+}
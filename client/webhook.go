@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	WEBHOOK_SECRET_ENVVAR = "ARK_WEBHOOK_SECRET"
+	// webhookSignatureHeader carries the hex HMAC-SHA256 of the body, keyed
+	// with the webhook secret.
+	webhookSignatureHeader = "X-Ark-Signature"
+	webhookMaxAttempts     = 4
+)
+
+var (
+	webhookURLFlag = cli.StringFlag{
+		Name:  "webhook-url",
+		Usage: "POST a JSON payload to this URL for every event",
+	}
+	webhookSecretFlag = cli.StringFlag{
+		Name:    "webhook-secret",
+		Usage:   "secret to sign the webhook payloads with HMAC-SHA256, sent in the " + webhookSignatureHeader + " header",
+		EnvVars: []string{WEBHOOK_SECRET_ENVVAR},
+	}
+)
+
+// webhook delivers the events to the configured URL.
+type webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// getWebhook returns nil if no webhook is configured.
+func getWebhook(ctx *cli.Context) (*webhook, error) {
+	webhookURL := ctx.String(webhookURLFlag.Name)
+	if len(webhookURL) <= 0 {
+		return nil, nil
+	}
+	if _, err := url.ParseRequestURI(webhookURL); err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %s", err)
+	}
+	return &webhook{
+		url:    webhookURL,
+		secret: []byte(ctx.String(webhookSecretFlag.Name)),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// notify posts the given event, retrying with exponential backoff.
+func (w *webhook) notify(event map[string]interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err = w.post(body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= webhookMaxAttempts {
+			return fmt.Errorf(
+				"failed to deliver %s to webhook after %d attempts: %s",
+				event["event"], attempt, err,
+			)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *webhook) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		mac := hmac.New(sha256.New, w.secret)
+		mac.Write(body)
+		req.Header.Set(webhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// nolint:all
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook answered %s: %s", resp.Status, string(msg))
+	}
+	return nil
+}
@@ -144,37 +144,14 @@ func (l *leaf) getAmount() int64 {
 }
 
 func (l *leaf) getOutputs() ([]*wire.TxOut, error) {
-	redeemClosure := &CSVSigClosure{
-		Pubkey:  l.vtxoKey,
-		Seconds: uint(l.exitDelay),
-	}
-
-	redeemLeaf, err := redeemClosure.Leaf()
-	if err != nil {
-		return nil, err
-	}
-
-	forfeitClosure := &ForfeitClosure{
-		Pubkey:    l.vtxoKey,
-		AspPubkey: l.aspKey,
-	}
-
-	forfeitLeaf, err := forfeitClosure.Leaf()
+	aggregatedKey, _, err := VtxoTaprootKey(
+		l.vtxoKey, l.aspKey, uint(l.exitDelay),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	leafTaprootTree := txscript.AssembleTaprootScriptTree(
-		*redeemLeaf, *forfeitLeaf,
-	)
-	root := leafTaprootTree.RootNode.TapHash()
-
-	taprootKey := txscript.ComputeTaprootOutputKey(
-		UnspendableKey(),
-		root[:],
-	)
-
-	script, err := taprootOutputScript(taprootKey)
+	script, err := taprootOutputScript(aggregatedKey.FinalKey)
 	if err != nil {
 		return nil, err
 	}
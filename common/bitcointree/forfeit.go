@@ -0,0 +1,192 @@
+package bitcointree
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// ForfeitVtxoInputIndex is the index of the vtxo input of a forfeit tx, the
+// first one being the connector.
+const ForfeitVtxoInputIndex = 1
+
+// VtxoScriptRoot returns the root of the taproot script tree of a vtxo, made
+// of the unilateral exit leaf of the owner only. The vtxo is forfeited with
+// the key path instead, with the MuSig2 key of the owner and the ASP.
+func VtxoScriptRoot(
+	userPubkey *secp256k1.PublicKey, unilateralExitDelay uint,
+) ([]byte, error) {
+	redeemClosure := &CSVSigClosure{
+		Pubkey:  userPubkey,
+		Seconds: unilateralExitDelay,
+	}
+
+	redeemLeaf, err := redeemClosure.Leaf()
+	if err != nil {
+		return nil, err
+	}
+
+	root := txscript.AssembleTaprootScriptTree(*redeemLeaf).RootNode.TapHash()
+	return root.CloneBytes(), nil
+}
+
+// VtxoKeys returns the keys aggregated in the taproot key of a vtxo.
+func VtxoKeys(userPubkey, aspPubkey *secp256k1.PublicKey) []*btcec.PublicKey {
+	return []*btcec.PublicKey{userPubkey, aspPubkey}
+}
+
+// VtxoTaprootKey returns the MuSig2 key of the owner and the ASP, tweaked
+// with the script root of the vtxo, and the script root.
+func VtxoTaprootKey(
+	userPubkey, aspPubkey *secp256k1.PublicKey, unilateralExitDelay uint,
+) (*musig2.AggregateKey, []byte, error) {
+	scriptRoot, err := VtxoScriptRoot(userPubkey, unilateralExitDelay)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aggregatedKey, err := AggregateKeys(
+		VtxoKeys(userPubkey, aspPubkey), scriptRoot,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aggregatedKey, scriptRoot, nil
+}
+
+// ForfeitSigHash returns the message the owner and the ASP sign the vtxo
+// input of the given forfeit tx with.
+func ForfeitSigHash(forfeitTx *psbt.Packet) ([32]byte, error) {
+	if len(forfeitTx.Inputs) <= ForfeitVtxoInputIndex {
+		return [32]byte{}, errors.New("missing vtxo input of forfeit tx")
+	}
+
+	prevouts := make(map[wire.OutPoint]*wire.TxOut)
+	for i, input := range forfeitTx.Inputs {
+		if input.WitnessUtxo == nil {
+			return [32]byte{}, fmt.Errorf("missing witness utxo of input %d", i)
+		}
+		prevouts[forfeitTx.UnsignedTx.TxIn[i].PreviousOutPoint] = input.WitnessUtxo
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(prevouts)
+
+	message, err := txscript.CalcTaprootSignatureHash(
+		txscript.NewTxSigHashes(forfeitTx.UnsignedTx, fetcher),
+		txscript.SigHashDefault,
+		forfeitTx.UnsignedTx,
+		ForfeitVtxoInputIndex,
+		fetcher,
+	)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return [32]byte(message), nil
+}
+
+// SignForfeit returns the MuSig2 partial signature of the vtxo input of the
+// given forfeit tx, made with the given secret nonce and the public ones of
+// all the signers.
+func SignForfeit(
+	forfeitTx *psbt.Packet, seckey *btcec.PrivateKey, secNonce [97]byte,
+	nonces [][66]byte, keys []*btcec.PublicKey, scriptRoot []byte,
+) (*musig2.PartialSignature, error) {
+	message, err := ForfeitSigHash(forfeitTx)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregatedNonce, err := musig2.AggregateNonces(nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	return musig2.Sign(
+		secNonce, seckey, aggregatedNonce, keys, message,
+		musig2.WithSortedKeys(), musig2.WithTaprootSignTweak(scriptRoot),
+	)
+}
+
+// CombineForfeitSigs combines the partial signatures of the vtxo input of
+// the given forfeit tx, made with the given public nonces of all the
+// signers, and adds the final signature to the input if valid.
+func CombineForfeitSigs(
+	forfeitTx *psbt.Packet, keys []*btcec.PublicKey, scriptRoot []byte,
+	nonces [][66]byte, sigs []*musig2.PartialSignature,
+) error {
+	message, err := ForfeitSigHash(forfeitTx)
+	if err != nil {
+		return err
+	}
+
+	aggregatedKey, err := AggregateKeys(keys, scriptRoot)
+	if err != nil {
+		return err
+	}
+
+	finalNonce, err := FinalNonce(nonces, aggregatedKey.FinalKey, message)
+	if err != nil {
+		return err
+	}
+
+	combinedSig, err := combineSigs(finalNonce, sigs, keys, scriptRoot, message)
+	if err != nil {
+		return err
+	}
+	if !combinedSig.Verify(message[:], aggregatedKey.FinalKey) {
+		return errors.New("invalid forfeit signature")
+	}
+
+	forfeitTx.Inputs[ForfeitVtxoInputIndex].TaprootKeySpendSig = combinedSig.Serialize()
+	return nil
+}
+
+// EncodeNonce returns the hex encoding of the given public nonce.
+func EncodeNonce(nonce [66]byte) string {
+	return hex.EncodeToString(nonce[:])
+}
+
+// DecodeNonce decodes the given hex public nonce.
+func DecodeNonce(hexNonce string) ([66]byte, error) {
+	buf, err := hex.DecodeString(hexNonce)
+	if err != nil {
+		return [66]byte{}, fmt.Errorf("invalid nonce format: %s", err)
+	}
+	if len(buf) != 66 {
+		return [66]byte{}, fmt.Errorf("invalid nonce, must be 66 bytes")
+	}
+	return [66]byte(buf), nil
+}
+
+// EncodeSignature returns the hex encoding of the given partial signature.
+func EncodeSignature(sig *musig2.PartialSignature) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := sig.Encode(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeSignature decodes the given hex partial signature.
+func DecodeSignature(hexSig string) (*musig2.PartialSignature, error) {
+	buf, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature format: %s", err)
+	}
+	if len(buf) != 32 {
+		return nil, fmt.Errorf("invalid signature, must be 32 bytes")
+	}
+
+	sig := &musig2.PartialSignature{}
+	if err := sig.Decode(bytes.NewReader(buf)); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
@@ -0,0 +1,115 @@
+package bitcointree_test
+
+import (
+	"testing"
+
+	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripSignForfeit(t *testing.T) {
+	alice, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	asp, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	aggregatedKey, scriptRoot, err := bitcointree.VtxoTaprootKey(
+		alice.PubKey(), asp.PubKey(), exitDelay,
+	)
+	require.NoError(t, err)
+
+	vtxoScript, err := p2trScript(aggregatedKey.FinalKey)
+	require.NoError(t, err)
+	aspScript, err := p2trScript(asp.PubKey())
+	require.NoError(t, err)
+
+	forfeitTx, err := psbt.New(
+		[]*wire.OutPoint{
+			{Hash: *testTxid, Index: 0},
+			{Hash: *testTxid, Index: 1},
+		},
+		[]*wire.TxOut{{Value: 1500, PkScript: aspScript}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum, wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+	forfeitTx.Inputs[0].WitnessUtxo = &wire.TxOut{Value: 500, PkScript: aspScript}
+	forfeitTx.Inputs[1].WitnessUtxo = &wire.TxOut{Value: 2000, PkScript: vtxoScript}
+
+	keys := bitcointree.VtxoKeys(alice.PubKey(), asp.PubKey())
+
+	// the owner sends its nonce first, the ASP signs right away.
+	aliceNonces, err := musig2.GenNonces(musig2.WithPublicKey(alice.PubKey()))
+	require.NoError(t, err)
+
+	aspNonces, err := musig2.GenNonces(musig2.WithPublicKey(asp.PubKey()))
+	require.NoError(t, err)
+	nonces := [][66]byte{aliceNonces.PubNonce, aspNonces.PubNonce}
+
+	aspSig, err := bitcointree.SignForfeit(
+		forfeitTx, asp, aspNonces.SecNonce, nonces, keys, scriptRoot,
+	)
+	require.NoError(t, err)
+
+	aliceSig, err := bitcointree.SignForfeit(
+		forfeitTx, alice, aliceNonces.SecNonce, nonces, keys, scriptRoot,
+	)
+	require.NoError(t, err)
+
+	// the ASP gets the signature of the owner encoded.
+	encodedSig, err := bitcointree.EncodeSignature(aliceSig)
+	require.NoError(t, err)
+	decodedSig, err := bitcointree.DecodeSignature(encodedSig)
+	require.NoError(t, err)
+
+	// the final nonce is derived from the public nonces, it matches the one
+	// of the partial signatures made locally.
+	message, err := bitcointree.ForfeitSigHash(forfeitTx)
+	require.NoError(t, err)
+	finalNonce, err := bitcointree.FinalNonce(
+		nonces, aggregatedKey.FinalKey, message,
+	)
+	require.NoError(t, err)
+	require.True(t, finalNonce.IsEqual(aspSig.R))
+
+	err = bitcointree.CombineForfeitSigs(
+		forfeitTx, keys, scriptRoot, nonces,
+		[]*musig2.PartialSignature{decodedSig, aspSig},
+	)
+	require.NoError(t, err)
+
+	sig, err := schnorr.ParseSignature(
+		forfeitTx.Inputs[bitcointree.ForfeitVtxoInputIndex].TaprootKeySpendSig,
+	)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(message[:], aggregatedKey.FinalKey))
+
+	// the partial signature of the owner is required.
+	err = bitcointree.CombineForfeitSigs(
+		forfeitTx, keys, scriptRoot, nonces,
+		[]*musig2.PartialSignature{aspSig, aspSig},
+	)
+	require.Error(t, err)
+
+	// the nonces must be the ones the partial signatures are made with.
+	otherNonces, err := musig2.GenNonces(musig2.WithPublicKey(alice.PubKey()))
+	require.NoError(t, err)
+	err = bitcointree.CombineForfeitSigs(
+		forfeitTx, keys, scriptRoot,
+		[][66]byte{otherNonces.PubNonce, aspNonces.PubNonce},
+		[]*musig2.PartialSignature{decodedSig, aspSig},
+	)
+	require.Error(t, err)
+}
+
+func p2trScript(taprootKey *secp256k1.PublicKey) ([]byte, error) {
+	return txscript.NewScriptBuilder().AddOp(txscript.OP_1).AddData(
+		schnorr.SerializePubKey(taprootKey),
+	).Script()
+}
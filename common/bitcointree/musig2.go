@@ -1,7 +1,10 @@
 package bitcointree
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -51,54 +55,113 @@ func AggregateKeys(
 	return key, nil
 }
 
+// FinalNonce returns the nonce of the MuSig2 signature made with the given
+// public nonces of all the signers, for the given tweaked aggregated key and
+// message. The partial signatures don't encode it, so it's needed to combine
+// the ones received from the other signers.
+func FinalNonce(
+	nonces [][66]byte, finalKey *btcec.PublicKey, message [32]byte,
+) (*btcec.PublicKey, error) {
+	aggregatedNonce, err := musig2.AggregateNonces(nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	// R = R1 + b*R2, with b = H(aggregated nonce || final key || message).
+	buf := make([]byte, 0, 66+32+32)
+	buf = append(buf, aggregatedNonce[:]...)
+	buf = append(buf, schnorr.SerializePubKey(finalKey)...)
+	buf = append(buf, message[:]...)
+	blinderHash := chainhash.TaggedHash(musig2.NonceBlindTag, buf)
+
+	var blinder btcec.ModNScalar
+	blinder.SetByteSlice(blinderHash[:])
+
+	r1, err := btcec.ParseJacobian(aggregatedNonce[:33])
+	if err != nil {
+		return nil, err
+	}
+	r2, err := btcec.ParseJacobian(aggregatedNonce[33:])
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce btcec.JacobianPoint
+	btcec.ScalarMultNonConst(&blinder, &r2, &r2)
+	btcec.AddNonConst(&r1, &r2, &nonce)
+	// the point at infinity is replaced by the generator, like musig2 does.
+	if nonce.Z.IsZero() {
+		btcec.Generator().AsJacobian(&nonce)
+	}
+	nonce.ToAffine()
+
+	return btcec.NewPublicKey(&nonce.X, &nonce.Y), nil
+}
+
+// combineSigs returns the MuSig2 signature combining the given partial ones,
+// made with the given final nonce. The partial signatures made locally carry
+// their nonce, that must match the final one.
+func combineSigs(
+	finalNonce *btcec.PublicKey, sigs []*musig2.PartialSignature,
+	keys []*btcec.PublicKey, scriptRoot []byte, message [32]byte,
+) (*schnorr.Signature, error) {
+	for _, sig := range sigs {
+		if sig.R != nil && !sig.R.IsEqual(finalNonce) {
+			return nil, errors.New("partial signature made with another nonce")
+		}
+	}
+
+	return musig2.CombineSigs(
+		finalNonce, sigs,
+		musig2.WithTaprootTweakedCombine(message, keys, scriptRoot, true),
+	), nil
+}
+
 func ValidateTreeSigs(
 	minRelayFee int64,
 	scriptRoot []byte,
 	finalAggregatedKey *btcec.PublicKey,
-	tree tree.CongestionTree,
+	congestionTree tree.CongestionTree,
 ) error {
 	prevoutFetcher, err := prevOutFetcherFactory(minRelayFee, finalAggregatedKey)
 	if err != nil {
 		return err
 	}
 
-	for _, level := range tree {
-		for _, node := range level {
-			partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
-			if err != nil {
-				return err
-			}
-
-			sig := partialTx.Inputs[0].TaprootKeySpendSig
-			if len(sig) == 0 {
-				return errors.New("unsigned tree input")
-			}
-
-			schnorrSig, err := schnorr.ParseSignature(sig)
-			if err != nil {
-				return err
-			}
+	return forEachNode(congestionTree, func(_, _ int, node tree.Node) error {
+		partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+		if err != nil {
+			return err
+		}
 
-			inputFetcher := prevoutFetcher(partialTx)
+		sig := partialTx.Inputs[0].TaprootKeySpendSig
+		if len(sig) == 0 {
+			return errors.New("unsigned tree input")
+		}
 
-			message, err := txscript.CalcTaprootSignatureHash(
-				txscript.NewTxSigHashes(partialTx.UnsignedTx, inputFetcher),
-				txscript.SigHashDefault,
-				partialTx.UnsignedTx,
-				0,
-				inputFetcher,
-			)
-			if err != nil {
-				return err
-			}
+		schnorrSig, err := schnorr.ParseSignature(sig)
+		if err != nil {
+			return err
+		}
 
-			if !schnorrSig.Verify(message, finalAggregatedKey) {
-				return errors.New("invalid signature")
-			}
+		inputFetcher := prevoutFetcher(partialTx)
+
+		message, err := txscript.CalcTaprootSignatureHash(
+			txscript.NewTxSigHashes(partialTx.UnsignedTx, inputFetcher),
+			txscript.SigHashDefault,
+			partialTx.UnsignedTx,
+			0,
+			inputFetcher,
+		)
+		if err != nil {
+			return err
 		}
-	}
 
-	return nil
+		if !schnorrSig.Verify(message, finalAggregatedKey) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	})
 }
 
 func (n TreeNonces) Decode(r io.Reader, matrixFormat []int) error {
@@ -139,6 +202,8 @@ func (n TreePartialSigs) Decode(r io.Reader, matrixFormat []int) error {
 			if err := sig.Decode(r); err != nil {
 				return err
 			}
+
+			n[i][j] = sig
 		}
 	}
 
@@ -157,6 +222,109 @@ func (n TreePartialSigs) Encode(w io.Writer) error {
 	return nil
 }
 
+// SweepScriptRoot returns the root of the taproot script tree, made of the
+// sweep leaf of the ASP, the keys of the cosigners are tweaked with.
+func SweepScriptRoot(
+	aspPubkey *secp256k1.PublicKey, roundLifetime int64,
+) ([]byte, error) {
+	sweepClosure := &CSVSigClosure{
+		Pubkey:  aspPubkey,
+		Seconds: uint(roundLifetime),
+	}
+
+	sweepLeaf, err := sweepClosure.Leaf()
+	if err != nil {
+		return nil, err
+	}
+
+	root := txscript.AssembleTaprootScriptTree(*sweepLeaf).RootNode.TapHash()
+	return root.CloneBytes(), nil
+}
+
+// EncodeNonces returns the hex encoding of the given tree nonces.
+func EncodeNonces(nonces TreeNonces) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := nonces.Encode(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeNonces decodes the hex nonces of the nodes of the given tree.
+func DecodeNonces(
+	hexNonces string, congestionTree tree.CongestionTree,
+) (TreeNonces, error) {
+	buf, err := hex.DecodeString(hexNonces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonces format: %s", err)
+	}
+
+	matrixFormat := treeMatrixFormat(congestionTree)
+	if len(buf) != 66*countNodes(matrixFormat) {
+		return nil, fmt.Errorf("invalid nonces, must be one per tree node")
+	}
+
+	nonces := make(TreeNonces, len(matrixFormat))
+	for i, size := range matrixFormat {
+		nonces[i] = make([][66]byte, size)
+	}
+	if err := nonces.Decode(bytes.NewReader(buf), matrixFormat); err != nil {
+		return nil, err
+	}
+	return nonces, nil
+}
+
+// EncodeSignatures returns the hex encoding of the given partial signatures.
+func EncodeSignatures(sigs TreePartialSigs) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := sigs.Encode(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeSignatures decodes the hex partial signatures of the nodes of the
+// given tree.
+func DecodeSignatures(
+	hexSigs string, congestionTree tree.CongestionTree,
+) (TreePartialSigs, error) {
+	buf, err := hex.DecodeString(hexSigs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signatures format: %s", err)
+	}
+
+	matrixFormat := treeMatrixFormat(congestionTree)
+	// partial signatures are encoded as 32-byte scalars.
+	if len(buf) != 32*countNodes(matrixFormat) {
+		return nil, fmt.Errorf("invalid signatures, must be one per tree node")
+	}
+
+	sigs := make(TreePartialSigs, len(matrixFormat))
+	for i, size := range matrixFormat {
+		sigs[i] = make([]*musig2.PartialSignature, size)
+	}
+	if err := sigs.Decode(bytes.NewReader(buf), matrixFormat); err != nil {
+		return nil, err
+	}
+	return sigs, nil
+}
+
+func treeMatrixFormat(congestionTree tree.CongestionTree) []int {
+	matrixFormat := make([]int, 0, len(congestionTree))
+	for _, level := range congestionTree {
+		matrixFormat = append(matrixFormat, len(level))
+	}
+	return matrixFormat
+}
+
+func countNodes(matrixFormat []int) int {
+	count := 0
+	for _, size := range matrixFormat {
+		count += size
+	}
+	return count
+}
+
 func NewTreeSignerSession(
 	congestionTree tree.CongestionTree,
 	minRelayFee int64,
@@ -184,10 +352,11 @@ func (t *treeSignerSession) generateNonces(key *btcec.PublicKey) error {
 		return ErrCongestionTreeNotSet
 	}
 
-	myNonces := make([][]*musig2.Nonces, 0)
-
+	// generating the nonces is cheap, unlike signing the nodes, so it's not
+	// worth spreading it on the workers.
+	myNonces := make([][]*musig2.Nonces, 0, len(t.tree))
 	for _, level := range t.tree {
-		levelNonces := make([]*musig2.Nonces, 0)
+		levelNonces := make([]*musig2.Nonces, 0, len(level))
 		for range level {
 			nonce, err := musig2.GenNonces(
 				musig2.WithPublicKey(key),
@@ -268,26 +437,26 @@ func (t *treeSignerSession) Sign(seckey *secp256k1.PrivateKey) (TreePartialSigs,
 		return nil, errors.New("nonces not set")
 	}
 
-	sigs := make(TreePartialSigs, 0)
-
+	sigs := make(TreePartialSigs, len(t.tree))
 	for i, level := range t.tree {
-		levelSigs := make([]*musig2.PartialSignature, 0)
-
-		for j, node := range level {
-			partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
-			if err != nil {
-				return nil, err
-			}
-			// sign the node
-			sig, err := t.signPartial(partialTx, i, j, seckey)
-			if err != nil {
-				return nil, err
-			}
+		sigs[i] = make([]*musig2.PartialSignature, len(level))
+	}
 
-			levelSigs = append(levelSigs, sig)
+	if err := forEachNode(t.tree, func(i, j int, node tree.Node) error {
+		partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+		if err != nil {
+			return err
+		}
+		// sign the node
+		sig, err := t.signPartial(partialTx, i, j, seckey)
+		if err != nil {
+			return err
 		}
 
-		sigs = append(sigs, levelSigs)
+		sigs[i][j] = sig
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	return sigs, nil
@@ -383,13 +552,11 @@ func (t *treeCoordinatorSession) AggregateNonces() (TreeNonces, error) {
 		}
 	}
 
-	aggregatedNonces := make(TreeNonces, 0)
-
+	aggregatedNonces := make(TreeNonces, 0, len(t.tree))
 	for i, level := range t.tree {
-		levelNonces := make([][66]byte, 0)
+		levelNonces := make([][66]byte, 0, len(level))
 		for j := range level {
-
-			nonces := make([][66]byte, 0)
+			nonces := make([][66]byte, 0, len(t.nonces))
 			for _, n := range t.nonces {
 				nonces = append(nonces, n[i][j])
 			}
@@ -401,7 +568,6 @@ func (t *treeCoordinatorSession) AggregateNonces() (TreeNonces, error) {
 
 			levelNonces = append(levelNonces, aggregatedNonce)
 		}
-
 		aggregatedNonces = append(aggregatedNonces, levelNonces)
 	}
 
@@ -421,50 +587,65 @@ func (t *treeCoordinatorSession) SignTree() (tree.CongestionTree, error) {
 		return nil, err
 	}
 
-	for i, level := range t.tree {
-		for j, node := range level {
-			partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
-			if err != nil {
-				return nil, err
-			}
-
-			sigs := make([]*musig2.PartialSignature, 0)
-			for _, sig := range t.sigs {
-				sigs = append(sigs, sig[i][j])
-			}
+	// every worker writes only the node it signs.
+	if err := forEachNode(t.tree, func(i, j int, node tree.Node) error {
+		partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+		if err != nil {
+			return err
+		}
 
-			inputFetcher := t.prevoutFetcher(partialTx)
+		sigs := make([]*musig2.PartialSignature, 0, len(t.sigs))
+		for _, sig := range t.sigs {
+			sigs = append(sigs, sig[i][j])
+		}
 
-			message, err := txscript.CalcTaprootSignatureHash(
-				txscript.NewTxSigHashes(partialTx.UnsignedTx, inputFetcher),
-				txscript.SigHashDefault,
-				partialTx.UnsignedTx,
-				0,
-				inputFetcher,
-			)
+		inputFetcher := t.prevoutFetcher(partialTx)
+
+		message, err := txscript.CalcTaprootSignatureHash(
+			txscript.NewTxSigHashes(partialTx.UnsignedTx, inputFetcher),
+			txscript.SigHashDefault,
+			partialTx.UnsignedTx,
+			0,
+			inputFetcher,
+		)
+		if err != nil {
+			return err
+		}
 
-			combinedSig := musig2.CombineSigs(
-				sigs[0].R, sigs,
-				musig2.WithTaprootTweakedCombine([32]byte(message), t.keys, t.scriptRoot, true),
-			)
-			if err != nil {
-				return nil, err
-			}
+		nonces := make([][66]byte, 0, len(t.nonces))
+		for _, n := range t.nonces {
+			nonces = append(nonces, n[i][j])
+		}
+		finalNonce, err := FinalNonce(
+			nonces, aggregatedKey.FinalKey, [32]byte(message),
+		)
+		if err != nil {
+			return err
+		}
 
-			if !combinedSig.Verify(message, aggregatedKey.FinalKey) {
-				return nil, errors.New("invalid signature")
-			}
+		combinedSig, err := combineSigs(
+			finalNonce, sigs, t.keys, t.scriptRoot, [32]byte(message),
+		)
+		if err != nil {
+			return err
+		}
 
-			partialTx.Inputs[0].TaprootKeySpendSig = combinedSig.Serialize()
+		if !combinedSig.Verify(message, aggregatedKey.FinalKey) {
+			return errors.New("invalid signature")
+		}
 
-			encodedSignedTx, err := partialTx.B64Encode()
-			if err != nil {
-				return nil, err
-			}
+		partialTx.Inputs[0].TaprootKeySpendSig = combinedSig.Serialize()
 
-			node.Tx = encodedSignedTx
-			t.tree[i][j] = node
+		encodedSignedTx, err := partialTx.B64Encode()
+		if err != nil {
+			return err
 		}
+
+		node.Tx = encodedSignedTx
+		t.tree[i][j] = node
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	return t.tree, nil
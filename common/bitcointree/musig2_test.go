@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/ark-network/ark/common/tree"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -64,6 +65,10 @@ func TestRoundTripSignTree(t *testing.T) {
 		sweepTapTree := txscript.AssembleTaprootScriptTree(*sweepTapLeaf)
 		root := sweepTapTree.RootNode.TapHash()
 
+		sweepRoot, err := bitcointree.SweepScriptRoot(asp.PubKey(), lifetime)
+		require.NoError(t, err)
+		require.Equal(t, root.CloneBytes(), sweepRoot)
+
 		aspCoordinator, err := bitcointree.NewTreeCoordinatorSession(
 			tree,
 			minRelayFee,
@@ -82,6 +87,10 @@ func TestRoundTripSignTree(t *testing.T) {
 		bobNonces, err := bobSession.GetNonces(bob.PubKey())
 		require.NoError(t, err)
 
+		// the nonces of the clients go through the wire.
+		aliceNonces = encodeDecodeNonces(t, aliceNonces, tree)
+		bobNonces = encodeDecodeNonces(t, bobNonces, tree)
+
 		aspNonces, err := aspSession.GetNonces(asp.PubKey())
 		require.NoError(t, err)
 
@@ -96,6 +105,7 @@ func TestRoundTripSignTree(t *testing.T) {
 
 		aggregatedNonce, err := aspCoordinator.AggregateNonces()
 		require.NoError(t, err)
+		aggregatedNonce = encodeDecodeNonces(t, aggregatedNonce, tree)
 
 		// coordinator sends the combined nonce to all signers
 
@@ -123,6 +133,9 @@ func TestRoundTripSignTree(t *testing.T) {
 		bobSig, err := bobSession.Sign(bob)
 		require.NoError(t, err)
 
+		aliceSig = encodeDecodeSigs(t, aliceSig, tree)
+		bobSig = encodeDecodeSigs(t, bobSig, tree)
+
 		aspSig, err := aspSession.Sign(asp)
 		require.NoError(t, err)
 
@@ -153,6 +166,34 @@ func TestRoundTripSignTree(t *testing.T) {
 	}
 }
 
+func encodeDecodeNonces(
+	t *testing.T, nonces bitcointree.TreeNonces, tree tree.CongestionTree,
+) bitcointree.TreeNonces {
+	encoded, err := bitcointree.EncodeNonces(nonces)
+	require.NoError(t, err)
+
+	decoded, err := bitcointree.DecodeNonces(encoded, tree)
+	require.NoError(t, err)
+	require.Equal(t, nonces, decoded)
+	return decoded
+}
+
+func encodeDecodeSigs(
+	t *testing.T, sigs bitcointree.TreePartialSigs, tree tree.CongestionTree,
+) bitcointree.TreePartialSigs {
+	encoded, err := bitcointree.EncodeSignatures(sigs)
+	require.NoError(t, err)
+
+	decoded, err := bitcointree.DecodeSignatures(encoded, tree)
+	require.NoError(t, err)
+	for i, level := range sigs {
+		for j, sig := range level {
+			require.True(t, sig.S.Equals(decoded[i][j].S))
+		}
+	}
+	return decoded
+}
+
 type fixture struct {
 	Valid []struct {
 		Receivers []bitcointree.Receiver `json:"receivers"`
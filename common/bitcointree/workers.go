@@ -0,0 +1,75 @@
+package bitcointree
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/ark-network/ark/common/tree"
+)
+
+// forEachNode runs fn for every node of the tree on a pool of workers, sized
+// to the available cpus. It's meant for the signing and verification of the
+// nodes, that are expensive and independent of each other. The nodes left
+// are skipped at the first error, which is returned.
+func forEachNode(
+	congestionTree tree.CongestionTree,
+	fn func(level, index int, node tree.Node) error,
+) error {
+	type job struct {
+		level, index int
+		node         tree.Node
+	}
+
+	numOfNodes := 0
+	for _, level := range congestionTree {
+		numOfNodes += len(level)
+	}
+	if numOfNodes == 0 {
+		return nil
+	}
+
+	numOfWorkers := runtime.NumCPU()
+	if numOfWorkers > numOfNodes {
+		numOfWorkers = numOfNodes
+	}
+
+	jobs := make(chan job)
+	done := make(chan struct{})
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			close(done)
+		})
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(numOfWorkers)
+	for i := 0; i < numOfWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := fn(j.level, j.index, j.node); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for i, level := range congestionTree {
+		for j, node := range level {
+			select {
+			case jobs <- job{i, j, node}:
+			case <-done:
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return firstErr
+}
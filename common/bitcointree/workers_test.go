@@ -0,0 +1,125 @@
+package bitcointree
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachNode(t *testing.T) {
+	t.Run("visits every node once", func(t *testing.T) {
+		congestionTree := makeTestTree(6)
+
+		lock := &sync.Mutex{}
+		visited := make(map[string]int)
+		err := forEachNode(congestionTree, func(i, j int, node tree.Node) error {
+			if position := fmt.Sprintf("%d:%d", i, j); position != node.Txid {
+				return fmt.Errorf("got node %s at %s", node.Txid, position)
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			visited[node.Txid]++
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, visited, countNodes(treeMatrixFormat(congestionTree)))
+		for txid, count := range visited {
+			require.Equal(t, 1, count, txid)
+		}
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		err := forEachNode(tree.CongestionTree{}, func(_, _ int, _ tree.Node) error {
+			return errors.New("unexpected call")
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("returns the error", func(t *testing.T) {
+		congestionTree := makeTestTree(4)
+		expectedErr := errors.New("invalid node")
+
+		err := forEachNode(congestionTree, func(i, j int, _ tree.Node) error {
+			if i == 2 && j == 1 {
+				return expectedErr
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("skips the nodes left at the first error", func(t *testing.T) {
+		// many more nodes than workers.
+		depth := 1
+		for 1<<depth < 8*runtime.NumCPU() {
+			depth++
+		}
+		congestionTree := makeTestTree(depth + 1)
+
+		// every worker stops at its first failure, so at most one node per
+		// worker is visited.
+		var calls atomic.Int64
+		err := forEachNode(congestionTree, func(_, _ int, _ tree.Node) error {
+			calls.Add(1)
+			return errors.New("failed")
+		})
+		require.Error(t, err)
+		require.LessOrEqual(t, calls.Load(), int64(runtime.NumCPU()))
+		require.Less(t, calls.Load(), int64(countNodes(treeMatrixFormat(congestionTree))))
+	})
+}
+
+func BenchmarkForEachNode(b *testing.B) {
+	congestionTree := makeTestTree(8)
+
+	// the work of every node stands for the signing of its tx.
+	work := func(_, _ int, node tree.Node) error {
+		hash := sha256.Sum256([]byte(node.Txid))
+		for k := 0; k < 1000; k++ {
+			hash = sha256.Sum256(hash[:])
+		}
+		return nil
+	}
+
+	b.Run("workers", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			if err := forEachNode(congestionTree, work); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("sequential", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i, level := range congestionTree {
+				for j, node := range level {
+					if err := work(i, j, node); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}
+	})
+}
+
+// makeTestTree returns a binary tree with the given number of levels, whose
+// nodes have their position as txid.
+func makeTestTree(levels int) tree.CongestionTree {
+	congestionTree := make(tree.CongestionTree, 0, levels)
+	for i := 0; i < levels; i++ {
+		level := make([]tree.Node, 0, 1<<i)
+		for j := 0; j < 1<<i; j++ {
+			level = append(level, tree.Node{Txid: fmt.Sprintf("%d:%d", i, j)})
+		}
+		congestionTree = append(congestionTree, level)
+	}
+	return congestionTree
+}
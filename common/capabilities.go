@@ -0,0 +1,105 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Capability is a protocol feature that might not be supported by every
+// client or ASP.
+type Capability uint8
+
+const (
+	CapabilityCovenantTree Capability = iota
+	CapabilityCovenantlessTree
+	CapabilityAsyncPayments
+	CapabilityPTLC
+)
+
+var capabilityNames = map[Capability]string{
+	CapabilityCovenantTree:     "covenant_tree",
+	CapabilityCovenantlessTree: "covenantless_tree",
+	CapabilityAsyncPayments:    "async_payments",
+	CapabilityPTLC:             "ptlc",
+}
+
+func (c Capability) String() string {
+	if name, ok := capabilityNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown_%d", c)
+}
+
+// Capabilities is a set of capabilities, encoded like lightning features
+// with a pair of bits for each capability: the even bit means that the
+// counterparty must support the capability, while the odd one that it's
+// supported but optional.
+// This way, a party can detect that the other one relies on capabilities it
+// doesn't know about and refuse, or just ignore the optional ones.
+type Capabilities uint64
+
+// Set returns a copy of the set with the given capability added.
+func (c Capabilities) Set(capability Capability, required bool) Capabilities {
+	bit := 2 * uint(capability)
+	if !required {
+		bit++
+	}
+	return c | 1<<bit
+}
+
+// Supports returns whether the capability is in the set, either as required
+// or optional.
+func (c Capabilities) Supports(capability Capability) bool {
+	bit := 2 * uint(capability)
+	return c&(3<<bit) != 0
+}
+
+// Requires returns whether the capability is in the set as required.
+func (c Capabilities) Requires(capability Capability) bool {
+	bit := 2 * uint(capability)
+	return c&(1<<bit) != 0
+}
+
+// Missing returns the capabilities required by the set but not supported by
+// the given one, including the unknown ones.
+func (c Capabilities) Missing(supported Capabilities) []Capability {
+	missing := make([]Capability, 0)
+	for capability := Capability(0); capability < 32; capability++ {
+		if c.Requires(capability) && !supported.Supports(capability) {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// List returns the capabilities in the set.
+func (c Capabilities) List() []Capability {
+	list := make([]Capability, 0)
+	for capability := Capability(0); capability < 32; capability++ {
+		if c.Supports(capability) {
+			list = append(list, capability)
+		}
+	}
+	return list
+}
+
+// Bytes returns the big endian serialization of the set without leading
+// zeros.
+func (c Capabilities) Bytes() []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(c))
+	for len(buf) > 0 && buf[0] == 0 {
+		buf = buf[1:]
+	}
+	return buf
+}
+
+// CapabilitiesFromBytes parses a set of capabilities serialized with Bytes.
+func CapabilitiesFromBytes(buf []byte) (Capabilities, error) {
+	if len(buf) > 8 {
+		return 0, fmt.Errorf("invalid capabilities length")
+	}
+	padded := make([]byte, 8)
+	copy(padded[8-len(buf):], buf)
+	return Capabilities(binary.BigEndian.Uint64(padded)), nil
+}
@@ -1,65 +1,285 @@
 package common
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/bech32"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
-func EncodeAddress(
-	hrp string, userKey, aspKey *secp256k1.PublicKey,
-) (addr string, err error) {
-	if userKey == nil {
-		err = fmt.Errorf("missing public key")
-		return
+// AddressVersion0 is the version of the addresses whose payload is made of the
+// public keys of the ASP and of the owner, optionally followed by a list of
+// records.
+const AddressVersion0 = byte(0)
+
+const (
+	// maxAddressLength is the length of the BCH code of bech32m, beyond which
+	// the checksum doesn't guarantee to detect any error anymore.
+	maxAddressLength = 1023
+	// legacyAddressDataLength is the number of 5-bit groups of the addresses
+	// encoded before versioning, without any version group. A versioned
+	// address can't have this length.
+	legacyAddressDataLength = 106
+	// maxAddressRecordLength is the max size of the value of a record.
+	maxAddressRecordLength = 255
+)
+
+// AddressRecordCapabilities is the type of the optional record listing the
+// capabilities of the wallet of the owner of an address.
+const AddressRecordCapabilities = byte(1)
+
+// AddressRecord is an extra type-length-value entry of the payload of an
+// address. Records with an even type are mandatory: addresses with an even
+// record unknown to the decoder are rejected, while unknown odd records are
+// ignored. Records must be sorted by type with no duplicates.
+type AddressRecord struct {
+	Type  byte
+	Value []byte
+}
+
+// ArkAddress is the decoded form of an ark address, that is the bech32m
+// encoding of a version group followed by a version-specific payload.
+type ArkAddress struct {
+	HRP     string
+	Version byte
+	AspKey  *secp256k1.PublicKey
+	UserKey *secp256k1.PublicKey
+	Records []AddressRecord
+}
+
+// Encode returns the bech32m encoding of the address.
+func (a ArkAddress) Encode() (string, error) {
+	if !isValidHRP(a.HRP) {
+		return "", fmt.Errorf("invalid prefix")
 	}
-	if aspKey == nil {
-		err = fmt.Errorf("missing asp public key")
-		return
+	if a.Version != AddressVersion0 {
+		return "", fmt.Errorf("unsupported address version %d", a.Version)
 	}
-	if hrp != Liquid.Addr && hrp != TestNet.Addr {
-		err = fmt.Errorf("invalid prefix")
-		return
+	if a.UserKey == nil {
+		return "", fmt.Errorf("missing public key")
+	}
+	if a.AspKey == nil {
+		return "", fmt.Errorf("missing asp public key")
 	}
-	combinedKey := append(
-		aspKey.SerializeCompressed(), userKey.SerializeCompressed()...,
-	)
-	grp, err := bech32.ConvertBits(combinedKey, 8, 5, true)
+
+	payload := bytes.NewBuffer(nil)
+	payload.Write(a.AspKey.SerializeCompressed())
+	payload.Write(a.UserKey.SerializeCompressed())
+	for i, record := range a.Records {
+		if i > 0 && record.Type <= a.Records[i-1].Type {
+			return "", fmt.Errorf("address records must be sorted by type")
+		}
+		if len(record.Value) > maxAddressRecordLength {
+			return "", fmt.Errorf(
+				"address record %d exceeds max length %d",
+				record.Type, maxAddressRecordLength,
+			)
+		}
+		payload.WriteByte(record.Type)
+		payload.WriteByte(byte(len(record.Value)))
+		payload.Write(record.Value)
+	}
+
+	grp, err := bech32.ConvertBits(payload.Bytes(), 8, 5, true)
 	if err != nil {
-		return
+		return "", err
 	}
-	addr, err = bech32.EncodeM(hrp, grp)
-	return
+	addr, err := bech32.EncodeM(a.HRP, append([]byte{a.Version}, grp...))
+	if err != nil {
+		return "", err
+	}
+	if len(addr) > maxAddressLength {
+		return "", fmt.Errorf("address exceeds max length %d", maxAddressLength)
+	}
+	return addr, nil
+}
+
+// DecodeArkAddress parses and validates the given ark address. Only bech32m
+// checksums are accepted. Addresses encoded before versioning are decoded as
+// version 0 ones.
+func DecodeArkAddress(addr string) (*ArkAddress, error) {
+	if len(addr) > maxAddressLength {
+		return nil, fmt.Errorf("address exceeds max length %d", maxAddressLength)
+	}
+
+	prefix, data, version, err := decodeBech32NoLimit(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !isValidHRP(prefix) {
+		return nil, fmt.Errorf("invalid prefix")
+	}
+	if version != bech32.VersionM {
+		return nil, fmt.Errorf("invalid checksum, must be bech32m")
+	}
+	if len(data) <= 0 {
+		return nil, fmt.Errorf("missing address version")
+	}
+
+	addrVersion := AddressVersion0
+	if len(data) != legacyAddressDataLength {
+		addrVersion = data[0]
+		data = data[1:]
+	}
+	if addrVersion != AddressVersion0 {
+		return nil, fmt.Errorf("unsupported address version %d", addrVersion)
+	}
+
+	payload, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < 66 {
+		return nil, fmt.Errorf("invalid address payload length")
+	}
+
+	aspKey, err := secp256k1.ParsePubKey(payload[:33])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asp public key: %s", err)
+	}
+	userKey, err := secp256k1.ParsePubKey(payload[33:66])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %s", err)
+	}
+	records, err := decodeAddressRecords(payload[66:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArkAddress{
+		HRP:     prefix,
+		Version: addrVersion,
+		AspKey:  aspKey,
+		UserKey: userKey,
+		Records: records,
+	}, nil
+}
+
+// Capabilities returns the capabilities advertised by the address, if any.
+func (a ArkAddress) Capabilities() Capabilities {
+	for _, record := range a.Records {
+		if record.Type == AddressRecordCapabilities {
+			// nolint:all
+			capabilities, _ := CapabilitiesFromBytes(record.Value)
+			return capabilities
+		}
+	}
+	return 0
+}
+
+// WithCapabilities returns a copy of the address advertising the given
+// capabilities.
+func (a ArkAddress) WithCapabilities(capabilities Capabilities) ArkAddress {
+	records := make([]AddressRecord, 0, len(a.Records)+1)
+	added := false
+	for _, record := range a.Records {
+		if record.Type == AddressRecordCapabilities {
+			continue
+		}
+		if !added && record.Type > AddressRecordCapabilities {
+			records = append(records, AddressRecord{
+				AddressRecordCapabilities, capabilities.Bytes(),
+			})
+			added = true
+		}
+		records = append(records, record)
+	}
+	if !added {
+		records = append(records, AddressRecord{
+			AddressRecordCapabilities, capabilities.Bytes(),
+		})
+	}
+	a.Records = records
+	return a
+}
+
+// ValidateAddress returns an error if the given string is not a valid ark
+// address.
+func ValidateAddress(addr string) error {
+	_, err := DecodeArkAddress(addr)
+	return err
+}
+
+func EncodeAddress(
+	hrp string, userKey, aspKey *secp256k1.PublicKey,
+) (addr string, err error) {
+	return ArkAddress{
+		HRP:     hrp,
+		Version: AddressVersion0,
+		AspKey:  aspKey,
+		UserKey: userKey,
+	}.Encode()
 }
 
 func DecodeAddress(
 	addr string,
 ) (hrp string, userKey *secp256k1.PublicKey, aspKey *secp256k1.PublicKey, err error) {
-	prefix, buf, err := bech32.DecodeNoLimit(addr)
+	arkAddr, err := DecodeArkAddress(addr)
 	if err != nil {
 		return
 	}
-	if prefix != Liquid.Addr && prefix != TestNet.Addr {
-		err = fmt.Errorf("invalid prefix")
-		return
+	hrp = arkAddr.HRP
+	userKey = arkAddr.UserKey
+	aspKey = arkAddr.AspKey
+	return
+}
+
+func decodeAddressRecords(buf []byte) ([]AddressRecord, error) {
+	records := make([]AddressRecord, 0)
+	for len(buf) > 0 {
+		if len(buf) < 2 {
+			return nil, fmt.Errorf("invalid address record")
+		}
+		recordType, length := buf[0], int(buf[1])
+		if len(buf) < 2+length {
+			return nil, fmt.Errorf("invalid address record %d length", recordType)
+		}
+		if len(records) > 0 && recordType <= records[len(records)-1].Type {
+			return nil, fmt.Errorf("address records must be sorted by type")
+		}
+		if recordType%2 == 0 {
+			return nil, fmt.Errorf("unknown mandatory address record %d", recordType)
+		}
+		if recordType == AddressRecordCapabilities {
+			if _, err := CapabilitiesFromBytes(buf[2 : 2+length]); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, AddressRecord{
+			Type:  recordType,
+			Value: buf[2 : 2+length],
+		})
+		buf = buf[2+length:]
 	}
-	grp, err := bech32.ConvertBits(buf, 5, 8, false)
+	return records, nil
+}
+
+// decodeBech32NoLimit is like bech32.DecodeNoLimit but also returns the
+// variant of the checksum.
+func decodeBech32NoLimit(addr string) (string, []byte, bech32.Version, error) {
+	// bech32.DecodeGeneric enforces the 90 chars limit of segwit addresses,
+	// therefore the checksum variant is found by re-encoding the data.
+	hrp, data, err := bech32.DecodeNoLimit(addr)
 	if err != nil {
-		return
+		return "", nil, bech32.VersionUnknown, err
 	}
-	aKey, err := secp256k1.ParsePubKey(grp[:33])
+	encodedM, err := bech32.EncodeM(hrp, data)
 	if err != nil {
-		err = fmt.Errorf("failed to parse public key: %s", err)
-		return
+		return "", nil, bech32.VersionUnknown, err
 	}
-	uKey, err := secp256k1.ParsePubKey(grp[33:])
-	if err != nil {
-		err = fmt.Errorf("failed to parse asp public key: %s", err)
-		return
+	if encodedM == strings.ToLower(addr) {
+		return hrp, data, bech32.VersionM, nil
 	}
-	hrp = prefix
-	userKey = uKey
-	aspKey = aKey
-	return
+	return hrp, data, bech32.Version0, nil
+}
+
+func isValidHRP(hrp string) bool {
+	for _, net := range []Network{Liquid, TestNet, RegTest} {
+		if hrp == net.Addr {
+			return true
+		}
+	}
+	return false
 }
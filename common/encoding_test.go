@@ -29,6 +29,10 @@ func TestAddressEncoding(t *testing.T) {
 				ExpectedUserKey string `json:"expectedUserKey"`
 				ExpectedAspKey  string `json:"expectedAspKey"`
 			} `json:"valid"`
+			Legacy []struct {
+				Addr         string `json:"addr"`
+				ExpectedAddr string `json:"expectedAddr"`
+			} `json:"legacy"`
 			Invalid []struct {
 				Addr          string `json:"addr"`
 				ExpectedError string `json:"expectedError"`
@@ -52,12 +56,27 @@ func TestAddressEncoding(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, f.ExpectedAspKey, hex.EncodeToString(aspKey.SerializeCompressed()))
 
-			addr, err := common.EncodeAddress(hrp, userKey, aspKey)
+			arkAddr, err := common.DecodeArkAddress(f.Addr)
+			require.NoError(t, err)
+			require.Equal(t, common.AddressVersion0, arkAddr.Version)
+
+			addr, err := arkAddr.Encode()
 			require.NoError(t, err)
 			require.Equal(t, f.Addr, addr)
 		}
 	})
 
+	t.Run("legacy", func(t *testing.T) {
+		for _, f := range fixtures.Address.Legacy {
+			hrp, userKey, aspKey, err := common.DecodeAddress(f.Addr)
+			require.NoError(t, err)
+
+			addr, err := common.EncodeAddress(hrp, userKey, aspKey)
+			require.NoError(t, err)
+			require.Equal(t, f.ExpectedAddr, addr)
+		}
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		for _, f := range fixtures.Address.Invalid {
 			hrp, userKey, aspKey, err := common.DecodeAddress(f.Addr)
@@ -67,4 +86,27 @@ func TestAddressEncoding(t *testing.T) {
 			require.Nil(t, aspKey)
 		}
 	})
+
+	t.Run("capabilities", func(t *testing.T) {
+		arkAddr, err := common.DecodeArkAddress(fixtures.Address.Valid[0].Addr)
+		require.NoError(t, err)
+		require.Zero(t, arkAddr.Capabilities())
+
+		capabilities := common.Capabilities(0).
+			Set(common.CapabilityCovenantTree, true).
+			Set(common.CapabilityAsyncPayments, false)
+		addr, err := arkAddr.WithCapabilities(capabilities).Encode()
+		require.NoError(t, err)
+
+		arkAddr, err = common.DecodeArkAddress(addr)
+		require.NoError(t, err)
+		require.Equal(t, capabilities, arkAddr.Capabilities())
+
+		supported := common.Capabilities(0).Set(common.CapabilityCovenantTree, false)
+		require.Empty(t, capabilities.Missing(supported))
+		require.Equal(
+			t, []common.Capability{common.CapabilityCovenantTree},
+			capabilities.Missing(common.Capabilities(0)),
+		)
+	})
 }
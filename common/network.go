@@ -17,5 +17,5 @@ var TestNet = Network{
 
 var RegTest = Network{
 	Name: "regtest",
-	Addr: TestNet.Addr,
+	Addr: "rark",
 }
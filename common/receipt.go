@@ -0,0 +1,168 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/network"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/taproot"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+// receiptTag domain-separates the hashes of the receipts.
+const receiptTag = "ark/receipt"
+
+// Receipt is the proof, signed by the ASP, that a vtxo owned by Pubkey has
+// been created by the round of PoolTxid.
+type Receipt struct {
+	PoolTxid  string `json:"pool_txid"`
+	VtxoTxid  string `json:"vtxo_txid"`
+	Vout      uint32 `json:"vout"`
+	Amount    uint64 `json:"amount"`
+	Pubkey    string `json:"pubkey"`
+	AspPubkey string `json:"asp_pubkey"`
+	CreatedAt int64  `json:"created_at"`
+	// Signature is the hex schnorr signature of the ASP of the input of the
+	// proof pset of the receipt.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Hash returns the tagged hash of the receipt without the signature.
+func (r Receipt) Hash() ([]byte, error) {
+	r.Signature = ""
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.TaggedHash([]byte(receiptTag), buf).CloneBytes(), nil
+}
+
+// ProofPset returns the virtual tx whose only input is signed by the ASP to
+// sign the receipt. Like in BIP-322, it spends a fake outpoint committing to
+// the hash of the receipt with a leaf checking only the signature of the ASP,
+// so that receipts are signed like any taproot input.
+func (r Receipt) ProofPset(net network.Network) (*psetv2.Pset, error) {
+	aspPubkey, err := r.aspPubkey()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := r.Hash()
+	if err != nil {
+		return nil, err
+	}
+	prevoutHash, err := chainhash.NewHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	leafScript, err := txscript.NewScriptBuilder().AddData(
+		schnorr.SerializePubKey(aspPubkey),
+	).AddOp(txscript.OP_CHECKSIG).Script()
+	if err != nil {
+		return nil, err
+	}
+	leaf := taproot.NewBaseTapElementsLeaf(leafScript)
+	tapTree := taproot.AssembleTaprootScriptTree(leaf)
+	root := tapTree.RootNode.TapHash()
+	outputKey := taproot.ComputeTaprootOutputKey(aspPubkey, root[:])
+	script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_1).AddData(
+		schnorr.SerializePubKey(outputKey),
+	).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := elementsutil.AssetHashToBytes(net.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := elementsutil.ValueToBytes(0)
+	if err != nil {
+		return nil, err
+	}
+
+	ptx, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	updater, err := psetv2.NewUpdater(ptx)
+	if err != nil {
+		return nil, err
+	}
+	if err := updater.AddInputs([]psetv2.InputArgs{
+		{Txid: prevoutHash.String(), TxIndex: 0},
+	}); err != nil {
+		return nil, err
+	}
+	if err := updater.AddInWitnessUtxo(
+		0, transaction.NewTxOutput(asset, value, script),
+	); err != nil {
+		return nil, err
+	}
+	proof := tapTree.LeafMerkleProofs[0]
+	if err := updater.AddInTapLeafScript(0, psetv2.TapLeafScript{
+		TapElementsLeaf: leaf,
+		ControlBlock:    proof.ToControlBlock(aspPubkey),
+	}); err != nil {
+		return nil, err
+	}
+	if err := updater.AddOutputs([]psetv2.OutputArgs{
+		{Asset: net.AssetID, Amount: 0, Script: []byte{txscript.OP_RETURN}},
+	}); err != nil {
+		return nil, err
+	}
+	return updater.Pset, nil
+}
+
+// Verify checks that the receipt is signed by its ASP.
+func (r Receipt) Verify(net network.Network) error {
+	aspPubkey, err := r.aspPubkey()
+	if err != nil {
+		return err
+	}
+	buf, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid receipt signature: %s", err)
+	}
+	sig, err := schnorr.ParseSignature(buf)
+	if err != nil {
+		return fmt.Errorf("invalid receipt signature: %s", err)
+	}
+
+	proof, err := r.ProofPset(net)
+	if err != nil {
+		return err
+	}
+	genesis, err := chainhash.NewHashFromStr(net.GenesisBlockHash)
+	if err != nil {
+		return err
+	}
+	leafHash := proof.Inputs[0].TapLeafScript[0].TapHash()
+	preimage, err := TaprootPreimage(genesis, proof, 0, &leafHash)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(preimage, aspPubkey) {
+		return fmt.Errorf("invalid receipt signature")
+	}
+	return nil
+}
+
+func (r Receipt) aspPubkey() (*secp256k1.PublicKey, error) {
+	buf, err := hex.DecodeString(r.AspPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt asp pubkey: %s", err)
+	}
+	key, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt asp pubkey: %s", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,57 @@
+package common_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	common "github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/vulpemventures/go-elements/network"
+)
+
+func TestReceipt(t *testing.T) {
+	aspKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	userKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	receipt := common.Receipt{
+		PoolTxid:  "2a4d5ba3e5d1bd1c4b8f1c8d6a6c2c3f0a2e7b2a0a8c1d1e8f0a1b2c3d4e5f60",
+		VtxoTxid:  "8b3f2a1e0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a",
+		Vout:      0,
+		Amount:    10000,
+		Pubkey:    hex.EncodeToString(userKey.PubKey().SerializeCompressed()),
+		AspPubkey: hex.EncodeToString(aspKey.PubKey().SerializeCompressed()),
+		CreatedAt: 1700000000,
+	}
+
+	proof, err := receipt.ProofPset(network.Regtest)
+	require.NoError(t, err)
+
+	genesis, err := chainhash.NewHashFromStr(network.Regtest.GenesisBlockHash)
+	require.NoError(t, err)
+	leafHash := proof.Inputs[0].TapLeafScript[0].TapHash()
+	preimage, err := common.TaprootPreimage(genesis, proof, 0, &leafHash)
+	require.NoError(t, err)
+	sig, err := schnorr.Sign(aspKey, preimage)
+	require.NoError(t, err)
+	receipt.Signature = hex.EncodeToString(sig.Serialize())
+
+	require.NoError(t, receipt.Verify(network.Regtest))
+
+	t.Run("invalid", func(t *testing.T) {
+		tampered := receipt
+		tampered.Amount++
+		require.Error(t, tampered.Verify(network.Regtest))
+
+		otherNetwork := receipt
+		require.Error(t, otherNetwork.Verify(network.Liquid))
+
+		otherAsp := receipt
+		otherAsp.AspPubkey = receipt.Pubkey
+		require.Error(t, otherAsp.Verify(network.Regtest))
+	})
+}
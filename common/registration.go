@@ -0,0 +1,23 @@
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// registrationTag domain-separates the hashes signed to register payments.
+const registrationTag = "ark/registration"
+
+// RegistrationHash returns the tagged hash signed by the owners of the inputs
+// of a payment to register it, proving they own them. It commits to the id
+// given by the client to the payment and to its inputs, as txid:vout.
+func RegistrationHash(clientPaymentId string, inputs []string) []byte {
+	// the marshaling of strings can't fail.
+	// nolint
+	buf, _ := json.Marshal(struct {
+		ClientPaymentId string   `json:"client_payment_id"`
+		Inputs          []string `json:"inputs"`
+	}{clientPaymentId, inputs})
+	return chainhash.TaggedHash([]byte(registrationTag), buf).CloneBytes()
+}
@@ -0,0 +1,30 @@
+package common_test
+
+import (
+	"testing"
+
+	common "github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationHash(t *testing.T) {
+	inputs := []string{
+		"2a4d5ba3e5d1bd1c4b8f1c8d6a6c2c3f0a2e7b2a0a8c1d1e8f0a1b2c3d4e5f60:0",
+		"8b3f2a1e0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a:1",
+	}
+
+	hash := common.RegistrationHash("id", inputs)
+	require.Len(t, hash, 32)
+	require.Equal(t, hash, common.RegistrationHash("id", inputs))
+	require.NotEqual(t, hash, common.RegistrationHash("other", inputs))
+	require.NotEqual(t, hash, common.RegistrationHash("id", inputs[:1]))
+
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	sig, err := schnorr.Sign(key, hash)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(hash, key.PubKey()))
+	require.False(t, sig.Verify(common.RegistrationHash("other", inputs), key.PubKey()))
+}
@@ -0,0 +1,8 @@
+package openapi
+
+import "embed"
+
+// Swagger contains the OpenAPI documents generated from the ark protos.
+//
+//go:embed swagger/ark/v1/*.swagger.json
+var Swagger embed.FS
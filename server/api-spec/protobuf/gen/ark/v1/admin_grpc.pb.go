@@ -22,6 +22,17 @@ type AdminServiceClient interface {
 	GetScheduledSweep(ctx context.Context, in *GetScheduledSweepRequest, opts ...grpc.CallOption) (*GetScheduledSweepResponse, error)
 	GetRoundDetails(ctx context.Context, in *GetRoundDetailsRequest, opts ...grpc.CallOption) (*GetRoundDetailsResponse, error)
 	GetRounds(ctx context.Context, in *GetRoundsRequest, opts ...grpc.CallOption) (*GetRoundsResponse, error)
+	GetRoundStats(ctx context.Context, in *GetRoundStatsRequest, opts ...grpc.CallOption) (*GetRoundStatsResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error)
+	GetVtxoSet(ctx context.Context, in *GetVtxoSetRequest, opts ...grpc.CallOption) (*GetVtxoSetResponse, error)
+	TriggerRound(ctx context.Context, in *TriggerRoundRequest, opts ...grpc.CallOption) (*TriggerRoundResponse, error)
+	PauseRegistrations(ctx context.Context, in *PauseRegistrationsRequest, opts ...grpc.CallOption) (*PauseRegistrationsResponse, error)
+	ResumeRegistrations(ctx context.Context, in *ResumeRegistrationsRequest, opts ...grpc.CallOption) (*ResumeRegistrationsResponse, error)
+	GetRoundPolicy(ctx context.Context, in *GetRoundPolicyRequest, opts ...grpc.CallOption) (*GetRoundPolicyResponse, error)
+	UpdateRoundPolicy(ctx context.Context, in *UpdateRoundPolicyRequest, opts ...grpc.CallOption) (*UpdateRoundPolicyResponse, error)
+	GetWalletAddress(ctx context.Context, in *GetWalletAddressRequest, opts ...grpc.CallOption) (*GetWalletAddressResponse, error)
+	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
 }
 
 type adminServiceClient struct {
@@ -68,6 +79,105 @@ func (c *adminServiceClient) GetRounds(ctx context.Context, in *GetRoundsRequest
 	return out, nil
 }
 
+func (c *adminServiceClient) GetRoundStats(ctx context.Context, in *GetRoundStatsRequest, opts ...grpc.CallOption) (*GetRoundStatsResponse, error) {
+	out := new(GetRoundStatsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetRoundStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error) {
+	out := new(GetLiquidityResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetLiquidity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetVtxoSet(ctx context.Context, in *GetVtxoSetRequest, opts ...grpc.CallOption) (*GetVtxoSetResponse, error) {
+	out := new(GetVtxoSetResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetVtxoSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TriggerRound(ctx context.Context, in *TriggerRoundRequest, opts ...grpc.CallOption) (*TriggerRoundResponse, error) {
+	out := new(TriggerRoundResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/TriggerRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PauseRegistrations(ctx context.Context, in *PauseRegistrationsRequest, opts ...grpc.CallOption) (*PauseRegistrationsResponse, error) {
+	out := new(PauseRegistrationsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/PauseRegistrations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeRegistrations(ctx context.Context, in *ResumeRegistrationsRequest, opts ...grpc.CallOption) (*ResumeRegistrationsResponse, error) {
+	out := new(ResumeRegistrationsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/ResumeRegistrations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetRoundPolicy(ctx context.Context, in *GetRoundPolicyRequest, opts ...grpc.CallOption) (*GetRoundPolicyResponse, error) {
+	out := new(GetRoundPolicyResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetRoundPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateRoundPolicy(ctx context.Context, in *UpdateRoundPolicyRequest, opts ...grpc.CallOption) (*UpdateRoundPolicyResponse, error) {
+	out := new(UpdateRoundPolicyResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/UpdateRoundPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetWalletAddress(ctx context.Context, in *GetWalletAddressRequest, opts ...grpc.CallOption) (*GetWalletAddressResponse, error) {
+	out := new(GetWalletAddressResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetWalletAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error) {
+	out := new(WithdrawResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/Withdraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations should embed UnimplementedAdminServiceServer
 // for forward compatibility
@@ -76,6 +186,17 @@ type AdminServiceServer interface {
 	GetScheduledSweep(context.Context, *GetScheduledSweepRequest) (*GetScheduledSweepResponse, error)
 	GetRoundDetails(context.Context, *GetRoundDetailsRequest) (*GetRoundDetailsResponse, error)
 	GetRounds(context.Context, *GetRoundsRequest) (*GetRoundsResponse, error)
+	GetRoundStats(context.Context, *GetRoundStatsRequest) (*GetRoundStatsResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error)
+	GetVtxoSet(context.Context, *GetVtxoSetRequest) (*GetVtxoSetResponse, error)
+	TriggerRound(context.Context, *TriggerRoundRequest) (*TriggerRoundResponse, error)
+	PauseRegistrations(context.Context, *PauseRegistrationsRequest) (*PauseRegistrationsResponse, error)
+	ResumeRegistrations(context.Context, *ResumeRegistrationsRequest) (*ResumeRegistrationsResponse, error)
+	GetRoundPolicy(context.Context, *GetRoundPolicyRequest) (*GetRoundPolicyResponse, error)
+	UpdateRoundPolicy(context.Context, *UpdateRoundPolicyRequest) (*UpdateRoundPolicyResponse, error)
+	GetWalletAddress(context.Context, *GetWalletAddressRequest) (*GetWalletAddressResponse, error)
+	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
 }
 
 // UnimplementedAdminServiceServer should be embedded to have forward compatible implementations.
@@ -94,6 +215,39 @@ func (UnimplementedAdminServiceServer) GetRoundDetails(context.Context, *GetRoun
 func (UnimplementedAdminServiceServer) GetRounds(context.Context, *GetRoundsRequest) (*GetRoundsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRounds not implemented")
 }
+func (UnimplementedAdminServiceServer) GetRoundStats(context.Context, *GetRoundStatsRequest) (*GetRoundStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoundStats not implemented")
+}
+func (UnimplementedAdminServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidity not implemented")
+}
+func (UnimplementedAdminServiceServer) GetVtxoSet(context.Context, *GetVtxoSetRequest) (*GetVtxoSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVtxoSet not implemented")
+}
+func (UnimplementedAdminServiceServer) TriggerRound(context.Context, *TriggerRoundRequest) (*TriggerRoundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerRound not implemented")
+}
+func (UnimplementedAdminServiceServer) PauseRegistrations(context.Context, *PauseRegistrationsRequest) (*PauseRegistrationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseRegistrations not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeRegistrations(context.Context, *ResumeRegistrationsRequest) (*ResumeRegistrationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeRegistrations not implemented")
+}
+func (UnimplementedAdminServiceServer) GetRoundPolicy(context.Context, *GetRoundPolicyRequest) (*GetRoundPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoundPolicy not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateRoundPolicy(context.Context, *UpdateRoundPolicyRequest) (*UpdateRoundPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoundPolicy not implemented")
+}
+func (UnimplementedAdminServiceServer) GetWalletAddress(context.Context, *GetWalletAddressRequest) (*GetWalletAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletAddress not implemented")
+}
+func (UnimplementedAdminServiceServer) Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
+}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AdminServiceServer will
@@ -178,6 +332,204 @@ func _AdminService_GetRounds_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetRoundStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetRoundStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetRoundStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetRoundStats(ctx, req.(*GetRoundStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLiquidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiquidityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLiquidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetLiquidity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLiquidity(ctx, req.(*GetLiquidityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetVtxoSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVtxoSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetVtxoSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetVtxoSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetVtxoSet(ctx, req.(*GetVtxoSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TriggerRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TriggerRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/TriggerRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TriggerRound(ctx, req.(*TriggerRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PauseRegistrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRegistrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseRegistrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/PauseRegistrations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseRegistrations(ctx, req.(*PauseRegistrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeRegistrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRegistrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeRegistrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/ResumeRegistrations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeRegistrations(ctx, req.(*ResumeRegistrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetRoundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetRoundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetRoundPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetRoundPolicy(ctx, req.(*GetRoundPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateRoundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoundPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateRoundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/UpdateRoundPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateRoundPolicy(ctx, req.(*UpdateRoundPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetWalletAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetWalletAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetWalletAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetWalletAddress(ctx, req.(*GetWalletAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/Withdraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Withdraw(ctx, req.(*WithdrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +553,50 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRounds",
 			Handler:    _AdminService_GetRounds_Handler,
 		},
+		{
+			MethodName: "GetRoundStats",
+			Handler:    _AdminService_GetRoundStats_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _AdminService_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetLiquidity",
+			Handler:    _AdminService_GetLiquidity_Handler,
+		},
+		{
+			MethodName: "GetVtxoSet",
+			Handler:    _AdminService_GetVtxoSet_Handler,
+		},
+		{
+			MethodName: "TriggerRound",
+			Handler:    _AdminService_TriggerRound_Handler,
+		},
+		{
+			MethodName: "PauseRegistrations",
+			Handler:    _AdminService_PauseRegistrations_Handler,
+		},
+		{
+			MethodName: "ResumeRegistrations",
+			Handler:    _AdminService_ResumeRegistrations_Handler,
+		},
+		{
+			MethodName: "GetRoundPolicy",
+			Handler:    _AdminService_GetRoundPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateRoundPolicy",
+			Handler:    _AdminService_UpdateRoundPolicy_Handler,
+		},
+		{
+			MethodName: "GetWalletAddress",
+			Handler:    _AdminService_GetWalletAddress_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _AdminService_Withdraw_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ark/v1/admin.proto",
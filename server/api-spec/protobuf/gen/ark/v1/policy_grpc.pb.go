@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package arkv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PolicyServiceClient is the client API for PolicyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PolicyServiceClient interface {
+	// AcceptRegistration is called when a payment is registered, with the
+	// vtxos it spends.
+	AcceptRegistration(ctx context.Context, in *AcceptRegistrationRequest, opts ...grpc.CallOption) (*AcceptRegistrationResponse, error)
+	// AcceptClaim is called when a payment is claimed, with its receivers.
+	AcceptClaim(ctx context.Context, in *AcceptClaimRequest, opts ...grpc.CallOption) (*AcceptClaimResponse, error)
+}
+
+type policyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPolicyServiceClient(cc grpc.ClientConnInterface) PolicyServiceClient {
+	return &policyServiceClient{cc}
+}
+
+func (c *policyServiceClient) AcceptRegistration(ctx context.Context, in *AcceptRegistrationRequest, opts ...grpc.CallOption) (*AcceptRegistrationResponse, error) {
+	out := new(AcceptRegistrationResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.PolicyService/AcceptRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyServiceClient) AcceptClaim(ctx context.Context, in *AcceptClaimRequest, opts ...grpc.CallOption) (*AcceptClaimResponse, error) {
+	out := new(AcceptClaimResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.PolicyService/AcceptClaim", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PolicyServiceServer is the server API for PolicyService service.
+// All implementations should embed UnimplementedPolicyServiceServer
+// for forward compatibility
+type PolicyServiceServer interface {
+	// AcceptRegistration is called when a payment is registered, with the
+	// vtxos it spends.
+	AcceptRegistration(context.Context, *AcceptRegistrationRequest) (*AcceptRegistrationResponse, error)
+	// AcceptClaim is called when a payment is claimed, with its receivers.
+	AcceptClaim(context.Context, *AcceptClaimRequest) (*AcceptClaimResponse, error)
+}
+
+// UnimplementedPolicyServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedPolicyServiceServer struct {
+}
+
+func (UnimplementedPolicyServiceServer) AcceptRegistration(context.Context, *AcceptRegistrationRequest) (*AcceptRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptRegistration not implemented")
+}
+func (UnimplementedPolicyServiceServer) AcceptClaim(context.Context, *AcceptClaimRequest) (*AcceptClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptClaim not implemented")
+}
+
+// UnsafePolicyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PolicyServiceServer will
+// result in compilation errors.
+type UnsafePolicyServiceServer interface {
+	mustEmbedUnimplementedPolicyServiceServer()
+}
+
+func RegisterPolicyServiceServer(s grpc.ServiceRegistrar, srv PolicyServiceServer) {
+	s.RegisterService(&PolicyService_ServiceDesc, srv)
+}
+
+func _PolicyService_AcceptRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).AcceptRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.PolicyService/AcceptRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).AcceptRegistration(ctx, req.(*AcceptRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyService_AcceptClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).AcceptClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.PolicyService/AcceptClaim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).AcceptClaim(ctx, req.(*AcceptClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PolicyService_ServiceDesc is the grpc.ServiceDesc for PolicyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PolicyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ark.v1.PolicyService",
+	HandlerType: (*PolicyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AcceptRegistration",
+			Handler:    _PolicyService_AcceptRegistration_Handler,
+		},
+		{
+			MethodName: "AcceptClaim",
+			Handler:    _PolicyService_AcceptClaim_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ark/v1/policy.proto",
+}
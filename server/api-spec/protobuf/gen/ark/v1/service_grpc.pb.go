@@ -21,14 +21,30 @@ type ArkServiceClient interface {
 	RegisterPayment(ctx context.Context, in *RegisterPaymentRequest, opts ...grpc.CallOption) (*RegisterPaymentResponse, error)
 	ClaimPayment(ctx context.Context, in *ClaimPaymentRequest, opts ...grpc.CallOption) (*ClaimPaymentResponse, error)
 	FinalizePayment(ctx context.Context, in *FinalizePaymentRequest, opts ...grpc.CallOption) (*FinalizePaymentResponse, error)
-	// TODO BTC: signTree rpc
+	// Out-of-round payments, the receivers claim the new vtxos in a round.
+	CreateAsyncPayment(ctx context.Context, in *CreateAsyncPaymentRequest, opts ...grpc.CallOption) (*CreateAsyncPaymentResponse, error)
+	CompleteAsyncPayment(ctx context.Context, in *CompleteAsyncPaymentRequest, opts ...grpc.CallOption) (*CompleteAsyncPaymentResponse, error)
+	SendTreeNonces(ctx context.Context, in *SendTreeNoncesRequest, opts ...grpc.CallOption) (*SendTreeNoncesResponse, error)
+	SendTreeSignatures(ctx context.Context, in *SendTreeSignaturesRequest, opts ...grpc.CallOption) (*SendTreeSignaturesResponse, error)
+	SendForfeitNonces(ctx context.Context, in *SendForfeitNoncesRequest, opts ...grpc.CallOption) (*SendForfeitNoncesResponse, error)
+	SendForfeitSignatures(ctx context.Context, in *SendForfeitSignaturesRequest, opts ...grpc.CallOption) (*SendForfeitSignaturesResponse, error)
 	GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*GetRoundResponse, error)
+	GetRoundById(ctx context.Context, in *GetRoundByIdRequest, opts ...grpc.CallOption) (*GetRoundByIdResponse, error)
 	GetEventStream(ctx context.Context, in *GetEventStreamRequest, opts ...grpc.CallOption) (ArkService_GetEventStreamClient, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	ListVtxos(ctx context.Context, in *ListVtxosRequest, opts ...grpc.CallOption) (*ListVtxosResponse, error)
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
 	Onboard(ctx context.Context, in *OnboardRequest, opts ...grpc.CallOption) (*OnboardResponse, error)
+	// Adds to the boarding tx the coins of the ASP paying its onchain fees and
+	// those of the congestion tree, for onboardings below the sponsorship
+	// threshold.
+	SponsorOnboarding(ctx context.Context, in *SponsorOnboardingRequest, opts ...grpc.CallOption) (*SponsorOnboardingResponse, error)
 	TrustedOnboarding(ctx context.Context, in *TrustedOnboardingRequest, opts ...grpc.CallOption) (*TrustedOnboardingResponse, error)
+	// Blind mailbox used by devices sharing the same wallet to exchange
+	// encrypted state updates.
+	PushSyncMessage(ctx context.Context, in *PushSyncMessageRequest, opts ...grpc.CallOption) (*PushSyncMessageResponse, error)
+	GetSyncMessages(ctx context.Context, in *GetSyncMessagesRequest, opts ...grpc.CallOption) (*GetSyncMessagesResponse, error)
+	GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error)
 }
 
 type arkServiceClient struct {
@@ -66,6 +82,60 @@ func (c *arkServiceClient) FinalizePayment(ctx context.Context, in *FinalizePaym
 	return out, nil
 }
 
+func (c *arkServiceClient) CreateAsyncPayment(ctx context.Context, in *CreateAsyncPaymentRequest, opts ...grpc.CallOption) (*CreateAsyncPaymentResponse, error) {
+	out := new(CreateAsyncPaymentResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/CreateAsyncPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) CompleteAsyncPayment(ctx context.Context, in *CompleteAsyncPaymentRequest, opts ...grpc.CallOption) (*CompleteAsyncPaymentResponse, error) {
+	out := new(CompleteAsyncPaymentResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/CompleteAsyncPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) SendTreeNonces(ctx context.Context, in *SendTreeNoncesRequest, opts ...grpc.CallOption) (*SendTreeNoncesResponse, error) {
+	out := new(SendTreeNoncesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SendTreeNonces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) SendTreeSignatures(ctx context.Context, in *SendTreeSignaturesRequest, opts ...grpc.CallOption) (*SendTreeSignaturesResponse, error) {
+	out := new(SendTreeSignaturesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SendTreeSignatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) SendForfeitNonces(ctx context.Context, in *SendForfeitNoncesRequest, opts ...grpc.CallOption) (*SendForfeitNoncesResponse, error) {
+	out := new(SendForfeitNoncesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SendForfeitNonces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) SendForfeitSignatures(ctx context.Context, in *SendForfeitSignaturesRequest, opts ...grpc.CallOption) (*SendForfeitSignaturesResponse, error) {
+	out := new(SendForfeitSignaturesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SendForfeitSignatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arkServiceClient) GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*GetRoundResponse, error) {
 	out := new(GetRoundResponse)
 	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/GetRound", in, out, opts...)
@@ -75,6 +145,15 @@ func (c *arkServiceClient) GetRound(ctx context.Context, in *GetRoundRequest, op
 	return out, nil
 }
 
+func (c *arkServiceClient) GetRoundById(ctx context.Context, in *GetRoundByIdRequest, opts ...grpc.CallOption) (*GetRoundByIdResponse, error) {
+	out := new(GetRoundByIdResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/GetRoundById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arkServiceClient) GetEventStream(ctx context.Context, in *GetEventStreamRequest, opts ...grpc.CallOption) (ArkService_GetEventStreamClient, error) {
 	stream, err := c.cc.NewStream(ctx, &ArkService_ServiceDesc.Streams[0], "/ark.v1.ArkService/GetEventStream", opts...)
 	if err != nil {
@@ -143,6 +222,15 @@ func (c *arkServiceClient) Onboard(ctx context.Context, in *OnboardRequest, opts
 	return out, nil
 }
 
+func (c *arkServiceClient) SponsorOnboarding(ctx context.Context, in *SponsorOnboardingRequest, opts ...grpc.CallOption) (*SponsorOnboardingResponse, error) {
+	out := new(SponsorOnboardingResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SponsorOnboarding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arkServiceClient) TrustedOnboarding(ctx context.Context, in *TrustedOnboardingRequest, opts ...grpc.CallOption) (*TrustedOnboardingResponse, error) {
 	out := new(TrustedOnboardingResponse)
 	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/TrustedOnboarding", in, out, opts...)
@@ -152,6 +240,33 @@ func (c *arkServiceClient) TrustedOnboarding(ctx context.Context, in *TrustedOnb
 	return out, nil
 }
 
+func (c *arkServiceClient) PushSyncMessage(ctx context.Context, in *PushSyncMessageRequest, opts ...grpc.CallOption) (*PushSyncMessageResponse, error) {
+	out := new(PushSyncMessageResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/PushSyncMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) GetSyncMessages(ctx context.Context, in *GetSyncMessagesRequest, opts ...grpc.CallOption) (*GetSyncMessagesResponse, error) {
+	out := new(GetSyncMessagesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/GetSyncMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error) {
+	out := new(GetReceiptResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/GetReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ArkServiceServer is the server API for ArkService service.
 // All implementations should embed UnimplementedArkServiceServer
 // for forward compatibility
@@ -159,14 +274,30 @@ type ArkServiceServer interface {
 	RegisterPayment(context.Context, *RegisterPaymentRequest) (*RegisterPaymentResponse, error)
 	ClaimPayment(context.Context, *ClaimPaymentRequest) (*ClaimPaymentResponse, error)
 	FinalizePayment(context.Context, *FinalizePaymentRequest) (*FinalizePaymentResponse, error)
-	// TODO BTC: signTree rpc
+	// Out-of-round payments, the receivers claim the new vtxos in a round.
+	CreateAsyncPayment(context.Context, *CreateAsyncPaymentRequest) (*CreateAsyncPaymentResponse, error)
+	CompleteAsyncPayment(context.Context, *CompleteAsyncPaymentRequest) (*CompleteAsyncPaymentResponse, error)
+	SendTreeNonces(context.Context, *SendTreeNoncesRequest) (*SendTreeNoncesResponse, error)
+	SendTreeSignatures(context.Context, *SendTreeSignaturesRequest) (*SendTreeSignaturesResponse, error)
+	SendForfeitNonces(context.Context, *SendForfeitNoncesRequest) (*SendForfeitNoncesResponse, error)
+	SendForfeitSignatures(context.Context, *SendForfeitSignaturesRequest) (*SendForfeitSignaturesResponse, error)
 	GetRound(context.Context, *GetRoundRequest) (*GetRoundResponse, error)
+	GetRoundById(context.Context, *GetRoundByIdRequest) (*GetRoundByIdResponse, error)
 	GetEventStream(*GetEventStreamRequest, ArkService_GetEventStreamServer) error
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	ListVtxos(context.Context, *ListVtxosRequest) (*ListVtxosResponse, error)
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
 	Onboard(context.Context, *OnboardRequest) (*OnboardResponse, error)
+	// Adds to the boarding tx the coins of the ASP paying its onchain fees and
+	// those of the congestion tree, for onboardings below the sponsorship
+	// threshold.
+	SponsorOnboarding(context.Context, *SponsorOnboardingRequest) (*SponsorOnboardingResponse, error)
 	TrustedOnboarding(context.Context, *TrustedOnboardingRequest) (*TrustedOnboardingResponse, error)
+	// Blind mailbox used by devices sharing the same wallet to exchange
+	// encrypted state updates.
+	PushSyncMessage(context.Context, *PushSyncMessageRequest) (*PushSyncMessageResponse, error)
+	GetSyncMessages(context.Context, *GetSyncMessagesRequest) (*GetSyncMessagesResponse, error)
+	GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error)
 }
 
 // UnimplementedArkServiceServer should be embedded to have forward compatible implementations.
@@ -182,9 +313,30 @@ func (UnimplementedArkServiceServer) ClaimPayment(context.Context, *ClaimPayment
 func (UnimplementedArkServiceServer) FinalizePayment(context.Context, *FinalizePaymentRequest) (*FinalizePaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinalizePayment not implemented")
 }
+func (UnimplementedArkServiceServer) CreateAsyncPayment(context.Context, *CreateAsyncPaymentRequest) (*CreateAsyncPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAsyncPayment not implemented")
+}
+func (UnimplementedArkServiceServer) CompleteAsyncPayment(context.Context, *CompleteAsyncPaymentRequest) (*CompleteAsyncPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteAsyncPayment not implemented")
+}
+func (UnimplementedArkServiceServer) SendTreeNonces(context.Context, *SendTreeNoncesRequest) (*SendTreeNoncesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTreeNonces not implemented")
+}
+func (UnimplementedArkServiceServer) SendTreeSignatures(context.Context, *SendTreeSignaturesRequest) (*SendTreeSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTreeSignatures not implemented")
+}
+func (UnimplementedArkServiceServer) SendForfeitNonces(context.Context, *SendForfeitNoncesRequest) (*SendForfeitNoncesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendForfeitNonces not implemented")
+}
+func (UnimplementedArkServiceServer) SendForfeitSignatures(context.Context, *SendForfeitSignaturesRequest) (*SendForfeitSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendForfeitSignatures not implemented")
+}
 func (UnimplementedArkServiceServer) GetRound(context.Context, *GetRoundRequest) (*GetRoundResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRound not implemented")
 }
+func (UnimplementedArkServiceServer) GetRoundById(context.Context, *GetRoundByIdRequest) (*GetRoundByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoundById not implemented")
+}
 func (UnimplementedArkServiceServer) GetEventStream(*GetEventStreamRequest, ArkService_GetEventStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetEventStream not implemented")
 }
@@ -200,9 +352,21 @@ func (UnimplementedArkServiceServer) GetInfo(context.Context, *GetInfoRequest) (
 func (UnimplementedArkServiceServer) Onboard(context.Context, *OnboardRequest) (*OnboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Onboard not implemented")
 }
+func (UnimplementedArkServiceServer) SponsorOnboarding(context.Context, *SponsorOnboardingRequest) (*SponsorOnboardingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SponsorOnboarding not implemented")
+}
 func (UnimplementedArkServiceServer) TrustedOnboarding(context.Context, *TrustedOnboardingRequest) (*TrustedOnboardingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TrustedOnboarding not implemented")
 }
+func (UnimplementedArkServiceServer) PushSyncMessage(context.Context, *PushSyncMessageRequest) (*PushSyncMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushSyncMessage not implemented")
+}
+func (UnimplementedArkServiceServer) GetSyncMessages(context.Context, *GetSyncMessagesRequest) (*GetSyncMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncMessages not implemented")
+}
+func (UnimplementedArkServiceServer) GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
 
 // UnsafeArkServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ArkServiceServer will
@@ -269,6 +433,114 @@ func _ArkService_FinalizePayment_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_CreateAsyncPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAsyncPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).CreateAsyncPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/CreateAsyncPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).CreateAsyncPayment(ctx, req.(*CreateAsyncPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_CompleteAsyncPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteAsyncPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).CompleteAsyncPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/CompleteAsyncPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).CompleteAsyncPayment(ctx, req.(*CompleteAsyncPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_SendTreeNonces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTreeNoncesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SendTreeNonces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SendTreeNonces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SendTreeNonces(ctx, req.(*SendTreeNoncesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_SendTreeSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTreeSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SendTreeSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SendTreeSignatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SendTreeSignatures(ctx, req.(*SendTreeSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_SendForfeitNonces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendForfeitNoncesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SendForfeitNonces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SendForfeitNonces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SendForfeitNonces(ctx, req.(*SendForfeitNoncesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_SendForfeitSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendForfeitSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SendForfeitSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SendForfeitSignatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SendForfeitSignatures(ctx, req.(*SendForfeitSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArkService_GetRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRoundRequest)
 	if err := dec(in); err != nil {
@@ -287,6 +559,24 @@ func _ArkService_GetRound_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_GetRoundById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).GetRoundById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/GetRoundById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).GetRoundById(ctx, req.(*GetRoundByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArkService_GetEventStream_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetEventStreamRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -380,6 +670,24 @@ func _ArkService_Onboard_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_SponsorOnboarding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SponsorOnboardingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SponsorOnboarding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SponsorOnboarding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SponsorOnboarding(ctx, req.(*SponsorOnboardingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArkService_TrustedOnboarding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TrustedOnboardingRequest)
 	if err := dec(in); err != nil {
@@ -398,6 +706,60 @@ func _ArkService_TrustedOnboarding_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_PushSyncMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushSyncMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).PushSyncMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/PushSyncMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).PushSyncMessage(ctx, req.(*PushSyncMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_GetSyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).GetSyncMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/GetSyncMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).GetSyncMessages(ctx, req.(*GetSyncMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/GetReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ArkService_ServiceDesc is the grpc.ServiceDesc for ArkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -417,10 +779,38 @@ var ArkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FinalizePayment",
 			Handler:    _ArkService_FinalizePayment_Handler,
 		},
+		{
+			MethodName: "CreateAsyncPayment",
+			Handler:    _ArkService_CreateAsyncPayment_Handler,
+		},
+		{
+			MethodName: "CompleteAsyncPayment",
+			Handler:    _ArkService_CompleteAsyncPayment_Handler,
+		},
+		{
+			MethodName: "SendTreeNonces",
+			Handler:    _ArkService_SendTreeNonces_Handler,
+		},
+		{
+			MethodName: "SendTreeSignatures",
+			Handler:    _ArkService_SendTreeSignatures_Handler,
+		},
+		{
+			MethodName: "SendForfeitNonces",
+			Handler:    _ArkService_SendForfeitNonces_Handler,
+		},
+		{
+			MethodName: "SendForfeitSignatures",
+			Handler:    _ArkService_SendForfeitSignatures_Handler,
+		},
 		{
 			MethodName: "GetRound",
 			Handler:    _ArkService_GetRound_Handler,
 		},
+		{
+			MethodName: "GetRoundById",
+			Handler:    _ArkService_GetRoundById_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _ArkService_Ping_Handler,
@@ -437,10 +827,26 @@ var ArkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Onboard",
 			Handler:    _ArkService_Onboard_Handler,
 		},
+		{
+			MethodName: "SponsorOnboarding",
+			Handler:    _ArkService_SponsorOnboarding_Handler,
+		},
 		{
 			MethodName: "TrustedOnboarding",
 			Handler:    _ArkService_TrustedOnboarding_Handler,
 		},
+		{
+			MethodName: "PushSyncMessage",
+			Handler:    _ArkService_PushSyncMessage_Handler,
+		},
+		{
+			MethodName: "GetSyncMessages",
+			Handler:    _ArkService_GetSyncMessages_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _ArkService_GetReceipt_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
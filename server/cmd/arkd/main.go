@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 
 	appconfig "github.com/ark-network/ark/internal/app-config"
 	"github.com/ark-network/ark/internal/config"
+	"github.com/ark-network/ark/internal/core/application"
+	"github.com/ark-network/ark/internal/infrastructure/telemetry"
 	grpcservice "github.com/ark-network/ark/internal/interface/grpc"
 	log "github.com/sirupsen/logrus"
 )
@@ -27,26 +30,70 @@ func main() {
 	log.SetLevel(log.Level(cfg.LogLevel))
 
 	svcConfig := grpcservice.Config{
-		Port:     cfg.Port,
-		NoTLS:    cfg.NoTLS,
-		AuthUser: cfg.AuthUser,
-		AuthPass: cfg.AuthPass,
+		Port:               cfg.Port,
+		NoTLS:              cfg.NoTLS,
+		AuthUser:           cfg.AuthUser,
+		AuthPass:           cfg.AuthPass,
+		GatewayPort:        cfg.GatewayPort,
+		CORSAllowedOrigins: cfg.CORSAllowedOrigins,
+
+		RegistrationRateLimit: cfg.RegistrationRateLimit,
+
+		TLSCertPath:     cfg.TLSCertPath,
+		TLSKeyPath:      cfg.TLSKeyPath,
+		TLSClientCAPath: cfg.TLSClientCAPath,
 	}
 
 	appConfig := &appconfig.Config{
-		EventDbType:           cfg.EventDbType,
-		DbType:                cfg.DbType,
-		DbDir:                 cfg.DbDir,
-		EventDbDir:            cfg.DbDir,
-		RoundInterval:         cfg.RoundInterval,
-		Network:               cfg.Network,
-		SchedulerType:         cfg.SchedulerType,
-		TxBuilderType:         cfg.TxBuilderType,
-		BlockchainScannerType: cfg.BlockchainScannerType,
-		WalletAddr:            cfg.WalletAddr,
-		MinRelayFee:           cfg.MinRelayFee,
-		RoundLifetime:         cfg.RoundLifetime,
-		UnilateralExitDelay:   cfg.UnilateralExitDelay,
+		EventDbType:             cfg.EventDbType,
+		DbType:                  cfg.DbType,
+		DbDir:                   cfg.DbDir,
+		DbURL:                   cfg.DbURL,
+		EventDbDir:              cfg.DbDir,
+		RoundInterval:           cfg.RoundInterval,
+		Network:                 cfg.Network,
+		SchedulerType:           cfg.SchedulerType,
+		TxBuilderType:           cfg.TxBuilderType,
+		BlockchainScannerType:   cfg.BlockchainScannerType,
+		WalletAddr:              cfg.WalletAddr,
+		WalletType:              cfg.WalletType,
+		ElementsRPCAddr:         cfg.ElementsRPCAddr,
+		ElementsRPCUser:         cfg.ElementsRPCUser,
+		ElementsRPCPass:         cfg.ElementsRPCPass,
+		ElementsRPCWallet:       cfg.ElementsRPCWallet,
+		MinRelayFee:             cfg.MinRelayFee,
+		RoundLifetime:           cfg.RoundLifetime,
+		UnilateralExitDelay:     cfg.UnilateralExitDelay,
+		ExitFee:                 cfg.ExitFee,
+		FeeSponsorshipThreshold: cfg.FeeSponsorshipThreshold,
+		FeePolicy: application.FeePolicy{
+			FlatFee:            cfg.PaymentFlatFee,
+			ProportionalFeePpm: cfg.PaymentProportionalFee,
+			OutputFee:          cfg.PaymentOutputFee,
+		},
+		RegistrationLimits: application.RegistrationLimits{
+			MaxQueuedPayments:    cfg.MaxQueuedPayments,
+			MaxPaymentsPerPubkey: cfg.MaxPaymentsPerPubkey,
+			MaxInputs:            cfg.MaxPaymentInputs,
+		},
+		RoundPolicy: application.RoundPolicy{
+			Adaptive:    cfg.RoundAdaptive,
+			MinInterval: cfg.RoundMinInterval,
+			MaxInterval: cfg.RoundMaxInterval,
+			MinSize:     cfg.RoundMinSize,
+			MaxSize:     cfg.RoundMaxSize,
+			HighFeeRate: cfg.RoundHighFeeRate,
+
+			MinPayments:          cfg.RoundMinPayments,
+			RegistrationDuration: cfg.RoundRegistrationDuration,
+		},
+		SweepConfig: application.SweepConfig{
+			BatchWindow: cfg.SweepBatchWindow,
+			MaxInputs:   cfg.SweepMaxInputs,
+			FeeRate:     cfg.SweepFeeRate,
+		},
+		MetricsAddr:      cfg.MetricsAddr,
+		PolicyPluginAddr: cfg.PolicyPluginAddr,
 	}
 	svc, err := grpcservice.NewService(svcConfig, appConfig)
 	if err != nil {
@@ -55,6 +102,22 @@ func main() {
 
 	log.RegisterExitHandler(svc.Stop)
 
+	if len(cfg.OtelCollectorEndpoint) > 0 {
+		shutdown, err := telemetry.InitOtelSDK(
+			context.Background(), cfg.OtelCollectorEndpoint, version,
+		)
+		if err != nil {
+			log.WithError(err).Fatal("failed to init tracing")
+		}
+		// the spans left are flushed once the service is stopped.
+		log.RegisterExitHandler(func() {
+			if err := shutdown(context.Background()); err != nil {
+				log.WithError(err).Warn("failed to flush traces")
+			}
+		})
+		log.Infof("exporting traces to %s", cfg.OtelCollectorEndpoint)
+	}
+
 	log.Info("starting service...")
 	if err := svc.Start(); err != nil {
 		log.Fatal(err)
@@ -64,6 +127,12 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 	<-sigChan
 
-	log.Info("shutting down service...")
+	log.Info("shutting down service, waiting for the current round to end...")
+	// a second signal skips waiting for the round.
+	go func() {
+		<-sigChan
+		log.Warn("forced shutdown")
+		os.Exit(1)
+	}()
 	log.Exit(0)
 }
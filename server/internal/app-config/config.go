@@ -8,7 +8,12 @@ import (
 	"github.com/ark-network/ark/internal/core/application"
 	"github.com/ark-network/ark/internal/core/ports"
 	"github.com/ark-network/ark/internal/infrastructure/db"
+	elementswallet "github.com/ark-network/ark/internal/infrastructure/elements-wallet"
+	noopmetrics "github.com/ark-network/ark/internal/infrastructure/metrics/noop"
+	prometheusmetrics "github.com/ark-network/ark/internal/infrastructure/metrics/prometheus"
 	oceanwallet "github.com/ark-network/ark/internal/infrastructure/ocean-wallet"
+	grpcpolicy "github.com/ark-network/ark/internal/infrastructure/policy/grpc"
+	nooppolicy "github.com/ark-network/ark/internal/infrastructure/policy/noop"
 	scheduler "github.com/ark-network/ark/internal/infrastructure/scheduler/gocron"
 	txbuilder "github.com/ark-network/ark/internal/infrastructure/tx-builder/covenant"
 	log "github.com/sirupsen/logrus"
@@ -22,8 +27,9 @@ var (
 		"badger": {},
 	}
 	supportedDbs = supportedType{
-		"badger": {},
-		"sqlite": {},
+		"badger":   {},
+		"sqlite":   {},
+		"postgres": {},
 	}
 	supportedSchedulers = supportedType{
 		"gocron": {},
@@ -32,24 +38,47 @@ var (
 		"covenant": {},
 	}
 	supportedScanners = supportedType{
-		"ocean": {},
+		"ocean":    {},
+		"elements": {},
+	}
+	supportedWallets = supportedType{
+		"ocean":    {},
+		"elements": {},
 	}
 )
 
 type Config struct {
-	DbType                string
-	EventDbType           string
-	DbDir                 string
-	EventDbDir            string
-	RoundInterval         int64
-	Network               common.Network
-	SchedulerType         string
-	TxBuilderType         string
-	BlockchainScannerType string
-	WalletAddr            string
-	MinRelayFee           uint64
-	RoundLifetime         int64
-	UnilateralExitDelay   int64
+	DbType                  string
+	EventDbType             string
+	DbDir                   string
+	DbURL                   string
+	EventDbDir              string
+	RoundInterval           int64
+	Network                 common.Network
+	SchedulerType           string
+	TxBuilderType           string
+	BlockchainScannerType   string
+	WalletAddr              string
+	WalletType              string
+	ElementsRPCAddr         string
+	ElementsRPCUser         string
+	ElementsRPCPass         string
+	ElementsRPCWallet       string
+	MinRelayFee             uint64
+	RoundLifetime           int64
+	UnilateralExitDelay     int64
+	ExitFee                 uint64
+	FeeSponsorshipThreshold uint64
+	FeePolicy               application.FeePolicy
+	RoundPolicy             application.RoundPolicy
+	RegistrationLimits      application.RegistrationLimits
+	SweepConfig             application.SweepConfig
+	// MetricsAddr is the listen address of the Prometheus metrics, disabled
+	// if empty.
+	MetricsAddr string
+	// PolicyPluginAddr is the address of the gRPC policy plugin accepting
+	// the payments, all accepted if empty.
+	PolicyPluginAddr string
 
 	repo      ports.RepoManager
 	svc       application.Service
@@ -58,6 +87,8 @@ type Config struct {
 	txBuilder ports.TxBuilder
 	scanner   ports.BlockchainScanner
 	scheduler ports.SchedulerService
+	metrics   ports.MetricsService
+	policy    ports.PolicyService
 }
 
 func (c *Config) Validate() error {
@@ -67,6 +98,9 @@ func (c *Config) Validate() error {
 	if !supportedDbs.supports(c.DbType) {
 		return fmt.Errorf("db type not supported, please select one of: %s", supportedDbs)
 	}
+	if c.DbType == "postgres" && len(c.DbURL) <= 0 {
+		return fmt.Errorf("missing postgres db url")
+	}
 	if !supportedSchedulers.supports(c.SchedulerType) {
 		return fmt.Errorf("scheduler type not supported, please select one of: %s", supportedSchedulers)
 	}
@@ -76,15 +110,53 @@ func (c *Config) Validate() error {
 	if !supportedScanners.supports(c.BlockchainScannerType) {
 		return fmt.Errorf("blockchain scanner type not supported, please select one of: %s", supportedScanners)
 	}
-	if c.RoundInterval < 2 {
-		return fmt.Errorf("invalid round interval, must be at least 2 seconds")
+	if !supportedWallets.supports(c.WalletType) {
+		return fmt.Errorf("wallet type not supported, please select one of: %s", supportedWallets)
+	}
+	// the wallet acts as blockchain scanner as well.
+	if c.BlockchainScannerType != c.WalletType {
+		return fmt.Errorf("blockchain scanner type must match wallet type %s", c.WalletType)
+	}
+	if c.FeePolicy.ProportionalFeePpm >= 1_000_000 {
+		return fmt.Errorf("invalid proportional fee, must be less than 1000000 ppm")
+	}
+	if c.RegistrationLimits.MaxQueuedPayments < 1 {
+		return fmt.Errorf("invalid max queued payments, must be at least 1")
+	}
+	if c.RegistrationLimits.MaxPaymentsPerPubkey < 0 {
+		return fmt.Errorf("invalid max payments per pubkey, must not be negative")
+	}
+	if c.RegistrationLimits.MaxInputs < 1 {
+		return fmt.Errorf("invalid max payment inputs, must be at least 1")
+	}
+	if c.SweepConfig.BatchWindow < 0 {
+		return fmt.Errorf("invalid sweep batch window, must not be negative")
+	}
+	if c.SweepConfig.MaxInputs < 1 {
+		return fmt.Errorf("invalid sweep max inputs, must be at least 1")
+	}
+	roundPolicy := c.RoundPolicy
+	roundPolicy.Interval = c.RoundInterval
+	if err := roundPolicy.Validate(); err != nil {
+		return err
 	}
 	if c.Network.Name != "liquid" && c.Network.Name != "testnet" && c.Network.Name != "regtest" {
 		return fmt.Errorf("invalid network, must be liquid, testnet or regtest")
 	}
-	if len(c.WalletAddr) <= 0 {
+	if c.WalletType == "ocean" && len(c.WalletAddr) <= 0 {
 		return fmt.Errorf("missing onchain wallet address")
 	}
+	if c.WalletType == "elements" {
+		if len(c.ElementsRPCAddr) <= 0 {
+			return fmt.Errorf("missing elements rpc address")
+		}
+		if len(c.ElementsRPCUser) <= 0 || len(c.ElementsRPCPass) <= 0 {
+			return fmt.Errorf("missing elements rpc credentials")
+		}
+		if len(c.ElementsRPCWallet) <= 0 {
+			return fmt.Errorf("missing elements rpc wallet name")
+		}
+	}
 	if c.MinRelayFee < 30 {
 		return fmt.Errorf("invalid min relay fee, must be at least 30 sats")
 	}
@@ -132,6 +204,12 @@ func (c *Config) Validate() error {
 	if err := c.schedulerService(); err != nil {
 		return err
 	}
+	if err := c.metricsService(); err != nil {
+		return err
+	}
+	if err := c.policyService(); err != nil {
+		return err
+	}
 	if err := c.appService(); err != nil {
 		return err
 	}
@@ -149,6 +227,10 @@ func (c *Config) AdminService() application.AdminService {
 	return c.adminSvc
 }
 
+func (c *Config) MetricsService() ports.MetricsService {
+	return c.metrics
+}
+
 func (c *Config) repoManager() error {
 	var svc ports.RepoManager
 	var err error
@@ -168,6 +250,8 @@ func (c *Config) repoManager() error {
 		dataStoreConfig = []interface{}{c.DbDir, logger}
 	case "sqlite":
 		dataStoreConfig = []interface{}{c.DbDir}
+	case "postgres":
+		dataStoreConfig = []interface{}{c.DbURL}
 	default:
 		return fmt.Errorf("unknown db type")
 	}
@@ -188,7 +272,19 @@ func (c *Config) repoManager() error {
 }
 
 func (c *Config) walletService() error {
-	svc, err := oceanwallet.NewService(c.WalletAddr)
+	var svc ports.WalletService
+	var err error
+	switch c.WalletType {
+	case "ocean":
+		svc, err = oceanwallet.NewService(c.WalletAddr)
+	case "elements":
+		svc, err = elementswallet.NewService(
+			c.ElementsRPCAddr, c.ElementsRPCUser, c.ElementsRPCPass,
+			c.ElementsRPCWallet, c.mainChain(),
+		)
+	default:
+		err = fmt.Errorf("unknown wallet type")
+	}
 	if err != nil {
 		return err
 	}
@@ -222,7 +318,7 @@ func (c *Config) scannerService() error {
 	var svc ports.BlockchainScanner
 	var err error
 	switch c.BlockchainScannerType {
-	case "ocean":
+	case "ocean", "elements":
 		svc = c.wallet
 	default:
 		err = fmt.Errorf("unknown blockchain scanner type")
@@ -252,12 +348,45 @@ func (c *Config) schedulerService() error {
 	return nil
 }
 
+func (c *Config) metricsService() error {
+	if len(c.MetricsAddr) <= 0 {
+		c.metrics = noopmetrics.NewService()
+		return nil
+	}
+
+	svc, err := prometheusmetrics.NewService(c.MetricsAddr, c.repo, c.wallet)
+	if err != nil {
+		return err
+	}
+
+	c.metrics = svc
+	return nil
+}
+
+func (c *Config) policyService() error {
+	if len(c.PolicyPluginAddr) <= 0 {
+		c.policy = nooppolicy.NewService()
+		return nil
+	}
+
+	svc, err := grpcpolicy.NewService(c.PolicyPluginAddr)
+	if err != nil {
+		return err
+	}
+
+	c.policy = svc
+	return nil
+}
+
 func (c *Config) appService() error {
 	net := c.mainChain()
 	svc, err := application.NewService(
 		c.Network, net,
 		c.RoundInterval, c.RoundLifetime, c.UnilateralExitDelay, c.MinRelayFee,
-		c.wallet, c.repo, c.txBuilder, c.scanner, c.scheduler,
+		c.ExitFee, c.FeeSponsorshipThreshold, c.FeePolicy, c.RoundPolicy,
+		c.RegistrationLimits, c.SweepConfig,
+		c.wallet, c.repo, c.txBuilder, c.scanner, c.scheduler, c.metrics,
+		c.policy,
 	)
 	if err != nil {
 		return err
@@ -268,7 +397,7 @@ func (c *Config) appService() error {
 }
 
 func (c *Config) adminService() error {
-	c.adminSvc = application.NewAdminService(c.wallet, c.repo, c.txBuilder)
+	c.adminSvc = application.NewAdminService(c.svc, c.wallet, c.repo, c.txBuilder)
 	return nil
 }
 
@@ -11,43 +11,135 @@ import (
 )
 
 type Config struct {
-	WalletAddr            string
-	RoundInterval         int64
-	Port                  uint32
-	EventDbType           string
-	DbType                string
-	DbDir                 string
-	SchedulerType         string
-	TxBuilderType         string
-	BlockchainScannerType string
-	NoTLS                 bool
-	Network               common.Network
-	LogLevel              int
-	MinRelayFee           uint64
-	RoundLifetime         int64
-	UnilateralExitDelay   int64
-	AuthUser              string
-	AuthPass              string
+	WalletAddr              string
+	RoundInterval           int64
+	Port                    uint32
+	EventDbType             string
+	DbType                  string
+	DbDir                   string
+	DbURL                   string
+	SchedulerType           string
+	TxBuilderType           string
+	BlockchainScannerType   string
+	NoTLS                   bool
+	Network                 common.Network
+	LogLevel                int
+	MinRelayFee             uint64
+	RoundLifetime           int64
+	UnilateralExitDelay     int64
+	AuthUser                string
+	AuthPass                string
+	GatewayPort             uint32
+	CORSAllowedOrigins      []string
+	WalletType              string
+	ElementsRPCAddr         string
+	ElementsRPCUser         string
+	ElementsRPCPass         string
+	ElementsRPCWallet       string
+	ExitFee                 uint64
+	FeeSponsorshipThreshold uint64
+	RoundAdaptive           bool
+	RoundMinInterval        int64
+	RoundMaxInterval        int64
+	RoundMinSize            int64
+	RoundMaxSize            int64
+	RoundHighFeeRate        uint64
+	// RoundMinPayments is the min number of payments for a round to be
+	// finalized, RoundRegistrationDuration the seconds its registration stage
+	// lasts, half the interval if zero.
+	RoundMinPayments          int64
+	RoundRegistrationDuration int64
+	// the fees charged for each payment of a round, the proportional one in
+	// parts per million of the amount sent to others.
+	PaymentFlatFee         uint64
+	PaymentProportionalFee uint64
+	PaymentOutputFee       uint64
+	// the limits of the payment registrations against dos.
+	RegistrationRateLimit uint64
+	MaxQueuedPayments     int
+	MaxPaymentsPerPubkey  int
+	MaxPaymentInputs      int
+	// MetricsAddr is the listen address of the Prometheus metrics, like
+	// :9090, disabled if empty.
+	MetricsAddr string
+	// OtelCollectorEndpoint is the address of the OpenTelemetry collector
+	// the traces are exported to, like localhost:4317, disabled if empty.
+	OtelCollectorEndpoint string
+	// the expired shared outputs are swept together if expiring within the
+	// batch window, in seconds, up to SweepMaxInputs per tx, at
+	// SweepFeeRate sats/kvbyte or at the one of the wallet if zero.
+	SweepBatchWindow int64
+	SweepMaxInputs   int
+	SweepFeeRate     uint64
+	// PolicyPluginAddr is the address of the gRPC policy plugin accepting
+	// the payments of the clients, disabled if empty.
+	PolicyPluginAddr string
+	// the TLS certificate and key of the gRPC and REST endpoints, used only
+	// if not insecure, and the CA of the client certificates required to
+	// connect, if set.
+	TLSCertPath     string
+	TLSKeyPath      string
+	TLSClientCAPath string
 }
 
 var (
-	Datadir               = "DATADIR"
-	WalletAddr            = "WALLET_ADDR"
-	RoundInterval         = "ROUND_INTERVAL"
-	Port                  = "PORT"
-	EventDbType           = "EVENT_DB_TYPE"
-	DbType                = "DB_TYPE"
-	SchedulerType         = "SCHEDULER_TYPE"
-	TxBuilderType         = "TX_BUILDER_TYPE"
-	BlockchainScannerType = "BC_SCANNER_TYPE"
-	Insecure              = "INSECURE"
-	LogLevel              = "LOG_LEVEL"
-	Network               = "NETWORK"
-	MinRelayFee           = "MIN_RELAY_FEE"
-	RoundLifetime         = "ROUND_LIFETIME"
-	UnilateralExitDelay   = "UNILATERAL_EXIT_DELAY"
-	AuthUser              = "AUTH_USER"
-	AuthPass              = "AUTH_PASS"
+	Datadir                 = "DATADIR"
+	WalletAddr              = "WALLET_ADDR"
+	RoundInterval           = "ROUND_INTERVAL"
+	Port                    = "PORT"
+	EventDbType             = "EVENT_DB_TYPE"
+	DbType                  = "DB_TYPE"
+	DbURL                   = "DB_URL"
+	SchedulerType           = "SCHEDULER_TYPE"
+	TxBuilderType           = "TX_BUILDER_TYPE"
+	BlockchainScannerType   = "BC_SCANNER_TYPE"
+	Insecure                = "INSECURE"
+	LogLevel                = "LOG_LEVEL"
+	Network                 = "NETWORK"
+	MinRelayFee             = "MIN_RELAY_FEE"
+	RoundLifetime           = "ROUND_LIFETIME"
+	UnilateralExitDelay     = "UNILATERAL_EXIT_DELAY"
+	AuthUser                = "AUTH_USER"
+	AuthPass                = "AUTH_PASS"
+	GatewayPort             = "GATEWAY_PORT"
+	CORSAllowedOrigins      = "CORS_ALLOWED_ORIGINS"
+	WalletType              = "WALLET_TYPE"
+	ElementsRPCAddr         = "ELEMENTS_RPC_ADDR"
+	ElementsRPCUser         = "ELEMENTS_RPC_USER"
+	ElementsRPCPass         = "ELEMENTS_RPC_PASS"
+	ElementsRPCWallet       = "ELEMENTS_RPC_WALLET"
+	ExitFee                 = "EXIT_FEE"
+	FeeSponsorshipThreshold = "FEE_SPONSORSHIP_THRESHOLD"
+	RoundAdaptive           = "ROUND_ADAPTIVE"
+	RoundMinInterval        = "ROUND_MIN_INTERVAL"
+	RoundMaxInterval        = "ROUND_MAX_INTERVAL"
+	RoundMinSize            = "ROUND_MIN_SIZE"
+	RoundMaxSize            = "ROUND_MAX_SIZE"
+	RoundHighFeeRate        = "ROUND_HIGH_FEE_RATE"
+
+	RoundMinPayments          = "ROUND_MIN_PAYMENTS"
+	RoundRegistrationDuration = "ROUND_REGISTRATION_DURATION"
+
+	PaymentFlatFee         = "PAYMENT_FLAT_FEE"
+	PaymentProportionalFee = "PAYMENT_PROPORTIONAL_FEE"
+	PaymentOutputFee       = "PAYMENT_OUTPUT_FEE"
+
+	RegistrationRateLimit = "REGISTRATION_RATE_LIMIT"
+	MaxQueuedPayments     = "MAX_QUEUED_PAYMENTS"
+	MaxPaymentsPerPubkey  = "MAX_PAYMENTS_PER_PUBKEY"
+	MaxPaymentInputs      = "MAX_PAYMENT_INPUTS"
+
+	MetricsAddr           = "METRICS_ADDR"
+	OtelCollectorEndpoint = "OTEL_COLLECTOR_ENDPOINT"
+
+	SweepBatchWindow = "SWEEP_BATCH_WINDOW"
+	SweepMaxInputs   = "SWEEP_MAX_INPUTS"
+	SweepFeeRate     = "SWEEP_FEE_RATE"
+	PolicyPluginAddr = "POLICY_PLUGIN_ADDR"
+
+	TLSCertPath     = "TLS_CERT_PATH"
+	TLSKeyPath      = "TLS_KEY_PATH"
+	TLSClientCAPath = "TLS_CLIENT_CA_PATH"
 
 	defaultDatadir               = common.AppDataDir("arkd", false)
 	defaultRoundInterval         = 5
@@ -66,6 +158,23 @@ var (
 	defaultUnilateralExitDelay   = 1024
 	defaultAuthUser              = "admin"
 	defaultAuthPass              = "admin"
+	defaultCORSAllowedOrigins    = "*"
+	defaultWalletType            = "ocean"
+	defaultElementsRPCAddr       = "localhost:7041"
+	defaultElementsRPCWallet     = "ark"
+	defaultRoundMinInterval      = 5
+	defaultRoundMaxInterval      = 60
+	defaultRoundMinSize          = 1
+	defaultRoundMaxSize          = 128
+	defaultRoundHighFeeRate      = 1000 // 1 sat/vbyte
+	defaultRoundMinPayments      = 1
+	defaultRegistrationRateLimit = 30 // per minute
+	defaultMaxQueuedPayments     = 10000
+	defaultMaxPaymentsPerPubkey  = 5
+	defaultMaxPaymentInputs      = 256
+
+	defaultSweepBatchWindow = 60
+	defaultSweepMaxInputs   = 100
 )
 
 func LoadConfig() (*Config, error) {
@@ -89,6 +198,22 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault(BlockchainScannerType, defaultBlockchainScannerType)
 	viper.SetDefault(AuthUser, defaultAuthUser)
 	viper.SetDefault(AuthPass, defaultAuthPass)
+	viper.SetDefault(CORSAllowedOrigins, defaultCORSAllowedOrigins)
+	viper.SetDefault(WalletType, defaultWalletType)
+	viper.SetDefault(ElementsRPCAddr, defaultElementsRPCAddr)
+	viper.SetDefault(ElementsRPCWallet, defaultElementsRPCWallet)
+	viper.SetDefault(RoundMinInterval, defaultRoundMinInterval)
+	viper.SetDefault(RoundMaxInterval, defaultRoundMaxInterval)
+	viper.SetDefault(RoundMinSize, defaultRoundMinSize)
+	viper.SetDefault(RoundMaxSize, defaultRoundMaxSize)
+	viper.SetDefault(RoundHighFeeRate, defaultRoundHighFeeRate)
+	viper.SetDefault(RoundMinPayments, defaultRoundMinPayments)
+	viper.SetDefault(RegistrationRateLimit, defaultRegistrationRateLimit)
+	viper.SetDefault(MaxQueuedPayments, defaultMaxQueuedPayments)
+	viper.SetDefault(MaxPaymentsPerPubkey, defaultMaxPaymentsPerPubkey)
+	viper.SetDefault(MaxPaymentInputs, defaultMaxPaymentInputs)
+	viper.SetDefault(SweepBatchWindow, defaultSweepBatchWindow)
+	viper.SetDefault(SweepMaxInputs, defaultSweepMaxInputs)
 
 	net, err := getNetwork()
 	if err != nil {
@@ -100,23 +225,63 @@ func LoadConfig() (*Config, error) {
 	}
 
 	return &Config{
-		WalletAddr:            viper.GetString(WalletAddr),
-		RoundInterval:         viper.GetInt64(RoundInterval),
-		Port:                  viper.GetUint32(Port),
-		EventDbType:           viper.GetString(EventDbType),
-		DbType:                viper.GetString(DbType),
-		SchedulerType:         viper.GetString(SchedulerType),
-		TxBuilderType:         viper.GetString(TxBuilderType),
-		BlockchainScannerType: viper.GetString(BlockchainScannerType),
-		NoTLS:                 viper.GetBool(Insecure),
-		DbDir:                 filepath.Join(viper.GetString(Datadir), "db"),
-		LogLevel:              viper.GetInt(LogLevel),
-		Network:               net,
-		MinRelayFee:           viper.GetUint64(MinRelayFee),
-		RoundLifetime:         viper.GetInt64(RoundLifetime),
-		UnilateralExitDelay:   viper.GetInt64(UnilateralExitDelay),
-		AuthUser:              viper.GetString(AuthUser),
-		AuthPass:              viper.GetString(AuthPass),
+		WalletAddr:              viper.GetString(WalletAddr),
+		RoundInterval:           viper.GetInt64(RoundInterval),
+		Port:                    viper.GetUint32(Port),
+		EventDbType:             viper.GetString(EventDbType),
+		DbType:                  viper.GetString(DbType),
+		SchedulerType:           viper.GetString(SchedulerType),
+		TxBuilderType:           viper.GetString(TxBuilderType),
+		BlockchainScannerType:   viper.GetString(BlockchainScannerType),
+		NoTLS:                   viper.GetBool(Insecure),
+		DbDir:                   filepath.Join(viper.GetString(Datadir), "db"),
+		DbURL:                   viper.GetString(DbURL),
+		LogLevel:                viper.GetInt(LogLevel),
+		Network:                 net,
+		MinRelayFee:             viper.GetUint64(MinRelayFee),
+		RoundLifetime:           viper.GetInt64(RoundLifetime),
+		UnilateralExitDelay:     viper.GetInt64(UnilateralExitDelay),
+		AuthUser:                viper.GetString(AuthUser),
+		AuthPass:                viper.GetString(AuthPass),
+		GatewayPort:             viper.GetUint32(GatewayPort),
+		CORSAllowedOrigins:      getCORSAllowedOrigins(),
+		WalletType:              viper.GetString(WalletType),
+		ElementsRPCAddr:         viper.GetString(ElementsRPCAddr),
+		ElementsRPCUser:         viper.GetString(ElementsRPCUser),
+		ElementsRPCPass:         viper.GetString(ElementsRPCPass),
+		ElementsRPCWallet:       viper.GetString(ElementsRPCWallet),
+		ExitFee:                 viper.GetUint64(ExitFee),
+		FeeSponsorshipThreshold: viper.GetUint64(FeeSponsorshipThreshold),
+		RoundAdaptive:           viper.GetBool(RoundAdaptive),
+		RoundMinInterval:        viper.GetInt64(RoundMinInterval),
+		RoundMaxInterval:        viper.GetInt64(RoundMaxInterval),
+		RoundMinSize:            viper.GetInt64(RoundMinSize),
+		RoundMaxSize:            viper.GetInt64(RoundMaxSize),
+		RoundHighFeeRate:        viper.GetUint64(RoundHighFeeRate),
+
+		RoundMinPayments:          viper.GetInt64(RoundMinPayments),
+		RoundRegistrationDuration: viper.GetInt64(RoundRegistrationDuration),
+
+		PaymentFlatFee:         viper.GetUint64(PaymentFlatFee),
+		PaymentProportionalFee: viper.GetUint64(PaymentProportionalFee),
+		PaymentOutputFee:       viper.GetUint64(PaymentOutputFee),
+
+		RegistrationRateLimit: viper.GetUint64(RegistrationRateLimit),
+		MaxQueuedPayments:     viper.GetInt(MaxQueuedPayments),
+		MaxPaymentsPerPubkey:  viper.GetInt(MaxPaymentsPerPubkey),
+		MaxPaymentInputs:      viper.GetInt(MaxPaymentInputs),
+
+		MetricsAddr:           viper.GetString(MetricsAddr),
+		OtelCollectorEndpoint: viper.GetString(OtelCollectorEndpoint),
+
+		SweepBatchWindow: viper.GetInt64(SweepBatchWindow),
+		SweepMaxInputs:   viper.GetInt(SweepMaxInputs),
+		SweepFeeRate:     viper.GetUint64(SweepFeeRate),
+		PolicyPluginAddr: viper.GetString(PolicyPluginAddr),
+
+		TLSCertPath:     viper.GetString(TLSCertPath),
+		TLSKeyPath:      viper.GetString(TLSKeyPath),
+		TLSClientCAPath: viper.GetString(TLSClientCAPath),
 	}, nil
 }
 
@@ -144,3 +309,13 @@ func getNetwork() (common.Network, error) {
 		return common.Network{}, fmt.Errorf("unknown network %s", viper.GetString(Network))
 	}
 }
+
+func getCORSAllowedOrigins() []string {
+	origins := make([]string, 0)
+	for _, origin := range strings.Split(viper.GetString(CORSAllowedOrigins), ",") {
+		if origin = strings.TrimSpace(origin); len(origin) > 0 {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/ark-network/ark/internal/core/domain"
 	"github.com/ark-network/ark/internal/core/ports"
 )
 
@@ -38,6 +40,29 @@ type RoundDetails struct {
 	InputsVtxos      []string
 	OutputsVtxos     []string
 	ExitAddresses    []string
+	SweptAmount      uint64
+}
+
+// RoundStats aggregates the rounds started in a time range, the amounts
+// being those of the ended ones only.
+type RoundStats struct {
+	Rounds           int
+	EndedRounds      int
+	FailedRounds     int
+	Payments         int
+	ForfeitedAmount  uint64
+	TotalVtxosAmount uint64
+	TotalExitAmount  uint64
+	FeesAmount       uint64
+}
+
+// OperatorStatus is the state of the current round and of the registrations.
+type OperatorStatus struct {
+	CurrentRoundId      string
+	CurrentRoundStage   string
+	CurrentRoundFailed  bool
+	QueuedPayments      int64
+	RegistrationsPaused bool
 }
 
 type AdminService interface {
@@ -45,16 +70,29 @@ type AdminService interface {
 	GetScheduledSweeps(ctx context.Context) ([]ScheduledSweep, error)
 	GetRoundDetails(ctx context.Context, roundId string) (*RoundDetails, error)
 	GetRounds(ctx context.Context, after int64, before int64) ([]string, error)
+	GetRoundStats(ctx context.Context, after int64, before int64) (*RoundStats, error)
+	GetStatus(ctx context.Context) (*OperatorStatus, error)
+	GetLiquidity(ctx context.Context) (*LiquidityInfo, error)
+	GetVtxoSet(ctx context.Context, pubkey string) ([]domain.Vtxo, error)
+	GetWalletAddress(ctx context.Context) (string, error)
+	Withdraw(ctx context.Context, address string, amount uint64) (string, error)
+	TriggerRound(ctx context.Context) error
+	PauseRegistrations(ctx context.Context)
+	ResumeRegistrations(ctx context.Context)
+	GetRoundPolicy(ctx context.Context) RoundPolicy
+	UpdateRoundPolicy(ctx context.Context, policy RoundPolicy) error
 }
 
 type adminService struct {
+	appSvc      Service
 	walletSvc   ports.WalletService
 	repoManager ports.RepoManager
 	txBuilder   ports.TxBuilder
 }
 
-func NewAdminService(walletSvc ports.WalletService, repoManager ports.RepoManager, txBuilder ports.TxBuilder) AdminService {
+func NewAdminService(appSvc Service, walletSvc ports.WalletService, repoManager ports.RepoManager, txBuilder ports.TxBuilder) AdminService {
 	return &adminService{
+		appSvc:      appSvc,
 		walletSvc:   walletSvc,
 		repoManager: repoManager,
 		txBuilder:   txBuilder,
@@ -94,11 +132,12 @@ func (a *adminService) GetRoundDetails(ctx context.Context, roundId string) (*Ro
 		FeesAmount:       0,
 		InputsVtxos:      []string{},
 		OutputsVtxos:     []string{},
+		SweptAmount:      round.SweptAmount,
 	}
 
 	for _, payment := range round.Payments {
-		// TODO: Add fees amount
 		roundDetails.ForfeitedAmount += payment.TotalInputAmount()
+		roundDetails.FeesAmount += payment.TotalInputAmount() - payment.TotalOutputAmount()
 
 		for _, receiver := range payment.Receivers {
 			if receiver.IsOnchain() {
@@ -131,6 +170,113 @@ func (a *adminService) GetRounds(ctx context.Context, after int64, before int64)
 	return a.repoManager.Rounds().GetRoundsIds(ctx, after, before)
 }
 
+func (a *adminService) GetRoundStats(ctx context.Context, after int64, before int64) (*RoundStats, error) {
+	roundIds, err := a.repoManager.Rounds().GetRoundsIds(ctx, after, before)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &RoundStats{Rounds: len(roundIds)}
+	for _, id := range roundIds {
+		round, err := a.repoManager.Rounds().GetRoundWithId(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if round.IsFailed() {
+			stats.FailedRounds++
+			continue
+		}
+		if !round.IsEnded() {
+			continue
+		}
+
+		stats.EndedRounds++
+		stats.Payments += len(round.Payments)
+		for _, payment := range round.Payments {
+			stats.ForfeitedAmount += payment.TotalInputAmount()
+			stats.FeesAmount += payment.TotalInputAmount() - payment.TotalOutputAmount()
+
+			for _, receiver := range payment.Receivers {
+				if receiver.IsOnchain() {
+					stats.TotalExitAmount += receiver.Amount
+					continue
+				}
+				stats.TotalVtxosAmount += receiver.Amount
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+func (a *adminService) GetStatus(ctx context.Context) (*OperatorStatus, error) {
+	round, err := a.repoManager.Rounds().GetCurrentRound(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paused, queued := a.appSvc.GetRegistrationsStatus(ctx)
+	return &OperatorStatus{
+		CurrentRoundId:      round.Id,
+		CurrentRoundStage:   round.Stage.Code.String(),
+		CurrentRoundFailed:  round.IsFailed(),
+		QueuedPayments:      queued,
+		RegistrationsPaused: paused,
+	}, nil
+}
+
+func (a *adminService) GetLiquidity(ctx context.Context) (*LiquidityInfo, error) {
+	return a.appSvc.GetLiquidity(ctx)
+}
+
+// ListVtxos returns the current vtxo set, that is the vtxos neither spent
+// nor redeemed, optionally only those of the given pubkey.
+func (a *adminService) GetVtxoSet(ctx context.Context, pubkey string) ([]domain.Vtxo, error) {
+	vtxos, _, err := a.repoManager.Vtxos().GetAllVtxos(ctx, pubkey)
+	return vtxos, err
+}
+
+func (a *adminService) TriggerRound(ctx context.Context) error {
+	return a.appSvc.TriggerRound(ctx)
+}
+
+func (a *adminService) PauseRegistrations(ctx context.Context) {
+	a.appSvc.PauseRegistrations(ctx)
+}
+
+func (a *adminService) ResumeRegistrations(ctx context.Context) {
+	a.appSvc.ResumeRegistrations(ctx)
+}
+
+func (a *adminService) GetRoundPolicy(ctx context.Context) RoundPolicy {
+	return a.appSvc.GetRoundPolicy(ctx)
+}
+
+func (a *adminService) UpdateRoundPolicy(ctx context.Context, policy RoundPolicy) error {
+	return a.appSvc.UpdateRoundPolicy(ctx, policy)
+}
+
+func (a *adminService) GetWalletAddress(ctx context.Context) (string, error) {
+	addresses, err := a.walletSvc.DeriveAddresses(ctx, 1)
+	if err != nil {
+		return "", err
+	}
+	return addresses[0], nil
+}
+
+func (a *adminService) Withdraw(ctx context.Context, address string, amount uint64) (string, error) {
+	available, _, err := a.walletSvc.MainAccountBalance(ctx)
+	if err != nil {
+		return "", err
+	}
+	if amount > available {
+		return "", fmt.Errorf("not enough funds, available balance is %d sats", available)
+	}
+
+	return a.walletSvc.Withdraw(ctx, address, amount)
+}
+
 func (a *adminService) GetScheduledSweeps(ctx context.Context) ([]ScheduledSweep, error) {
 	sweepableRounds, err := a.repoManager.Rounds().GetSweepableRounds(ctx)
 	if err != nil {
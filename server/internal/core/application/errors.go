@@ -1,6 +1,19 @@
 package application
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errRegistrationsPaused = errors.New(
+		"payment registrations are paused by the operator, retry later",
+	)
+	errTooManyPayments = errors.New(
+		"too many payments registered, retry later",
+	)
+	errShuttingDown = errors.New("the ASP is shutting down, retry later")
+)
 
 type errPaymentNotFound struct {
 	id string
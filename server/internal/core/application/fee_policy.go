@@ -0,0 +1,47 @@
+package application
+
+import "github.com/ark-network/ark/internal/core/domain"
+
+const ppmDenominator = 1_000_000
+
+// FeePolicy are the fees charged by the ASP for each payment of a round, on
+// top of the exit fee of its cooperative exits. The outputs sent back to the
+// owners of the inputs, like the change, are charged only the flat fee.
+type FeePolicy struct {
+	// FlatFee is charged once per payment, in sats.
+	FlatFee uint64
+	// ProportionalFeePpm is charged on the amount sent to others, in parts
+	// per million.
+	ProportionalFeePpm uint64
+	// OutputFee is charged for each output to others, in sats.
+	OutputFee uint64
+}
+
+// paymentFees returns the fees of a payment spending the given inputs to the
+// given receivers.
+func (p FeePolicy) paymentFees(
+	inputs []domain.Vtxo, receivers []domain.Receiver,
+) uint64 {
+	owners := make(map[string]struct{})
+	for _, input := range inputs {
+		owners[input.Pubkey] = struct{}{}
+	}
+
+	sentAmount, sentOutputs := uint64(0), uint64(0)
+	for _, r := range receivers {
+		if !r.IsOnchain() {
+			if _, ok := owners[r.Pubkey]; ok {
+				continue
+			}
+		}
+		sentAmount += r.Amount
+		sentOutputs++
+	}
+
+	// the proportional fee is rounded up, and split not to overflow.
+	proportionalFee := sentAmount/ppmDenominator*p.ProportionalFeePpm +
+		(sentAmount%ppmDenominator*p.ProportionalFeePpm+ppmDenominator-1)/
+			ppmDenominator
+
+	return p.FlatFee + p.OutputFee*sentOutputs + proportionalFee
+}
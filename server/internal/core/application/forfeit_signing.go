@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// forfeitSigningSession signs with MuSig2 the vtxo inputs of the forfeit txs
+// of a round, the vtxos being spent with the key of their owner and the ASP.
+// The owner sends its nonce first and the ASP signs right away, so that it
+// has no nonce to keep, then the owner sends its partial signature.
+type forfeitSigningSession struct {
+	roundId  string
+	lock     sync.Mutex
+	forfeits map[string]*forfeitSigning
+}
+
+type forfeitSigning struct {
+	tx         *psbt.Packet
+	message    [32]byte
+	keys       []*secp256k1.PublicKey
+	scriptRoot []byte
+	// nonces are the public nonces of the owner and the ASP, the last sent
+	// for the forfeit tx, and aspSig the partial signature of the ASP made
+	// with them.
+	nonces [][66]byte
+	aspSig *musig2.PartialSignature
+}
+
+func newForfeitSigningSession(
+	roundId string, forfeitTxs []string, payments []domain.Payment,
+	aspPubkey *secp256k1.PublicKey, unilateralExitDelay int64,
+) (*forfeitSigningSession, error) {
+	owners := make(map[domain.VtxoKey]string)
+	for _, payment := range payments {
+		for _, vtxo := range payment.Inputs {
+			owners[vtxo.VtxoKey] = vtxo.Pubkey
+		}
+	}
+
+	forfeits := make(map[string]*forfeitSigning, len(forfeitTxs))
+	for _, b64 := range forfeitTxs {
+		tx, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forfeit tx: %s", err)
+		}
+		if len(tx.UnsignedTx.TxIn) <= bitcointree.ForfeitVtxoInputIndex {
+			return nil, fmt.Errorf("missing vtxo input of forfeit tx")
+		}
+
+		outpoint := tx.UnsignedTx.TxIn[bitcointree.ForfeitVtxoInputIndex].PreviousOutPoint
+		owner, ok := owners[domain.VtxoKey{
+			Txid: outpoint.Hash.String(),
+			VOut: outpoint.Index,
+		}]
+		if !ok {
+			return nil, fmt.Errorf("vtxo %s of forfeit tx not spent in round", outpoint)
+		}
+		buf, err := hex.DecodeString(owner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vtxo pubkey: %s", err)
+		}
+		ownerPubkey, err := secp256k1.ParsePubKey(buf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vtxo pubkey: %s", err)
+		}
+
+		scriptRoot, err := bitcointree.VtxoScriptRoot(
+			ownerPubkey, uint(unilateralExitDelay),
+		)
+		if err != nil {
+			return nil, err
+		}
+		message, err := bitcointree.ForfeitSigHash(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		forfeits[tx.UnsignedTx.TxHash().String()] = &forfeitSigning{
+			tx:         tx,
+			message:    message,
+			keys:       bitcointree.VtxoKeys(ownerPubkey, aspPubkey),
+			scriptRoot: scriptRoot,
+		}
+	}
+
+	return &forfeitSigningSession{
+		roundId:  roundId,
+		forfeits: forfeits,
+	}, nil
+}
+
+func (s *forfeitSigningSession) getForfeit(txid string) (*forfeitSigning, error) {
+	forfeit, ok := s.forfeits[txid]
+	if !ok {
+		return nil, fmt.Errorf("forfeit tx %s not found in round %s", txid, s.roundId)
+	}
+	return forfeit, nil
+}
+
+func (s *forfeitSigningSession) setAspSignature(
+	txid string, nonces [][66]byte, sig *musig2.PartialSignature,
+) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.forfeits[txid].nonces = nonces
+	s.forfeits[txid].aspSig = sig
+}
+
+// combineSignatures adds the final signature to the vtxo input of the given
+// forfeit tx, and returns the signed tx.
+func (s *forfeitSigningSession) combineSignatures(
+	txid string, sig *musig2.PartialSignature,
+) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	forfeit, err := s.getForfeit(txid)
+	if err != nil {
+		return "", err
+	}
+	if forfeit.aspSig == nil {
+		return "", fmt.Errorf("nonce of forfeit tx %s not sent yet", txid)
+	}
+
+	if err := bitcointree.CombineForfeitSigs(
+		forfeit.tx, forfeit.keys, forfeit.scriptRoot, forfeit.nonces,
+		[]*musig2.PartialSignature{sig, forfeit.aspSig},
+	); err != nil {
+		return "", fmt.Errorf("forfeit tx %s: %s", txid, err)
+	}
+	return forfeit.tx.B64Encode()
+}
+
+func (s *service) RegisterForfeitNonces(
+	ctx context.Context, roundId string, nonces map[string]string,
+) (map[string]string, error) {
+	session, err := s.getForfeitSigningSession(roundId)
+	if err != nil {
+		return nil, err
+	}
+
+	aspNonces := make(map[string]string, len(nonces))
+	for txid, encodedNonce := range nonces {
+		nonce, err := bitcointree.DecodeNonce(encodedNonce)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce of forfeit tx %s: %s", txid, err)
+		}
+		forfeit, err := session.getForfeit(txid)
+		if err != nil {
+			return nil, err
+		}
+
+		aspNonce, aspSig, err := s.wallet.SignMusig2(
+			ctx, forfeit.message, forfeit.keys, forfeit.scriptRoot,
+			[][66]byte{nonce},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign forfeit tx %s: %s", txid, err)
+		}
+		session.setAspSignature(txid, [][66]byte{nonce, aspNonce}, aspSig)
+		aspNonces[txid] = bitcointree.EncodeNonce(aspNonce)
+	}
+	return aspNonces, nil
+}
+
+func (s *service) RegisterForfeitSignatures(
+	_ context.Context, roundId string, signatures map[string]string,
+) error {
+	session, err := s.getForfeitSigningSession(roundId)
+	if err != nil {
+		return err
+	}
+
+	for txid, encodedSig := range signatures {
+		sig, err := bitcointree.DecodeSignature(encodedSig)
+		if err != nil {
+			return fmt.Errorf("invalid signature of forfeit tx %s: %s", txid, err)
+		}
+		signedTx, err := session.combineSignatures(txid, sig)
+		if err != nil {
+			return err
+		}
+		if err := s.forfeitTxs.setSigned(txid, signedTx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) getForfeitSigningSession(
+	roundId string,
+) (*forfeitSigningSession, error) {
+	session := s.forfeitSigning.Load()
+	if session == nil || session.roundId != roundId {
+		return nil, fmt.Errorf(
+			"forfeits of round %s are not being signed with musig2", roundId,
+		)
+	}
+	return session, nil
+}
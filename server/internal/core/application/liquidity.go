@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	log "github.com/sirupsen/logrus"
+)
+
+// LiquidityInfo compares the onchain liquidity required by the payments
+// waiting for the next rounds with the balance of the ASP wallet.
+type LiquidityInfo struct {
+	// AvailableBalance is the balance of the main account that can fund
+	// the next rounds, LockedBalance the one of the utxos locked by the
+	// wallet, reserved for the txs of the ongoing rounds.
+	AvailableBalance uint64
+	LockedBalance    uint64
+	// RequiredLiquidity is the estimated amount the ASP must fund to
+	// include all the claimed payments in the queue.
+	RequiredLiquidity uint64
+	QueuedPayments    int
+	// PendingSweepAmount is the amount of the vtxos not swept yet, that the
+	// ASP gets back once their rounds expire.
+	PendingSweepAmount uint64
+}
+
+func (s *service) GetLiquidity(ctx context.Context) (*LiquidityInfo, error) {
+	available, locked, err := s.wallet.MainAccountBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := s.paymentRequests.claimed()
+	required := uint64(0)
+	for _, p := range payments {
+		required += s.paymentLiquidity(p)
+	}
+
+	vtxos, err := s.repoManager.Vtxos().GetAllSweepableVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pendingSweep := uint64(0)
+	for _, v := range vtxos {
+		pendingSweep += v.Amount
+	}
+
+	return &LiquidityInfo{
+		AvailableBalance:   available,
+		LockedBalance:      locked,
+		RequiredLiquidity:  required,
+		QueuedPayments:     len(payments),
+		PendingSweepAmount: pendingSweep,
+	}, nil
+}
+
+// paymentLiquidity estimates the amount the ASP funds in the pool tx for the
+// given payment, that is its outputs and the connectors of its forfeits.
+func (s *service) paymentLiquidity(payment domain.Payment) uint64 {
+	amount := (dustAmount + s.minRelayFee) * uint64(len(payment.Inputs))
+	for _, r := range payment.Receivers {
+		amount += r.Amount
+	}
+	return amount
+}
+
+// fundablePayments returns the oldest of the given payments the balance of
+// the ASP wallet can fund, the others are put back in the queue for the next
+// rounds, rather than failing the round when broadcasting the pool tx.
+func (s *service) fundablePayments(
+	ctx context.Context, payments []domain.Payment,
+	ephemeralPubkeys map[string]string,
+) ([]domain.Payment, error) {
+	available, _, err := s.wallet.MainAccountBalance(ctx)
+	if err != nil {
+		s.paymentRequests.requeue(payments, ephemeralPubkeys)
+		return nil, fmt.Errorf("failed to get wallet balance: %s", err)
+	}
+
+	// the fees of the pool tx are paid by the ASP as well.
+	if available <= s.minRelayFee {
+		s.paymentRequests.requeue(payments, ephemeralPubkeys)
+		return nil, nil
+	}
+	available -= s.minRelayFee
+
+	fundable := make([]domain.Payment, 0, len(payments))
+	deferred := make([]domain.Payment, 0)
+	required := uint64(0)
+	for _, p := range payments {
+		amount := s.paymentLiquidity(p)
+		if required+amount > available {
+			deferred = append(deferred, p)
+			continue
+		}
+		required += amount
+		fundable = append(fundable, p)
+	}
+
+	if len(deferred) > 0 {
+		s.paymentRequests.requeue(deferred, ephemeralPubkeys)
+		log.Warnf(
+			"not enough liquidity (%d sats available), deferred %d payments to the next rounds",
+			available, len(deferred),
+		)
+	}
+	return fundable, nil
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// maxMailboxMessages is the max number of messages retained per mailbox,
+	// older ones are dropped first.
+	maxMailboxMessages = 100
+	// mailboxMessageTTL is the time after which a message is dropped.
+	mailboxMessageTTL     = 7 * 24 * time.Hour
+	maxMailboxPayloadSize = 64 * 1024
+	// maxMailboxes and maxMailboxesSize bound the memory used by the
+	// mailboxes, the least recently updated ones are evicted first.
+	maxMailboxes     = 10000
+	maxMailboxesSize = 256 * 1024 * 1024
+)
+
+type SyncMessage struct {
+	Sequence  uint64
+	Payload   string
+	Timestamp int64
+}
+
+type mailbox struct {
+	messages  []SyncMessage
+	size      int
+	updatedAt time.Time
+}
+
+// mailboxes is an in-memory relay of opaque messages, used by the devices
+// sharing the same wallet to exchange their state updates. Mailboxes are
+// identified by an id derived client-side from the wallet key, therefore the
+// ASP can't link them to any user.
+// The sequence of the messages restarts with the server, the epoch tells the
+// clients apart the sequences of different runs.
+type mailboxes struct {
+	lock      *sync.RWMutex
+	mailboxes map[string]*mailbox
+	epoch     uint64
+	sequence  uint64
+	size      int
+	maxCount  int
+	maxSize   int
+}
+
+func newMailboxes() *mailboxes {
+	return newMailboxesWithLimits(maxMailboxes, maxMailboxesSize)
+}
+
+func newMailboxesWithLimits(maxCount, maxSize int) *mailboxes {
+	return &mailboxes{
+		lock:      &sync.RWMutex{},
+		mailboxes: make(map[string]*mailbox),
+		epoch:     uint64(time.Now().UnixNano()),
+		maxCount:  maxCount,
+		maxSize:   maxSize,
+	}
+}
+
+// push adds the given message to the mailbox, and returns the epoch and the
+// sequence of the message.
+func (m *mailboxes) push(id, payload string) (uint64, uint64, error) {
+	if len(id) <= 0 {
+		return 0, 0, fmt.Errorf("missing mailbox id")
+	}
+	if len(payload) <= 0 {
+		return 0, 0, fmt.Errorf("missing payload")
+	}
+	if len(payload) > maxMailboxPayloadSize {
+		return 0, 0, fmt.Errorf(
+			"payload too big, must be at most %d bytes", maxMailboxPayloadSize,
+		)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	box, ok := m.mailboxes[id]
+	if !ok {
+		box = &mailbox{}
+		m.mailboxes[id] = box
+	}
+	m.sequence++
+	box.messages = append(box.messages, SyncMessage{
+		Sequence:  m.sequence,
+		Payload:   payload,
+		Timestamp: now.Unix(),
+	})
+	box.size += len(payload)
+	box.updatedAt = now
+	m.size += len(payload)
+
+	// drop the expired messages, and the oldest ones above the limit.
+	expiration := now.Add(-mailboxMessageTTL).Unix()
+	dropped := 0
+	for i, msg := range box.messages {
+		if msg.Timestamp >= expiration && len(box.messages)-i <= maxMailboxMessages {
+			break
+		}
+		box.size -= len(msg.Payload)
+		m.size -= len(msg.Payload)
+		dropped++
+	}
+	box.messages = box.messages[dropped:]
+
+	m.evict(id)
+
+	return m.epoch, m.sequence, nil
+}
+
+// evict drops the least recently updated mailboxes, other than the given
+// one, until within the limits.
+func (m *mailboxes) evict(keep string) {
+	for len(m.mailboxes) > m.maxCount || m.size > m.maxSize {
+		oldestId := ""
+		var oldest *mailbox
+		for id, box := range m.mailboxes {
+			if id == keep {
+				continue
+			}
+			if oldest == nil || box.updatedAt.Before(oldest.updatedAt) {
+				oldestId, oldest = id, box
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		m.size -= oldest.size
+		delete(m.mailboxes, oldestId)
+	}
+}
+
+// view returns the messages of the mailbox following the given sequence,
+// all of them if the sequence is of another epoch, and the current epoch.
+func (m *mailboxes) view(
+	id string, epoch, afterSequence uint64,
+) ([]SyncMessage, uint64) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if epoch != m.epoch {
+		afterSequence = 0
+	}
+
+	expiration := time.Now().Add(-mailboxMessageTTL).Unix()
+	messages := make([]SyncMessage, 0)
+	if box, ok := m.mailboxes[id]; ok {
+		for _, msg := range box.messages {
+			if msg.Sequence <= afterSequence || msg.Timestamp < expiration {
+				continue
+			}
+			messages = append(messages, msg)
+		}
+	}
+	return messages, m.epoch
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailboxes(t *testing.T) {
+	t.Run("push_and_view", func(t *testing.T) {
+		m := newMailboxes()
+
+		epoch, first, err := m.push("alice", "msg1")
+		require.NoError(t, err)
+		_, second, err := m.push("alice", "msg2")
+		require.NoError(t, err)
+		require.Greater(t, second, first)
+
+		_, _, err = m.push("bob", "msg3")
+		require.NoError(t, err)
+
+		messages, currentEpoch := m.view("alice", epoch, 0)
+		require.Equal(t, epoch, currentEpoch)
+		require.Len(t, messages, 2)
+
+		messages, _ = m.view("alice", epoch, first)
+		require.Len(t, messages, 1)
+		require.Equal(t, "msg2", messages[0].Payload)
+
+		messages, _ = m.view("carol", epoch, 0)
+		require.Empty(t, messages)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		m := newMailboxes()
+
+		fixtures := []struct {
+			id, payload string
+			expectedErr string
+		}{
+			{"", "msg", "missing mailbox id"},
+			{"alice", "", "missing payload"},
+			{
+				"alice", strings.Repeat("a", maxMailboxPayloadSize+1),
+				fmt.Sprintf("payload too big, must be at most %d bytes", maxMailboxPayloadSize),
+			},
+		}
+		for _, f := range fixtures {
+			_, _, err := m.push(f.id, f.payload)
+			require.EqualError(t, err, f.expectedErr)
+		}
+	})
+
+	t.Run("max_messages", func(t *testing.T) {
+		m := newMailboxes()
+
+		var epoch uint64
+		for i := 0; i < maxMailboxMessages+10; i++ {
+			var err error
+			epoch, _, err = m.push("alice", fmt.Sprintf("msg%d", i))
+			require.NoError(t, err)
+		}
+
+		messages, _ := m.view("alice", epoch, 0)
+		require.Len(t, messages, maxMailboxMessages)
+		require.Equal(t, "msg10", messages[0].Payload)
+	})
+
+	t.Run("evict_least_recently_updated", func(t *testing.T) {
+		m := newMailboxesWithLimits(2, 10)
+
+		epoch, _, err := m.push("alice", "12345")
+		require.NoError(t, err)
+		_, _, err = m.push("bob", "1234")
+		require.NoError(t, err)
+
+		// above the max number of mailboxes.
+		_, _, err = m.push("carol", "1")
+		require.NoError(t, err)
+		messages, _ := m.view("alice", epoch, 0)
+		require.Empty(t, messages)
+
+		// above the max size.
+		_, _, err = m.push("carol", "123456")
+		require.NoError(t, err)
+		messages, _ = m.view("bob", epoch, 0)
+		require.Empty(t, messages)
+		messages, _ = m.view("carol", epoch, 0)
+		require.Len(t, messages, 2)
+		require.LessOrEqual(t, m.size, 10)
+	})
+
+	t.Run("other_epoch", func(t *testing.T) {
+		m := newMailboxes()
+
+		epoch, sequence, err := m.push("alice", "msg1")
+		require.NoError(t, err)
+
+		// a cursor of a previous run of the server, ahead of the sequence of
+		// the current one, gets all the messages.
+		messages, currentEpoch := m.view("alice", epoch-1, sequence+100)
+		require.Equal(t, epoch, currentEpoch)
+		require.Len(t, messages, 1)
+	})
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	log "github.com/sirupsen/logrus"
+)
+
+// demandSmoothing is the weight of the last round in the moving average of
+// the registered payments per round.
+const demandSmoothing = 0.3
+
+// RoundPolicy are the bounds set by the operator within which the interval
+// and the max number of payments of the rounds are adjusted.
+// The intervals and durations are in seconds, the fee rate in sats/kvbyte.
+type RoundPolicy struct {
+	// Interval is the one of the rounds when not adaptive.
+	Interval    int64
+	Adaptive    bool
+	MinInterval int64
+	MaxInterval int64
+	MinSize     int64
+	// MaxSize is the max number of payments of a round, adaptive or not.
+	MaxSize int64
+	// HighFeeRate is the fee rate above which payments are batched into
+	// bigger and less frequent rounds.
+	HighFeeRate uint64
+	// MinPayments is the number of payments below which a round is skipped,
+	// the registered ones wait for the next round.
+	MinPayments int64
+	// RegistrationDuration is how long the registration stage of a round
+	// lasts, half the interval if zero.
+	RegistrationDuration int64
+}
+
+func (p RoundPolicy) Validate() error {
+	if p.Interval < 2 {
+		return fmt.Errorf("invalid round interval, must be at least 2 seconds")
+	}
+	if p.MaxSize < 1 {
+		return fmt.Errorf("invalid round max size, must be at least 1 payment")
+	}
+	if p.MinPayments < 1 {
+		return fmt.Errorf("invalid round min payments, must be at least 1")
+	}
+	if p.MinPayments > p.MaxSize {
+		return fmt.Errorf("invalid round min payments, must be at most the max size")
+	}
+
+	shortestInterval := p.Interval
+	if p.Adaptive {
+		if p.MinInterval < 2 {
+			return fmt.Errorf("invalid round min interval, must be at least 2 seconds")
+		}
+		if p.MaxInterval < p.MinInterval {
+			return fmt.Errorf("invalid round max interval, must be at least the min interval")
+		}
+		if p.MinSize < 1 {
+			return fmt.Errorf("invalid round min size, must be at least 1 payment")
+		}
+		if p.MaxSize < p.MinSize {
+			return fmt.Errorf("invalid round max size, must be at least the min size")
+		}
+		shortestInterval = p.MinInterval
+	}
+	if p.RegistrationDuration < 0 || p.RegistrationDuration >= shortestInterval {
+		return fmt.Errorf(
+			"invalid round registration duration, must be shorter than the round interval",
+		)
+	}
+	return nil
+}
+
+// roundPolicy decides the interval and size of the next round based on the
+// recent registration volume and the current onchain fee rate.
+// In high demand, rounds are more frequent and smaller, while when fees spike
+// they are less frequent and bigger to reduce the onchain footprint.
+type roundPolicy struct {
+	RoundPolicy
+	wallet ports.WalletService
+
+	lock     *sync.RWMutex
+	demand   float64
+	interval int64
+	size     int64
+}
+
+func newRoundPolicy(
+	policy RoundPolicy, roundInterval int64, wallet ports.WalletService,
+) *roundPolicy {
+	policy.Interval = roundInterval
+	return &roundPolicy{
+		policy, wallet, &sync.RWMutex{}, 0, roundInterval, policy.MaxSize,
+	}
+}
+
+func (p *roundPolicy) get() RoundPolicy {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.RoundPolicy
+}
+
+// set replaces the policy, effective from the next round.
+func (p *roundPolicy) set(policy RoundPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.RoundPolicy = policy
+	if !policy.Adaptive {
+		p.interval, p.size = policy.Interval, policy.MaxSize
+	}
+	log.Infof(
+		"round policy updated: adaptive %t, interval %ds, size %d, "+
+			"min payments %d, registration %ds",
+		policy.Adaptive, p.interval, p.size,
+		policy.MinPayments, p.registrationDuration(p.interval),
+	)
+	return nil
+}
+
+func (p *roundPolicy) currentInterval() int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.interval
+}
+
+func (p *roundPolicy) currentSize() int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.size
+}
+
+func (p *roundPolicy) minPayments() int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.MinPayments
+}
+
+// currentRegistrationDuration returns how long the registration stage of a
+// round with the given interval lasts.
+func (p *roundPolicy) currentRegistrationDuration(interval int64) int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.registrationDuration(interval)
+}
+
+func (p *roundPolicy) registrationDuration(interval int64) int64 {
+	if p.RegistrationDuration > 0 && p.RegistrationDuration < interval {
+		return p.RegistrationDuration
+	}
+	return interval / 2
+}
+
+// registered updates the moving average of the demand with the number of
+// payments registered for the last round.
+func (p *roundPolicy) registered(numOfPayments int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.demand = demandSmoothing*float64(numOfPayments) +
+		(1-demandSmoothing)*p.demand
+}
+
+// update decides the interval and size of the next round, given the number
+// of payments currently waiting to be included in a round.
+func (p *roundPolicy) update(ctx context.Context, queued int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.Adaptive {
+		return
+	}
+
+	demand := math.Max(p.demand, float64(queued))
+
+	feeRate, err := p.wallet.FeeRate(ctx)
+	if err != nil {
+		log.WithError(err).Warn("failed to get fee rate, keeping round policy")
+		return
+	}
+
+	interval, size := p.MaxInterval, p.MaxSize
+	if feeRate < p.HighFeeRate {
+		// the closer the demand to the max round size, the shorter the
+		// interval, and the size follows the demand.
+		load := math.Min(1, demand/float64(p.MaxSize))
+		interval = p.MaxInterval -
+			int64(math.Round(load*float64(p.MaxInterval-p.MinInterval)))
+		size = int64(math.Ceil(demand))
+		if size < p.MinSize {
+			size = p.MinSize
+		}
+		if size > p.MaxSize {
+			size = p.MaxSize
+		}
+	}
+
+	if interval != p.interval || size != p.size {
+		log.Infof(
+			"round policy: interval %ds -> %ds, size %d -> %d "+
+				"(demand %.1f payments, fee rate %d sats/kvb)",
+			p.interval, interval, p.size, size, demand, feeRate,
+		)
+	}
+	p.interval, p.size = interval, size
+}
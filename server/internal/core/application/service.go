@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ark-network/ark/common"
@@ -15,15 +17,18 @@ import (
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	log "github.com/sirupsen/logrus"
+	"github.com/vulpemventures/go-elements/elementsutil"
 	"github.com/vulpemventures/go-elements/network"
 	"github.com/vulpemventures/go-elements/payment"
 	"github.com/vulpemventures/go-elements/psetv2"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-var (
-	paymentsThreshold = int64(128)
-	dustAmount        = uint64(450)
-)
+// shutdownGracePeriod is how long the current round is waited for to end,
+// on top of the round interval, when the service is stopped.
+const shutdownGracePeriod = 10 * time.Second
+
+var dustAmount = uint64(450)
 
 type ServiceInfo struct {
 	PubKey              string
@@ -32,15 +37,62 @@ type ServiceInfo struct {
 	RoundInterval       int64
 	Network             string
 	MinRelayFee         int64
+	// ExitFee is charged for each cooperative exit, unless its amount is below
+	// FeeSponsorshipThreshold, in which case the ASP pays for it. The ASP pays
+	// also the onchain fees of the onboardings below the threshold.
+	ExitFee                 int64
+	FeeSponsorshipThreshold int64
+	FeePolicy               FeePolicy
+	Capabilities            common.Capabilities
+}
+
+// RegistrationLimits bound the payments registered at any time, not to let
+// bogus registrations fill the rounds or exhaust the memory.
+type RegistrationLimits struct {
+	MaxQueuedPayments int
+	// MaxPaymentsPerPubkey is the max number of registered payments with
+	// inputs of the same owner, unlimited if zero.
+	MaxPaymentsPerPubkey int
+	MaxInputs            int
+}
+
+// SweepConfig is how the sweeper batches the expired shared outputs of the
+// rounds: those expiring within BatchWindow seconds of each other are swept
+// together, up to MaxInputs per tx, at FeeRate sats/kvbyte, or at the fee
+// rate of the wallet if zero.
+type SweepConfig struct {
+	BatchWindow int64
+	MaxInputs   int
+	FeeRate     uint64
 }
 
 type Service interface {
 	Start() error
 	Stop()
-	SpendVtxos(ctx context.Context, inputs []domain.VtxoKey) (string, error)
-	ClaimVtxos(ctx context.Context, creds string, receivers []domain.Receiver) error
+	// SpendVtxos registers a payment for the given inputs, the signatures
+	// being those of their owners of the registration hash, in the same order.
+	SpendVtxos(ctx context.Context, inputs []domain.VtxoKey, clientPaymentId string, signatures []string) (string, error)
+	// ClaimVtxos adds the receivers to a registered payment. The ephemeral
+	// pubkey is the key its owner cosigns the congestion tree with, required
+	// only if the tree must be signed.
+	ClaimVtxos(ctx context.Context, creds string, receivers []domain.Receiver, ephemeralPubkey string) error
 	SignVtxos(ctx context.Context, forfeitTxs []string) error
+	// RegisterCosignerNonces and RegisterCosignerSignatures take part in the
+	// MuSig2 signing of the congestion tree of the given round, the nonces
+	// and the signatures being hex encoded.
+	RegisterCosignerNonces(ctx context.Context, roundId string, pubkey *secp256k1.PublicKey, nonces string) error
+	RegisterCosignerSignatures(ctx context.Context, roundId string, pubkey *secp256k1.PublicKey, signatures string) error
+	// RegisterForfeitNonces and RegisterForfeitSignatures take part in the
+	// MuSig2 signing of the forfeit txs of the given round, by txid, if they
+	// spend the vtxos with the key path. The ASP signs as soon as it gets the
+	// nonces, and returns its own ones.
+	RegisterForfeitNonces(ctx context.Context, roundId string, nonces map[string]string) (map[string]string, error)
+	RegisterForfeitSignatures(ctx context.Context, roundId string, signatures map[string]string) error
+	CreateAsyncPayment(ctx context.Context, inputs []domain.VtxoKey, receivers []domain.Receiver) (string, error)
+	CompleteAsyncPayment(ctx context.Context, redeemTx string) error
 	GetRoundByTxid(ctx context.Context, poolTxid string) (*domain.Round, error)
+	GetRoundById(ctx context.Context, id string) (*domain.Round, error)
+	GetReceipt(ctx context.Context, vtxoKey domain.VtxoKey) (*common.Receipt, error)
 	GetCurrentRound(ctx context.Context) (*domain.Round, error)
 	GetEventsChannel(ctx context.Context) <-chan domain.RoundEvent
 	UpdatePaymentStatus(ctx context.Context, id string) (unsignedForfeitTxs []string, err error)
@@ -48,6 +100,29 @@ type Service interface {
 	GetInfo(ctx context.Context) (*ServiceInfo, error)
 	Onboard(ctx context.Context, boardingTx string, congestionTree tree.CongestionTree, userPubkey *secp256k1.PublicKey) error
 	TrustedOnboarding(ctx context.Context, userPubKey *secp256k1.PublicKey) (string, error)
+	// SponsorOnboarding funds and signs the given boarding tx, whose inputs
+	// cover only the amount onboarded, if below the sponsorship threshold.
+	SponsorOnboarding(ctx context.Context, boardingTx string, userPubkey *secp256k1.PublicKey) (string, error)
+	// PushSyncMessage returns the epoch and the sequence of the message,
+	// GetSyncMessages the messages after the sequence, all of them if of
+	// another epoch, and the current epoch.
+	PushSyncMessage(ctx context.Context, mailboxId, payload string) (uint64, uint64, error)
+	GetSyncMessages(ctx context.Context, mailboxId string, epoch, afterSequence uint64) ([]SyncMessage, uint64, error)
+	// TriggerRound ends the registration stage of the current round now,
+	// without waiting for the round interval.
+	TriggerRound(ctx context.Context) error
+	// PauseRegistrations rejects the new payments, while the rounds go on
+	// with those already registered.
+	PauseRegistrations(ctx context.Context)
+	ResumeRegistrations(ctx context.Context)
+	GetRegistrationsStatus(ctx context.Context) (paused bool, queuedPayments int64)
+	// GetLiquidity returns the liquidity required by the queued payments
+	// and the balance available to fund them.
+	GetLiquidity(ctx context.Context) (*LiquidityInfo, error)
+	GetRoundPolicy(ctx context.Context) RoundPolicy
+	// UpdateRoundPolicy replaces the round policy from the next round on,
+	// until restart.
+	UpdateRoundPolicy(ctx context.Context, policy RoundPolicy) error
 }
 
 type onboarding struct {
@@ -64,18 +139,62 @@ type service struct {
 	roundInterval       int64
 	unilateralExitDelay int64
 	minRelayFee         uint64
+	// exitFee is the fee charged for the onchain output of each cooperative
+	// exit, waived for exits below feeSponsorshipThreshold.
+	// Onboardings are not sponsored since their congestion tree, fees
+	// included, is funded by the boarding tx of the user.
+	exitFee                 uint64
+	feeSponsorshipThreshold uint64
+	feePolicy               FeePolicy
+	registrationLimits      RegistrationLimits
 
 	wallet      ports.WalletService
 	repoManager ports.RepoManager
 	builder     ports.TxBuilder
 	scanner     ports.BlockchainScanner
 	sweeper     *sweeper
+	watchtower  *watchtower
+	metrics     ports.MetricsService
+	// paymentPolicy accepts the payments with the rules of the operator.
+	paymentPolicy ports.PolicyService
+	roundPolicy   *roundPolicy
+	roundTracer   *roundTracer
+	// treeSigning is the signing session of the congestion tree of the
+	// current round, if in progress.
+	treeSigning *atomic.Pointer[treeSigningSession]
+	// forfeitSigning is the MuSig2 signing session of the forfeit txs of the
+	// current round, if they spend the vtxos with the key path.
+	forfeitSigning *atomic.Pointer[forfeitSigningSession]
 
 	paymentRequests *paymentsMap
 	forfeitTxs      *forfeitTxsMap
+	mailboxes       *mailboxes
+	// asyncPaymentsLock serializes the completion of the async payments, so
+	// that their inputs can't be spent twice.
+	asyncPayments     *asyncPaymentsMap
+	asyncPaymentsLock *sync.Mutex
 
 	eventsCh     chan domain.RoundEvent
 	onboardingCh chan onboarding
+	// roundTriggerCh ends the registration stage of the current round
+	// before the interval elapses.
+	roundTriggerCh      chan struct{}
+	registrationsPaused *atomic.Bool
+	// stopCh is closed when the service is stopped, making the loop of the
+	// rounds end once the current one is finalized, or right away by
+	// aborting it if still in registration stage. roundsDoneCh is closed
+	// once the loop has ended, lastRoundId being the last round.
+	stopCh       chan struct{}
+	roundsDoneCh chan struct{}
+	lastRoundId  *atomic.Value
+	// stopOnce makes Stop safe to call more than once.
+	stopOnce *sync.Once
+	// lastNotifiedRound is the last round whose end has been propagated to
+	// the clients. The events channel is closed only once no event is being
+	// propagated.
+	lastNotifiedRound *atomic.Value
+	eventsLock        *sync.RWMutex
+	eventsClosed      bool
 
 	trustedOnboardingScriptLock *sync.Mutex
 	trustedOnboardingScripts    map[string]*secp256k1.PublicKey
@@ -84,9 +203,12 @@ type service struct {
 func NewService(
 	network common.Network, onchainNetwork network.Network,
 	roundInterval, roundLifetime, unilateralExitDelay int64, minRelayFee uint64,
+	exitFee, feeSponsorshipThreshold uint64, feePolicy FeePolicy,
+	policy RoundPolicy, limits RegistrationLimits, sweepConfig SweepConfig,
 	walletSvc ports.WalletService, repoManager ports.RepoManager,
 	builder ports.TxBuilder, scanner ports.BlockchainScanner,
-	scheduler ports.SchedulerService,
+	scheduler ports.SchedulerService, metrics ports.MetricsService,
+	paymentPolicy ports.PolicyService,
 ) (Service, error) {
 	eventsCh := make(chan domain.RoundEvent)
 	onboardingCh := make(chan onboarding)
@@ -99,18 +221,31 @@ func NewService(
 		return nil, fmt.Errorf("failed to fetch pubkey: %s", err)
 	}
 
-	sweeper := newSweeper(walletSvc, repoManager, builder, scheduler)
+	sweeper := newSweeper(
+		walletSvc, repoManager, builder, scheduler, metrics, sweepConfig,
+	)
 
 	svc := &service{
 		network, onchainNetwork, pubkey,
 		roundLifetime, roundInterval, unilateralExitDelay, minRelayFee,
+		exitFee, feeSponsorshipThreshold, feePolicy, limits,
 		walletSvc, repoManager, builder, scanner, sweeper,
-		paymentRequests, forfeitTxs, eventsCh, onboardingCh,
+		newWatchtower(walletSvc, repoManager, unilateralExitDelay), metrics,
+		paymentPolicy,
+		newRoundPolicy(policy, roundInterval, walletSvc), newRoundTracer(),
+		&atomic.Pointer[treeSigningSession]{},
+		&atomic.Pointer[forfeitSigningSession]{},
+		paymentRequests, forfeitTxs, newMailboxes(),
+		newAsyncPaymentsMap(), &sync.Mutex{}, eventsCh, onboardingCh,
+		make(chan struct{}, 1), &atomic.Bool{},
+		make(chan struct{}), nil, &atomic.Value{}, &sync.Once{}, &atomic.Value{},
+		&sync.RWMutex{}, false,
 		&sync.Mutex{}, make(map[string]*secp256k1.PublicKey),
 	}
 	repoManager.RegisterEventsHandler(
 		func(round *domain.Round) {
 			go svc.propagateEvents(round)
+			go svc.recordRoundMetrics(round)
 			go func() {
 				// utxo db must be updated before scheduling the sweep events
 				svc.updateVtxoSet(round)
@@ -134,27 +269,104 @@ func (s *service) Start() error {
 	}
 
 	log.Debug("starting app service")
+	s.roundsDoneCh = make(chan struct{})
 	go s.start()
 	return nil
 }
 
 func (s *service) Stop() {
-	s.sweeper.stop()
-	// nolint
-	vtxos, _ := s.repoManager.Vtxos().GetAllSweepableVtxos(context.Background())
-	if len(vtxos) > 0 {
-		s.stopWatchingVtxos(vtxos)
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		if s.roundsDoneCh != nil {
+			s.waitForRoundsToEnd()
+		}
+
+		s.sweeper.stop()
+		s.watchtower.stop()
+		// nolint
+		vtxos, _ := s.repoManager.Vtxos().GetAllSweepableVtxos(context.Background())
+		if len(vtxos) > 0 {
+			s.stopWatchingVtxos(vtxos)
+		}
+
+		s.wallet.Close()
+		log.Debug("closed connection to wallet")
+		s.paymentPolicy.Close()
+		s.repoManager.Close()
+		log.Debug("closed connection to db")
+
+		s.eventsLock.Lock()
+		close(s.eventsCh)
+		s.eventsClosed = true
+		s.eventsLock.Unlock()
+		close(s.onboardingCh)
+	})
+}
+
+// waitForRoundsToEnd waits for the current round to be finalized or aborted,
+// and for its end to be notified to the clients. It gives up after the
+// duration of a round, in case the loop of the rounds is stuck.
+func (s *service) waitForRoundsToEnd() {
+	timeout := time.After(
+		time.Duration(s.roundPolicy.currentInterval())*time.Second +
+			shutdownGracePeriod,
+	)
+	select {
+	case <-s.roundsDoneCh:
+	case <-timeout:
+		log.Warn("timed out waiting for the current round to end")
+		return
 	}
 
-	s.wallet.Close()
-	log.Debug("closed connection to wallet")
-	s.repoManager.Close()
-	log.Debug("closed connection to db")
-	close(s.eventsCh)
-	close(s.onboardingCh)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for s.lastNotifiedRound.Load() != s.lastRoundId.Load() {
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			log.Warn("timed out notifying the end of the last round")
+			return
+		}
+	}
+	log.Debugf("ended rounds, last one %v", s.lastRoundId.Load())
 }
 
-func (s *service) SpendVtxos(ctx context.Context, inputs []domain.VtxoKey) (string, error) {
+func (s *service) isStopping() bool {
+	select {
+	case <-s.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *service) SpendVtxos(
+	ctx context.Context, inputs []domain.VtxoKey, clientPaymentId string,
+	signatures []string,
+) (id string, err error) {
+	ctx, span := s.roundTracer.startRequest(
+		ctx, "payment.registration", attribute.Int("payment.inputs", len(inputs)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if s.isStopping() {
+		return "", errShuttingDown
+	}
+	if s.registrationsPaused.Load() {
+		return "", errRegistrationsPaused
+	}
+	if len(inputs) > s.registrationLimits.MaxInputs {
+		return "", fmt.Errorf(
+			"too many inputs, max %d per payment", s.registrationLimits.MaxInputs,
+		)
+	}
+	if len(signatures) != len(inputs) {
+		return "", fmt.Errorf("missing signatures of the inputs")
+	}
+	if s.paymentRequests.size() >= s.registrationLimits.MaxQueuedPayments {
+		return "", errTooManyPayments
+	}
+
 	vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, inputs)
 	if err != nil {
 		return "", err
@@ -165,27 +377,89 @@ func (s *service) SpendVtxos(ctx context.Context, inputs []domain.VtxoKey) (stri
 		}
 	}
 
-	payment, err := domain.NewPayment(vtxos)
-	if err != nil {
+	// the owners of the inputs prove to be registering them, so that nobody
+	// else can lock their vtxos into bogus payments. The signature of an
+	// owner is the same for all its inputs, so it's verified once.
+	hash := common.RegistrationHash(clientPaymentId, outpoints(inputs))
+	verifiedSigs := make(map[string]string)
+	for i, v := range vtxos {
+		if sig, ok := verifiedSigs[v.Pubkey]; ok && sig == signatures[i] {
+			continue
+		}
+		if err := verifyOwnerSig(hash, v, signatures[i]); err != nil {
+			return "", err
+		}
+		verifiedSigs[v.Pubkey] = signatures[i]
+	}
+
+	if err := s.paymentPolicy.AcceptRegistration(ctx, vtxos); err != nil {
 		return "", err
 	}
-	if err := s.paymentRequests.push(*payment); err != nil {
+
+	payment, err := domain.NewPayment(vtxos)
+	if err != nil {
 		return "", err
 	}
-	return payment.Id, nil
+	return s.paymentRequests.push(
+		*payment, clientPaymentId, s.registrationLimits.MaxPaymentsPerPubkey,
+	)
 }
 
-func (s *service) ClaimVtxos(ctx context.Context, creds string, receivers []domain.Receiver) error {
+func (s *service) ClaimVtxos(
+	ctx context.Context, creds string, receivers []domain.Receiver,
+	ephemeralPubkey string,
+) (err error) {
+	ctx, span := s.roundTracer.startRequest(
+		ctx, "payment.claim", attribute.Int("payment.receivers", len(receivers)),
+	)
+	defer func() { endSpan(span, err) }()
+
 	// Check credentials
 	payment, ok := s.paymentRequests.view(creds)
 	if !ok {
 		return fmt.Errorf("invalid credentials")
 	}
+	if s.builder.RequiresTreeSigning() {
+		if len(ephemeralPubkey) <= 0 {
+			return fmt.Errorf("missing ephemeral pubkey")
+		}
+		buf, err := hex.DecodeString(ephemeralPubkey)
+		if err != nil {
+			return fmt.Errorf("invalid ephemeral pubkey")
+		}
+		if _, err := secp256k1.ParsePubKey(buf); err != nil {
+			return fmt.Errorf("invalid ephemeral pubkey")
+		}
+	}
+	// a retried claim must not add the receivers twice.
+	if sameReceivers(payment.Receivers, receivers) {
+		return s.paymentRequests.update(*payment, ephemeralPubkey)
+	}
 
-	if err := payment.AddReceivers(receivers); err != nil {
+	if err := s.paymentPolicy.AcceptClaim(ctx, *payment, receivers); err != nil {
 		return err
 	}
-	return s.paymentRequests.update(*payment)
+
+	fees := s.feePolicy.paymentFees(payment.Inputs, receivers) +
+		s.exitFees(receivers)
+	if err := payment.AddReceiversWithMinFee(receivers, fees); err != nil {
+		return err
+	}
+	return s.paymentRequests.update(*payment, ephemeralPubkey)
+}
+
+// exitFees returns the fees charged for the cooperative exits among the
+// given receivers. Exits below the sponsorship threshold are free, their
+// onchain fees are absorbed by the ASP.
+func (s *service) exitFees(receivers []domain.Receiver) uint64 {
+	fees := uint64(0)
+	for _, r := range receivers {
+		if !r.IsOnchain() || r.Amount < s.feeSponsorshipThreshold {
+			continue
+		}
+		fees += s.exitFee
+	}
+	return fees
 }
 
 func (s *service) UpdatePaymentStatus(_ context.Context, id string) ([]string, error) {
@@ -201,10 +475,224 @@ func (s *service) UpdatePaymentStatus(_ context.Context, id string) ([]string, e
 	return nil, nil
 }
 
-func (s *service) SignVtxos(ctx context.Context, forfeitTxs []string) error {
+func (s *service) SignVtxos(ctx context.Context, forfeitTxs []string) (err error) {
+	_, span := s.roundTracer.startRequest(
+		ctx, "forfeits.submission", attribute.Int("forfeits", len(forfeitTxs)),
+	)
+	defer func() { endSpan(span, err) }()
+
 	return s.forfeitTxs.sign(forfeitTxs)
 }
 
+func (s *service) CreateAsyncPayment(
+	ctx context.Context, inputs []domain.VtxoKey, receivers []domain.Receiver,
+) (string, error) {
+	vtxos, err := s.getAsyncPaymentInputs(ctx, inputs)
+	if err != nil {
+		return "", err
+	}
+
+	inputAmount, outputAmount := uint64(0), uint64(0)
+	for _, v := range vtxos {
+		inputAmount += v.Amount
+	}
+	for _, r := range receivers {
+		outputAmount += r.Amount
+	}
+	if outputAmount+s.minRelayFee > inputAmount {
+		return "", fmt.Errorf(
+			"not enough inputs amount %d to pay %d and %d of fees",
+			inputAmount, outputAmount, s.minRelayFee,
+		)
+	}
+
+	redeemTx, err := s.builder.BuildAsyncPaymentTx(vtxos, s.pubkey, receivers)
+	if err != nil {
+		return "", err
+	}
+
+	ptx, err := psetv2.NewPsetFromBase64(redeemTx)
+	if err != nil {
+		return "", err
+	}
+	utx, err := ptx.UnsignedTx()
+	if err != nil {
+		return "", err
+	}
+
+	s.asyncPayments.push(utx.TxHash().String(), asyncPayment{
+		redeemTx, vtxos, receivers, time.Now(),
+	})
+	return redeemTx, nil
+}
+
+// CompleteAsyncPayment verifies the signatures of the redeem tx made by the
+// owners of its inputs, co-signs it and replaces the inputs with the vtxos
+// of the receivers, that can claim them in the next rounds.
+func (s *service) CompleteAsyncPayment(ctx context.Context, redeemTx string) error {
+	ptx, err := psetv2.NewPsetFromBase64(redeemTx)
+	if err != nil {
+		return fmt.Errorf("failed to parse redeem tx: %s", err)
+	}
+	utx, err := ptx.UnsignedTx()
+	if err != nil {
+		return fmt.Errorf("failed to parse redeem tx: %s", err)
+	}
+	txid := utx.TxHash().String()
+
+	s.asyncPaymentsLock.Lock()
+	defer s.asyncPaymentsLock.Unlock()
+
+	payment, ok := s.asyncPayments.view(txid)
+	if !ok {
+		return fmt.Errorf("async payment %s not found or expired", txid)
+	}
+
+	// only the signatures are taken from the given tx, the rest of the
+	// redeem tx must be the one created by the ASP.
+	unsignedTx, err := psetv2.NewPsetFromBase64(payment.redeemTx)
+	if err != nil {
+		return err
+	}
+	genesisHash, _ := chainhash.NewHashFromStr(s.onchainNework.GenesisBlockHash)
+	for i, vtxo := range payment.inputs {
+		if err := s.verifyAsyncPaymentSig(
+			genesisHash, unsignedTx, ptx, i, vtxo,
+		); err != nil {
+			return fmt.Errorf("input %s:%d: %s", vtxo.Txid, vtxo.VOut, err)
+		}
+	}
+
+	// the inputs might have been spent since the creation of the payment.
+	keys := make([]domain.VtxoKey, 0, len(payment.inputs))
+	for _, v := range payment.inputs {
+		keys = append(keys, v.VtxoKey)
+	}
+	inputs, err := s.getAsyncPaymentInputs(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	b64, err := unsignedTx.ToBase64()
+	if err != nil {
+		return err
+	}
+	signedRedeemTx, err := s.wallet.SignPsetWithKey(ctx, b64, nil)
+	if err != nil {
+		return fmt.Errorf("failed to sign redeem tx: %s", err)
+	}
+
+	// the new vtxos expire with the earliest input and are swept along
+	// with its round.
+	earliest := inputs[0]
+	for _, v := range inputs[1:] {
+		if v.ExpireAt < earliest.ExpireAt {
+			earliest = v
+		}
+	}
+	newVtxos := make([]domain.Vtxo, 0, len(payment.receivers))
+	for i, r := range payment.receivers {
+		newVtxos = append(newVtxos, domain.Vtxo{
+			VtxoKey:  domain.VtxoKey{Txid: txid, VOut: uint32(i)},
+			Receiver: r,
+			PoolTx:   earliest.PoolTx,
+			ExpireAt: earliest.ExpireAt,
+			RedeemTx: signedRedeemTx,
+		})
+	}
+
+	if err := s.repoManager.Vtxos().SpendVtxos(ctx, keys, txid); err != nil {
+		return err
+	}
+	if err := s.repoManager.Vtxos().AddVtxos(ctx, newVtxos); err != nil {
+		return err
+	}
+	s.asyncPayments.delete(txid)
+	log.Debugf("completed async payment %s", txid)
+
+	if err := s.startWatchingVtxos(newVtxos); err != nil {
+		log.WithError(err).Warn("failed to start watching async vtxos")
+	}
+	return nil
+}
+
+// getAsyncPaymentInputs returns the given vtxos if they can be spent out of
+// round, that is if they're not spent, gone or about to be spent in a round.
+func (s *service) getAsyncPaymentInputs(
+	ctx context.Context, inputs []domain.VtxoKey,
+) ([]domain.Vtxo, error) {
+	if len(inputs) <= 0 {
+		return nil, fmt.Errorf("missing inputs")
+	}
+	vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// there might be no ongoing round in between two of them.
+	// nolint:all
+	round, _ := s.repoManager.Rounds().GetCurrentRound(ctx)
+
+	now := time.Now().Unix()
+	for _, v := range vtxos {
+		var reason string
+		switch {
+		case v.Spent:
+			reason = "spent"
+		case v.Redeemed:
+			reason = "redeemed"
+		case v.Swept:
+			reason = "swept"
+		case v.ExpireAt > 0 && v.ExpireAt <= now:
+			reason = "expired"
+		case s.paymentRequests.includes(v.VtxoKey):
+			reason = "registered for a round"
+		case round != nil && round.IncludesInput(v.VtxoKey):
+			reason = "spent in the ongoing round"
+		default:
+			continue
+		}
+		return nil, fmt.Errorf("input %s:%d %s", v.Txid, v.VOut, reason)
+	}
+	return vtxos, nil
+}
+
+// verifyAsyncPaymentSig checks that the input of the signed redeem tx has a
+// valid signature of the owner of the vtxo, and copies it to the unsigned tx.
+func (s *service) verifyAsyncPaymentSig(
+	genesisHash *chainhash.Hash, unsignedTx, signedTx *psetv2.Pset,
+	index int, vtxo domain.Vtxo,
+) error {
+	if index >= len(signedTx.Inputs) {
+		return fmt.Errorf("missing input")
+	}
+
+	buf, err := hex.DecodeString(vtxo.Pubkey)
+	if err != nil {
+		return err
+	}
+	owner, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return err
+	}
+
+	for _, tapScriptSig := range signedTx.Inputs[index].TapScriptSig {
+		if !bytes.Equal(tapScriptSig.PubKey, owner.SerializeCompressed()[1:]) {
+			continue
+		}
+		if err := verifyTapscriptSig(
+			genesisHash, unsignedTx, index, tapScriptSig,
+		); err != nil {
+			return err
+		}
+		unsignedTx.Inputs[index].TapScriptSig = append(
+			unsignedTx.Inputs[index].TapScriptSig, tapScriptSig,
+		)
+		return nil
+	}
+	return fmt.Errorf("missing signature of the owner")
+}
+
 func (s *service) ListVtxos(ctx context.Context, pubkey *secp256k1.PublicKey) ([]domain.Vtxo, []domain.Vtxo, error) {
 	pk := hex.EncodeToString(pubkey.SerializeCompressed())
 	return s.repoManager.Vtxos().GetAllVtxos(ctx, pk)
@@ -218,6 +706,77 @@ func (s *service) GetRoundByTxid(ctx context.Context, poolTxid string) (*domain.
 	return s.repoManager.Rounds().GetRoundWithTxid(ctx, poolTxid)
 }
 
+func (s *service) GetRoundById(ctx context.Context, id string) (*domain.Round, error) {
+	return s.repoManager.Rounds().GetRoundWithId(ctx, id)
+}
+
+// GetReceipt returns the receipt, signed by the ASP, of a vtxo created by a
+// finalized round.
+func (s *service) GetReceipt(
+	ctx context.Context, vtxoKey domain.VtxoKey,
+) (*common.Receipt, error) {
+	vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, []domain.VtxoKey{vtxoKey})
+	if err != nil {
+		return nil, err
+	}
+	if len(vtxos) <= 0 {
+		return nil, fmt.Errorf("vtxo %s:%d not found", vtxoKey.Txid, vtxoKey.VOut)
+	}
+	vtxo := vtxos[0]
+	if len(vtxo.RedeemTx) > 0 {
+		return nil, fmt.Errorf(
+			"vtxo %s:%d was not created by a round", vtxoKey.Txid, vtxoKey.VOut,
+		)
+	}
+
+	round, err := s.repoManager.Rounds().GetRoundWithTxid(ctx, vtxo.PoolTx)
+	if err != nil {
+		return nil, err
+	}
+	if !round.IsEnded() {
+		return nil, fmt.Errorf("round %s is not finalized", round.Id)
+	}
+
+	receipt := &common.Receipt{
+		PoolTxid:  vtxo.PoolTx,
+		VtxoTxid:  vtxo.Txid,
+		Vout:      vtxo.VOut,
+		Amount:    vtxo.Amount,
+		Pubkey:    vtxo.Pubkey,
+		AspPubkey: hex.EncodeToString(s.pubkey.SerializeCompressed()),
+		CreatedAt: round.EndingTimestamp,
+	}
+	proof, err := receipt.ProofPset(s.onchainNework)
+	if err != nil {
+		return nil, err
+	}
+	b64, err := proof.ToBase64()
+	if err != nil {
+		return nil, err
+	}
+	signedProof, err := s.wallet.SignPsetWithKey(ctx, b64, []int{0})
+	if err != nil {
+		return nil, err
+	}
+	signed, err := psetv2.NewPsetFromBase64(signedProof)
+	if err != nil {
+		return nil, err
+	}
+
+	aspXonly := s.pubkey.SerializeCompressed()[1:]
+	for _, tapScriptSig := range signed.Inputs[0].TapScriptSig {
+		if !bytes.Equal(tapScriptSig.PubKey, aspXonly) {
+			continue
+		}
+		receipt.Signature = hex.EncodeToString(tapScriptSig.Signature)
+		if err := receipt.Verify(s.onchainNework); err != nil {
+			return nil, err
+		}
+		return receipt, nil
+	}
+	return nil, fmt.Errorf("missing asp signature of the receipt")
+}
+
 func (s *service) GetCurrentRound(ctx context.Context) (*domain.Round, error) {
 	return s.repoManager.Rounds().GetCurrentRound(ctx)
 }
@@ -226,15 +785,32 @@ func (s *service) GetInfo(ctx context.Context) (*ServiceInfo, error) {
 	pubkey := hex.EncodeToString(s.pubkey.SerializeCompressed())
 
 	return &ServiceInfo{
-		PubKey:              pubkey,
-		RoundLifetime:       s.roundLifetime,
-		UnilateralExitDelay: s.unilateralExitDelay,
-		RoundInterval:       s.roundInterval,
-		Network:             s.network.Name,
-		MinRelayFee:         int64(s.minRelayFee),
+		PubKey:                  pubkey,
+		RoundLifetime:           s.roundLifetime,
+		UnilateralExitDelay:     s.unilateralExitDelay,
+		RoundInterval:           s.roundPolicy.currentInterval(),
+		Network:                 s.network.Name,
+		MinRelayFee:             int64(s.minRelayFee),
+		ExitFee:                 int64(s.exitFee),
+		FeeSponsorshipThreshold: int64(s.feeSponsorshipThreshold),
+		FeePolicy:               s.feePolicy,
+		Capabilities:            s.capabilities(),
 	}, nil
 }
 
+// capabilities returns the protocol features supported by the ASP, clients
+// must support the kind of congestion tree of the tx builder to take part to
+// rounds.
+func (s *service) capabilities() common.Capabilities {
+	if s.builder.RequiresTreeSigning() {
+		return common.Capabilities(0).
+			Set(common.CapabilityCovenantlessTree, true)
+	}
+	return common.Capabilities(0).
+		Set(common.CapabilityCovenantTree, true).
+		Set(common.CapabilityAsyncPayments, false)
+}
+
 func (s *service) Onboard(
 	ctx context.Context, boardingTx string,
 	congestionTree tree.CongestionTree, userPubkey *secp256k1.PublicKey,
@@ -279,6 +855,114 @@ func (s *service) Onboard(
 	return nil
 }
 
+func (s *service) SponsorOnboarding(
+	ctx context.Context, boardingTx string, userPubkey *secp256k1.PublicKey,
+) (string, error) {
+	if s.feeSponsorshipThreshold <= 0 {
+		return "", fmt.Errorf("onboardings are not sponsored")
+	}
+
+	ptx, err := psetv2.NewPsetFromBase64(boardingTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse boarding tx: %s", err)
+	}
+	if len(ptx.Outputs) <= 0 {
+		return "", fmt.Errorf("missing shared output")
+	}
+	for _, out := range ptx.Outputs {
+		if len(out.Script) <= 0 {
+			return "", fmt.Errorf("boarding tx must not have a fee output")
+		}
+	}
+
+	// the shared output of the tree, the first one, must pay to the user the
+	// onboarded amount less the fees of the tree.
+	sharedOutput := ptx.Outputs[0]
+	treeFees, err := s.onboardingTreeFees(userPubkey, sharedOutput.Value)
+	if err != nil {
+		return "", err
+	}
+	if sharedOutput.Value < treeFees+dustAmount {
+		return "", fmt.Errorf("invalid shared output amount")
+	}
+	amount := sharedOutput.Value - treeFees
+	_, sharedOutputScript, sharedOutputAmount, err := tree.CraftCongestionTree(
+		s.onchainNework.AssetID, s.pubkey, []tree.Receiver{{
+			Pubkey: hex.EncodeToString(userPubkey.SerializeCompressed()),
+			Amount: amount,
+		}},
+		s.minRelayFee, s.roundLifetime, s.unilateralExitDelay,
+	)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(sharedOutput.Script, sharedOutputScript) ||
+		sharedOutput.Value != sharedOutputAmount {
+		return "", fmt.Errorf("invalid shared output")
+	}
+	if amount >= s.feeSponsorshipThreshold {
+		return "", fmt.Errorf(
+			"onboardings of at least %d sats are not sponsored",
+			s.feeSponsorshipThreshold,
+		)
+	}
+
+	// only the fees are paid by the ASP, the onboarded amount must be covered
+	// by the inputs of the user, without any change left to the ASP.
+	inAmount, outAmount := uint64(0), uint64(0)
+	for _, in := range ptx.Inputs {
+		if in.WitnessUtxo == nil {
+			return "", fmt.Errorf("missing prevout of boarding tx input")
+		}
+		value, err := elementsutil.ValueFromBytes(in.WitnessUtxo.Value)
+		if err != nil {
+			return "", fmt.Errorf("boarding tx inputs must be unblinded")
+		}
+		inAmount += value
+	}
+	for _, out := range ptx.Outputs {
+		outAmount += out.Value
+	}
+	if inAmount+treeFees < outAmount {
+		return "", fmt.Errorf(
+			"boarding tx inputs must cover the onboarded amount, only the fees are sponsored",
+		)
+	}
+	if inAmount >= outAmount+dustAmount {
+		return "", fmt.Errorf("boarding tx inputs exceed its outputs")
+	}
+
+	fundedTx, err := s.builder.FundBoardingTx(s.pubkey, boardingTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fund boarding tx: %s", err)
+	}
+	signedTx, err := s.wallet.SignPset(ctx, fundedTx, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign boarding tx: %s", err)
+	}
+
+	log.Debugf("sponsored onboarding of %d sats", amount)
+	return signedTx, nil
+}
+
+// onboardingTreeFees returns the fees of the congestion tree of an onboarding,
+// that don't depend on the onboarded amount.
+func (s *service) onboardingTreeFees(
+	userPubkey *secp256k1.PublicKey, amount uint64,
+) (uint64, error) {
+	_, _, sharedOutputAmount, err := tree.CraftCongestionTree(
+		s.onchainNework.AssetID, s.pubkey, []tree.Receiver{{
+			Pubkey: hex.EncodeToString(userPubkey.SerializeCompressed()),
+			Amount: amount,
+		}},
+		s.minRelayFee, s.roundLifetime, s.unilateralExitDelay,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return sharedOutputAmount - amount, nil
+}
+
 func (s *service) TrustedOnboarding(
 	ctx context.Context, userPubKey *secp256k1.PublicKey,
 ) (string, error) {
@@ -318,47 +1002,153 @@ func (s *service) TrustedOnboarding(
 	return address, nil
 }
 
+func (s *service) PushSyncMessage(
+	_ context.Context, mailboxId, payload string,
+) (uint64, uint64, error) {
+	return s.mailboxes.push(mailboxId, payload)
+}
+
+func (s *service) GetSyncMessages(
+	_ context.Context, mailboxId string, epoch, afterSequence uint64,
+) ([]SyncMessage, uint64, error) {
+	messages, currentEpoch := s.mailboxes.view(mailboxId, epoch, afterSequence)
+	return messages, currentEpoch, nil
+}
+
+func (s *service) TriggerRound(ctx context.Context) error {
+	round, err := s.repoManager.Rounds().GetCurrentRound(ctx)
+	if err != nil {
+		return err
+	}
+	if round.Stage.Code != domain.RegistrationStage || round.IsFailed() {
+		return fmt.Errorf("current round is not in registration stage")
+	}
+
+	select {
+	case s.roundTriggerCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *service) PauseRegistrations(_ context.Context) {
+	s.registrationsPaused.Store(true)
+	log.Info("paused payment registrations")
+}
+
+func (s *service) ResumeRegistrations(_ context.Context) {
+	s.registrationsPaused.Store(false)
+	log.Info("resumed payment registrations")
+}
+
+func (s *service) GetRegistrationsStatus(_ context.Context) (bool, int64) {
+	return s.registrationsPaused.Load(), s.paymentRequests.len()
+}
+
+func (s *service) GetRoundPolicy(_ context.Context) RoundPolicy {
+	return s.roundPolicy.get()
+}
+
+func (s *service) UpdateRoundPolicy(_ context.Context, policy RoundPolicy) error {
+	return s.roundPolicy.set(policy)
+}
+
 func (s *service) start() {
 	s.startRound()
 }
 
 func (s *service) startRound() {
+	if s.isStopping() {
+		close(s.roundsDoneCh)
+		return
+	}
+
+	// drop any trigger left from the previous round.
+	select {
+	case <-s.roundTriggerCh:
+	default:
+	}
+
 	round := domain.NewRound(dustAmount)
+	s.lastRoundId.Store(round.Id)
+	s.roundTracer.startRound(round.Id)
 	changes, _ := round.StartRegistration()
 	if err := s.saveEvents(
 		context.Background(), round.Id, changes,
 	); err != nil {
+		s.roundTracer.endRound(err)
 		log.WithError(err).Warn("failed to store new round events")
 		return
 	}
 
+	s.roundPolicy.update(context.Background(), s.paymentRequests.len())
+	interval := s.roundPolicy.currentInterval()
+	registration := s.roundPolicy.currentRegistrationDuration(interval)
+
+	_, span := s.roundTracer.startStage("round.registration")
 	defer func() {
-		time.Sleep(time.Duration(s.roundInterval/2) * time.Second)
-		s.startFinalization()
+		select {
+		case <-time.After(time.Duration(registration) * time.Second):
+		case <-s.roundTriggerCh:
+			log.Debugf("round %s triggered by the operator", round.Id)
+		case <-s.stopCh:
+			span.End()
+			s.abortRound(round, errShuttingDown)
+			close(s.roundsDoneCh)
+			return
+		}
+		span.End()
+		s.startFinalization(interval - registration)
 	}()
 
 	log.Debugf("started registration stage for new round: %s", round.Id)
 }
 
-func (s *service) startFinalization() {
+// abortRound fails the given round in registration stage, notifying the
+// clients. The registered payments are left in the queue.
+func (s *service) abortRound(round *domain.Round, reason error) {
+	err := fmt.Errorf("round aborted: %s", reason)
+	changes := round.Fail(err)
+	if err := s.saveEvents(context.Background(), round.Id, changes); err != nil {
+		log.WithError(err).Warn("failed to store new round events")
+	}
+	s.roundTracer.endRound(err)
+	log.Debugf("round %s aborted: %s", round.Id, reason)
+}
+
+// startFinalization builds the txs of the round, and has the congestion tree
+// signed by its cosigners if required, then waits for the forfeits to be
+// signed until the given seconds are elapsed before finalizing it.
+func (s *service) startFinalization(duration int64) {
 	ctx := context.Background()
+	startedAt := time.Now()
 	round, err := s.repoManager.Rounds().GetCurrentRound(ctx)
 	if err != nil {
 		log.WithError(err).Warn("failed to retrieve current round")
 		return
 	}
 
+	_, span := s.roundTracer.startStage("round.tree_building")
 	var changes []domain.RoundEvent
 	defer func() {
 		if err := s.saveEvents(ctx, round.Id, changes); err != nil {
 			log.WithError(err).Warn("failed to store new round events")
 		}
 
+		failure := roundFailure(changes)
+		endSpan(span, failure)
 		if round.IsFailed() {
+			s.roundTracer.endRound(failure)
 			s.startRound()
 			return
 		}
-		time.Sleep(time.Duration((s.roundInterval/2)-1) * time.Second)
+
+		// the forfeits are signed by the clients in the meanwhile.
+		_, signingSpan := s.roundTracer.startStage("round.forfeits_signing")
+		time.Sleep(time.Until(
+			startedAt.Add(time.Duration(duration-1) * time.Second),
+		))
+		signingSpan.End()
 		s.finalizeRound()
 	}()
 
@@ -366,18 +1156,48 @@ func (s *service) startFinalization() {
 		return
 	}
 
-	// TODO: understand how many payments must be popped from the queue and actually registered for the round
 	num := s.paymentRequests.len()
+	s.roundPolicy.registered(num)
+	span.SetAttributes(attribute.Int64("round.registered_payments", num))
 	if num == 0 {
 		err := fmt.Errorf("no payments registered")
 		changes = round.Fail(fmt.Errorf("round aborted: %s", err))
 		log.WithError(err).Debugf("round %s aborted", round.Id)
 		return
 	}
-	if num > paymentsThreshold {
-		num = paymentsThreshold
+	// the payments are left in the queue for the next round.
+	if minPayments := s.roundPolicy.minPayments(); num < minPayments {
+		err := fmt.Errorf(
+			"not enough payments registered (%d/%d)", num, minPayments,
+		)
+		changes = round.Fail(fmt.Errorf("round aborted: %s", err))
+		log.WithError(err).Debugf("round %s aborted", round.Id)
+		return
+	}
+	if size := s.roundPolicy.currentSize(); num > size {
+		num = size
+	}
+	popped, ephemeralPubkeys := s.paymentRequests.pop(num)
+	payments := s.rejectPaymentsWithInvalidInputs(ctx, round.Id, popped)
+	if len(payments) <= 0 {
+		err := fmt.Errorf("no valid payments registered")
+		changes = round.Fail(fmt.Errorf("round aborted: %s", err))
+		log.WithError(err).Debugf("round %s aborted", round.Id)
+		return
+	}
+	// the payments the ASP can't fund are left for the next rounds.
+	payments, err = s.fundablePayments(ctx, payments, ephemeralPubkeys)
+	if err != nil {
+		changes = round.Fail(fmt.Errorf("failed to check liquidity: %s", err))
+		log.WithError(err).Warn("failed to check liquidity")
+		return
+	}
+	if len(payments) <= 0 {
+		err := fmt.Errorf("not enough liquidity to fund the payments")
+		changes = round.Fail(fmt.Errorf("round aborted: %s", err))
+		log.WithError(err).Warnf("round %s aborted", round.Id)
+		return
 	}
-	payments := s.paymentRequests.pop(num)
 	changes, err = round.RegisterPayments(payments)
 	if err != nil {
 		changes = round.Fail(fmt.Errorf("failed to register payments: %s", err))
@@ -392,15 +1212,51 @@ func (s *service) startFinalization() {
 		return
 	}
 
-	unsignedPoolTx, tree, connectorAddress, err := s.builder.BuildPoolTx(s.pubkey, payments, s.minRelayFee, sweptRounds)
+	// the ASP cosigns the tree with an ephemeral key as well.
+	var cosigners []*secp256k1.PublicKey
+	var aspSigningKey *secp256k1.PrivateKey
+	if s.builder.RequiresTreeSigning() {
+		aspSigningKey, err = secp256k1.GeneratePrivateKey()
+		if err != nil {
+			changes = round.Fail(fmt.Errorf("failed to generate signing key: %s", err))
+			log.WithError(err).Warn("failed to generate signing key")
+			return
+		}
+		cosigners, err = getCosigners(payments, ephemeralPubkeys)
+		if err != nil {
+			changes = round.Fail(fmt.Errorf("failed to retrieve cosigners: %s", err))
+			log.WithError(err).Warn("failed to retrieve cosigners")
+			return
+		}
+		cosigners = append(cosigners, aspSigningKey.PubKey())
+	}
+
+	unsignedPoolTx, tree, connectorAddress, err := s.builder.BuildPoolTx(s.pubkey, payments, s.minRelayFee, sweptRounds, cosigners...)
 	if err != nil {
 		changes = round.Fail(fmt.Errorf("failed to create pool tx: %s", err))
 		log.WithError(err).Warn("failed to create pool tx")
 		return
 	}
 	log.Debugf("pool tx created for round %s", round.Id)
-
-	// TODO BTC make the senders sign the tree
+	span.AddEvent("pool tx created")
+
+	// the cosigners have half of the stage to sign the tree, the rest is
+	// left for the forfeits.
+	if s.builder.RequiresTreeSigning() && len(tree) > 0 {
+		_, treeSigningSpan := s.roundTracer.startStage("round.tree_signing")
+		signedTree, err := s.signCongestionTree(
+			round.Id, tree, unsignedPoolTx, aspSigningKey, cosigners,
+			startedAt.Add(time.Duration(duration)*time.Second/2),
+		)
+		endSpan(treeSigningSpan, err)
+		if err != nil {
+			changes = round.Fail(fmt.Errorf("failed to sign congestion tree: %s", err))
+			log.WithError(err).Warn("failed to sign congestion tree")
+			return
+		}
+		tree = signedTree
+		log.Debugf("congestion tree signed for round %s", round.Id)
+	}
 
 	connectors, forfeitTxs, err := s.builder.BuildForfeitTxs(s.pubkey, unsignedPoolTx, payments, s.minRelayFee)
 	if err != nil {
@@ -410,6 +1266,21 @@ func (s *service) startFinalization() {
 	}
 
 	log.Debugf("forfeit transactions created for round %s", round.Id)
+	span.AddEvent("forfeit txs created")
+
+	// the vtxos of the covenantless trees are forfeited with the MuSig2 key
+	// of their owner and the ASP.
+	if s.builder.RequiresTreeSigning() {
+		session, err := newForfeitSigningSession(
+			round.Id, forfeitTxs, payments, s.pubkey, s.unilateralExitDelay,
+		)
+		if err != nil {
+			changes = round.Fail(fmt.Errorf("failed to start forfeits signing: %s", err))
+			log.WithError(err).Warn("failed to start forfeits signing")
+			return
+		}
+		s.forfeitSigning.Store(session)
+	}
 
 	events, err := round.StartFinalization(connectorAddress, connectors, tree, unsignedPoolTx)
 	if err != nil {
@@ -426,6 +1297,7 @@ func (s *service) startFinalization() {
 
 func (s *service) finalizeRound() {
 	defer s.startRound()
+	s.forfeitSigning.Store(nil)
 
 	ctx := context.Background()
 	round, err := s.repoManager.Rounds().GetCurrentRound(ctx)
@@ -437,8 +1309,13 @@ func (s *service) finalizeRound() {
 		return
 	}
 
+	ctx, span := s.roundTracer.startStage("round.finalization")
 	var changes []domain.RoundEvent
 	defer func() {
+		failure := roundFailure(changes)
+		endSpan(span, failure)
+		s.roundTracer.endRound(failure)
+
 		if err := s.saveEvents(ctx, round.Id, changes); err != nil {
 			log.WithError(err).Warn("failed to store new round events")
 			return
@@ -461,7 +1338,9 @@ func (s *service) finalizeRound() {
 		return
 	}
 
-	txid, err := s.wallet.BroadcastTransaction(ctx, signedPoolTx)
+	broadcastCtx, broadcastSpan := tracer.Start(ctx, "round.broadcast")
+	txid, err := s.wallet.BroadcastTransaction(broadcastCtx, signedPoolTx)
+	endSpan(broadcastSpan, err)
 	if err != nil {
 		changes = round.Fail(fmt.Errorf("failed to broadcast pool tx: %s", err))
 		log.WithError(err).Warn("failed to broadcast pool tx")
@@ -469,10 +1348,72 @@ func (s *service) finalizeRound() {
 	}
 
 	changes, _ = round.EndFinalization(forfeitTxs, txid)
+	span.SetAttributes(attribute.String("round.txid", txid))
 
 	log.Debugf("finalized round %s with pool tx %s", round.Id, round.Txid)
 }
 
+// rejectPaymentsWithInvalidInputs excludes from the round those payments
+// whose inputs have been spent, redeemed, swept or are expired since their
+// registration. The owners are notified so that they can register again
+// with other inputs.
+func (s *service) rejectPaymentsWithInvalidInputs(
+	ctx context.Context, roundId string, payments []domain.Payment,
+) []domain.Payment {
+	validPayments := make([]domain.Payment, 0, len(payments))
+	now := time.Now().Unix()
+	for _, p := range payments {
+		keys := make([]domain.VtxoKey, 0, len(p.Inputs))
+		for _, in := range p.Inputs {
+			keys = append(keys, in.VtxoKey)
+		}
+
+		rejected := make([]domain.VtxoKey, 0)
+		reasons := make([]string, 0)
+
+		vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, keys)
+		if err != nil {
+			rejected = keys
+			reasons = append(reasons, fmt.Sprintf("failed to retrieve inputs: %s", err))
+		}
+
+		for _, v := range vtxos {
+			var reason string
+			switch {
+			case v.Spent:
+				reason = "spent"
+			case v.Redeemed:
+				reason = "redeemed"
+			case v.Swept:
+				reason = "swept"
+			case v.ExpireAt > 0 && v.ExpireAt <= now:
+				reason = "expired"
+			default:
+				continue
+			}
+			rejected = append(rejected, v.VtxoKey)
+			reasons = append(reasons, fmt.Sprintf("input %s:%d %s", v.Txid, v.VOut, reason))
+		}
+
+		if len(rejected) <= 0 {
+			validPayments = append(validPayments, p)
+			continue
+		}
+
+		log.Debugf(
+			"payment %s excluded from round %s: %s",
+			p.Id, roundId, strings.Join(reasons, ", "),
+		)
+		go s.notifyEvent(domain.PaymentInputsRejected{
+			Id:        roundId,
+			PaymentId: p.Id,
+			Inputs:    rejected,
+			Reason:    strings.Join(reasons, ", "),
+		})
+	}
+	return validPayments
+}
+
 func (s *service) listenToOnboarding() {
 	for onboarding := range s.onboardingCh {
 		go s.handleOnboarding(onboarding)
@@ -525,11 +1466,9 @@ func (s *service) listenToScannerNotifications() {
 	ctx := context.Background()
 	chVtxos := s.scanner.GetNotificationChannel(ctx)
 
-	mutx := &sync.Mutex{}
 	for vtxoKeys := range chVtxos {
 		go func(vtxoKeys map[string]ports.VtxoWithValue) {
 			vtxosRepo := s.repoManager.Vtxos()
-			roundRepo := s.repoManager.Rounds()
 
 			for script, v := range vtxoKeys {
 				//onboarding
@@ -608,144 +1547,12 @@ func (s *service) listenToScannerNotifications() {
 				}
 
 				log.Debugf("fraud detected on vtxo %s", vtxo.Txid)
-
-				round, err := roundRepo.GetRoundWithTxid(ctx, vtxo.SpentBy)
-				if err != nil {
-					log.WithError(err).Warn("failed to retrieve round")
-					continue
-				}
-
-				mutx.Lock()
-				defer mutx.Unlock()
-
-				connectorTxid, connectorVout, err := s.getNextConnector(ctx, *round)
-				if err != nil {
-					log.WithError(err).Warn("failed to retrieve next connector")
-					continue
-				}
-
-				forfeitTx, err := findForfeitTx(round.ForfeitTxs, connectorTxid, connectorVout, vtxo.Txid)
-				if err != nil {
-					log.WithError(err).Warn("failed to retrieve forfeit tx")
-					continue
-				}
-
-				if err := s.wallet.LockConnectorUtxos(ctx, []ports.TxOutpoint{txOutpoint{connectorTxid, connectorVout}}); err != nil {
-					log.WithError(err).Warn("failed to lock connector utxos")
-					continue
-				}
-
-				signedForfeitTx, err := s.wallet.SignPset(ctx, forfeitTx, false)
-				if err != nil {
-					log.WithError(err).Warn("failed to sign connector input in forfeit tx")
-					continue
-				}
-
-				signedForfeitTx, err = s.wallet.SignPsetWithKey(ctx, signedForfeitTx, []int{1})
-				if err != nil {
-					log.WithError(err).Warn("failed to sign vtxo input in forfeit tx")
-					continue
-				}
-
-				forfeitTxHex, err := finalizeAndExtractForfeit(signedForfeitTx)
-				if err != nil {
-					log.WithError(err).Warn("failed to finalize forfeit tx")
-					continue
-				}
-
-				forfeitTxid, err := s.wallet.BroadcastTransaction(ctx, forfeitTxHex)
-				if err != nil {
-					log.WithError(err).Warn("failed to broadcast forfeit tx")
-					continue
-				}
-
-				log.Debugf("broadcasted forfeit tx %s", forfeitTxid)
+				s.watchtower.watch(vtxo)
 			}
 		}(vtxoKeys)
 	}
 }
 
-func (s *service) getNextConnector(
-	ctx context.Context,
-	round domain.Round,
-) (string, uint32, error) {
-	connectorTx, err := psetv2.NewPsetFromBase64(round.Connectors[0])
-	if err != nil {
-		return "", 0, err
-	}
-
-	prevout := connectorTx.Inputs[0].WitnessUtxo
-	if prevout == nil {
-		return "", 0, fmt.Errorf("connector prevout not found")
-	}
-
-	utxos, err := s.wallet.ListConnectorUtxos(ctx, round.ConnectorAddress)
-	if err != nil {
-		return "", 0, err
-	}
-
-	// if we do not find any utxos, we make sure to wait for the connector outpoint to be confirmed then we retry
-	if len(utxos) <= 0 {
-		if err := s.wallet.WaitForSync(ctx, round.Txid); err != nil {
-			return "", 0, err
-		}
-
-		utxos, err = s.wallet.ListConnectorUtxos(ctx, round.ConnectorAddress)
-		if err != nil {
-			return "", 0, err
-		}
-	}
-
-	// search for an already existing connector
-	for _, u := range utxos {
-		if u.GetValue() == 450 {
-			return u.GetTxid(), u.GetIndex(), nil
-		}
-	}
-
-	for _, u := range utxos {
-		if u.GetValue() > 450 {
-			for _, b64 := range round.Connectors {
-				pset, err := psetv2.NewPsetFromBase64(b64)
-				if err != nil {
-					return "", 0, err
-				}
-
-				for _, i := range pset.Inputs {
-					if chainhash.Hash(i.PreviousTxid).String() == u.GetTxid() && i.PreviousTxIndex == u.GetIndex() {
-						connectorOutpoint := newOutpointFromPsetInput(pset.Inputs[0])
-
-						if err := s.wallet.LockConnectorUtxos(ctx, []ports.TxOutpoint{connectorOutpoint}); err != nil {
-							return "", 0, err
-						}
-
-						// sign & broadcast the connector tx
-						signedConnectorTx, err := s.wallet.SignPset(ctx, b64, true)
-						if err != nil {
-							return "", 0, err
-						}
-
-						connectorTxid, err := s.wallet.BroadcastTransaction(ctx, signedConnectorTx)
-						if err != nil {
-							return "", 0, err
-						}
-						log.Debugf("broadcasted connector tx %s", connectorTxid)
-
-						// wait for the connector tx to be in the mempool
-						if err := s.wallet.WaitForSync(ctx, connectorTxid); err != nil {
-							return "", 0, err
-						}
-
-						return connectorTxid, 0, nil
-					}
-				}
-			}
-		}
-	}
-
-	return "", 0, fmt.Errorf("no connector utxos found")
-}
-
 func (s *service) updateVtxoSet(round *domain.Round) {
 	// Update the vtxo set only after a round is finalized.
 	if !round.IsEnded() {
@@ -795,6 +1602,12 @@ func (s *service) updateVtxoSet(round *domain.Round) {
 }
 
 func (s *service) propagateEvents(round *domain.Round) {
+	s.eventsLock.RLock()
+	defer s.eventsLock.RUnlock()
+	if s.eventsClosed {
+		return
+	}
+
 	lastEvent := round.Events()[len(round.Events())-1]
 	switch e := lastEvent.(type) {
 	case domain.RoundFinalizationStarted:
@@ -808,6 +1621,34 @@ func (s *service) propagateEvents(round *domain.Round) {
 		}
 	case domain.RoundFinalized, domain.RoundFailed:
 		s.eventsCh <- e
+		// the onboarding rounds are not part of the loop.
+		if s.lastRoundId.Load() == round.Id {
+			s.lastNotifiedRound.Store(round.Id)
+		}
+	}
+}
+
+// notifyEvent sends to the clients an event that is not part of the history
+// of a round, unless the events channel is closed.
+func (s *service) notifyEvent(event domain.RoundEvent) {
+	s.eventsLock.RLock()
+	defer s.eventsLock.RUnlock()
+	if s.eventsClosed {
+		return
+	}
+	s.eventsCh <- event
+}
+
+func (s *service) recordRoundMetrics(round *domain.Round) {
+	lastEvent := round.Events()[len(round.Events())-1]
+	switch lastEvent.(type) {
+	case domain.RoundFinalized:
+		duration := time.Duration(
+			round.EndingTimestamp-round.StartingTimestamp,
+		) * time.Second
+		s.metrics.RoundEnded(duration, len(round.Payments))
+	case domain.RoundFailed:
+		s.metrics.RoundFailed()
 	}
 }
 
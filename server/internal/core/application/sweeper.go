@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ark-network/ark/common/tree"
@@ -21,9 +22,27 @@ type sweeper struct {
 	repoManager ports.RepoManager
 	builder     ports.TxBuilder
 	scheduler   ports.SchedulerService
+	metrics     ports.MetricsService
+	config      SweepConfig
 
 	// cache of scheduled tasks, avoid scheduling the same sweep event multiple times
 	scheduledTasks map[string]struct{}
+
+	// the expired shared outputs waiting to be swept together, flushed once
+	// the batch window elapses.
+	batchLock  sync.Mutex
+	batch      []sweepBatchEntry
+	batchTimer *time.Timer
+	// sweepLock serializes the flushes of the batches.
+	sweepLock sync.Mutex
+}
+
+// sweepBatchEntry is an expired shared output of a round, with the vtxos
+// swept with it.
+type sweepBatchEntry struct {
+	roundTxid string
+	input     ports.SweepInput
+	vtxos     []domain.VtxoKey
 }
 
 func newSweeper(
@@ -31,13 +50,17 @@ func newSweeper(
 	repoManager ports.RepoManager,
 	builder ports.TxBuilder,
 	scheduler ports.SchedulerService,
+	metrics ports.MetricsService,
+	config SweepConfig,
 ) *sweeper {
 	return &sweeper{
-		wallet,
-		repoManager,
-		builder,
-		scheduler,
-		make(map[string]struct{}),
+		wallet:         wallet,
+		repoManager:    repoManager,
+		builder:        builder,
+		scheduler:      scheduler,
+		metrics:        metrics,
+		config:         config,
+		scheduledTasks: make(map[string]struct{}),
 	}
 }
 
@@ -59,6 +82,16 @@ func (s *sweeper) start() error {
 
 func (s *sweeper) stop() {
 	s.scheduler.Stop()
+
+	// the pending shared outputs are swept at the next start, their rounds
+	// being still sweepable.
+	s.batchLock.Lock()
+	defer s.batchLock.Unlock()
+	if s.batchTimer != nil {
+		s.batchTimer.Stop()
+		s.batchTimer = nil
+	}
+	s.batch = nil
 }
 
 // removeTask update the cached map of scheduled tasks
@@ -101,7 +134,7 @@ func (s *sweeper) schedule(
 }
 
 // createTask returns a function passed as handler in the scheduler
-// it tries to craft a sweep tx containing the onchain outputs of the given congestion tree
+// it looks for the onchain shared outputs of the given congestion tree and adds the expired ones to the batch to sweep
 // if some parts of the tree have been broadcasted in the meantine, it will schedule the next taskes for the remaining parts of the tree
 func (s *sweeper) createTask(
 	roundTxid string, congestionTree tree.CongestionTree,
@@ -117,8 +150,7 @@ func (s *sweeper) createTask(
 		s.removeTask(root.Txid)
 		log.Debugf("sweeper: %s", root.Txid)
 
-		sweepInputs := make([]ports.SweepInput, 0)
-		vtxoKeys := make([]domain.VtxoKey, 0) // vtxos associated to the sweep inputs
+		entries := make([]sweepBatchEntry, 0)
 
 		// inspect the congestion tree to find onchain shared outputs
 		sharedOutputs, err := findSweepableOutputs(ctx, s.wallet, s.builder, congestionTree)
@@ -190,7 +222,8 @@ func (s *sweeper) createTask(
 					firstVtxo, err := s.repoManager.Vtxos().GetVtxos(ctx, sweepableVtxos[:1])
 					if err != nil {
 						log.Error(fmt.Errorf("error while getting vtxo: %w", err))
-						sweepInputs = append(sweepInputs, input) // add the input anyway in order to try to sweep it
+						// add the input anyway in order to try to sweep it
+						entries = append(entries, sweepBatchEntry{roundTxid, input, nil})
 						continue
 					}
 
@@ -202,81 +235,220 @@ func (s *sweeper) createTask(
 				}
 
 				if len(sweepableVtxos) > 0 {
-					vtxoKeys = append(vtxoKeys, sweepableVtxos...)
-					sweepInputs = append(sweepInputs, input)
+					entries = append(entries, sweepBatchEntry{roundTxid, input, sweepableVtxos})
 				}
 			}
 		}
 
-		vtxosRepository := s.repoManager.Vtxos()
-		if len(sweepInputs) > 0 {
-			// build the sweep transaction with all the expired non-swept shared outputs
-			sweepTx, err := s.builder.BuildSweepTx(sweepInputs)
-			if err != nil {
-				log.WithError(err).Error("error while building sweep tx")
-				return
-			}
+		if len(entries) <= 0 {
+			s.updateRound(ctx, roundTxid, 0)
+			return
+		}
 
-			err = nil
-			txid := ""
-			// retry until the tx is broadcasted or the error is not BIP68 final
-			for len(txid) == 0 && (err == nil || strings.Contains(err.Error(), "non-BIP68-final")) {
-				if err != nil {
-					log.Debugln("sweep tx not BIP68 final, retrying in 5 seconds")
-					time.Sleep(5 * time.Second)
-				}
+		s.enqueue(entries)
+	}
+}
 
-				txid, err = s.wallet.BroadcastTransaction(ctx, sweepTx)
-			}
+// enqueue adds the given expired shared outputs to the batch, flushed once
+// the batch window elapses from the first one added.
+func (s *sweeper) enqueue(entries []sweepBatchEntry) {
+	if s.config.BatchWindow <= 0 {
+		s.sweepBatch(entries)
+		return
+	}
 
-			if err != nil {
-				log.WithError(err).Error("error while broadcasting sweep tx")
-				return
-			}
-			if len(txid) > 0 {
-				log.Debugln("sweep tx broadcasted:", txid)
+	s.batchLock.Lock()
+	defer s.batchLock.Unlock()
 
-				// mark the vtxos as swept
-				if err := vtxosRepository.SweepVtxos(ctx, vtxoKeys); err != nil {
-					log.Error(fmt.Errorf("error while deleting vtxos: %w", err))
-					return
-				}
+	s.batch = append(s.batch, entries...)
+	if s.batchTimer == nil {
+		window := time.Duration(s.config.BatchWindow) * time.Second
+		s.batchTimer = time.AfterFunc(window, s.flush)
+		log.Debugf("sweep batch flushed in %s", window)
+	}
+}
+
+func (s *sweeper) flush() {
+	s.batchLock.Lock()
+	entries := s.batch
+	s.batch = nil
+	s.batchTimer = nil
+	s.batchLock.Unlock()
+
+	s.sweepBatch(entries)
+}
+
+// sweepBatch sweeps the given shared outputs with as few txs as possible,
+// each spending up to the configured max number of inputs.
+func (s *sweeper) sweepBatch(entries []sweepBatchEntry) {
+	s.sweepLock.Lock()
+	defer s.sweepLock.Unlock()
+
+	// the same output may be added more than once by overlapping tasks.
+	seen := make(map[string]struct{})
+	unique := make([]sweepBatchEntry, 0, len(entries))
+	for _, entry := range entries {
+		outpoint := fmt.Sprintf("%s:%d", entry.input.GetHash(), entry.input.GetIndex())
+		if _, ok := seen[outpoint]; ok {
+			continue
+		}
+		seen[outpoint] = struct{}{}
+		unique = append(unique, entry)
+	}
 
-				log.Debugf("%d vtxos swept", len(vtxoKeys))
+	for len(unique) > 0 {
+		num := min(len(unique), s.config.MaxInputs)
+		if num <= 0 {
+			num = len(unique)
+		}
+		s.sweep(context.Background(), unique[:num])
+		unique = unique[num:]
+	}
+}
+
+// sweep broadcasts a tx spending the given shared outputs and accounts the
+// swept amounts in their rounds. If the tx can't be built, the outputs of
+// every round are swept separately, not to let a bad one block the others.
+func (s *sweeper) sweep(ctx context.Context, entries []sweepBatchEntry) {
+	sweepInputs := make([]ports.SweepInput, 0, len(entries))
+	vtxoKeys := make([]domain.VtxoKey, 0) // vtxos associated to the sweep inputs
+	roundTxids := make([]string, 0)
+	sweptAmounts := make(map[string]uint64)
+	for _, entry := range entries {
+		sweepInputs = append(sweepInputs, entry.input)
+		vtxoKeys = append(vtxoKeys, entry.vtxos...)
+		if _, ok := sweptAmounts[entry.roundTxid]; !ok {
+			roundTxids = append(roundTxids, entry.roundTxid)
+		}
+		sweptAmounts[entry.roundTxid] += entry.input.GetAmount()
+	}
+
+	// build the sweep transaction with all the expired non-swept shared outputs
+	sweepTx, err := s.builder.BuildSweepTx(sweepInputs, s.config.FeeRate)
+	if err != nil {
+		if len(roundTxids) > 1 {
+			log.WithError(err).Warn("error while building batched sweep tx, sweeping rounds separately")
+			for _, roundTxid := range roundTxids {
+				roundEntries := make([]sweepBatchEntry, 0)
+				for _, entry := range entries {
+					if entry.roundTxid == roundTxid {
+						roundEntries = append(roundEntries, entry)
+					}
+				}
+				s.sweep(ctx, roundEntries)
 			}
+			return
 		}
+		log.WithError(err).Error("error while building sweep tx")
+		return
+	}
 
-		roundVtxos, err := vtxosRepository.GetVtxosForRound(ctx, roundTxid)
+	err = nil
+	txid := ""
+	// retry until the tx is broadcasted or the error is not BIP68 final
+	for len(txid) == 0 && (err == nil || strings.Contains(err.Error(), "non-BIP68-final")) {
 		if err != nil {
-			log.WithError(err).Error("error while getting vtxos for round")
+			log.Debugln("sweep tx not BIP68 final, retrying in 5 seconds")
+			time.Sleep(5 * time.Second)
+		}
+
+		txid, err = s.wallet.BroadcastTransaction(ctx, sweepTx)
+	}
+
+	if err != nil {
+		log.WithError(err).Error("error while broadcasting sweep tx")
+		return
+	}
+	if len(txid) <= 0 {
+		return
+	}
+
+	log.Debugf(
+		"sweep tx %s broadcasted, spending %d shared outputs of %d rounds",
+		txid, len(sweepInputs), len(roundTxids),
+	)
+
+	// mark the vtxos as swept
+	if err := s.repoManager.Vtxos().SweepVtxos(ctx, vtxoKeys); err != nil {
+		log.Error(fmt.Errorf("error while deleting vtxos: %w", err))
+		return
+	}
+
+	log.Debugf("%d vtxos swept", len(vtxoKeys))
+
+	sweptAmount := uint64(0)
+	for _, amount := range sweptAmounts {
+		sweptAmount += amount
+	}
+	s.metrics.VtxosSwept(len(vtxoKeys), sweptAmount)
+
+	for _, roundTxid := range roundTxids {
+		s.updateRound(ctx, roundTxid, sweptAmounts[roundTxid])
+	}
+}
+
+// updateRound adds the given swept amount to the round and marks it as swept
+// if all its vtxos are swept or redeemed.
+func (s *sweeper) updateRound(
+	ctx context.Context, roundTxid string, sweptAmount uint64,
+) {
+	vtxosRepository := s.repoManager.Vtxos()
+	roundVtxos, err := vtxosRepository.GetVtxosForRound(ctx, roundTxid)
+	if err != nil {
+		log.WithError(err).Error("error while getting vtxos for round")
+		return
+	}
+
+	// the vtxos of the async payments expire with the earliest of their
+	// inputs, whose round they're attached to, and are gone with it.
+	asyncVtxoKeys := make([]domain.VtxoKey, 0)
+	now := time.Now().Unix()
+	for i, vtxo := range roundVtxos {
+		if len(vtxo.RedeemTx) <= 0 || vtxo.Swept || vtxo.Redeemed {
+			continue
+		}
+		if vtxo.ExpireAt > 0 && vtxo.ExpireAt <= now {
+			asyncVtxoKeys = append(asyncVtxoKeys, vtxo.VtxoKey)
+			roundVtxos[i].Swept = true
+		}
+	}
+	if len(asyncVtxoKeys) > 0 {
+		if err := vtxosRepository.SweepVtxos(ctx, asyncVtxoKeys); err != nil {
+			log.WithError(err).Error("error while marking async vtxos as swept")
 			return
 		}
+		log.Debugf("%d async vtxos swept", len(asyncVtxoKeys))
+	}
 
-		allSwept := true
-		for _, vtxo := range roundVtxos {
-			allSwept = allSwept && (vtxo.Swept || vtxo.Redeemed)
-			if !allSwept {
-				break
-			}
+	allSwept := true
+	for _, vtxo := range roundVtxos {
+		allSwept = allSwept && (vtxo.Swept || vtxo.Redeemed)
+		if !allSwept {
+			break
 		}
+	}
 
-		if allSwept {
-			// update the round
-			roundRepo := s.repoManager.Rounds()
-			round, err := roundRepo.GetRoundWithTxid(ctx, roundTxid)
-			if err != nil {
-				log.WithError(err).Error("error while getting round")
-				return
-			}
+	if !allSwept && sweptAmount <= 0 {
+		return
+	}
 
-			log.Debugf("round %s fully swept", roundTxid)
-			round.Sweep()
+	// update the round
+	roundRepo := s.repoManager.Rounds()
+	round, err := roundRepo.GetRoundWithTxid(ctx, roundTxid)
+	if err != nil {
+		log.WithError(err).Error("error while getting round")
+		return
+	}
 
-			if err := roundRepo.AddOrUpdateRound(ctx, *round); err != nil {
-				log.WithError(err).Error("error while marking round as swept")
-				return
-			}
-		}
+	round.AddSweptAmount(sweptAmount)
+	if allSwept {
+		log.Debugf("round %s fully swept", roundTxid)
+		round.Sweep()
+	}
+
+	if err := roundRepo.AddOrUpdateRound(ctx, *round); err != nil {
+		log.WithError(err).Error("error while marking round as swept")
+		return
 	}
 }
 
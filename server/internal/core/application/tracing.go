@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer is a no-op unless the tracing is enabled by setting the global
+// tracer provider.
+var tracer = otel.Tracer("github.com/ark-network/ark/internal/core/application")
+
+// roundTracer traces the pipeline of the rounds, with a span for each round
+// parent of those of its stages. The spans of the requests of the clients,
+// like payment registrations and claims, are linked to the one of the
+// current round, since their traces start from the gRPC calls.
+type roundTracer struct {
+	lock sync.RWMutex
+	ctx  context.Context
+	span trace.Span
+}
+
+func newRoundTracer() *roundTracer {
+	ctx := context.Background()
+	return &roundTracer{ctx: ctx, span: trace.SpanFromContext(ctx)}
+}
+
+// startRound ends the span of the previous round, if still open, and starts
+// the one of the given round.
+func (t *roundTracer) startRound(roundId string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.span.End()
+	t.ctx, t.span = tracer.Start(
+		context.Background(), "round",
+		trace.WithAttributes(attribute.String("round.id", roundId)),
+	)
+}
+
+// endRound ends the span of the current round, failed with the given error
+// if not nil.
+func (t *roundTracer) endRound(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	endSpan(t.span, err)
+}
+
+// startStage starts the span of a stage of the current round.
+func (t *roundTracer) startStage(
+	name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return tracer.Start(t.ctx, name, trace.WithAttributes(attrs...))
+}
+
+// startRequest starts the span of a request of a client, linked to the one
+// of the current round.
+func (t *roundTracer) startRequest(
+	ctx context.Context, name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	t.lock.RLock()
+	link := trace.LinkFromContext(t.ctx)
+	t.lock.RUnlock()
+
+	return tracer.Start(
+		ctx, name, trace.WithLinks(link), trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan ends the given span, marking it as failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// roundFailure returns the error the round failed with, if any among the
+// given events.
+func roundFailure(events []domain.RoundEvent) error {
+	for _, event := range events {
+		if e, ok := event.(domain.RoundFailed); ok {
+			return errors.New(e.Err)
+		}
+	}
+	return nil
+}
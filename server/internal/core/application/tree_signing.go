@@ -0,0 +1,289 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/common/bitcointree"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// treeSigningSession collects the nonces, and then the partial signatures,
+// of the cosigners of the congestion tree of a round. The ASP coordinates
+// the MuSig2 sessions of the tree txs, and is one of the cosigners too.
+type treeSigningSession struct {
+	roundId     string
+	tree        tree.CongestionTree
+	cosigners   map[string]*secp256k1.PublicKey
+	coordinator bitcointree.CoordinatorSession
+
+	lock sync.Mutex
+	// nonces and sigs are the cosigners that sent their nonces and their
+	// signatures. noncesCh and sigsCh are closed once all have sent them.
+	nonces           map[string]bool
+	sigs             map[string]bool
+	aggregatedNonces bitcointree.TreeNonces
+	noncesCh         chan struct{}
+	sigsCh           chan struct{}
+}
+
+func newTreeSigningSession(
+	roundId string, congestionTree tree.CongestionTree,
+	cosigners []*secp256k1.PublicKey,
+	coordinator bitcointree.CoordinatorSession,
+) *treeSigningSession {
+	keys := make(map[string]*secp256k1.PublicKey, len(cosigners))
+	for _, key := range cosigners {
+		keys[hex.EncodeToString(key.SerializeCompressed())] = key
+	}
+	return &treeSigningSession{
+		roundId:     roundId,
+		tree:        congestionTree,
+		cosigners:   keys,
+		coordinator: coordinator,
+		nonces:      make(map[string]bool),
+		sigs:        make(map[string]bool),
+		noncesCh:    make(chan struct{}),
+		sigsCh:      make(chan struct{}),
+	}
+}
+
+func (s *treeSigningSession) addNonces(
+	pubkey *secp256k1.PublicKey, nonces bitcointree.TreeNonces,
+) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := hex.EncodeToString(pubkey.SerializeCompressed())
+	if _, ok := s.cosigners[key]; !ok {
+		return fmt.Errorf("%s is not a cosigner of round %s", key, s.roundId)
+	}
+	if s.nonces[key] {
+		return fmt.Errorf("nonces of %s already sent", key)
+	}
+	if err := s.coordinator.AddNonce(pubkey, nonces); err != nil {
+		return err
+	}
+
+	s.nonces[key] = true
+	if len(s.nonces) == len(s.cosigners) {
+		close(s.noncesCh)
+	}
+	return nil
+}
+
+func (s *treeSigningSession) aggregateNonces() (bitcointree.TreeNonces, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nonces, err := s.coordinator.AggregateNonces()
+	if err != nil {
+		return nil, err
+	}
+	s.aggregatedNonces = nonces
+	return nonces, nil
+}
+
+func (s *treeSigningSession) addSignatures(
+	pubkey *secp256k1.PublicKey, sigs bitcointree.TreePartialSigs,
+) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := hex.EncodeToString(pubkey.SerializeCompressed())
+	if _, ok := s.cosigners[key]; !ok {
+		return fmt.Errorf("%s is not a cosigner of round %s", key, s.roundId)
+	}
+	if s.aggregatedNonces == nil {
+		return fmt.Errorf("nonces of round %s not aggregated yet", s.roundId)
+	}
+	if s.sigs[key] {
+		return fmt.Errorf("signatures of %s already sent", key)
+	}
+	if err := s.coordinator.AddSig(pubkey, sigs); err != nil {
+		return err
+	}
+
+	s.sigs[key] = true
+	if len(s.sigs) == len(s.cosigners) {
+		close(s.sigsCh)
+	}
+	return nil
+}
+
+func (s *treeSigningSession) signTree() (tree.CongestionTree, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.coordinator.SignTree()
+}
+
+// missing returns how many cosigners are yet to send their nonces, or
+// their signatures once the nonces are aggregated.
+func (s *treeSigningSession) missing() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.aggregatedNonces == nil {
+		return len(s.cosigners) - len(s.nonces)
+	}
+	return len(s.cosigners) - len(s.sigs)
+}
+
+func (s *service) RegisterCosignerNonces(
+	_ context.Context, roundId string, pubkey *secp256k1.PublicKey,
+	encodedNonces string,
+) error {
+	session, err := s.getTreeSigningSession(roundId)
+	if err != nil {
+		return err
+	}
+
+	nonces, err := bitcointree.DecodeNonces(encodedNonces, session.tree)
+	if err != nil {
+		return fmt.Errorf("invalid tree nonces: %s", err)
+	}
+	return session.addNonces(pubkey, nonces)
+}
+
+func (s *service) RegisterCosignerSignatures(
+	_ context.Context, roundId string, pubkey *secp256k1.PublicKey,
+	encodedSignatures string,
+) error {
+	session, err := s.getTreeSigningSession(roundId)
+	if err != nil {
+		return err
+	}
+
+	sigs, err := bitcointree.DecodeSignatures(encodedSignatures, session.tree)
+	if err != nil {
+		return fmt.Errorf("invalid tree signatures: %s", err)
+	}
+	return session.addSignatures(pubkey, sigs)
+}
+
+func (s *service) getTreeSigningSession(
+	roundId string,
+) (*treeSigningSession, error) {
+	session := s.treeSigning.Load()
+	if session == nil || session.roundId != roundId {
+		return nil, fmt.Errorf("round %s is not in signing stage", roundId)
+	}
+	return session, nil
+}
+
+// signCongestionTree runs the MuSig2 signing session of the txs of the
+// given tree with the cosigners, the ASP taking part with its ephemeral
+// key. It returns the signed tree, or an error if any cosigner doesn't
+// send its nonces and signatures before the deadline.
+func (s *service) signCongestionTree(
+	roundId string, unsignedTree tree.CongestionTree, unsignedPoolTx string,
+	aspKey *secp256k1.PrivateKey, cosigners []*secp256k1.PublicKey,
+	deadline time.Time,
+) (tree.CongestionTree, error) {
+	sweepRoot, err := bitcointree.SweepScriptRoot(s.pubkey, s.roundLifetime)
+	if err != nil {
+		return nil, err
+	}
+	minRelayFee := int64(s.minRelayFee)
+
+	coordinator, err := bitcointree.NewTreeCoordinatorSession(
+		unsignedTree, minRelayFee, sweepRoot, cosigners,
+	)
+	if err != nil {
+		return nil, err
+	}
+	session := newTreeSigningSession(
+		roundId, unsignedTree, cosigners, coordinator,
+	)
+	s.treeSigning.Store(session)
+	defer s.treeSigning.Store(nil)
+
+	aspSigner := bitcointree.NewTreeSignerSession(
+		unsignedTree, minRelayFee, sweepRoot,
+	)
+	aspNonces, err := aspSigner.GetNonces(aspKey.PubKey())
+	if err != nil {
+		return nil, err
+	}
+	if err := session.addNonces(aspKey.PubKey(), aspNonces); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(cosigners))
+	for _, key := range cosigners {
+		keys = append(keys, hex.EncodeToString(key.SerializeCompressed()))
+	}
+	s.notifyEvent(domain.RoundSigningStarted{
+		Id:               roundId,
+		UnsignedVtxoTree: unsignedTree,
+		Cosigners:        keys,
+		UnsignedRoundTx:  unsignedPoolTx,
+	})
+
+	timeout := time.NewTimer(time.Until(deadline))
+	defer timeout.Stop()
+
+	select {
+	case <-session.noncesCh:
+	case <-timeout.C:
+		return nil, fmt.Errorf(
+			"%d cosigners didn't send their nonces", session.missing(),
+		)
+	}
+
+	aggregatedNonces, err := session.aggregateNonces()
+	if err != nil {
+		return nil, err
+	}
+	encodedNonces, err := bitcointree.EncodeNonces(aggregatedNonces)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyEvent(domain.RoundSigningNoncesGenerated{
+		Id:     roundId,
+		Nonces: encodedNonces,
+	})
+	log.Debugf("aggregated nonces of the tree of round %s", roundId)
+
+	if err := aspSigner.SetKeys(cosigners, aggregatedNonces); err != nil {
+		return nil, err
+	}
+	aspSigs, err := aspSigner.Sign(aspKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.addSignatures(aspKey.PubKey(), aspSigs); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-session.sigsCh:
+	case <-timeout.C:
+		return nil, fmt.Errorf(
+			"%d cosigners didn't send their signatures", session.missing(),
+		)
+	}
+
+	signedTree, err := session.signTree()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregatedKey, err := bitcointree.AggregateKeys(cosigners, sweepRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := bitcointree.ValidateTreeSigs(
+		minRelayFee, sweepRoot, aggregatedKey.FinalKey, signedTree,
+	); err != nil {
+		return nil, fmt.Errorf("invalid tree signatures: %s", err)
+	}
+	return signedTree, nil
+}
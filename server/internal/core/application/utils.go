@@ -2,8 +2,10 @@ package application
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,7 +14,9 @@ import (
 	"github.com/ark-network/ark/internal/core/domain"
 	"github.com/ark-network/ark/internal/core/ports"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/vulpemventures/go-elements/psetv2"
 )
 
@@ -20,20 +24,41 @@ type timedPayment struct {
 	domain.Payment
 	timestamp     time.Time
 	pingTimestamp time.Time
+	// ephemeralPubkey is the key the owner cosigns the congestion tree
+	// with, if the tree must be signed.
+	ephemeralPubkey string
+}
+
+// clientPaymentIdTTL is how long the ids given by the clients to their
+// payments are remembered.
+const clientPaymentIdTTL = 24 * time.Hour
+
+// stalePaymentTimeout is how long a payment is kept without being pinged by
+// its owner, so that abandoned registrations don't pile up in memory.
+const stalePaymentTimeout = 5 * time.Minute
+
+type clientPayment struct {
+	paymentId string
+	inputs    string
+	timestamp time.Time
 }
 
 type paymentsMap struct {
 	lock     *sync.RWMutex
 	payments map[string]*timedPayment
+	// clientPayments maps the ids given by the clients to the payments
+	// registered with them. They're kept also after the payments are taken by
+	// a round, so that a retried registration can't enter the next one too.
+	clientPayments map[string]clientPayment
 }
 
 func newPaymentsMap(payments []domain.Payment) *paymentsMap {
 	paymentsById := make(map[string]*timedPayment)
 	for _, p := range payments {
-		paymentsById[p.Id] = &timedPayment{p, time.Now(), time.Time{}}
+		paymentsById[p.Id] = &timedPayment{p, time.Now(), time.Time{}, ""}
 	}
 	lock := &sync.RWMutex{}
-	return &paymentsMap{lock, paymentsById}
+	return &paymentsMap{lock, paymentsById, make(map[string]clientPayment)}
 }
 
 func (m *paymentsMap) len() int64 {
@@ -49,19 +74,76 @@ func (m *paymentsMap) len() int64 {
 	return count
 }
 
-func (m *paymentsMap) push(payment domain.Payment) error {
+// push adds the given payment, unless the client already registered its
+// inputs with the same client id, in which case the id of the payment
+// already registered is returned. If maxPerPubkey is positive, the payment
+// is rejected if the owner of any input has that many registered already.
+func (m *paymentsMap) push(
+	payment domain.Payment, clientPaymentId string, maxPerPubkey int,
+) (string, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	for id, p := range m.clientPayments {
+		if time.Since(p.timestamp) > clientPaymentIdTTL {
+			delete(m.clientPayments, id)
+		}
+	}
+	for id, p := range m.payments {
+		lastSeen := p.timestamp
+		if p.pingTimestamp.After(lastSeen) {
+			lastSeen = p.pingTimestamp
+		}
+		if time.Since(lastSeen) > stalePaymentTimeout {
+			delete(m.payments, id)
+		}
+	}
+
+	inputs := inputsKey(payment.Inputs)
+	if len(clientPaymentId) > 0 {
+		if p, ok := m.clientPayments[clientPaymentId]; ok {
+			if p.inputs != inputs {
+				return "", fmt.Errorf(
+					"client payment id %s already used for other inputs",
+					clientPaymentId,
+				)
+			}
+			if _, ok := m.payments[p.paymentId]; !ok {
+				return "", fmt.Errorf(
+					"payment %s already taken by a round", p.paymentId,
+				)
+			}
+			return p.paymentId, nil
+		}
+	}
+
 	if _, ok := m.payments[payment.Id]; ok {
-		return fmt.Errorf("duplicated inputs")
+		return "", fmt.Errorf("duplicated inputs")
 	}
 
-	m.payments[payment.Id] = &timedPayment{payment, time.Now(), time.Time{}}
-	return nil
+	if maxPerPubkey > 0 {
+		for _, in := range payment.Inputs {
+			if m.countOwnedBy(in.Pubkey) >= maxPerPubkey {
+				return "", fmt.Errorf(
+					"too many payments registered for pubkey %s, max %d",
+					in.Pubkey, maxPerPubkey,
+				)
+			}
+		}
+	}
+
+	m.payments[payment.Id] = &timedPayment{payment, time.Now(), time.Time{}, ""}
+	if len(clientPaymentId) > 0 {
+		m.clientPayments[clientPaymentId] = clientPayment{
+			payment.Id, inputs, time.Now(),
+		}
+	}
+	return payment.Id, nil
 }
 
-func (m *paymentsMap) pop(num int64) []domain.Payment {
+// pop takes the oldest payments, up to the given number, along with their
+// ephemeral keys by payment id.
+func (m *paymentsMap) pop(num int64) ([]domain.Payment, map[string]string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -86,14 +168,35 @@ func (m *paymentsMap) pop(num int64) []domain.Payment {
 	}
 
 	payments := make([]domain.Payment, 0, num)
+	ephemeralPubkeys := make(map[string]string)
 	for _, p := range paymentsByTime[:num] {
 		payments = append(payments, p.Payment)
+		if len(p.ephemeralPubkey) > 0 {
+			ephemeralPubkeys[p.Id] = p.ephemeralPubkey
+		}
 		delete(m.payments, p.Id)
 	}
-	return payments
+	return payments, ephemeralPubkeys
 }
 
-func (m *paymentsMap) update(payment domain.Payment) error {
+// requeue puts back the given payments, taken by a round that couldn't
+// include them, ahead of those registered in the meantime.
+func (m *paymentsMap) requeue(
+	payments []domain.Payment, ephemeralPubkeys map[string]string,
+) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, p := range payments {
+		m.payments[p.Id] = &timedPayment{
+			p, time.Time{}, time.Now(), ephemeralPubkeys[p.Id],
+		}
+	}
+}
+
+func (m *paymentsMap) update(
+	payment domain.Payment, ephemeralPubkey string,
+) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -103,6 +206,7 @@ func (m *paymentsMap) update(payment domain.Payment) error {
 	}
 
 	p.Payment = payment
+	p.ephemeralPubkey = ephemeralPubkey
 
 	return nil
 }
@@ -136,6 +240,232 @@ func (m *paymentsMap) view(id string) (*domain.Payment, bool) {
 	}, true
 }
 
+// size returns the number of registered payments, including those still
+// without receivers.
+func (m *paymentsMap) size() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return len(m.payments)
+}
+
+// claimed returns the payments with registered receivers.
+func (m *paymentsMap) claimed() []domain.Payment {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	payments := make([]domain.Payment, 0, len(m.payments))
+	for _, p := range m.payments {
+		if len(p.Receivers) > 0 {
+			payments = append(payments, p.Payment)
+		}
+	}
+	return payments
+}
+
+// countOwnedBy returns the number of registered payments with inputs owned
+// by the given pubkey. The caller must hold the lock.
+func (m *paymentsMap) countOwnedBy(pubkey string) int {
+	count := 0
+	for _, p := range m.payments {
+		for _, in := range p.Inputs {
+			if in.Pubkey == pubkey {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// includes returns whether the given vtxo is the input of a registered
+// payment.
+func (m *paymentsMap) includes(key domain.VtxoKey) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, p := range m.payments {
+		for _, in := range p.Inputs {
+			if in.VtxoKey == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getCosigners returns the ephemeral keys the owners of the given payments
+// cosign the congestion tree with.
+func getCosigners(
+	payments []domain.Payment, ephemeralPubkeys map[string]string,
+) ([]*secp256k1.PublicKey, error) {
+	cosigners := make([]*secp256k1.PublicKey, 0, len(payments))
+	for _, p := range payments {
+		key, ok := ephemeralPubkeys[p.Id]
+		if !ok {
+			return nil, fmt.Errorf("missing ephemeral pubkey of payment %s", p.Id)
+		}
+		buf, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, err
+		}
+		pubkey, err := secp256k1.ParsePubKey(buf)
+		if err != nil {
+			return nil, err
+		}
+		cosigners = append(cosigners, pubkey)
+	}
+	return cosigners, nil
+}
+
+// inputsKey returns a key identifying the given set of inputs, whatever
+// their order.
+func inputsKey(inputs []domain.Vtxo) string {
+	keys := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		keys = append(keys, fmt.Sprintf("%s:%d", in.Txid, in.VOut))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func outpoints(keys []domain.VtxoKey) []string {
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, fmt.Sprintf("%s:%d", key.Txid, key.VOut))
+	}
+	return list
+}
+
+// verifyOwnerSig returns an error if the given hex signature of the hash is
+// not the one of the owner of the vtxo.
+func verifyOwnerSig(hash []byte, vtxo domain.Vtxo, signature string) error {
+	buf, err := hex.DecodeString(vtxo.Pubkey)
+	if err != nil {
+		return err
+	}
+	owner, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return err
+	}
+
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature format")
+	}
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("invalid signature format")
+	}
+	if !sig.Verify(hash, owner) {
+		return fmt.Errorf(
+			"invalid signature of the owner of input %s:%d", vtxo.Txid, vtxo.VOut,
+		)
+	}
+	return nil
+}
+
+func sameReceivers(a, b []domain.Receiver) bool {
+	if len(a) <= 0 || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// asyncPaymentTTL is how long the sender of an async payment has to submit
+// its signatures of the redeem tx.
+const asyncPaymentTTL = 5 * time.Minute
+
+type asyncPayment struct {
+	redeemTx  string
+	inputs    []domain.Vtxo
+	receivers []domain.Receiver
+	timestamp time.Time
+}
+
+// asyncPaymentsMap holds the unsigned redeem txs of the async payments, by
+// txid, until their senders complete them.
+type asyncPaymentsMap struct {
+	lock     *sync.Mutex
+	payments map[string]asyncPayment
+}
+
+func newAsyncPaymentsMap() *asyncPaymentsMap {
+	return &asyncPaymentsMap{&sync.Mutex{}, make(map[string]asyncPayment)}
+}
+
+func (m *asyncPaymentsMap) push(txid string, payment asyncPayment) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for id, p := range m.payments {
+		if time.Since(p.timestamp) > asyncPaymentTTL {
+			delete(m.payments, id)
+		}
+	}
+	m.payments[txid] = payment
+}
+
+func (m *asyncPaymentsMap) view(txid string) (*asyncPayment, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	payment, ok := m.payments[txid]
+	if !ok || time.Since(payment.timestamp) > asyncPaymentTTL {
+		return nil, false
+	}
+	return &payment, true
+}
+
+func (m *asyncPaymentsMap) delete(txid string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.payments, txid)
+}
+
+// verifyTapscriptSig returns an error if the given signature of the input of
+// the pset is not valid.
+func verifyTapscriptSig(
+	genesisBlockHash *chainhash.Hash, ptx *psetv2.Pset, index int,
+	tapScriptSig psetv2.TapScriptSig,
+) error {
+	leafHash, err := chainhash.NewHash(tapScriptSig.LeafHash)
+	if err != nil {
+		return err
+	}
+
+	preimage, err := common.TaprootPreimage(
+		genesisBlockHash,
+		ptx,
+		index,
+		leafHash,
+	)
+	if err != nil {
+		return err
+	}
+
+	sig, err := schnorr.ParseSignature(tapScriptSig.Signature)
+	if err != nil {
+		return err
+	}
+
+	pubkey, err := schnorr.ParsePubKey(tapScriptSig.PubKey)
+	if err != nil {
+		return err
+	}
+
+	if !sig.Verify(preimage, pubkey) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
 type signedTx struct {
 	tx     string
 	signed bool
@@ -156,9 +486,10 @@ func (m *forfeitTxsMap) push(txs []string) {
 	defer m.lock.Unlock()
 
 	for _, tx := range txs {
-		ptx, _ := psetv2.NewPsetFromBase64(tx)
-		utx, _ := ptx.UnsignedTx()
-		txid := utx.TxHash().String()
+		txid, err := getForfeitTxid(tx)
+		if err != nil {
+			continue
+		}
 		signed := false
 
 		m.forfeitTxs[txid] = &signedTx{tx, signed}
@@ -170,45 +501,28 @@ func (m *forfeitTxsMap) sign(txs []string) error {
 	defer m.lock.Unlock()
 
 	for _, tx := range txs {
-		ptx, _ := psetv2.NewPsetFromBase64(tx)
-		utx, _ := ptx.UnsignedTx()
+		ptx, err := psetv2.NewPsetFromBase64(tx)
+		if err != nil {
+			return fmt.Errorf("invalid forfeit tx: %s", err)
+		}
+		utx, err := ptx.UnsignedTx()
+		if err != nil {
+			return fmt.Errorf("invalid forfeit tx: %s", err)
+		}
 		txid := utx.TxHash().String()
 
 		if _, ok := m.forfeitTxs[txid]; ok {
 			for index, input := range ptx.Inputs {
 				if len(input.TapScriptSig) > 0 {
 					for _, tapScriptSig := range input.TapScriptSig {
-						leafHash, err := chainhash.NewHash(tapScriptSig.LeafHash)
-						if err != nil {
+						if err := verifyTapscriptSig(
+							m.genesisBlockHash, ptx, index, tapScriptSig,
+						); err != nil {
 							return err
 						}
 
-						preimage, err := common.TaprootPreimage(
-							m.genesisBlockHash,
-							ptx,
-							index,
-							leafHash,
-						)
-						if err != nil {
-							return err
-						}
-
-						sig, err := schnorr.ParseSignature(tapScriptSig.Signature)
-						if err != nil {
-							return err
-						}
-
-						pubkey, err := schnorr.ParsePubKey(tapScriptSig.PubKey)
-						if err != nil {
-							return err
-						}
-
-						if sig.Verify(preimage, pubkey) {
-							m.forfeitTxs[txid].tx = tx
-							m.forfeitTxs[txid].signed = true
-						} else {
-							return fmt.Errorf("invalid signature")
-						}
+						m.forfeitTxs[txid].tx = tx
+						m.forfeitTxs[txid].signed = true
 					}
 				}
 			}
@@ -218,6 +532,20 @@ func (m *forfeitTxsMap) sign(txs []string) error {
 	return nil
 }
 
+// setSigned replaces the given forfeit tx with the signed one, for those
+// signed with MuSig2 by the owner of the vtxo and the ASP.
+func (m *forfeitTxsMap) setSigned(txid, tx string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.forfeitTxs[txid]; !ok {
+		return fmt.Errorf("forfeit tx %s not found", txid)
+	}
+	m.forfeitTxs[txid].tx = tx
+	m.forfeitTxs[txid].signed = true
+	return nil
+}
+
 func (m *forfeitTxsMap) pop() (signed, unsigned []string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -245,6 +573,24 @@ func (m *forfeitTxsMap) view() []string {
 	return txs
 }
 
+// getForfeitTxid returns the txid of the given forfeit tx, either a pset or
+// a psbt depending on the tx builder.
+func getForfeitTxid(tx string) (string, error) {
+	if ptx, err := psetv2.NewPsetFromBase64(tx); err == nil {
+		utx, err := ptx.UnsignedTx()
+		if err != nil {
+			return "", err
+		}
+		return utx.TxHash().String(), nil
+	}
+
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+	if err != nil {
+		return "", err
+	}
+	return ptx.UnsignedTx.TxHash().String(), nil
+}
+
 // onchainOutputs iterates over all the nodes' outputs in the congestion tree and checks their onchain state
 // returns the sweepable outputs as ports.SweepInput mapped by their expiration time
 func findSweepableOutputs(
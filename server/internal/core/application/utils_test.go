@@ -0,0 +1,77 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentsMapPush(t *testing.T) {
+	newTestPayment := func(txid, pubkey string) domain.Payment {
+		return *domain.NewPaymentUnsafe([]domain.Vtxo{
+			{
+				VtxoKey:  domain.VtxoKey{Txid: txid, VOut: 0},
+				Receiver: domain.Receiver{Pubkey: pubkey, Amount: 1000},
+			},
+		}, nil)
+	}
+
+	t.Run("max_payments_per_pubkey", func(t *testing.T) {
+		m := newPaymentsMap(nil)
+
+		_, err := m.push(newTestPayment("a", "alice"), "", 2)
+		require.NoError(t, err)
+		_, err = m.push(newTestPayment("b", "alice"), "", 2)
+		require.NoError(t, err)
+		_, err = m.push(newTestPayment("c", "alice"), "", 2)
+		require.ErrorContains(t, err, "too many payments registered for pubkey alice")
+
+		// the cap is per pubkey, and disabled if not positive.
+		_, err = m.push(newTestPayment("d", "bob"), "", 2)
+		require.NoError(t, err)
+		_, err = m.push(newTestPayment("e", "alice"), "", 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("retried_registration", func(t *testing.T) {
+		m := newPaymentsMap(nil)
+
+		payment := newTestPayment("a", "alice")
+		id, err := m.push(payment, "client-id", 1)
+		require.NoError(t, err)
+
+		// a retry with the same client id isn't a new payment.
+		retried := newTestPayment("a", "alice")
+		retriedId, err := m.push(retried, "client-id", 1)
+		require.NoError(t, err)
+		require.Equal(t, id, retriedId)
+	})
+
+	t.Run("concurrent_pushes", func(t *testing.T) {
+		m := newPaymentsMap(nil)
+		maxPerPubkey := 3
+
+		wg := &sync.WaitGroup{}
+		lock := &sync.Mutex{}
+		pushed := 0
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				payment := newTestPayment(fmt.Sprintf("tx%d", i), "alice")
+				if _, err := m.push(payment, "", maxPerPubkey); err == nil {
+					lock.Lock()
+					pushed++
+					lock.Unlock()
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		require.Equal(t, maxPerPubkey, pushed)
+		require.Equal(t, maxPerPubkey, m.size())
+	})
+}
@@ -0,0 +1,261 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	log "github.com/sirupsen/logrus"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// watchtowerRetryInterval is the wait before reacting again to a fraud if
+// the previous attempt failed.
+const watchtowerRetryInterval = 10 * time.Second
+
+// watchtower is an unexported service running while the main application service is started
+// it reacts to the frauds detected by the blockchain scanner, that is the broadcast of the redeem branch of a vtxo
+// already spent in a round or by an async payment
+// it publishes the forfeit tx, or the redeem tx of the async payment, before the owner can claim the funds with the
+// unilateral exit path, retrying until the timelock of the exit path expires
+type watchtower struct {
+	wallet      ports.WalletService
+	repoManager ports.RepoManager
+	// exitDelay is the timelock, in seconds, of the unilateral exit path.
+	exitDelay int64
+
+	lock sync.Mutex
+	// the vtxos whose fraud is being reacted to, not to react twice.
+	frauds map[domain.VtxoKey]struct{}
+	stopCh chan struct{}
+	// connectorLock serializes the spending of the connectors, a new one
+	// being created from the chain of connector txs if none is available.
+	connectorLock sync.Mutex
+}
+
+func newWatchtower(
+	wallet ports.WalletService, repoManager ports.RepoManager, exitDelay int64,
+) *watchtower {
+	return &watchtower{
+		wallet:      wallet,
+		repoManager: repoManager,
+		exitDelay:   exitDelay,
+		frauds:      make(map[domain.VtxoKey]struct{}),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// stop makes the pending reactions give up retrying.
+func (w *watchtower) stop() {
+	close(w.stopCh)
+}
+
+// watch reacts to the fraud on the given vtxo, whose redeem branch has just
+// been broadcasted, until the exit timelock expires.
+func (w *watchtower) watch(vtxo domain.Vtxo) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.frauds[vtxo.VtxoKey]; ok {
+		return
+	}
+	w.frauds[vtxo.VtxoKey] = struct{}{}
+
+	deadline := time.Now().Add(time.Duration(w.exitDelay) * time.Second)
+	go w.react(vtxo, deadline)
+}
+
+func (w *watchtower) react(vtxo domain.Vtxo, deadline time.Time) {
+	defer func() {
+		w.lock.Lock()
+		delete(w.frauds, vtxo.VtxoKey)
+		w.lock.Unlock()
+	}()
+
+	ctx := context.Background()
+	for {
+		txid, err := w.reactToFraud(ctx, vtxo)
+		if err == nil {
+			log.Debugf("broadcasted tx %s reacting to fraud on vtxo %s", txid, vtxo.Txid)
+			return
+		}
+
+		if time.Now().Add(watchtowerRetryInterval).After(deadline) {
+			log.WithError(err).Errorf(
+				"failed to react to fraud on vtxo %s before the exit timelock expires",
+				vtxo.Txid,
+			)
+			return
+		}
+
+		log.WithError(err).Warnf(
+			"failed to react to fraud on vtxo %s, retrying in %s",
+			vtxo.Txid, watchtowerRetryInterval,
+		)
+		select {
+		case <-w.stopCh:
+			return
+		case <-time.After(watchtowerRetryInterval):
+		}
+	}
+}
+
+// reactToFraud broadcasts the tx that spends the given vtxo to the ASP, or
+// to the receivers of the async payment, and returns its txid.
+func (w *watchtower) reactToFraud(
+	ctx context.Context, vtxo domain.Vtxo,
+) (string, error) {
+	// a vtxo spent out of round is forfeited by broadcasting the
+	// redeem tx, that moves the funds to the receivers.
+	if redeemTx := w.getRedeemTx(ctx, vtxo.SpentBy); len(redeemTx) > 0 {
+		redeemTxHex, err := finalizeAndExtractForfeit(redeemTx)
+		if err != nil {
+			return "", fmt.Errorf("failed to finalize redeem tx: %s", err)
+		}
+		redeemTxid, err := w.wallet.BroadcastTransaction(ctx, redeemTxHex)
+		if err != nil {
+			return "", fmt.Errorf("failed to broadcast redeem tx: %s", err)
+		}
+		return redeemTxid, nil
+	}
+
+	round, err := w.repoManager.Rounds().GetRoundWithTxid(ctx, vtxo.SpentBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve round: %s", err)
+	}
+
+	w.connectorLock.Lock()
+	defer w.connectorLock.Unlock()
+
+	connectorTxid, connectorVout, err := w.getNextConnector(ctx, *round)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve next connector: %s", err)
+	}
+
+	forfeitTx, err := findForfeitTx(round.ForfeitTxs, connectorTxid, connectorVout, vtxo.Txid)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve forfeit tx: %s", err)
+	}
+
+	if err := w.wallet.LockConnectorUtxos(ctx, []ports.TxOutpoint{txOutpoint{connectorTxid, connectorVout}}); err != nil {
+		return "", fmt.Errorf("failed to lock connector utxos: %s", err)
+	}
+
+	signedForfeitTx, err := w.wallet.SignPset(ctx, forfeitTx, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign connector input in forfeit tx: %s", err)
+	}
+
+	signedForfeitTx, err = w.wallet.SignPsetWithKey(ctx, signedForfeitTx, []int{1})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vtxo input in forfeit tx: %s", err)
+	}
+
+	forfeitTxHex, err := finalizeAndExtractForfeit(signedForfeitTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize forfeit tx: %s", err)
+	}
+
+	forfeitTxid, err := w.wallet.BroadcastTransaction(ctx, forfeitTxHex)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast forfeit tx: %s", err)
+	}
+	return forfeitTxid, nil
+}
+
+// getRedeemTx returns the redeem tx with the given txid, if the vtxos were
+// spent by an async payment rather than a round.
+func (w *watchtower) getRedeemTx(ctx context.Context, txid string) string {
+	vtxos, err := w.repoManager.Vtxos().GetVtxos(
+		ctx, []domain.VtxoKey{{Txid: txid, VOut: 0}},
+	)
+	if err != nil || len(vtxos) <= 0 {
+		return ""
+	}
+	return vtxos[0].RedeemTx
+}
+
+func (w *watchtower) getNextConnector(
+	ctx context.Context,
+	round domain.Round,
+) (string, uint32, error) {
+	connectorTx, err := psetv2.NewPsetFromBase64(round.Connectors[0])
+	if err != nil {
+		return "", 0, err
+	}
+
+	prevout := connectorTx.Inputs[0].WitnessUtxo
+	if prevout == nil {
+		return "", 0, fmt.Errorf("connector prevout not found")
+	}
+
+	utxos, err := w.wallet.ListConnectorUtxos(ctx, round.ConnectorAddress)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// if we do not find any utxos, we make sure to wait for the connector outpoint to be confirmed then we retry
+	if len(utxos) <= 0 {
+		if err := w.wallet.WaitForSync(ctx, round.Txid); err != nil {
+			return "", 0, err
+		}
+
+		utxos, err = w.wallet.ListConnectorUtxos(ctx, round.ConnectorAddress)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	// search for an already existing connector
+	for _, u := range utxos {
+		if u.GetValue() == 450 {
+			return u.GetTxid(), u.GetIndex(), nil
+		}
+	}
+
+	for _, u := range utxos {
+		if u.GetValue() > 450 {
+			for _, b64 := range round.Connectors {
+				pset, err := psetv2.NewPsetFromBase64(b64)
+				if err != nil {
+					return "", 0, err
+				}
+
+				for _, i := range pset.Inputs {
+					if chainhash.Hash(i.PreviousTxid).String() == u.GetTxid() && i.PreviousTxIndex == u.GetIndex() {
+						connectorOutpoint := newOutpointFromPsetInput(pset.Inputs[0])
+
+						if err := w.wallet.LockConnectorUtxos(ctx, []ports.TxOutpoint{connectorOutpoint}); err != nil {
+							return "", 0, err
+						}
+
+						// sign & broadcast the connector tx
+						signedConnectorTx, err := w.wallet.SignPset(ctx, b64, true)
+						if err != nil {
+							return "", 0, err
+						}
+
+						connectorTxid, err := w.wallet.BroadcastTransaction(ctx, signedConnectorTx)
+						if err != nil {
+							return "", 0, err
+						}
+						log.Debugf("broadcasted connector tx %s", connectorTxid)
+
+						// wait for the connector tx to be in the mempool
+						if err := w.wallet.WaitForSync(ctx, connectorTxid); err != nil {
+							return "", 0, err
+						}
+
+						return connectorTxid, 0, nil
+					}
+				}
+			}
+		}
+	}
+
+	return "", 0, fmt.Errorf("no connector utxos found")
+}
@@ -6,11 +6,14 @@ type RoundEvent interface {
 	isEvent()
 }
 
-func (r RoundStarted) isEvent()             {}
-func (r RoundFinalizationStarted) isEvent() {}
-func (r RoundFinalized) isEvent()           {}
-func (r RoundFailed) isEvent()              {}
-func (r PaymentsRegistered) isEvent()       {}
+func (r RoundStarted) isEvent()                {}
+func (r RoundFinalizationStarted) isEvent()    {}
+func (r RoundFinalized) isEvent()              {}
+func (r RoundFailed) isEvent()                 {}
+func (r PaymentsRegistered) isEvent()          {}
+func (r PaymentInputsRejected) isEvent()       {}
+func (r RoundSigningStarted) isEvent()         {}
+func (r RoundSigningNoncesGenerated) isEvent() {}
 
 type RoundStarted struct {
 	Id        string
@@ -43,3 +46,31 @@ type PaymentsRegistered struct {
 	Id       string
 	Payments []Payment
 }
+
+// PaymentInputsRejected is not part of the history of a round, it only
+// notifies the owner of a payment that it has been excluded from the round
+// because some of its inputs can't be spent anymore.
+type PaymentInputsRejected struct {
+	Id        string
+	PaymentId string
+	Inputs    []VtxoKey
+	Reason    string
+}
+
+// RoundSigningStarted is not part of the history of a round, it asks the
+// cosigners to send their nonces for the MuSig2 signing of the txs of the
+// unsigned congestion tree.
+type RoundSigningStarted struct {
+	Id               string
+	UnsignedVtxoTree tree.CongestionTree
+	Cosigners        []string
+	UnsignedRoundTx  string
+}
+
+// RoundSigningNoncesGenerated is not part of the history of a round, it
+// notifies the cosigners of the aggregated nonces, hex encoded, for them to
+// sign the congestion tree.
+type RoundSigningNoncesGenerated struct {
+	Id     string
+	Nonces string
+}
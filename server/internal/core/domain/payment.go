@@ -15,6 +15,9 @@ type Payment struct {
 	Id        string
 	Inputs    []Vtxo
 	Receivers []Receiver
+	// Fee is the amount of the inputs not assigned to any receiver, kept by
+	// the ASP.
+	Fee uint64
 }
 
 func NewPayment(inputs []Vtxo) (*Payment, error) {
@@ -50,6 +53,40 @@ func (p *Payment) AddReceivers(receivers []Receiver) (err error) {
 	return
 }
 
+// AddReceiversWithFee adds the receivers of the payment, whose inputs must
+// cover also the given fee charged by the ASP.
+func (p *Payment) AddReceiversWithFee(receivers []Receiver, fee uint64) (err error) {
+	prevFee := p.Fee
+	p.Fee = fee
+	defer func() {
+		if err != nil {
+			p.Fee = prevFee
+		}
+	}()
+	err = p.AddReceivers(receivers)
+	return
+}
+
+// AddReceiversWithMinFee adds the receivers of the payment, whose inputs must
+// cover also the given fee charged by the ASP. What's left of the inputs,
+// less than dust since it couldn't be an output, is kept by the ASP as well.
+func (p *Payment) AddReceiversWithMinFee(receivers []Receiver, minFee uint64) error {
+	inAmount := p.TotalInputAmount()
+	outAmount := p.TotalOutputAmount()
+	for _, r := range receivers {
+		outAmount += r.Amount
+	}
+	if inAmount < outAmount+minFee {
+		return fmt.Errorf(
+			"input amount doesn't cover outputs and fees of %d sats", minFee,
+		)
+	}
+	if surplus := inAmount - outAmount - minFee; surplus >= dustAmount {
+		return fmt.Errorf("input and output amounts mismatch")
+	}
+	return p.AddReceiversWithFee(receivers, inAmount-outAmount)
+}
+
 func (p Payment) TotalInputAmount() uint64 {
 	tot := uint64(0)
 	for _, in := range p.Inputs {
@@ -91,7 +128,7 @@ func (p Payment) validate(ignoreOuts bool) error {
 		}
 		outAmount += r.Amount
 	}
-	if inAmount != outAmount {
+	if inAmount != outAmount+p.Fee {
 		return fmt.Errorf("input and output amounts mismatch")
 	}
 	return nil
@@ -136,4 +173,7 @@ type Vtxo struct {
 	Redeemed bool
 	Swept    bool
 	ExpireAt int64
+	// RedeemTx is the out-of-round tx, co-signed by the ASP, that created the
+	// vtxo spending other vtxos. It's empty for the vtxos created by a round.
+	RedeemTx string
 }
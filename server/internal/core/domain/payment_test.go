@@ -69,6 +69,54 @@ func TestPayment(t *testing.T) {
 			require.NoError(t, err)
 		})
 
+		t.Run("valid_with_fee", func(t *testing.T) {
+			payment, err := domain.NewPayment(inputs)
+			require.NoError(t, err)
+			require.NotNil(t, payment)
+
+			receivers := []domain.Receiver{
+				{
+					OnchainAddress: "ex1qjlnfpmpcnm8pqtsuwhlwgwpstnf84ye5xasgz0",
+					Amount:         900,
+				},
+			}
+
+			err = payment.AddReceiversWithFee(receivers, 50)
+			require.EqualError(t, err, "input and output amounts mismatch")
+			require.Empty(t, payment.Receivers)
+			require.Zero(t, payment.Fee)
+
+			err = payment.AddReceiversWithFee(receivers, 100)
+			require.NoError(t, err)
+			require.Equal(t, uint64(100), payment.Fee)
+		})
+
+		t.Run("valid_with_min_fee", func(t *testing.T) {
+			payment, err := domain.NewPayment(inputs)
+			require.NoError(t, err)
+			require.NotNil(t, payment)
+
+			receivers := []domain.Receiver{
+				{
+					Pubkey: "020000000000000000000000000000000000000000000000000000000000000002",
+					Amount: 500,
+				},
+			}
+
+			err = payment.AddReceiversWithMinFee(receivers, 600)
+			require.EqualError(t, err, "input amount doesn't cover outputs and fees of 600 sats")
+			require.Empty(t, payment.Receivers)
+
+			err = payment.AddReceiversWithMinFee(receivers, 10)
+			require.EqualError(t, err, "input and output amounts mismatch")
+			require.Empty(t, payment.Receivers)
+
+			// the surplus below dust is kept as fee.
+			err = payment.AddReceiversWithMinFee(receivers, 100)
+			require.NoError(t, err)
+			require.Equal(t, uint64(500), payment.Fee)
+		})
+
 		t.Run("invalid", func(t *testing.T) {
 			fixtures := []struct {
 				receivers   []domain.Receiver
@@ -47,7 +47,8 @@ type Round struct {
 	ConnectorAddress  string
 	DustAmount        uint64
 	Version           uint
-	Swept             bool // true if all the vtxos are vtxo.Swept or vtxo.Redeemed
+	Swept             bool   // true if all the vtxos are vtxo.Swept or vtxo.Redeemed
+	SweptAmount       uint64 // amount of the shared outputs swept by the ASP, fees included
 	changes           []RoundEvent
 }
 
@@ -270,10 +271,27 @@ func (r *Round) TotalOutputAmount() uint64 {
 	return tot
 }
 
+// IncludesInput returns whether the given vtxo is spent by a payment of the
+// round.
+func (r *Round) IncludesInput(key VtxoKey) bool {
+	for _, p := range r.Payments {
+		for _, in := range p.Inputs {
+			if in.VtxoKey == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *Round) Sweep() {
 	r.Swept = true
 }
 
+func (r *Round) AddSweptAmount(amount uint64) {
+	r.SweptAmount += amount
+}
+
 func (r *Round) raise(event RoundEvent) {
 	if r.changes == nil {
 		r.changes = make([]RoundEvent, 0)
@@ -0,0 +1,16 @@
+package ports
+
+import "time"
+
+// MetricsService collects the metrics of the operator and exposes them to
+// be scraped.
+type MetricsService interface {
+	Start() error
+	Stop()
+
+	RoundEnded(duration time.Duration, numOfPayments int)
+	RoundFailed()
+	VtxosSwept(numOfVtxos int, amount uint64)
+	// RequestHandled counts the gRPC requests by method and status code.
+	RequestHandled(method, code string)
+}
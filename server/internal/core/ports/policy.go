@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/ark-network/ark/internal/core/domain"
+)
+
+// PolicyService lets the operator accept or reject the payments of the
+// clients with custom rules, like amount limits or velocity checks, without
+// forking arkd. The returned error is the reason of the rejection.
+type PolicyService interface {
+	// AcceptRegistration is called when a payment spending the given vtxos
+	// is registered.
+	AcceptRegistration(ctx context.Context, inputs []domain.Vtxo) error
+	// AcceptClaim is called when the given payment is claimed with the
+	// given receivers.
+	AcceptClaim(
+		ctx context.Context, payment domain.Payment, receivers []domain.Receiver,
+	) error
+	Close()
+}
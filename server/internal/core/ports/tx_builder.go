@@ -17,9 +17,24 @@ type SweepInput interface {
 }
 
 type TxBuilder interface {
-	BuildPoolTx(aspPubkey *secp256k1.PublicKey, payments []domain.Payment, minRelayFee uint64, sweptRounds []domain.Round) (poolTx string, congestionTree tree.CongestionTree, connectorAddress string, err error)
+	// BuildPoolTx builds the pool tx and the congestion tree of the round.
+	// The cosigners, if any, are the keys aggregated with MuSig2 in the
+	// outputs of the tree txs, for the builders requiring the tree to be
+	// signed.
+	BuildPoolTx(aspPubkey *secp256k1.PublicKey, payments []domain.Payment, minRelayFee uint64, sweptRounds []domain.Round, cosigners ...*secp256k1.PublicKey) (poolTx string, congestionTree tree.CongestionTree, connectorAddress string, err error)
 	BuildForfeitTxs(aspPubkey *secp256k1.PublicKey, poolTx string, payments []domain.Payment, minRelayFee uint64) (connectors []string, forfeitTxs []string, err error)
-	BuildSweepTx(inputs []SweepInput) (signedSweepTx string, err error)
+	// BuildSweepTx builds the tx sweeping the given inputs at the given fee
+	// rate, in sats/kvbyte, or at the one of the wallet if zero.
+	BuildSweepTx(inputs []SweepInput, feeRate uint64) (signedSweepTx string, err error)
+	// FundBoardingTx adds to the given boarding tx the coins of the wallet
+	// covering what its inputs are missing and the onchain fees, the change
+	// going to the ASP. The returned tx is unsigned.
+	FundBoardingTx(aspPubkey *secp256k1.PublicKey, boardingTx string) (fundedTx string, err error)
+	BuildAsyncPaymentTx(vtxos []domain.Vtxo, aspPubkey *secp256k1.PublicKey, receivers []domain.Receiver) (redeemTx string, err error)
 	GetVtxoScript(userPubkey, aspPubkey *secp256k1.PublicKey) ([]byte, error)
 	GetSweepInput(parentblocktime int64, node tree.Node) (expirationtime int64, sweepInput SweepInput, err error)
+	// RequiresTreeSigning returns whether the txs of the congestion tree must
+	// be signed by the cosigners before finalizing the round, that is if the
+	// tree is not enforced by covenants.
+	RequiresTreeSigning() bool
 }
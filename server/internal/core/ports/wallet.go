@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
@@ -18,13 +19,24 @@ type WalletService interface {
 	SelectUtxos(ctx context.Context, asset string, amount uint64) ([]TxInput, uint64, error)
 	BroadcastTransaction(ctx context.Context, txHex string) (string, error)
 	SignPsetWithKey(ctx context.Context, pset string, inputIndexes []int) (string, error) // inputIndexes == nil means sign all inputs
+	// SignMusig2 returns the public nonce and the MuSig2 partial signature of
+	// the ASP of the given message, with the given keys aggregated and tweaked
+	// with the script root, and the nonces of the other signers. The ASP signs
+	// last, so that its nonce is used right away and never stored.
+	SignMusig2(
+		ctx context.Context, message [32]byte, keys []*secp256k1.PublicKey,
+		scriptRoot []byte, nonces [][66]byte,
+	) ([66]byte, *musig2.PartialSignature, error)
 	IsTransactionConfirmed(ctx context.Context, txid string) (isConfirmed bool, blocktime int64, err error)
 	WaitForSync(ctx context.Context, txid string) error
 	EstimateFees(ctx context.Context, pset string) (uint64, error)
+	// FeeRate returns the current onchain fee rate in sats/kvbyte.
+	FeeRate(ctx context.Context) (uint64, error)
 	ListConnectorUtxos(ctx context.Context, connectorAddress string) ([]TxInput, error)
 	MainAccountBalance(ctx context.Context) (uint64, uint64, error)
 	ConnectorsAccountBalance(ctx context.Context) (uint64, uint64, error)
 	LockConnectorUtxos(ctx context.Context, utxos []TxOutpoint) error
+	Withdraw(ctx context.Context, address string, amount uint64) (string, error)
 	Close()
 }
 
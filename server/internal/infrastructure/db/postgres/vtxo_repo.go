@@ -0,0 +1,312 @@
+package postgresdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ark-network/ark/internal/core/domain"
+)
+
+const (
+	createVtxoTable = `
+CREATE TABLE IF NOT EXISTS vtxo (
+	txid TEXT NOT NULL,
+	vout INTEGER NOT NULL,
+	pubkey TEXT NOT NULL,
+	amount BIGINT NOT NULL,
+	pool_tx TEXT NOT NULL,
+	spent_by TEXT NOT NULL,
+	spent BOOLEAN NOT NULL,
+	redeemed BOOLEAN NOT NULL,
+	swept BOOLEAN NOT NULL,
+	expire_at BIGINT NOT NULL,
+	payment_id TEXT REFERENCES payment(id),
+	redeem_tx TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (txid, vout)
+);
+CREATE INDEX IF NOT EXISTS vtxo_pubkey_idx ON vtxo (pubkey);
+CREATE INDEX IF NOT EXISTS vtxo_pool_tx_idx ON vtxo (pool_tx);
+`
+
+	upsertVtxos = `
+INSERT INTO vtxo (txid, vout, pubkey, amount, pool_tx, spent_by, spent, redeemed, swept, expire_at, redeem_tx)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT(txid, vout) DO UPDATE SET
+	pubkey = excluded.pubkey,
+	amount = excluded.amount,
+	pool_tx = excluded.pool_tx,
+	spent_by = excluded.spent_by,
+	spent = excluded.spent,
+	redeemed = excluded.redeemed,
+	swept = excluded.swept,
+	expire_at = excluded.expire_at,
+	redeem_tx = excluded.redeem_tx;
+`
+
+	selectVtxos = `
+SELECT txid, vout, pubkey, amount, pool_tx, spent_by, spent, redeemed, swept, expire_at, payment_id, redeem_tx
+FROM vtxo
+`
+
+	selectSweepableVtxos             = selectVtxos + " WHERE redeemed = false AND swept = false"
+	selectNotRedeemedVtxos           = selectVtxos + " WHERE redeemed = false"
+	selectNotRedeemedVtxosWithPubkey = selectVtxos + " WHERE redeemed = false AND pubkey = $1"
+	selectVtxoByOutpoint             = selectVtxos + " WHERE txid = $1 AND vout = $2"
+	selectVtxosByPoolTxid            = selectVtxos + " WHERE pool_tx = $1"
+
+	markVtxoAsRedeemed = `
+UPDATE vtxo SET redeemed = true WHERE txid = $1 AND vout = $2
+`
+
+	markVtxoAsSwept = `
+UPDATE vtxo SET swept = true WHERE txid = $1 AND vout = $2
+`
+
+	markVtxoAsSpent = `
+UPDATE vtxo SET spent = true, spent_by = $1 WHERE txid = $2 AND vout = $3
+`
+
+	updateVtxoExpireAt = `
+UPDATE vtxo SET expire_at = $1 WHERE txid = $2 AND vout = $3
+`
+)
+
+type vtxoRow struct {
+	txid      *string
+	vout      *uint32
+	pubkey    *string
+	amount    *uint64
+	poolTx    *string
+	spentBy   *string
+	spent     *bool
+	redeemed  *bool
+	swept     *bool
+	expireAt  *int64
+	paymentID *string
+	redeemTx  *string
+}
+
+type vxtoRepository struct {
+	db *sql.DB
+}
+
+func NewVtxoRepository(config ...interface{}) (domain.VtxoRepository, error) {
+	if len(config) != 1 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	db, ok := config[0].(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf("cannot open vtxo repository: invalid config")
+	}
+
+	return newVtxoRepository(db)
+}
+
+func newVtxoRepository(db *sql.DB) (*vxtoRepository, error) {
+	if _, err := db.Exec(createVtxoTable); err != nil {
+		return nil, err
+	}
+
+	return &vxtoRepository{db}, nil
+}
+
+func (v *vxtoRepository) Close() {
+	_ = v.db.Close()
+}
+
+func (v *vxtoRepository) AddVtxos(ctx context.Context, vtxos []domain.Vtxo) error {
+	return execTx(ctx, v.db, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, upsertVtxos)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, vtxo := range vtxos {
+			if _, err := stmt.ExecContext(
+				ctx,
+				vtxo.Txid,
+				vtxo.VOut,
+				vtxo.Pubkey,
+				vtxo.Amount,
+				vtxo.PoolTx,
+				vtxo.SpentBy,
+				vtxo.Spent,
+				vtxo.Redeemed,
+				vtxo.Swept,
+				vtxo.ExpireAt,
+				vtxo.RedeemTx,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (v *vxtoRepository) GetAllSweepableVtxos(ctx context.Context) ([]domain.Vtxo, error) {
+	rows, err := v.db.QueryContext(ctx, selectSweepableVtxos)
+	if err != nil {
+		return nil, err
+	}
+
+	return readRows(rows)
+}
+
+func (v *vxtoRepository) GetAllVtxos(ctx context.Context, pubkey string) ([]domain.Vtxo, []domain.Vtxo, error) {
+	var rows *sql.Rows
+	var err error
+
+	if len(pubkey) > 0 {
+		rows, err = v.db.QueryContext(ctx, selectNotRedeemedVtxosWithPubkey, pubkey)
+	} else {
+		rows, err = v.db.QueryContext(ctx, selectNotRedeemedVtxos)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vtxos, err := readRows(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unspentVtxos := make([]domain.Vtxo, 0)
+	spentVtxos := make([]domain.Vtxo, 0)
+
+	for _, vtxo := range vtxos {
+		if vtxo.Spent {
+			spentVtxos = append(spentVtxos, vtxo)
+		} else {
+			unspentVtxos = append(unspentVtxos, vtxo)
+		}
+	}
+
+	return unspentVtxos, spentVtxos, nil
+}
+
+func (v *vxtoRepository) GetVtxos(ctx context.Context, outpoints []domain.VtxoKey) ([]domain.Vtxo, error) {
+	stmt, err := v.db.PrepareContext(ctx, selectVtxoByOutpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	vtxos := make([]domain.Vtxo, 0, len(outpoints))
+
+	for _, outpoint := range outpoints {
+		rows, err := stmt.QueryContext(ctx, outpoint.Txid, outpoint.VOut)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := readRows(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result) == 0 {
+			return nil, fmt.Errorf("vtxo not found")
+		}
+
+		vtxos = append(vtxos, result[0])
+	}
+
+	return vtxos, nil
+}
+
+func (v *vxtoRepository) GetVtxosForRound(ctx context.Context, txid string) ([]domain.Vtxo, error) {
+	rows, err := v.db.QueryContext(ctx, selectVtxosByPoolTxid, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	return readRows(rows)
+}
+
+func (v *vxtoRepository) RedeemVtxos(ctx context.Context, vtxos []domain.VtxoKey) error {
+	return v.updateVtxos(ctx, markVtxoAsRedeemed, vtxos)
+}
+
+func (v *vxtoRepository) SpendVtxos(ctx context.Context, vtxos []domain.VtxoKey, txid string) error {
+	return v.updateVtxos(ctx, markVtxoAsSpent, vtxos, txid)
+}
+
+func (v *vxtoRepository) SweepVtxos(ctx context.Context, vtxos []domain.VtxoKey) error {
+	return v.updateVtxos(ctx, markVtxoAsSwept, vtxos)
+}
+
+func (v *vxtoRepository) UpdateExpireAt(ctx context.Context, vtxos []domain.VtxoKey, expireAt int64) error {
+	return v.updateVtxos(ctx, updateVtxoExpireAt, vtxos, expireAt)
+}
+
+// updateVtxos runs the given update for every vtxo, with the given args
+// preceding the outpoint.
+func (v *vxtoRepository) updateVtxos(
+	ctx context.Context, query string, vtxos []domain.VtxoKey, args ...interface{},
+) error {
+	return execTx(ctx, v.db, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, vtxo := range vtxos {
+			params := append(append([]interface{}{}, args...), vtxo.Txid, vtxo.VOut)
+			if _, err := stmt.ExecContext(ctx, params...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func rowToVtxo(row vtxoRow) domain.Vtxo {
+	return domain.Vtxo{
+		VtxoKey: domain.VtxoKey{
+			Txid: *row.txid,
+			VOut: *row.vout,
+		},
+		Receiver: domain.Receiver{
+			Pubkey: *row.pubkey,
+			Amount: *row.amount,
+		},
+		PoolTx:   *row.poolTx,
+		SpentBy:  *row.spentBy,
+		Spent:    *row.spent,
+		Redeemed: *row.redeemed,
+		Swept:    *row.swept,
+		ExpireAt: *row.expireAt,
+		RedeemTx: *row.redeemTx,
+	}
+}
+
+func readRows(rows *sql.Rows) ([]domain.Vtxo, error) {
+	defer rows.Close()
+	vtxos := make([]domain.Vtxo, 0)
+
+	for rows.Next() {
+		var row vtxoRow
+		if err := rows.Scan(
+			&row.txid,
+			&row.vout,
+			&row.pubkey,
+			&row.amount,
+			&row.poolTx,
+			&row.spentBy,
+			&row.spent,
+			&row.redeemed,
+			&row.swept,
+			&row.expireAt,
+			&row.paymentID,
+			&row.redeemTx,
+		); err != nil {
+			return nil, err
+		}
+
+		vtxos = append(vtxos, rowToVtxo(row))
+	}
+
+	return vtxos, rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ark-network/ark/internal/core/domain"
 	"github.com/ark-network/ark/internal/core/ports"
 	badgerdb "github.com/ark-network/ark/internal/infrastructure/db/badger"
+	postgresdb "github.com/ark-network/ark/internal/infrastructure/db/postgres"
 	sqlitedb "github.com/ark-network/ark/internal/infrastructure/db/sqlite"
 )
 
@@ -15,12 +16,14 @@ var (
 		"badger": badgerdb.NewRoundEventRepository,
 	}
 	roundStoreTypes = map[string]func(...interface{}) (domain.RoundRepository, error){
-		"badger": badgerdb.NewRoundRepository,
-		"sqlite": sqlitedb.NewRoundRepository,
+		"badger":   badgerdb.NewRoundRepository,
+		"sqlite":   sqlitedb.NewRoundRepository,
+		"postgres": postgresdb.NewRoundRepository,
 	}
 	vtxoStoreTypes = map[string]func(...interface{}) (domain.VtxoRepository, error){
-		"badger": badgerdb.NewVtxoRepository,
-		"sqlite": sqlitedb.NewVtxoRepository,
+		"badger":   badgerdb.NewVtxoRepository,
+		"sqlite":   sqlitedb.NewVtxoRepository,
+		"postgres": postgresdb.NewVtxoRepository,
 	}
 )
 
@@ -102,7 +105,29 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to open vtxo store: %s", err)
 		}
+	case "postgres":
+		if len(config.DataStoreConfig) != 1 {
+			return nil, fmt.Errorf("invalid data store config")
+		}
+		dbURL, ok := config.DataStoreConfig[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid db url")
+		}
+		db, err := postgresdb.OpenDb(dbURL)
+		if err != nil {
+			return nil, err
+		}
 
+		// the vtxo table references the payment one, created along with the
+		// round store.
+		roundStore, err = roundStoreFactory(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open round store: %s", err)
+		}
+		vtxoStore, err = vtxoStoreFactory(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open vtxo store: %s", err)
+		}
 	}
 
 	return &service{eventStore, roundStore, vtxoStore}, nil
@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/hex"
 	"os"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 	"github.com/ark-network/ark/internal/core/ports"
 	"github.com/ark-network/ark/internal/infrastructure/db"
 	"github.com/google/uuid"
+	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +102,23 @@ func TestService(t *testing.T) {
 			},
 		},
 	}
+	// the postgres stores are tested only against the db given with the env
+	// var, whose tables are dropped.
+	if dbURL := os.Getenv("ARK_TEST_POSTGRES_URL"); len(dbURL) > 0 {
+		resetPostgresDb(t, dbURL)
+		tests = append(tests, struct {
+			name   string
+			config db.ServiceConfig
+		}{
+			name: "repo_manager_with_postgres_stores",
+			config: db.ServiceConfig{
+				EventStoreType:   "badger",
+				DataStoreType:    "postgres",
+				EventStoreConfig: []interface{}{"", nil},
+				DataStoreConfig:  []interface{}{dbURL},
+			},
+		})
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -349,6 +368,16 @@ func testRoundRepository(t *testing.T, svc ports.RepoManager) {
 		require.NoError(t, err)
 		require.NotNil(t, roundByTxid)
 		require.Condition(t, roundsMatch(*finalizedRound, *roundByTxid))
+
+		finalizedRound.AddSweptAmount(1000)
+		finalizedRound.Sweep()
+		err = svc.Rounds().AddOrUpdateRound(ctx, *finalizedRound)
+		require.NoError(t, err)
+
+		sweptRound, err := svc.Rounds().GetRoundWithTxid(ctx, txid)
+		require.NoError(t, err)
+		require.True(t, sweptRound.Swept)
+		require.Equal(t, uint64(1000), sweptRound.SweptAmount)
 	})
 }
 
@@ -527,6 +556,15 @@ func roundsMatch(expected, got domain.Round) assert.Comparison {
 	}
 }
 
+func resetPostgresDb(t *testing.T, dbURL string) {
+	conn, err := sql.Open("postgres", dbURL)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Exec("DROP TABLE IF EXISTS tx, receiver, vtxo, payment, round CASCADE;")
+	require.NoError(t, err)
+}
+
 func randomString(len int) string {
 	buf := make([]byte, len)
 	// nolint
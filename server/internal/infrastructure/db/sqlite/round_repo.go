@@ -43,8 +43,17 @@ CREATE TABLE IF NOT EXISTS round (
 	connector_address TEXT NOT NULL,
 	dust_amount INTEGER NOT NULL,
 	version INTEGER NOT NULL,
-	swept BOOLEAN NOT NULL
+	swept BOOLEAN NOT NULL,
+	swept_amount INTEGER NOT NULL DEFAULT 0
 );
+`
+
+	selectRoundColumns = `
+SELECT name FROM pragma_table_info('round');
+`
+
+	migrateRoundTable = `
+ALTER TABLE round ADD COLUMN swept_amount INTEGER NOT NULL DEFAULT 0;
 `
 
 	createTransactionTable = `
@@ -88,8 +97,9 @@ INSERT INTO round (
 	connector_address, 
 	dust_amount, 
 	version, 
-	swept
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	swept,
+	swept_amount
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
 	starting_timestamp = EXCLUDED.starting_timestamp,
 	ending_timestamp = EXCLUDED.ending_timestamp,
@@ -101,7 +111,8 @@ ON CONFLICT(id) DO UPDATE SET
 	connector_address = EXCLUDED.connector_address,
 	dust_amount = EXCLUDED.dust_amount,
 	version = EXCLUDED.version,
-	swept = EXCLUDED.swept;
+	swept = EXCLUDED.swept,
+	swept_amount = EXCLUDED.swept_amount;
 `
 
 	upsertPayment = `
@@ -123,9 +134,9 @@ UPDATE vtxo SET payment_id = ? WHERE txid = ? AND vout = ?
 
 	selectRound = `
 SELECT round.id, round.starting_timestamp, round.ending_timestamp, round.ended, round.failed, round.stage_code, round.txid, 
-round.unsigned_tx, round.connector_address, round.dust_amount, round.version, round.swept, payment.id, receiver.payment_id, 
+round.unsigned_tx, round.connector_address, round.dust_amount, round.version, round.swept, round.swept_amount, payment.id, receiver.payment_id, 
 receiver.pubkey, receiver.amount, receiver.onchain_address, vtxo.txid, vtxo.vout, vtxo.pubkey, vtxo.amount, 
-vtxo.pool_tx, vtxo.spent_by, vtxo.spent, vtxo.redeemed, vtxo.swept, vtxo.expire_at, vtxo.payment_id, 
+vtxo.pool_tx, vtxo.spent_by, vtxo.spent, vtxo.redeemed, vtxo.swept, vtxo.expire_at, vtxo.payment_id, vtxo.redeem_tx, 
 tx.tx, tx.type, tx.position, tx.txid, 
 tx.tree_level, tx.parent_txid, tx.is_leaf
 FROM round 
@@ -184,6 +195,7 @@ type roundRow struct {
 	dustAmount        *uint64
 	version           *uint
 	swept             *bool
+	sweptAmount       *uint64
 }
 
 type roundRepository struct {
@@ -207,6 +219,10 @@ func newRoundRepository(db *sql.DB) (*roundRepository, error) {
 		return nil, err
 	}
 
+	if err := migrateRounds(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate round table: %s", err)
+	}
+
 	if _, err := db.Exec(createPaymentTable); err != nil {
 		return nil, err
 	}
@@ -222,6 +238,33 @@ func newRoundRepository(db *sql.DB) (*roundRepository, error) {
 	return &roundRepository{db}, nil
 }
 
+// migrateRounds adds the swept_amount column to the round table if created
+// without it, the rounds already swept are accounted with no amount.
+func migrateRounds(db *sql.DB) error {
+	rows, err := db.Query(selectRoundColumns)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return err
+		}
+		if column == "swept_amount" {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(migrateRoundTable)
+	return err
+}
+
 func (r *roundRepository) Close() {
 	_ = r.db.Close()
 }
@@ -296,6 +339,7 @@ func (r *roundRepository) AddOrUpdateRound(ctx context.Context, round domain.Rou
 		round.DustAmount,
 		round.Version,
 		round.Swept,
+		round.SweptAmount,
 	)
 	if err != nil {
 		return err
@@ -536,6 +580,7 @@ func readRoundRows(rows *sql.Rows) ([]*domain.Round, error) {
 			&roundRow.dustAmount,
 			&roundRow.version,
 			&roundRow.swept,
+			&roundRow.sweptAmount,
 			&paymentRow.id,
 			&receiverRow.paymentId,
 			&receiverRow.pubkey,
@@ -552,6 +597,7 @@ func readRoundRows(rows *sql.Rows) ([]*domain.Round, error) {
 			&vtxoRow.swept,
 			&vtxoRow.expireAt,
 			&vtxoRow.paymentID,
+			&vtxoRow.redeemTx,
 			&transactionRow.tx,
 			&transactionRow.txType,
 			&transactionRow.position,
@@ -587,6 +633,7 @@ func readRoundRows(rows *sql.Rows) ([]*domain.Round, error) {
 				DustAmount:       *roundRow.dustAmount,
 				Version:          *roundRow.version,
 				Swept:            *roundRow.swept,
+				SweptAmount:      *roundRow.sweptAmount,
 				Payments:         make(map[string]domain.Payment),
 			}
 		}
@@ -11,7 +11,7 @@ import (
 const (
 	createVtxoTable = `
 CREATE TABLE IF NOT EXISTS vtxo (
-	txid TEXT NOT NULL PRIMARY KEY,
+	txid TEXT NOT NULL,
 	vout INTEGER NOT NULL,
 	pubkey TEXT NOT NULL,
 	amount INTEGER NOT NULL,
@@ -22,13 +22,28 @@ CREATE TABLE IF NOT EXISTS vtxo (
 	swept BOOLEAN NOT NULL,
 	expire_at INTEGER NOT NULL,
 	payment_id TEXT,
+	redeem_tx TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (txid, vout),
 	FOREIGN KEY (payment_id) REFERENCES payment(id)
 );
+`
+
+	// the vtxos of the async payments share the txid of their redeem tx, so
+	// the tables created before must be rebuilt with the outpoint as key.
+	selectVtxoColumns = `
+SELECT name FROM pragma_table_info('vtxo');
+`
+
+	migrateVtxoTable = `
+ALTER TABLE vtxo RENAME TO vtxo_old;
+%s
+INSERT INTO vtxo SELECT *, '' FROM vtxo_old;
+DROP TABLE vtxo_old;
 `
 
 	upsertVtxos = `
-INSERT INTO vtxo (txid, vout, pubkey, amount, pool_tx, spent_by, spent, redeemed, swept, expire_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(txid) DO UPDATE SET
+INSERT INTO vtxo (txid, vout, pubkey, amount, pool_tx, spent_by, spent, redeemed, swept, expire_at, redeem_tx)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(txid, vout) DO UPDATE SET
 	vout = excluded.vout,
 	pubkey = excluded.pubkey,
 	amount = excluded.amount,
@@ -37,7 +52,8 @@ VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(txid) DO UPDATE SET
 	spent = excluded.spent,
 	redeemed = excluded.redeemed,
 	swept = excluded.swept,
-	expire_at = excluded.expire_at;
+	expire_at = excluded.expire_at,
+	redeem_tx = excluded.redeem_tx;
 `
 
 	selectSweepableVtxos = `
@@ -89,6 +105,7 @@ type vtxoRow struct {
 	swept     *bool
 	expireAt  *int64
 	paymentID *string
+	redeemTx  *string
 }
 
 type vxtoRepository struct {
@@ -113,9 +130,50 @@ func newVtxoRepository(db *sql.DB) (*vxtoRepository, error) {
 		return nil, err
 	}
 
+	if err := migrateVtxos(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate vtxo table: %s", err)
+	}
+
 	return &vxtoRepository{db}, nil
 }
 
+// migrateVtxos rebuilds the vtxo table if created without the redeem_tx
+// column, the existing vtxos are all created by rounds.
+func migrateVtxos(db *sql.DB) error {
+	rows, err := db.Query(selectVtxoColumns)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return err
+		}
+		if column == "redeem_tx" {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		fmt.Sprintf(migrateVtxoTable, createVtxoTable),
+	); err != nil {
+		// nolint:all
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
 func (v *vxtoRepository) Close() {
 	_ = v.db.Close()
 }
@@ -145,6 +203,7 @@ func (v *vxtoRepository) AddVtxos(ctx context.Context, vtxos []domain.Vtxo) erro
 			vtxo.Redeemed,
 			vtxo.Swept,
 			vtxo.ExpireAt,
+			vtxo.RedeemTx,
 		)
 		if err != nil {
 			return err
@@ -345,6 +404,7 @@ func rowToVtxo(row vtxoRow) domain.Vtxo {
 		Redeemed: *row.redeemed,
 		Swept:    *row.swept,
 		ExpireAt: *row.expireAt,
+		RedeemTx: *row.redeemTx,
 	}
 }
 
@@ -366,6 +426,7 @@ func readRows(rows *sql.Rows) ([]domain.Vtxo, error) {
 			&row.swept,
 			&row.expireAt,
 			&row.paymentID,
+			&row.redeemTx,
 		); err != nil {
 			return nil, err
 		}
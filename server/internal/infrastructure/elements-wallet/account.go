@@ -0,0 +1,298 @@
+package elementswallet
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/vulpemventures/go-elements/address"
+)
+
+const (
+	zero32 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	// selectedUtxosLockTimeout is the time after which the utxos selected to
+	// fund a pool tx are released if not spent in the meanwhile.
+	selectedUtxosLockTimeout = 5 * time.Minute
+)
+
+// unspent is an entry returned by listunspent.
+type unspent struct {
+	Txid          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	Address       string  `json:"address"`
+	Label         string  `json:"label"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	Amount        float64 `json:"amount"`
+	Asset         string  `json:"asset"`
+	AmountBlinder string  `json:"amountblinder"`
+	AssetBlinder  string  `json:"assetblinder"`
+	Confirmations int64   `json:"confirmations"`
+	Spendable     bool    `json:"spendable"`
+}
+
+func (u unspent) GetTxid() string {
+	return u.Txid
+}
+func (u unspent) GetIndex() uint32 {
+	return u.Vout
+}
+func (u unspent) GetScript() string {
+	return u.ScriptPubKey
+}
+func (u unspent) GetAsset() string {
+	return u.Asset
+}
+func (u unspent) GetValue() uint64 {
+	return toSatoshis(u.Amount)
+}
+
+func (u unspent) isConfidential() bool {
+	return (len(u.AmountBlinder) > 0 && u.AmountBlinder != zero32) ||
+		(len(u.AssetBlinder) > 0 && u.AssetBlinder != zero32)
+}
+
+type outpoint struct {
+	Txid string `json:"txid"`
+	Vout uint32 `json:"vout"`
+}
+
+func (s *service) DeriveAddresses(
+	ctx context.Context, numOfAddresses int,
+) ([]string, error) {
+	return s.deriveAddresses(ctx, numOfAddresses, arkLabel)
+}
+
+func (s *service) DeriveConnectorAddress(ctx context.Context) (string, error) {
+	addresses, err := s.deriveAddresses(ctx, 1, connectorLabel)
+	if err != nil {
+		return "", err
+	}
+
+	return addresses[0], nil
+}
+
+func (s *service) ListConnectorUtxos(
+	ctx context.Context, connectorAddress string,
+) ([]ports.TxInput, error) {
+	script, err := address.ToOutputScript(connectorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos, err := s.listUtxos(ctx, connectorLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]ports.TxInput, 0)
+	for _, utxo := range utxos {
+		if utxo.ScriptPubKey == fmt.Sprintf("%x", script) {
+			inputs = append(inputs, utxo)
+		}
+	}
+	return inputs, nil
+}
+
+func (s *service) MainAccountBalance(ctx context.Context) (uint64, uint64, error) {
+	return s.getBalance(ctx, arkLabel)
+}
+
+func (s *service) ConnectorsAccountBalance(ctx context.Context) (uint64, uint64, error) {
+	return s.getBalance(ctx, connectorLabel)
+}
+
+func (s *service) LockConnectorUtxos(
+	ctx context.Context, utxos []ports.TxOutpoint,
+) error {
+	outpoints := make([]outpoint, 0, len(utxos))
+	for _, utxo := range utxos {
+		outpoints = append(outpoints, outpoint{utxo.GetTxid(), utxo.GetIndex()})
+	}
+	return s.lockUtxos(ctx, outpoints)
+}
+
+func (s *service) deriveAddresses(
+	ctx context.Context, numOfAddresses int, label string,
+) ([]string, error) {
+	addresses := make([]string, 0, numOfAddresses)
+	for i := 0; i < numOfAddresses; i++ {
+		addr := ""
+		if err := s.rpc.call(
+			ctx, "getnewaddress", &addr, label, "bech32",
+		); err != nil {
+			return nil, err
+		}
+
+		// the pool txs are unblinded, therefore use unconfidential addresses.
+		if isConf, _ := address.IsConfidential(addr); isConf {
+			info, err := address.FromConfidential(addr)
+			if err != nil {
+				return nil, err
+			}
+			addr = info.Address
+		}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
+
+// listUtxos returns the spendable, unlocked and unblinded utxos of the
+// addresses with the given label.
+func (s *service) listUtxos(
+	ctx context.Context, label string,
+) ([]unspent, error) {
+	utxos := make([]unspent, 0)
+	if err := s.rpc.call(
+		ctx, "listunspent", &utxos, 0, 9999999, []string{}, true,
+	); err != nil {
+		return nil, err
+	}
+
+	filtered := make([]unspent, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.Label != label || !utxo.Spendable || utxo.isConfidential() {
+			continue
+		}
+		filtered = append(filtered, utxo)
+	}
+	return filtered, nil
+}
+
+func (s *service) getBalance(
+	ctx context.Context, label string,
+) (uint64, uint64, error) {
+	utxos, err := s.listUtxos(ctx, label)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	available := uint64(0)
+	for _, utxo := range utxos {
+		if utxo.Asset == s.network.AssetID {
+			available += utxo.GetValue()
+		}
+	}
+
+	locked, err := s.getLockedBalance(ctx, label)
+	if err != nil {
+		return 0, 0, err
+	}
+	return available, locked, nil
+}
+
+// getLockedBalance sums the locked utxos of the addresses with the given
+// label, which are not returned by listunspent.
+func (s *service) getLockedBalance(
+	ctx context.Context, label string,
+) (uint64, error) {
+	lockedUtxos := make([]outpoint, 0)
+	if err := s.rpc.call(ctx, "listlockunspent", &lockedUtxos); err != nil {
+		return 0, err
+	}
+	if len(lockedUtxos) <= 0 {
+		return 0, nil
+	}
+
+	addresses := make(map[string]interface{})
+	err := s.rpc.call(ctx, "getaddressesbylabel", &addresses, label)
+	if err != nil {
+		if isRPCError(err, rpcErrLabelNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	scripts := make(map[string]struct{})
+	for addr := range addresses {
+		script, err := address.ToOutputScript(addr)
+		if err != nil {
+			continue
+		}
+		scripts[fmt.Sprintf("%x", script)] = struct{}{}
+	}
+
+	locked := uint64(0)
+	for _, utxo := range lockedUtxos {
+		txout := struct {
+			Value        float64 `json:"value"`
+			Asset        string  `json:"asset"`
+			ScriptPubKey struct {
+				Hex string `json:"hex"`
+			} `json:"scriptPubKey"`
+		}{}
+		if err := s.rpc.call(
+			ctx, "gettxout", &txout, utxo.Txid, utxo.Vout, true,
+		); err != nil {
+			return 0, err
+		}
+		// spent in the meanwhile.
+		if len(txout.ScriptPubKey.Hex) <= 0 {
+			continue
+		}
+		if _, ok := scripts[txout.ScriptPubKey.Hex]; !ok {
+			continue
+		}
+		if txout.Asset == s.network.AssetID {
+			locked += toSatoshis(txout.Value)
+		}
+	}
+	return locked, nil
+}
+
+func (s *service) lockUtxos(ctx context.Context, utxos []outpoint) error {
+	return s.rpc.call(ctx, "lockunspent", nil, false, utxos)
+}
+
+func (s *service) unlockUtxos(ctx context.Context, utxos []outpoint) error {
+	err := s.rpc.call(ctx, "lockunspent", nil, true, utxos)
+	// the utxos might have been spent in the meanwhile.
+	if err != nil && strings.Contains(err.Error(), "expected locked output") {
+		return nil
+	}
+	return err
+}
+
+// selectUtxos selects the utxos of the main account with the given asset
+// to cover the target amount, from the biggest to the smallest.
+func (s *service) selectUtxos(
+	ctx context.Context, asset string, amount uint64,
+) ([]unspent, uint64, error) {
+	utxos, err := s.listUtxos(ctx, arkLabel)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	candidates := make([]unspent, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.Asset == asset {
+			candidates = append(candidates, utxo)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].GetValue() > candidates[j].GetValue()
+	})
+
+	selected := make([]unspent, 0)
+	total := uint64(0)
+	for _, utxo := range candidates {
+		if total >= amount {
+			break
+		}
+		selected = append(selected, utxo)
+		total += utxo.GetValue()
+	}
+	if total < amount {
+		return nil, 0, fmt.Errorf(
+			"not enough funds, missing %d sats", amount-total,
+		)
+	}
+	return selected, total - amount, nil
+}
+
+func toSatoshis(amount float64) uint64 {
+	return uint64(math.Round(amount * 1e8))
+}
@@ -0,0 +1,125 @@
+package elementswallet
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/ark-network/ark/internal/core/ports"
+	log "github.com/sirupsen/logrus"
+)
+
+// WatchScripts imports the given scripts as watch-only into the wallet.
+func (s *service) WatchScripts(ctx context.Context, scripts []string) error {
+	for _, script := range scripts {
+		// script, label, rescan, p2sh
+		if err := s.rpc.call(
+			ctx, "importaddress", nil, script, watchLabel, false, false,
+		); err != nil {
+			return err
+		}
+
+		s.lock.Lock()
+		s.watchedScripts[script] = struct{}{}
+		s.lock.Unlock()
+	}
+
+	return nil
+}
+
+// UnwatchScripts stops notifying about the given scripts. Legacy wallets
+// don't support removing watch-only scripts, therefore they're just ignored
+// from now on.
+func (s *service) UnwatchScripts(ctx context.Context, scripts []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, script := range scripts {
+		delete(s.watchedScripts, script)
+	}
+	return nil
+}
+
+func (s *service) GetNotificationChannel(
+	ctx context.Context,
+) <-chan map[string]ports.VtxoWithValue {
+	return s.chVtxos
+}
+
+// listenToNotifications polls the wallet for new utxos locked by the watched
+// scripts, since elementsd has no push notifications over rpc.
+func (s *service) listenToNotifications() {
+	defer close(s.chVtxos)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	seen := make(map[string]struct{})
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+		}
+
+		vtxos, err := s.getNewWatchedUtxos(seen)
+		if err != nil {
+			log.WithError(err).Warn("failed to list watched utxos")
+			continue
+		}
+		if len(vtxos) <= 0 {
+			continue
+		}
+
+		select {
+		case <-s.quit:
+			return
+		case s.chVtxos <- vtxos:
+		}
+	}
+}
+
+func (s *service) getNewWatchedUtxos(
+	seen map[string]struct{},
+) (map[string]ports.VtxoWithValue, error) {
+	utxos := make([]unspent, 0)
+	if err := s.rpc.call(
+		context.Background(), "listunspent", &utxos, 0, 9999999, []string{}, true,
+	); err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	vtxos := make(map[string]ports.VtxoWithValue)
+	for _, utxo := range utxos {
+		if _, ok := s.watchedScripts[utxo.ScriptPubKey]; !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", utxo.Txid, utxo.Vout)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		vtxos[utxo.ScriptPubKey] = ports.VtxoWithValue{
+			VtxoKey: domain.VtxoKey{
+				Txid: utxo.Txid,
+				VOut: utxo.Vout,
+			},
+			Value: utxo.GetValue(),
+		}
+	}
+	return vtxos, nil
+}
+
+func hexToBytes(str string) ([]byte, error) {
+	buf, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string %s: %s", str, err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,115 @@
+package elementswallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// rpcErrNoTx is the code returned by elementsd for unknown transactions.
+const rpcErrNoTx = -5
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JsonRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcClient is a minimal JSON-RPC client for the wallet endpoint of elementsd.
+type rpcClient struct {
+	url    string
+	user   string
+	pass   string
+	client *http.Client
+	nextID uint64
+}
+
+func newRPCClient(addr, user, pass, wallet string) *rpcClient {
+	return &rpcClient{
+		url:    fmt.Sprintf("http://%s/wallet/%s", addr, wallet),
+		user:   user,
+		pass:   pass,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *rpcClient) call(
+	ctx context.Context, method string, result interface{},
+	params ...interface{},
+) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(rpcRequest{
+		JsonRPC: "1.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.user, c.pass)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach elementsd: %s", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("invalid elementsd rpc credentials")
+	}
+
+	res := rpcResponse{}
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return fmt.Errorf(
+			"failed to parse response of %s (status %d): %s",
+			method, resp.StatusCode, err,
+		)
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+func isRPCError(err error, code int) bool {
+	rpcErr, ok := err.(*rpcError)
+	return ok && rpcErr.Code == code
+}
@@ -0,0 +1,202 @@
+package elementswallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/vulpemventures/go-elements/network"
+)
+
+// Labels used to partition the addresses of the elementsd wallet like the
+// accounts of an HD wallet.
+const (
+	arkLabel       = "ark"
+	connectorLabel = "ark-connector"
+	signerLabel    = "ark-signer"
+	watchLabel     = "ark-watch"
+)
+
+const (
+	rpcErrWalletNotFound  = -18
+	rpcErrLabelNotFound   = -11
+	rpcErrWalletNotLoaded = -35
+
+	pollInterval        = 5 * time.Second
+	consolidateInterval = time.Hour
+)
+
+type service struct {
+	rpc       *rpcClient
+	network   network.Network
+	signerKey *secp256k1.PrivateKey
+
+	lock           *sync.Mutex
+	watchedScripts map[string]struct{}
+
+	chVtxos chan map[string]ports.VtxoWithValue
+	quit    chan struct{}
+}
+
+// NewService returns a wallet service backed by the given elementsd wallet,
+// which is created if not existing yet. The wallet funds and signs the pool
+// transactions, holds the connectors and the key of the ASP, and watches the
+// vtxo scripts as blockchain scanner.
+// The wallet must be a legacy (non-descriptor) one because it relies on
+// importaddress to watch external scripts, and on dumpprivkey to get the key
+// of the ASP.
+func NewService(
+	addr, user, pass, walletName string, net network.Network,
+) (ports.WalletService, error) {
+	svc := &service{
+		rpc:            newRPCClient(addr, user, pass, walletName),
+		network:        net,
+		lock:           &sync.Mutex{},
+		watchedScripts: make(map[string]struct{}),
+		chVtxos:        make(chan map[string]ports.VtxoWithValue),
+		quit:           make(chan struct{}),
+	}
+
+	ctx := context.Background()
+
+	if err := svc.loadWallet(ctx, walletName); err != nil {
+		return nil, err
+	}
+
+	isReady := false
+	for !isReady {
+		status, err := svc.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		isReady = status.IsUnlocked() && status.IsSynced()
+
+		if !isReady {
+			log.Info("Elements wallet must be unlocked and node synced to proceed. Waiting for wallet to be ready...")
+			time.Sleep(3 * time.Second)
+		}
+	}
+
+	signerKey, err := svc.getSignerKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer key: %s", err)
+	}
+	svc.signerKey = signerKey
+
+	go svc.listenToNotifications()
+	go svc.consolidateUtxos()
+
+	return svc, nil
+}
+
+func (s *service) Close() {
+	close(s.quit)
+}
+
+func (s *service) GetPubkey(ctx context.Context) (*secp256k1.PublicKey, error) {
+	return s.signerKey.PubKey(), nil
+}
+
+func (s *service) Status(ctx context.Context) (ports.WalletStatus, error) {
+	walletInfo := struct {
+		UnlockedUntil *int64 `json:"unlocked_until"`
+	}{}
+	if err := s.rpc.call(ctx, "getwalletinfo", &walletInfo); err != nil {
+		return nil, err
+	}
+
+	chainInfo := struct {
+		InitialBlockDownload bool `json:"initialblockdownload"`
+	}{}
+	if err := s.rpc.call(ctx, "getblockchaininfo", &chainInfo); err != nil {
+		return nil, err
+	}
+
+	return walletStatus{
+		// unlocked_until is returned only for encrypted wallets.
+		unlocked: walletInfo.UnlockedUntil == nil || *walletInfo.UnlockedUntil > 0,
+		synced:   !chainInfo.InitialBlockDownload,
+	}, nil
+}
+
+type walletStatus struct {
+	unlocked bool
+	synced   bool
+}
+
+func (w walletStatus) IsInitialized() bool {
+	return true
+}
+func (w walletStatus) IsUnlocked() bool {
+	return w.unlocked
+}
+func (w walletStatus) IsSynced() bool {
+	return w.synced
+}
+
+func (s *service) loadWallet(ctx context.Context, walletName string) error {
+	err := s.rpc.call(ctx, "getwalletinfo", nil)
+	if err == nil {
+		return nil
+	}
+	if !isRPCError(err, rpcErrWalletNotFound) &&
+		!isRPCError(err, rpcErrWalletNotLoaded) {
+		return err
+	}
+
+	if err := s.rpc.call(ctx, "loadwallet", nil, walletName); err == nil {
+		return nil
+	} else if !isRPCError(err, rpcErrWalletNotFound) {
+		return fmt.Errorf("failed to load wallet %s: %s", walletName, err)
+	}
+
+	log.Infof("creating elements wallet %s", walletName)
+	// name, disable_private_keys, blank, passphrase, avoid_reuse, descriptors
+	if err := s.rpc.call(
+		ctx, "createwallet", nil, walletName, false, false, "", false, false,
+	); err != nil {
+		return fmt.Errorf("failed to create wallet %s: %s", walletName, err)
+	}
+	return nil
+}
+
+// getSignerKey returns the private key of the ASP, that is the one of the
+// first address with the signer label, derived at the first startup.
+func (s *service) getSignerKey(
+	ctx context.Context,
+) (*secp256k1.PrivateKey, error) {
+	addresses := make(map[string]interface{})
+	err := s.rpc.call(ctx, "getaddressesbylabel", &addresses, signerLabel)
+	if err != nil && !isRPCError(err, rpcErrLabelNotFound) {
+		return nil, err
+	}
+
+	signerAddr := ""
+	for addr := range addresses {
+		signerAddr = addr
+		break
+	}
+	if len(signerAddr) <= 0 {
+		if err := s.rpc.call(
+			ctx, "getnewaddress", &signerAddr, signerLabel, "bech32",
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	wif := ""
+	if err := s.rpc.call(ctx, "dumpprivkey", &wif, signerAddr); err != nil {
+		return nil, err
+	}
+	key, err := btcutil.DecodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivKey, nil
+}
@@ -0,0 +1,511 @@
+package elementswallet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+const (
+	// minFeeRate is the fee rate in sats/kvbyte used if elementsd can't
+	// estimate one, 0.1 sat/vbyte on Liquid.
+	minFeeRate = 100
+	// maxUtxosBeforeConsolidation is the number of utxos of the main account
+	// above which they're consolidated into a single one.
+	maxUtxosBeforeConsolidation = 20
+	// maxConsolidatedUtxos is the max number of utxos spent by a
+	// consolidation tx.
+	maxConsolidatedUtxos = 100
+	dustAmount           = 450
+)
+
+func (s *service) SelectUtxos(
+	ctx context.Context, asset string, amount uint64,
+) ([]ports.TxInput, uint64, error) {
+	utxos, change, err := s.selectUtxos(ctx, asset, amount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// lock the selected utxos so that they're not selected again while the
+	// pool tx is being built, they're released after a while if not spent.
+	outpoints := make([]outpoint, 0, len(utxos))
+	inputs := make([]ports.TxInput, 0, len(utxos))
+	for _, utxo := range utxos {
+		outpoints = append(outpoints, outpoint{utxo.Txid, utxo.Vout})
+		inputs = append(inputs, utxo)
+	}
+	if err := s.lockUtxos(ctx, outpoints); err != nil {
+		return nil, 0, err
+	}
+	time.AfterFunc(selectedUtxosLockTimeout, func() {
+		if err := s.unlockUtxos(context.Background(), outpoints); err != nil {
+			log.WithError(err).Warn("failed to unlock selected utxos")
+		}
+	})
+
+	return inputs, change, nil
+}
+
+func (s *service) SignPset(
+	ctx context.Context, pset string, extractRawTx bool,
+) (string, error) {
+	res := struct {
+		Psbt     string `json:"psbt"`
+		Complete bool   `json:"complete"`
+	}{}
+	// psbt, sign, sighashtype, bip32derivs, finalize
+	if err := s.rpc.call(
+		ctx, "walletprocesspsbt", &res, pset, true, "ALL", true, false,
+	); err != nil {
+		return "", err
+	}
+	signedPset := res.Psbt
+
+	if !extractRawTx {
+		return signedPset, nil
+	}
+
+	ptx, err := psetv2.NewPsetFromBase64(signedPset)
+	if err != nil {
+		return "", err
+	}
+
+	if err := psetv2.MaybeFinalizeAll(ptx); err != nil {
+		return "", fmt.Errorf("failed to finalize signed pset: %s", err)
+	}
+
+	extractedTx, err := psetv2.Extract(ptx)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract signed pset: %s", err)
+	}
+
+	txHex, err := extractedTx.ToHex()
+	if err != nil {
+		return "", fmt.Errorf("failed to convert extracted tx to hex: %s", err)
+	}
+
+	return txHex, nil
+}
+
+// SignPsetWithKey signs the first tapscript leaf of the given inputs with the
+// key of the ASP.
+func (s *service) SignPsetWithKey(
+	ctx context.Context, b64 string, indexes []int,
+) (string, error) {
+	pset, err := psetv2.NewPsetFromBase64(b64)
+	if err != nil {
+		return "", err
+	}
+
+	if indexes == nil {
+		for i := 0; i < len(pset.Inputs); i++ {
+			indexes = append(indexes, i)
+		}
+	}
+
+	utx, err := pset.UnsignedTx()
+	if err != nil {
+		return "", err
+	}
+
+	prevoutsScripts := make([][]byte, 0, len(pset.Inputs))
+	prevoutsValues := make([][]byte, 0, len(pset.Inputs))
+	prevoutsAssets := make([][]byte, 0, len(pset.Inputs))
+	for i, in := range pset.Inputs {
+		if in.WitnessUtxo == nil {
+			return "", fmt.Errorf("missing witness utxo for input %d", i)
+		}
+		prevoutsScripts = append(prevoutsScripts, in.WitnessUtxo.Script)
+		prevoutsValues = append(prevoutsValues, in.WitnessUtxo.Value)
+		prevoutsAssets = append(prevoutsAssets, in.WitnessUtxo.Asset)
+	}
+
+	genesis, err := chainhash.NewHashFromStr(s.network.GenesisBlockHash)
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := psetv2.NewSigner(pset)
+	if err != nil {
+		return "", err
+	}
+
+	for _, i := range indexes {
+		if len(pset.Inputs[i].TapLeafScript) == 0 {
+			return "", fmt.Errorf("no tap leaf script found for input %d", i)
+		}
+
+		leafHash := pset.Inputs[i].TapLeafScript[0].TapHash()
+		preimage := utx.HashForWitnessV1(
+			i, prevoutsScripts, prevoutsAssets, prevoutsValues,
+			txscript.SigHashDefault, genesis, &leafHash, nil,
+		)
+
+		sig, err := schnorr.Sign(s.signerKey, preimage[:])
+		if err != nil {
+			return "", err
+		}
+
+		if err := signer.SignTaprootInputTapscriptSig(i, psetv2.TapScriptSig{
+			PartialSig: psetv2.PartialSig{
+				PubKey:    schnorr.SerializePubKey(s.signerKey.PubKey()),
+				Signature: sig.Serialize(),
+			},
+			LeafHash: leafHash.CloneBytes(),
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	return pset.ToBase64()
+}
+
+// SignMusig2 generates the nonce of the ASP and signs the message with it
+// right away, the nonces of the other signers being already known.
+func (s *service) SignMusig2(
+	_ context.Context, message [32]byte, keys []*secp256k1.PublicKey,
+	scriptRoot []byte, nonces [][66]byte,
+) ([66]byte, *musig2.PartialSignature, error) {
+	aspNonces, err := musig2.GenNonces(
+		musig2.WithPublicKey(s.signerKey.PubKey()),
+		musig2.WithNonceSecretKeyAux(s.signerKey),
+		musig2.WithNonceMessageAux(message),
+	)
+	if err != nil {
+		return [66]byte{}, nil, err
+	}
+
+	allNonces := append([][66]byte{}, nonces...)
+	allNonces = append(allNonces, aspNonces.PubNonce)
+	aggregatedNonce, err := musig2.AggregateNonces(allNonces)
+	if err != nil {
+		return [66]byte{}, nil, err
+	}
+
+	sig, err := musig2.Sign(
+		aspNonces.SecNonce, s.signerKey, aggregatedNonce, keys, message,
+		musig2.WithSortedKeys(), musig2.WithTaprootSignTweak(scriptRoot),
+	)
+	if err != nil {
+		return [66]byte{}, nil, err
+	}
+	return aspNonces.PubNonce, sig, nil
+}
+
+func (s *service) BroadcastTransaction(
+	ctx context.Context, txHex string,
+) (string, error) {
+	txid := ""
+	if err := s.rpc.call(ctx, "sendrawtransaction", &txid, txHex); err != nil {
+		if strings.Contains(err.Error(), "non-BIP68-final") {
+			return "", fmt.Errorf("non-BIP68-final")
+		}
+
+		return "", err
+	}
+	return txid, nil
+}
+
+// IsTransactionConfirmed requires elementsd to run with txindex enabled to
+// look for txs not related to the wallet.
+func (s *service) IsTransactionConfirmed(
+	ctx context.Context, txid string,
+) (bool, int64, error) {
+	tx := struct {
+		Confirmations int64 `json:"confirmations"`
+		Blocktime     int64 `json:"blocktime"`
+	}{}
+	if err := s.rpc.call(
+		ctx, "getrawtransaction", &tx, txid, true,
+	); err != nil {
+		if isRPCError(err, rpcErrNoTx) {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+
+	if tx.Confirmations > 0 {
+		return true, tx.Blocktime, nil
+	}
+
+	// if not confirmed, we return now + 1 min to estimate the next blocktime
+	return false, time.Now().Add(time.Minute).Unix(), nil
+}
+
+func (s *service) WaitForSync(ctx context.Context, txid string) error {
+	for {
+		time.Sleep(5 * time.Second)
+		if err := s.rpc.call(
+			ctx, "getrawtransaction", nil, txid, false,
+		); err != nil {
+			if isRPCError(err, rpcErrNoTx) {
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+func (s *service) EstimateFees(
+	ctx context.Context, pset string,
+) (uint64, error) {
+	tx, err := psetv2.NewPsetFromBase64(pset)
+	if err != nil {
+		return 0, err
+	}
+
+	utx, err := tx.UnsignedTx()
+	if err != nil {
+		return 0, err
+	}
+
+	// the empty issuance and pegin witnesses of each input and the empty
+	// proofs of each output are part of the witness in elements.
+	witnessSize := 3*len(tx.Inputs) + 2*len(tx.Outputs)
+	for _, in := range tx.Inputs {
+		if len(in.TapLeafScript) > 0 {
+			leaf := in.TapLeafScript[0]
+			controlBlock, err := leaf.ControlBlock.ToBytes()
+			if err != nil {
+				return 0, err
+			}
+			// signature + leaf script + control block
+			witnessSize += 1 + 65 + 1 + len(leaf.Script) + 1 + len(controlBlock)
+			continue
+		}
+		// p2wpkh: signature + pubkey
+		witnessSize += 1 + 73 + 34
+	}
+	vsize := utx.SerializeSize(false, false) + (witnessSize+3)/4
+
+	feeRate, err := s.FeeRate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate fees: %s", err)
+	}
+
+	// we add 5 sats in order to avoid min-relay-fee not met errors
+	return (uint64(vsize)*feeRate+999)/1000 + 5, nil
+}
+
+// Withdraw sends the given amount of LBTC from the main account to the given
+// address.
+func (s *service) Withdraw(
+	ctx context.Context, addr string, amount uint64,
+) (string, error) {
+	script, err := address.ToOutputScript(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %s", err)
+	}
+
+	// the fee depends on the number of selected utxos, therefore start with a
+	// rough estimation and select again until it's enough.
+	fee := uint64(0)
+	for {
+		utxos, change, err := s.selectUtxos(ctx, s.network.AssetID, amount+fee)
+		if err != nil {
+			return "", err
+		}
+
+		outputs := []psetv2.OutputArgs{
+			{Asset: s.network.AssetID, Amount: amount, Script: script},
+		}
+		if change > dustAmount {
+			changeAddr, err := s.deriveAddresses(ctx, 1, arkLabel)
+			if err != nil {
+				return "", err
+			}
+			changeScript, err := address.ToOutputScript(changeAddr[0])
+			if err != nil {
+				return "", err
+			}
+			outputs = append(outputs, psetv2.OutputArgs{
+				Asset: s.network.AssetID, Amount: change, Script: changeScript,
+			})
+		}
+
+		pset, err := s.createPset(utxos, outputs, fee)
+		if err != nil {
+			return "", err
+		}
+		estimatedFee, err := s.EstimateFees(ctx, pset)
+		if err != nil {
+			return "", err
+		}
+		if estimatedFee > fee {
+			fee = estimatedFee
+			continue
+		}
+
+		return s.signAndBroadcast(ctx, pset)
+	}
+}
+
+// consolidateUtxos periodically merges the utxos of the main account into a
+// single one, if there are too many, to keep the pool txs small.
+func (s *service) consolidateUtxos() {
+	ticker := time.NewTicker(consolidateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+		}
+
+		ctx := context.Background()
+		utxos, err := s.listUtxos(ctx, arkLabel)
+		if err != nil {
+			log.WithError(err).Warn("failed to list utxos to consolidate")
+			continue
+		}
+
+		confirmed := make([]unspent, 0, len(utxos))
+		total := uint64(0)
+		for _, utxo := range utxos {
+			if utxo.Confirmations <= 0 || utxo.Asset != s.network.AssetID {
+				continue
+			}
+			confirmed = append(confirmed, utxo)
+			total += utxo.GetValue()
+			if len(confirmed) >= maxConsolidatedUtxos {
+				break
+			}
+		}
+		if len(confirmed) <= maxUtxosBeforeConsolidation {
+			continue
+		}
+
+		txid, err := s.consolidate(ctx, confirmed, total)
+		if err != nil {
+			log.WithError(err).Warn("failed to consolidate utxos")
+			continue
+		}
+		log.Infof("consolidated %d utxos in tx %s", len(confirmed), txid)
+	}
+}
+
+func (s *service) consolidate(
+	ctx context.Context, utxos []unspent, total uint64,
+) (string, error) {
+	addr, err := s.deriveAddresses(ctx, 1, arkLabel)
+	if err != nil {
+		return "", err
+	}
+	script, err := address.ToOutputScript(addr[0])
+	if err != nil {
+		return "", err
+	}
+
+	outputs := []psetv2.OutputArgs{
+		{Asset: s.network.AssetID, Amount: total, Script: script},
+	}
+	pset, err := s.createPset(utxos, outputs, 0)
+	if err != nil {
+		return "", err
+	}
+	fee, err := s.EstimateFees(ctx, pset)
+	if err != nil {
+		return "", err
+	}
+	if fee+dustAmount >= total {
+		return "", fmt.Errorf("utxos not worth consolidating")
+	}
+
+	outputs[0].Amount = total - fee
+	pset, err = s.createPset(utxos, outputs, fee)
+	if err != nil {
+		return "", err
+	}
+	return s.signAndBroadcast(ctx, pset)
+}
+
+func (s *service) createPset(
+	utxos []unspent, outputs []psetv2.OutputArgs, fee uint64,
+) (string, error) {
+	inputs := make([]psetv2.InputArgs, 0, len(utxos))
+	for _, utxo := range utxos {
+		inputs = append(inputs, psetv2.InputArgs{
+			Txid:    utxo.Txid,
+			TxIndex: utxo.Vout,
+		})
+	}
+	if fee > 0 {
+		outputs = append(outputs, psetv2.OutputArgs{
+			Asset: s.network.AssetID, Amount: fee,
+		})
+	}
+
+	pset, err := psetv2.New(inputs, outputs, nil)
+	if err != nil {
+		return "", err
+	}
+
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return "", err
+	}
+	for i, utxo := range utxos {
+		asset, err := elementsutil.AssetHashToBytes(utxo.Asset)
+		if err != nil {
+			return "", err
+		}
+		value, err := elementsutil.ValueToBytes(utxo.GetValue())
+		if err != nil {
+			return "", err
+		}
+		script, err := hexToBytes(utxo.ScriptPubKey)
+		if err != nil {
+			return "", err
+		}
+		if err := updater.AddInWitnessUtxo(
+			i, transaction.NewTxOutput(asset, value, script),
+		); err != nil {
+			return "", err
+		}
+	}
+
+	return pset.ToBase64()
+}
+
+func (s *service) signAndBroadcast(
+	ctx context.Context, pset string,
+) (string, error) {
+	txHex, err := s.SignPset(ctx, pset, true)
+	if err != nil {
+		return "", err
+	}
+	return s.BroadcastTransaction(ctx, txHex)
+}
+
+// FeeRate returns the fee rate estimated by elementsd in sats/kvbyte.
+func (s *service) FeeRate(ctx context.Context) (uint64, error) {
+	res := struct {
+		FeeRate float64 `json:"feerate"`
+	}{}
+	if err := s.rpc.call(ctx, "estimatesmartfee", &res, 2); err != nil {
+		return 0, err
+	}
+
+	feeRate := toSatoshis(res.FeeRate)
+	if feeRate < minFeeRate {
+		return minFeeRate, nil
+	}
+	return feeRate, nil
+}
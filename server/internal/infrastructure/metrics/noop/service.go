@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/ark-network/ark/internal/core/ports"
+)
+
+// service is the metrics service used when the metrics are disabled.
+type service struct{}
+
+func NewService() ports.MetricsService {
+	return service{}
+}
+
+func (service) Start() error                       { return nil }
+func (service) Stop()                              {}
+func (service) RoundEnded(time.Duration, int)      {}
+func (service) RoundFailed()                       {}
+func (service) VtxosSwept(int, uint64)             {}
+func (service) RequestHandled(method, code string) {}
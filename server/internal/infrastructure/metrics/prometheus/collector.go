@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	vtxosDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "vtxos"),
+		"Number of unspent vtxos not yet swept nor redeemed.",
+		nil, nil,
+	)
+	vtxosAmountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "vtxos_amount_sats"),
+		"Amount of the unspent vtxos not yet swept nor redeemed, in sats.",
+		nil, nil,
+	)
+	walletBalanceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "wallet_balance_sats"),
+		"Balance of the onchain wallet, in sats, by account and status.",
+		[]string{"account", "status"}, nil,
+	)
+)
+
+// stateCollector fetches the vtxo set and the wallet balance at every scrape,
+// rather than tracking them at every change.
+type stateCollector struct {
+	repoManager ports.RepoManager
+	wallet      ports.WalletService
+}
+
+func newStateCollector(
+	repoManager ports.RepoManager, wallet ports.WalletService,
+) prometheus.Collector {
+	return &stateCollector{repoManager, wallet}
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vtxosDesc
+	ch <- vtxosAmountDesc
+	ch <- walletBalanceDesc
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if vtxos, _, err := c.repoManager.Vtxos().GetAllVtxos(ctx, ""); err != nil {
+		log.WithError(err).Warn("failed to collect vtxo metrics")
+	} else {
+		count, amount := 0, uint64(0)
+		for _, vtxo := range vtxos {
+			if vtxo.Swept {
+				continue
+			}
+			count++
+			amount += vtxo.Amount
+		}
+		ch <- prometheus.MustNewConstMetric(
+			vtxosDesc, prometheus.GaugeValue, float64(count),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			vtxosAmountDesc, prometheus.GaugeValue, float64(amount),
+		)
+	}
+
+	accounts := []struct {
+		name    string
+		balance func(context.Context) (uint64, uint64, error)
+	}{
+		{"main", c.wallet.MainAccountBalance},
+		{"connectors", c.wallet.ConnectorsAccountBalance},
+	}
+	for _, account := range accounts {
+		confirmed, unconfirmed, err := account.balance(ctx)
+		if err != nil {
+			log.WithError(err).Warnf(
+				"failed to collect %s account balance", account.name,
+			)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			walletBalanceDesc, prometheus.GaugeValue, float64(confirmed),
+			account.name, "confirmed",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			walletBalanceDesc, prometheus.GaugeValue, float64(unconfirmed),
+			account.name, "unconfirmed",
+		)
+	}
+}
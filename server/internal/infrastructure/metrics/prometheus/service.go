@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	namespace = "arkd"
+	// collectTimeout bounds the time spent querying the db and the wallet at
+	// every scrape.
+	collectTimeout = 10 * time.Second
+)
+
+type service struct {
+	addr   string
+	server *http.Server
+
+	roundDuration prometheus.Histogram
+	roundPayments prometheus.Histogram
+	failedRounds  prometheus.Counter
+	sweptVtxos    prometheus.Counter
+	sweptAmount   prometheus.Counter
+	grpcRequests  *prometheus.CounterVec
+	grpcErrors    *prometheus.CounterVec
+}
+
+// NewService returns the metrics service serving the metrics in the
+// Prometheus format at http://<addr>/metrics. The vtxo count and the wallet
+// balance are fetched at every scrape.
+func NewService(
+	addr string, repoManager ports.RepoManager, wallet ports.WalletService,
+) (ports.MetricsService, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return nil, errors.New("invalid metrics listen address")
+	}
+
+	svc := &service{
+		addr: addr,
+		roundDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "round_duration_seconds",
+			Help:      "Duration of the ended rounds, from start to finalization.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}),
+		roundPayments: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "round_payments",
+			Help:      "Number of payments of the ended rounds.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		failedRounds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rounds_failed_total",
+			Help:      "Number of failed rounds.",
+		}),
+		sweptVtxos: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "swept_vtxos_total",
+			Help:      "Number of expired vtxos swept.",
+		}),
+		sweptAmount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "swept_amount_sats_total",
+			Help:      "Amount of the shared outputs swept, in sats.",
+		}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of handled gRPC requests, by method and status code.",
+		}, []string{"method", "code"}),
+		grpcErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_errors_total",
+			Help:      "Number of gRPC requests failed, by method and status code.",
+		}, []string{"method", "code"}),
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		svc.roundDuration, svc.roundPayments, svc.failedRounds,
+		svc.sweptVtxos, svc.sweptAmount, svc.grpcRequests, svc.grpcErrors,
+		newStateCollector(repoManager, wallet),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	svc.server = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return svc, nil
+}
+
+func (s *service) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.server.Serve(lis); err != nil &&
+			!errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("metrics server stopped")
+		}
+	}()
+	log.Infof("serving metrics at %s/metrics", s.addr)
+	return nil
+}
+
+func (s *service) Stop() {
+	// nolint:all
+	s.server.Shutdown(context.Background())
+}
+
+func (s *service) RoundEnded(duration time.Duration, numOfPayments int) {
+	s.roundDuration.Observe(duration.Seconds())
+	s.roundPayments.Observe(float64(numOfPayments))
+}
+
+func (s *service) RoundFailed() {
+	s.failedRounds.Inc()
+}
+
+func (s *service) VtxosSwept(numOfVtxos int, amount uint64) {
+	s.sweptVtxos.Add(float64(numOfVtxos))
+	s.sweptAmount.Add(float64(amount))
+}
+
+func (s *service) RequestHandled(method, code string) {
+	s.grpcRequests.WithLabelValues(method, code).Inc()
+	if code != "OK" {
+		s.grpcErrors.WithLabelValues(method, code).Inc()
+	}
+}
@@ -11,8 +11,10 @@ import (
 	pb "github.com/ark-network/ark/api-spec/protobuf/gen/ocean/v1"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/vulpemventures/go-elements/elementsutil"
 	"github.com/vulpemventures/go-elements/psetv2"
 )
@@ -127,6 +129,16 @@ func (s *service) WaitForSync(ctx context.Context, txid string) error {
 	return nil
 }
 
+// SignMusig2 is not supported, ocean can't sign with MuSig2.
+func (s *service) SignMusig2(
+	_ context.Context, _ [32]byte, _ []*secp256k1.PublicKey, _ []byte,
+	_ [][66]byte,
+) ([66]byte, *musig2.PartialSignature, error) {
+	return [66]byte{}, nil, fmt.Errorf(
+		"musig2 signing is not supported by the ocean wallet",
+	)
+}
+
 func (s *service) SignPsetWithKey(ctx context.Context, b64 string, indexes []int) (string, error) {
 	pset, err := psetv2.NewPsetFromBase64(b64)
 	if err != nil {
@@ -218,6 +230,33 @@ func (s *service) LockConnectorUtxos(ctx context.Context, utxos []ports.TxOutpoi
 	return err
 }
 
+// Withdraw sends the given amount of LBTC from the main account to the given
+// address.
+func (s *service) Withdraw(
+	ctx context.Context, address string, amount uint64,
+) (string, error) {
+	info, err := s.walletClient.GetInfo(ctx, &pb.GetInfoRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	res, err := s.txClient.Transfer(ctx, &pb.TransferRequest{
+		AccountName: arkAccount,
+		Receivers: []*pb.Output{{
+			Asset:   info.GetNativeAsset(),
+			Amount:  amount,
+			Address: address,
+		}},
+		// 0.1 sat/vbyte on Liquid
+		MillisatsPerByte: 100,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.BroadcastTransaction(ctx, res.GetTxHex())
+}
+
 func (s *service) EstimateFees(
 	ctx context.Context, pset string,
 ) (uint64, error) {
@@ -281,6 +320,12 @@ func (s *service) EstimateFees(
 	return fee.GetFeeAmount() + 5, nil
 }
 
+// FeeRate returns the min relay fee rate of Liquid, 0.1 sat/vbyte, since ocean
+// doesn't expose any fee estimation based on the mempool.
+func (s *service) FeeRate(ctx context.Context) (uint64, error) {
+	return 100, nil
+}
+
 func (s *service) getTransaction(
 	ctx context.Context, txid string,
 ) (string, bool, int64, error) {
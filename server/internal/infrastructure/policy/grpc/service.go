@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/ark-network/ark/internal/core/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// requestTimeout bounds the time the plugin takes to accept a payment, not
+// to stall the registrations of the clients.
+const requestTimeout = 5 * time.Second
+
+type service struct {
+	conn   *grpc.ClientConn
+	client arkv1.PolicyServiceClient
+}
+
+// NewService returns the policy service calling the plugin implementing
+// the ark.v1.PolicyService at the given address, usually a sidecar of
+// arkd. The payments are rejected if the plugin can't be reached.
+func NewService(addr string) (ports.PolicyService, error) {
+	if len(addr) <= 0 {
+		return nil, fmt.Errorf("missing policy plugin address")
+	}
+	conn, err := grpc.NewClient(
+		addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &service{conn, arkv1.NewPolicyServiceClient(conn)}, nil
+}
+
+func (s *service) AcceptRegistration(
+	ctx context.Context, inputs []domain.Vtxo,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.AcceptRegistration(
+		ctx, &arkv1.AcceptRegistrationRequest{Inputs: toPolicyVtxos(inputs)},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check payment policy: %s", err)
+	}
+	if !resp.GetAccepted() {
+		return rejectionError(resp.GetReason())
+	}
+	return nil
+}
+
+func (s *service) AcceptClaim(
+	ctx context.Context, payment domain.Payment, receivers []domain.Receiver,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	policyReceivers := make([]*arkv1.PolicyReceiver, 0, len(receivers))
+	for _, r := range receivers {
+		policyReceivers = append(policyReceivers, &arkv1.PolicyReceiver{
+			Pubkey:  r.Pubkey,
+			Address: r.OnchainAddress,
+			Amount:  r.Amount,
+		})
+	}
+
+	resp, err := s.client.AcceptClaim(ctx, &arkv1.AcceptClaimRequest{
+		PaymentId: payment.Id,
+		Inputs:    toPolicyVtxos(payment.Inputs),
+		Receivers: policyReceivers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check payment policy: %s", err)
+	}
+	if !resp.GetAccepted() {
+		return rejectionError(resp.GetReason())
+	}
+	return nil
+}
+
+func (s *service) Close() {
+	// nolint
+	s.conn.Close()
+}
+
+func toPolicyVtxos(vtxos []domain.Vtxo) []*arkv1.PolicyVtxo {
+	list := make([]*arkv1.PolicyVtxo, 0, len(vtxos))
+	for _, v := range vtxos {
+		list = append(list, &arkv1.PolicyVtxo{
+			Txid:     v.Txid,
+			Vout:     v.VOut,
+			Pubkey:   v.Pubkey,
+			Amount:   v.Amount,
+			ExpireAt: v.ExpireAt,
+		})
+	}
+	return list
+}
+
+func rejectionError(reason string) error {
+	if len(reason) <= 0 {
+		return fmt.Errorf("payment rejected by the operator policy")
+	}
+	return fmt.Errorf("payment rejected by the operator policy: %s", reason)
+}
@@ -0,0 +1,28 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/ark-network/ark/internal/core/ports"
+)
+
+// service is the policy service used when no plugin is configured, it
+// accepts all payments.
+type service struct{}
+
+func NewService() ports.PolicyService {
+	return service{}
+}
+
+func (service) AcceptRegistration(context.Context, []domain.Vtxo) error {
+	return nil
+}
+
+func (service) AcceptClaim(
+	context.Context, domain.Payment, []domain.Receiver,
+) error {
+	return nil
+}
+
+func (service) Close() {}
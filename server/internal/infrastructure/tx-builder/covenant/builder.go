@@ -38,6 +38,94 @@ func NewTxBuilder(
 	return &txBuilder{wallet, &net, roundLifetime, exitDelay}
 }
 
+func (b *txBuilder) FundBoardingTx(
+	aspPubkey *secp256k1.PublicKey, boardingTx string,
+) (string, error) {
+	boarding, err := psetv2.NewPsetFromBase64(boardingTx)
+	if err != nil {
+		return "", err
+	}
+	inAmount, outAmount, err := getExplicitAmounts(boarding, b.net.AssetID)
+	if err != nil {
+		return "", err
+	}
+
+	aspScript, err := p2wpkhScript(aspPubkey, b.net)
+	if err != nil {
+		return "", err
+	}
+
+	// the tx is funded again as long as the added coins make the estimated
+	// fees grow.
+	ctx := context.Background()
+	feeAmount := uint64(0)
+	for {
+		ptx, err := psetv2.NewPsetFromBase64(boardingTx)
+		if err != nil {
+			return "", err
+		}
+		updater, err := psetv2.NewUpdater(ptx)
+		if err != nil {
+			return "", err
+		}
+
+		var change uint64
+		if outAmount+feeAmount > inAmount {
+			utxos, utxosChange, err := b.wallet.SelectUtxos(
+				ctx, b.net.AssetID, outAmount+feeAmount-inAmount,
+			)
+			if err != nil {
+				return "", err
+			}
+			if err := addInputs(updater, utxos); err != nil {
+				return "", err
+			}
+			change = utxosChange
+		} else {
+			change = inAmount - outAmount - feeAmount
+		}
+
+		if change >= dustLimit {
+			if err := updater.AddOutputs([]psetv2.OutputArgs{
+				{
+					Asset:  b.net.AssetID,
+					Amount: change,
+					Script: aspScript,
+				},
+			}); err != nil {
+				return "", err
+			}
+		}
+
+		b64, err := ptx.ToBase64()
+		if err != nil {
+			return "", err
+		}
+		estimatedFees, err := b.wallet.EstimateFees(ctx, b64)
+		if err != nil {
+			return "", err
+		}
+		if estimatedFees > feeAmount {
+			feeAmount = estimatedFees
+			continue
+		}
+
+		// a change below dust goes to the fees.
+		if change < dustLimit {
+			feeAmount += change
+		}
+		if err := updater.AddOutputs([]psetv2.OutputArgs{
+			{
+				Asset:  b.net.AssetID,
+				Amount: feeAmount,
+			},
+		}); err != nil {
+			return "", err
+		}
+		return ptx.ToBase64()
+	}
+}
+
 func (b *txBuilder) GetVtxoScript(userPubkey, aspPubkey *secp256k1.PublicKey) ([]byte, error) {
 	outputScript, _, err := b.getLeafScriptAndTree(userPubkey, aspPubkey)
 	if err != nil {
@@ -46,11 +134,12 @@ func (b *txBuilder) GetVtxoScript(userPubkey, aspPubkey *secp256k1.PublicKey) ([
 	return outputScript, nil
 }
 
-func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput) (signedSweepTx string, err error) {
+func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput, feeRate uint64) (signedSweepTx string, err error) {
 	sweepPset, err := sweepTransaction(
 		b.wallet,
 		inputs,
 		b.net.AssetID,
+		feeRate,
 	)
 	if err != nil {
 		return "", err
@@ -84,6 +173,60 @@ func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput) (signedSweepTx strin
 	return extractedTx.ToHex()
 }
 
+func (b *txBuilder) BuildAsyncPaymentTx(
+	vtxos []domain.Vtxo, aspPubkey *secp256k1.PublicKey, receivers []domain.Receiver,
+) (string, error) {
+	inputs := make([]redeemInput, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		pubkeyBytes, err := hex.DecodeString(vtxo.Pubkey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode pubkey: %s", err)
+		}
+		vtxoPubkey, err := secp256k1.ParsePubKey(pubkeyBytes)
+		if err != nil {
+			return "", err
+		}
+
+		vtxoScript, vtxoTaprootTree, err := b.getLeafScriptAndTree(vtxoPubkey, aspPubkey)
+		if err != nil {
+			return "", err
+		}
+		forfeitProof, err := findForfeitProof(vtxoTaprootTree)
+		if err != nil {
+			return "", err
+		}
+
+		inputs = append(inputs, redeemInput{vtxo, vtxoScript, *forfeitProof})
+	}
+
+	receiversScripts := make([][]byte, 0, len(receivers))
+	for _, r := range receivers {
+		if r.IsOnchain() {
+			return "", fmt.Errorf("async payments can't have onchain receivers")
+		}
+		if r.Amount < dustLimit {
+			return "", fmt.Errorf("receiver amount must be at least %d", dustLimit)
+		}
+
+		pubkeyBytes, err := hex.DecodeString(r.Pubkey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode pubkey: %s", err)
+		}
+		receiverPubkey, err := secp256k1.ParsePubKey(pubkeyBytes)
+		if err != nil {
+			return "", err
+		}
+
+		script, err := b.GetVtxoScript(receiverPubkey, aspPubkey)
+		if err != nil {
+			return "", err
+		}
+		receiversScripts = append(receiversScripts, script)
+	}
+
+	return craftRedeemTx(b.net.AssetID, inputs, receiversScripts, receivers)
+}
+
 func (b *txBuilder) BuildForfeitTxs(
 	aspPubkey *secp256k1.PublicKey, poolTx string, payments []domain.Payment, minRelayFee uint64,
 ) (connectors []string, forfeitTxs []string, err error) {
@@ -109,8 +252,15 @@ func (b *txBuilder) BuildForfeitTxs(
 	return connectors, forfeitTxs, nil
 }
 
+// RequiresTreeSigning returns false, the congestion tree being enforced by
+// covenants.
+func (b *txBuilder) RequiresTreeSigning() bool {
+	return false
+}
+
 func (b *txBuilder) BuildPoolTx(
 	aspPubkey *secp256k1.PublicKey, payments []domain.Payment, minRelayFee uint64, sweptRounds []domain.Round,
+	_ ...*secp256k1.PublicKey,
 ) (poolTx string, congestionTree tree.CongestionTree, connectorAddress string, err error) {
 	// The creation of the tree and the pool tx are tightly coupled:
 	// - building the tree requires knowing the shared outpoint (txid:vout)
@@ -524,20 +674,9 @@ func (b *txBuilder) createForfeitTxs(
 				return nil, err
 			}
 
-			var forfeitProof *taproot.TapscriptElementsProof
-
-			for _, proof := range vtxoTaprootTree.LeafMerkleProofs {
-				isForfeit, err := (&tree.ForfeitClosure{}).Decode(proof.Script)
-				if !isForfeit || err != nil {
-					continue
-				}
-
-				forfeitProof = &proof
-				break
-			}
-
-			if forfeitProof == nil {
-				return nil, fmt.Errorf("forfeit proof not found")
+			forfeitProof, err := findForfeitProof(vtxoTaprootTree)
+			if err != nil {
+				return nil, err
 			}
 
 			for _, connector := range connectors {
@@ -555,6 +694,20 @@ func (b *txBuilder) createForfeitTxs(
 	return forfeitTxs, nil
 }
 
+func findForfeitProof(
+	vtxoTaprootTree *taproot.IndexedElementsTapScriptTree,
+) (*taproot.TapscriptElementsProof, error) {
+	for _, proof := range vtxoTaprootTree.LeafMerkleProofs {
+		isForfeit, err := (&tree.ForfeitClosure{}).Decode(proof.Script)
+		if !isForfeit || err != nil {
+			continue
+		}
+
+		return &proof, nil
+	}
+	return nil, fmt.Errorf("forfeit proof not found")
+}
+
 func (b *txBuilder) getConnectorAddress(poolTx string) (string, error) {
 	pset, err := psetv2.NewPsetFromBase64(poolTx)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 
@@ -15,8 +16,11 @@ import (
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/elementsutil"
 	"github.com/vulpemventures/go-elements/network"
 	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/transaction"
 )
 
 const (
@@ -153,6 +157,113 @@ func TestBuildForfeitTxs(t *testing.T) {
 	}
 }
 
+func TestBuildAsyncPaymentTx(t *testing.T) {
+	builder := txbuilder.NewTxBuilder(
+		wallet, network.Liquid, roundLifetime, unilateralExitDelay,
+	)
+
+	vtxos := []domain.Vtxo{
+		{
+			VtxoKey:  domain.VtxoKey{Txid: randomHex(32), VOut: 0},
+			Receiver: domain.Receiver{Pubkey: testingKey, Amount: 1000},
+		},
+		{
+			VtxoKey:  domain.VtxoKey{Txid: randomHex(32), VOut: 0},
+			Receiver: domain.Receiver{Pubkey: testingKey, Amount: 2000},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		receivers := []domain.Receiver{
+			{Pubkey: testingKey, Amount: 1500},
+			{Pubkey: testingKey, Amount: 1470},
+		}
+		redeemTx, err := builder.BuildAsyncPaymentTx(vtxos, pubkey, receivers)
+		require.NoError(t, err)
+
+		tx, err := psetv2.NewPsetFromBase64(redeemTx)
+		require.NoError(t, err)
+		require.Len(t, tx.Inputs, 2)
+		require.Len(t, tx.Outputs, 3)
+		for _, in := range tx.Inputs {
+			require.NotNil(t, in.WitnessUtxo)
+			require.Len(t, in.TapLeafScript, 1)
+		}
+
+		vtxoScript, err := builder.GetVtxoScript(pubkey, pubkey)
+		require.NoError(t, err)
+		require.Equal(t, vtxoScript, tx.Outputs[0].Script)
+		require.Equal(t, uint64(30), tx.Outputs[2].Value)
+		require.Empty(t, tx.Outputs[2].Script)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		redeemTx, err := builder.BuildAsyncPaymentTx(
+			vtxos, pubkey, []domain.Receiver{{Pubkey: testingKey, Amount: 3000}},
+		)
+		require.EqualError(
+			t, err,
+			"receivers amount 3000 must be lower than inputs amount 3000 to pay for fees",
+		)
+		require.Empty(t, redeemTx)
+
+		redeemTx, err = builder.BuildAsyncPaymentTx(
+			vtxos, pubkey, []domain.Receiver{{OnchainAddress: connectorAddress, Amount: 1000}},
+		)
+		require.EqualError(t, err, "async payments can't have onchain receivers")
+		require.Empty(t, redeemTx)
+	})
+}
+
+func TestFundBoardingTx(t *testing.T) {
+	builder := txbuilder.NewTxBuilder(
+		wallet, network.Liquid, roundLifetime, unilateralExitDelay,
+	)
+
+	boardingTx := func(inAsset string, inAmount, outAmount uint64) string {
+		script, _ := address.ToOutputScript(connectorAddress)
+		ptx, _ := psetv2.New(nil, []psetv2.OutputArgs{{
+			Asset:  network.Liquid.AssetID,
+			Amount: outAmount,
+			Script: script,
+		}}, nil)
+		updater, _ := psetv2.NewUpdater(ptx)
+		// nolint
+		updater.AddInputs([]psetv2.InputArgs{{Txid: randomHex(32), TxIndex: 0}})
+		asset, _ := elementsutil.AssetHashToBytes(inAsset)
+		value, _ := elementsutil.ValueToBytes(inAmount)
+		// nolint
+		updater.AddInWitnessUtxo(0, transaction.NewTxOutput(asset, value, script))
+		b64, _ := ptx.ToBase64()
+		return b64
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		fundedTx, err := builder.FundBoardingTx(
+			pubkey, boardingTx(network.Liquid.AssetID, 5000, 5060),
+		)
+		require.NoError(t, err)
+
+		tx, err := psetv2.NewPsetFromBase64(fundedTx)
+		require.NoError(t, err)
+		require.Len(t, tx.Inputs, 2)
+		require.Len(t, tx.Outputs, 2)
+		require.Equal(t, uint64(5060), tx.Outputs[0].Value)
+		require.Equal(t, uint64(100), tx.Outputs[1].Value)
+		require.Empty(t, tx.Outputs[1].Script)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fundedTx, err := builder.FundBoardingTx(
+			pubkey, boardingTx(network.Testnet.AssetID, 5000, 5060),
+		)
+		require.EqualError(
+			t, err, fmt.Sprintf("input 0 must be unblinded %s", network.Liquid.AssetID),
+		)
+		require.Empty(t, fundedTx)
+	})
+}
+
 func randomInput() []ports.TxInput {
 	txid := randomHex(32)
 	input := &mockedInput{}
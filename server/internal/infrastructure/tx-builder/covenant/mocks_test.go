@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ark-network/ark/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/mock"
 )
@@ -133,6 +134,19 @@ func (m *mockedWallet) SignPsetWithKey(ctx context.Context, pset string, inputIn
 	return res, args.Error(1)
 }
 
+func (m *mockedWallet) SignMusig2(
+	ctx context.Context, message [32]byte, keys []*secp256k1.PublicKey,
+	scriptRoot []byte, nonces [][66]byte,
+) ([66]byte, *musig2.PartialSignature, error) {
+	args := m.Called(ctx, message, keys, scriptRoot, nonces)
+
+	var sig *musig2.PartialSignature
+	if a := args.Get(1); a != nil {
+		sig = a.(*musig2.PartialSignature)
+	}
+	return args.Get(0).([66]byte), sig, args.Error(2)
+}
+
 func (m *mockedWallet) WatchScripts(
 	ctx context.Context, scripts []string,
 ) error {
@@ -186,6 +200,14 @@ func (m *mockedWallet) MainAccountBalance(ctx context.Context) (uint64, uint64,
 	panic("not implemented")
 }
 
+func (m *mockedWallet) FeeRate(ctx context.Context) (uint64, error) {
+	panic("not implemented")
+}
+
+func (m *mockedWallet) Withdraw(ctx context.Context, address string, amount uint64) (string, error) {
+	panic("not implemented")
+}
+
 type mockedInput struct {
 	mock.Mock
 }
@@ -0,0 +1,104 @@
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/internal/core/domain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/vulpemventures/go-elements/elementsutil"
+	"github.com/vulpemventures/go-elements/psetv2"
+	"github.com/vulpemventures/go-elements/taproot"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+// redeemInput is a vtxo spent by an async payment with its forfeit leaf,
+// the one signed by both the owner and the ASP.
+type redeemInput struct {
+	vtxo         domain.Vtxo
+	vtxoScript   []byte
+	forfeitProof taproot.TapscriptElementsProof
+}
+
+// craftRedeemTx creates the out-of-round tx spending the given vtxos to the
+// receivers' vtxo scripts. The difference between the inputs and the
+// receivers amount goes to the fee output.
+func craftRedeemTx(
+	asset string, inputs []redeemInput,
+	receiversScripts [][]byte, receivers []domain.Receiver,
+) (string, error) {
+	assetBytes, err := elementsutil.AssetHashToBytes(asset)
+	if err != nil {
+		return "", err
+	}
+
+	inputAmount := uint64(0)
+	for _, in := range inputs {
+		inputAmount += in.vtxo.Amount
+	}
+	outputAmount := uint64(0)
+	for _, r := range receivers {
+		outputAmount += r.Amount
+	}
+	if outputAmount >= inputAmount {
+		return "", fmt.Errorf(
+			"receivers amount %d must be lower than inputs amount %d to pay for fees",
+			outputAmount, inputAmount,
+		)
+	}
+
+	pset, err := psetv2.New(nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	updater, err := psetv2.NewUpdater(pset)
+	if err != nil {
+		return "", err
+	}
+
+	unspendableKey := tree.UnspendableKey()
+	for i, in := range inputs {
+		if err := updater.AddInputs([]psetv2.InputArgs{{
+			Txid:    in.vtxo.Txid,
+			TxIndex: in.vtxo.VOut,
+		}}); err != nil {
+			return "", err
+		}
+
+		amount, err := elementsutil.ValueToBytes(in.vtxo.Amount)
+		if err != nil {
+			return "", err
+		}
+		prevout := transaction.NewTxOutput(assetBytes, amount, in.vtxoScript)
+		if err := updater.AddInWitnessUtxo(i, prevout); err != nil {
+			return "", err
+		}
+
+		if err := updater.AddInSighashType(i, txscript.SigHashDefault); err != nil {
+			return "", err
+		}
+
+		tapScript := psetv2.NewTapLeafScript(in.forfeitProof, unspendableKey)
+		if err := updater.AddInTapLeafScript(i, tapScript); err != nil {
+			return "", err
+		}
+	}
+
+	outputs := make([]psetv2.OutputArgs, 0, len(receivers)+1)
+	for i, r := range receivers {
+		outputs = append(outputs, psetv2.OutputArgs{
+			Asset:  asset,
+			Amount: r.Amount,
+			Script: receiversScripts[i],
+		})
+	}
+	outputs = append(outputs, psetv2.OutputArgs{
+		Asset:  asset,
+		Amount: inputAmount - outputAmount,
+	})
+	if err := updater.AddOutputs(outputs); err != nil {
+		return "", err
+	}
+
+	return pset.ToBase64()
+}
@@ -18,6 +18,7 @@ func sweepTransaction(
 	wallet ports.WalletService,
 	sweepInputs []ports.SweepInput,
 	lbtc string,
+	feeRate uint64,
 ) (*psetv2.Pset, error) {
 	sweepPset, err := psetv2.New(nil, nil, nil)
 	if err != nil {
@@ -131,6 +132,18 @@ func sweepTransaction(
 		return nil, err
 	}
 
+	// the fees are estimated at the fee rate of the wallet, scaled to the
+	// target one if any.
+	if feeRate > 0 {
+		walletFeeRate, err := wallet.FeeRate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if walletFeeRate > 0 {
+			fees = fees * feeRate / walletFeeRate
+		}
+	}
+
 	if amount < fees {
 		return nil, fmt.Errorf("insufficient funds (%d) to cover fees (%d) for sweep transaction", amount, fees)
 	}
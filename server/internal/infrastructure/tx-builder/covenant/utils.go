@@ -1,6 +1,7 @@
 package txbuilder
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 
@@ -150,3 +151,36 @@ func isOnchainOnly(payments []domain.Payment) bool {
 	}
 	return true
 }
+
+// getExplicitAmounts returns the total amounts of the inputs and of the
+// outputs of the given tx, that must be all unblinded and of the given asset.
+func getExplicitAmounts(ptx *psetv2.Pset, asset string) (uint64, uint64, error) {
+	assetBytes, err := elementsutil.AssetHashToBytes(asset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	inAmount := uint64(0)
+	for i, in := range ptx.Inputs {
+		if in.WitnessUtxo == nil {
+			return 0, 0, fmt.Errorf("missing prevout of input %d", i)
+		}
+		if !bytes.Equal(in.WitnessUtxo.Asset, assetBytes) {
+			return 0, 0, fmt.Errorf("input %d must be unblinded %s", i, asset)
+		}
+		value, err := elementsutil.ValueFromBytes(in.WitnessUtxo.Value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("input %d must be unblinded %s", i, asset)
+		}
+		inAmount += value
+	}
+
+	outAmount := uint64(0)
+	for i, out := range ptx.Outputs {
+		if !bytes.Equal(out.Asset, assetBytes[1:]) || out.NeedsBlinding() {
+			return 0, 0, fmt.Errorf("output %d must be unblinded %s", i, asset)
+		}
+		outAmount += out.Value
+	}
+	return inAmount, outAmount, nil
+}
@@ -39,18 +39,21 @@ func NewTxBuilder(
 	return &txBuilder{wallet, net, roundLifetime, exitDelay}
 }
 
+func (b *txBuilder) FundBoardingTx(
+	_ *secp256k1.PublicKey, _ string,
+) (string, error) {
+	return "", fmt.Errorf("onboardings are not supported with covenantless congestion trees")
+}
+
 func (b *txBuilder) GetVtxoScript(userPubkey, aspPubkey *secp256k1.PublicKey) ([]byte, error) {
-	outputScript, _, err := b.getLeafScriptAndTree(userPubkey, aspPubkey)
-	if err != nil {
-		return nil, err
-	}
-	return outputScript, nil
+	return b.getVtxoScript(userPubkey, aspPubkey)
 }
 
-func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput) (signedSweepTx string, err error) {
+func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput, feeRate uint64) (signedSweepTx string, err error) {
 	sweepPsbt, err := sweepTransaction(
 		b.wallet,
 		inputs,
+		feeRate,
 	)
 	if err != nil {
 		return "", err
@@ -92,6 +95,13 @@ func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput) (signedSweepTx strin
 	return hex.EncodeToString(buf.Bytes()), nil
 }
 
+func (b *txBuilder) BuildAsyncPaymentTx(
+	_ []domain.Vtxo, _ *secp256k1.PublicKey, _ []domain.Receiver,
+) (string, error) {
+	// TODO: support async payments with covenantless congestion trees
+	return "", fmt.Errorf("async payments are not supported with covenantless congestion trees")
+}
+
 func (b *txBuilder) BuildForfeitTxs(
 	aspPubkey *secp256k1.PublicKey, poolTx string, payments []domain.Payment, minRelayFee uint64,
 ) (connectors []string, forfeitTxs []string, err error) {
@@ -119,17 +129,22 @@ func (b *txBuilder) BuildForfeitTxs(
 
 func (b *txBuilder) BuildPoolTx(
 	aspPubkey *secp256k1.PublicKey, payments []domain.Payment, minRelayFee uint64, sweptRounds []domain.Round,
+	cosigners ...*secp256k1.PublicKey,
 ) (poolTx string, congestionTree tree.CongestionTree, connectorAddress string, err error) {
 	var sharedOutputScript []byte
 	var sharedOutputAmount int64
 
-	var senders []*secp256k1.PublicKey
-	senders, err = getCosigners(payments)
-	if err != nil {
-		return
+	// without the ephemeral keys of the signing session, the tree is
+	// cosigned by the owners of the inputs and the ASP.
+	if len(cosigners) <= 0 {
+		var senders []*secp256k1.PublicKey
+		senders, err = getCosigners(payments)
+		if err != nil {
+			return
+		}
+		cosigners = append(senders, aspPubkey)
 	}
 
-	cosigners := append(senders, aspPubkey)
 	receivers := getOffchainReceivers(payments)
 
 	if !isOnchainOnly(payments) {
@@ -175,6 +190,10 @@ func (b *txBuilder) BuildPoolTx(
 	return
 }
 
+func (b *txBuilder) RequiresTreeSigning() bool {
+	return true
+}
+
 func (b *txBuilder) GetSweepInput(parentblocktime int64, node tree.Node) (expirationtime int64, sweepInput ports.SweepInput, err error) {
 	partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
 	if err != nil {
@@ -214,43 +233,20 @@ func (b *txBuilder) GetSweepInput(parentblocktime int64, node tree.Node) (expira
 	return expirationTime, sweepInput, nil
 }
 
-func (b *txBuilder) getLeafScriptAndTree(
+// getVtxoScript returns the output script of a vtxo, whose taproot key is the
+// MuSig2 key of the owner and the ASP, spent to forfeit it, tweaked with the
+// unilateral exit leaf of the owner.
+func (b *txBuilder) getVtxoScript(
 	userPubkey, aspPubkey *secp256k1.PublicKey,
-) ([]byte, *txscript.IndexedTapScriptTree, error) {
-	redeemClosure := &bitcointree.CSVSigClosure{
-		Pubkey:  userPubkey,
-		Seconds: uint(b.exitDelay),
-	}
-
-	redeemLeaf, err := redeemClosure.Leaf()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	forfeitClosure := &bitcointree.ForfeitClosure{
-		Pubkey:    userPubkey,
-		AspPubkey: aspPubkey,
-	}
-
-	forfeitLeaf, err := forfeitClosure.Leaf()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	taprootTree := txscript.AssembleTaprootScriptTree(
-		*redeemLeaf, *forfeitLeaf,
+) ([]byte, error) {
+	aggregatedKey, _, err := bitcointree.VtxoTaprootKey(
+		userPubkey, aspPubkey, uint(b.exitDelay),
 	)
-
-	root := taprootTree.RootNode.TapHash()
-	unspendableKey := tree.UnspendableKey()
-	taprootKey := txscript.ComputeTaprootOutputKey(unspendableKey, root[:])
-
-	outputScript, err := taprootOutputScript(taprootKey)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return outputScript, taprootTree, nil
+	return taprootOutputScript(aggregatedKey.FinalKey)
 }
 
 func (b *txBuilder) createPoolTx(
@@ -580,27 +576,11 @@ func (b *txBuilder) createForfeitTxs(
 				return nil, err
 			}
 
-			vtxoScript, vtxoTaprootTree, err := b.getLeafScriptAndTree(vtxoPubkey, aspPubkey)
+			vtxoScript, err := b.getVtxoScript(vtxoPubkey, aspPubkey)
 			if err != nil {
 				return nil, err
 			}
 
-			var forfeitProof *txscript.TapscriptProof
-
-			for _, proof := range vtxoTaprootTree.LeafMerkleProofs {
-				isForfeit, err := (&bitcointree.ForfeitClosure{}).Decode(proof.Script)
-				if !isForfeit || err != nil {
-					continue
-				}
-
-				forfeitProof = &proof
-				break
-			}
-
-			if forfeitProof == nil {
-				return nil, fmt.Errorf("forfeit proof not found")
-			}
-
 			for _, connector := range connectors {
 				txs, err := craftForfeitTxs(
 					connector, vtxo, vtxoScript, aspScript, minRelayFee,
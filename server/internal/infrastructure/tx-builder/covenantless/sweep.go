@@ -17,6 +17,7 @@ import (
 func sweepTransaction(
 	wallet ports.WalletService,
 	sweepInputs []ports.SweepInput,
+	feeRate uint64,
 ) (*psbt.Packet, error) {
 	ins := make([]*wire.OutPoint, 0)
 	sequences := make([]uint32, 0)
@@ -136,6 +137,18 @@ func sweepTransaction(
 		return nil, err
 	}
 
+	// the fees are estimated at the fee rate of the wallet, scaled to the
+	// target one if any.
+	if feeRate > 0 {
+		walletFeeRate, err := wallet.FeeRate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if walletFeeRate > 0 {
+			fees = fees * feeRate / walletFeeRate
+		}
+	}
+
 	if amount < int64(fees) {
 		return nil, fmt.Errorf("insufficient funds (%d) to cover fees (%d) for sweep transaction", amount, fees)
 	}
@@ -40,7 +40,6 @@ func getOnchainReceivers(
 	return receivers
 }
 
-// TODO: use ephemeral keys ?
 func getCosigners(
 	payments []domain.Payment,
 ) ([]*secp256k1.PublicKey, error) {
@@ -2,8 +2,10 @@ package grpcservice
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"os"
 )
 
 type Config struct {
@@ -11,6 +13,19 @@ type Config struct {
 	NoTLS    bool
 	AuthUser string
 	AuthPass string
+	// GatewayPort, if set, makes the REST gateway listen on a dedicated port,
+	// otherwise it's served on the same one of the grpc server.
+	GatewayPort        uint32
+	CORSAllowedOrigins []string
+	// RegistrationRateLimit is the max number of payment registrations per
+	// minute of each client ip, unlimited if zero.
+	RegistrationRateLimit uint64
+	// TLSCertPath and TLSKeyPath are the PEM files of the certificate of the
+	// server, required if not NoTLS. If TLSClientCAPath is set, the clients
+	// must present a certificate signed by that CA to connect.
+	TLSCertPath     string
+	TLSKeyPath      string
+	TLSClientCAPath string
 }
 
 func (c Config) Validate() error {
@@ -28,8 +43,21 @@ func (c Config) Validate() error {
 	}
 	defer lis.Close()
 
+	if c.hasDedicatedGatewayPort() {
+		gatewayLis, err := net.Listen("tcp", c.gatewayListenAddress())
+		if err != nil {
+			return fmt.Errorf("invalid gateway port: %s", err)
+		}
+		defer gatewayLis.Close()
+	}
+
 	if !c.NoTLS {
-		return fmt.Errorf("tls termination not supported yet")
+		if len(c.TLSCertPath) <= 0 || len(c.TLSKeyPath) <= 0 {
+			return fmt.Errorf("missing tls certificate or key")
+		}
+		if _, err := c.tlsConfig(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -42,10 +70,51 @@ func (c Config) address() string {
 	return fmt.Sprintf(":%d", c.Port)
 }
 
-func (c Config) gatewayAddress() string {
-	return fmt.Sprintf("localhost:%d", c.Port)
+func (c Config) hasDedicatedGatewayPort() bool {
+	return c.GatewayPort > 0 && c.GatewayPort != c.Port
 }
 
-func (c Config) tlsConfig() *tls.Config {
-	return nil
+func (c Config) gatewayListenAddress() string {
+	return fmt.Sprintf(":%d", c.GatewayPort)
+}
+
+func (c Config) isCORSAllowedOrigin(origin string) bool {
+	for _, allowed := range c.CORSAllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// tlsConfig returns the config terminating the TLS connections of both the
+// grpc and REST clients, nil if insecure.
+func (c Config) tlsConfig() (*tls.Config, error) {
+	if c.insecure() {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.TLSCertPath, c.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls certificate or key: %s", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if len(c.TLSClientCAPath) > 0 {
+		buf, err := os.ReadFile(c.TLSClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls client ca: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, fmt.Errorf("invalid tls client ca: no pem certificate")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
@@ -57,6 +58,7 @@ func (a *adminHandler) GetRoundDetails(ctx context.Context, req *arkv1.GetRoundD
 		InputsVtxos:      details.InputsVtxos,
 		OutputsVtxos:     details.OutputsVtxos,
 		ExitAddresses:    details.ExitAddresses,
+		SweptAmount:      convertSatoshis(details.SweptAmount),
 	}, nil
 }
 
@@ -85,6 +87,164 @@ func (a *adminHandler) GetRounds(ctx context.Context, req *arkv1.GetRoundsReques
 	return &arkv1.GetRoundsResponse{Rounds: rounds}, nil
 }
 
+func (a *adminHandler) GetRoundStats(ctx context.Context, req *arkv1.GetRoundStatsRequest) (*arkv1.GetRoundStatsResponse, error) {
+	after := req.GetAfter()
+	before := req.GetBefore()
+
+	if after < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid after (must be >= 0)")
+	}
+
+	if before < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid before (must be >= 0)")
+	}
+
+	if before > 0 && after >= before {
+		return nil, status.Error(codes.InvalidArgument, "invalid range")
+	}
+
+	stats, err := a.adminService.GetRoundStats(ctx, after, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetRoundStatsResponse{
+		Rounds:           int64(stats.Rounds),
+		EndedRounds:      int64(stats.EndedRounds),
+		FailedRounds:     int64(stats.FailedRounds),
+		Payments:         int64(stats.Payments),
+		ForfeitedAmount:  convertSatoshis(stats.ForfeitedAmount),
+		TotalVtxosAmount: convertSatoshis(stats.TotalVtxosAmount),
+		TotalExitAmount:  convertSatoshis(stats.TotalExitAmount),
+		FeesAmount:       convertSatoshis(stats.FeesAmount),
+	}, nil
+}
+
+func (a *adminHandler) GetStatus(ctx context.Context, _ *arkv1.GetStatusRequest) (*arkv1.GetStatusResponse, error) {
+	operatorStatus, err := a.adminService.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetStatusResponse{
+		CurrentRoundId:      operatorStatus.CurrentRoundId,
+		CurrentRoundStage:   operatorStatus.CurrentRoundStage,
+		CurrentRoundFailed:  operatorStatus.CurrentRoundFailed,
+		QueuedPayments:      operatorStatus.QueuedPayments,
+		RegistrationsPaused: operatorStatus.RegistrationsPaused,
+	}, nil
+}
+
+func (a *adminHandler) GetLiquidity(ctx context.Context, _ *arkv1.GetLiquidityRequest) (*arkv1.GetLiquidityResponse, error) {
+	liquidity, err := a.adminService.GetLiquidity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetLiquidityResponse{
+		AvailableBalance:   convertSatoshis(liquidity.AvailableBalance),
+		LockedBalance:      convertSatoshis(liquidity.LockedBalance),
+		RequiredLiquidity:  convertSatoshis(liquidity.RequiredLiquidity),
+		QueuedPayments:     int64(liquidity.QueuedPayments),
+		PendingSweepAmount: convertSatoshis(liquidity.PendingSweepAmount),
+	}, nil
+}
+
+func (a *adminHandler) GetVtxoSet(ctx context.Context, req *arkv1.GetVtxoSetRequest) (*arkv1.GetVtxoSetResponse, error) {
+	pubkey := req.GetPubkey()
+	if len(pubkey) > 0 {
+		pk, err := parsePubkey(pubkey)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pubkey = hex.EncodeToString(pk.SerializeCompressed())
+	}
+
+	vtxos, err := a.adminService.GetVtxoSet(ctx, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*arkv1.AdminVtxo, 0, len(vtxos))
+	totalAmount := uint64(0)
+	for _, vtxo := range vtxos {
+		list = append(list, &arkv1.AdminVtxo{
+			Txid:     vtxo.Txid,
+			Vout:     vtxo.VOut,
+			Pubkey:   vtxo.Pubkey,
+			Amount:   convertSatoshis(vtxo.Amount),
+			PoolTxid: vtxo.PoolTx,
+			ExpireAt: vtxo.ExpireAt,
+			Swept:    vtxo.Swept,
+		})
+		totalAmount += vtxo.Amount
+	}
+
+	return &arkv1.GetVtxoSetResponse{
+		Vtxos:       list,
+		TotalAmount: convertSatoshis(totalAmount),
+	}, nil
+}
+
+func (a *adminHandler) TriggerRound(ctx context.Context, _ *arkv1.TriggerRoundRequest) (*arkv1.TriggerRoundResponse, error) {
+	if err := a.adminService.TriggerRound(ctx); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &arkv1.TriggerRoundResponse{}, nil
+}
+
+func (a *adminHandler) PauseRegistrations(ctx context.Context, _ *arkv1.PauseRegistrationsRequest) (*arkv1.PauseRegistrationsResponse, error) {
+	a.adminService.PauseRegistrations(ctx)
+	return &arkv1.PauseRegistrationsResponse{}, nil
+}
+
+func (a *adminHandler) ResumeRegistrations(ctx context.Context, _ *arkv1.ResumeRegistrationsRequest) (*arkv1.ResumeRegistrationsResponse, error) {
+	a.adminService.ResumeRegistrations(ctx)
+	return &arkv1.ResumeRegistrationsResponse{}, nil
+}
+
+func (a *adminHandler) GetRoundPolicy(ctx context.Context, _ *arkv1.GetRoundPolicyRequest) (*arkv1.GetRoundPolicyResponse, error) {
+	policy := a.adminService.GetRoundPolicy(ctx)
+
+	return &arkv1.GetRoundPolicyResponse{
+		Policy: &arkv1.RoundPolicy{
+			Interval:             policy.Interval,
+			Adaptive:             policy.Adaptive,
+			MinInterval:          policy.MinInterval,
+			MaxInterval:          policy.MaxInterval,
+			MinSize:              policy.MinSize,
+			MaxSize:              policy.MaxSize,
+			HighFeeRate:          policy.HighFeeRate,
+			MinPayments:          policy.MinPayments,
+			RegistrationDuration: policy.RegistrationDuration,
+		},
+	}, nil
+}
+
+func (a *adminHandler) UpdateRoundPolicy(ctx context.Context, req *arkv1.UpdateRoundPolicyRequest) (*arkv1.UpdateRoundPolicyResponse, error) {
+	policy := req.GetPolicy()
+	if policy == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing policy")
+	}
+
+	if err := a.adminService.UpdateRoundPolicy(ctx, application.RoundPolicy{
+		Interval:             policy.GetInterval(),
+		Adaptive:             policy.GetAdaptive(),
+		MinInterval:          policy.GetMinInterval(),
+		MaxInterval:          policy.GetMaxInterval(),
+		MinSize:              policy.GetMinSize(),
+		MaxSize:              policy.GetMaxSize(),
+		HighFeeRate:          policy.GetHighFeeRate(),
+		MinPayments:          policy.GetMinPayments(),
+		RegistrationDuration: policy.GetRegistrationDuration(),
+	}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &arkv1.UpdateRoundPolicyResponse{}, nil
+}
+
 func (a *adminHandler) GetScheduledSweep(ctx context.Context, _ *arkv1.GetScheduledSweepRequest) (*arkv1.GetScheduledSweepResponse, error) {
 	scheduledSweeps, err := a.adminService.GetScheduledSweeps(ctx)
 	if err != nil {
@@ -114,6 +274,31 @@ func (a *adminHandler) GetScheduledSweep(ctx context.Context, _ *arkv1.GetSchedu
 	return &arkv1.GetScheduledSweepResponse{Sweeps: sweeps}, nil
 }
 
+func (a *adminHandler) GetWalletAddress(ctx context.Context, _ *arkv1.GetWalletAddressRequest) (*arkv1.GetWalletAddressResponse, error) {
+	address, err := a.adminService.GetWalletAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetWalletAddressResponse{Address: address}, nil
+}
+
+func (a *adminHandler) Withdraw(ctx context.Context, req *arkv1.WithdrawRequest) (*arkv1.WithdrawResponse, error) {
+	if len(req.GetAddress()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing address")
+	}
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing amount")
+	}
+
+	txid, err := a.adminService.Withdraw(ctx, req.GetAddress(), req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.WithdrawResponse{Txid: txid}, nil
+}
+
 // convert sats to string BTC
 func convertSatoshis(sats uint64) string {
 	btc := float64(sats) * 1e-8
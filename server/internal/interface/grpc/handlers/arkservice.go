@@ -65,6 +65,37 @@ func (h *handler) TrustedOnboarding(ctx context.Context, req *arkv1.TrustedOnboa
 	}, nil
 }
 
+func (h *handler) SponsorOnboarding(ctx context.Context, req *arkv1.SponsorOnboardingRequest) (*arkv1.SponsorOnboardingResponse, error) {
+	if req.GetUserPubkey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing user pubkey")
+	}
+
+	pubKey, err := hex.DecodeString(req.GetUserPubkey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user pubkey")
+	}
+
+	decodedPubKey, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user pubkey")
+	}
+
+	if req.GetBoardingTx() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing boarding tx")
+	}
+
+	boardingTx, err := h.svc.SponsorOnboarding(
+		ctx, req.GetBoardingTx(), decodedPubKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.SponsorOnboardingResponse{
+		BoardingTx: boardingTx,
+	}, nil
+}
+
 func (h *handler) Onboard(ctx context.Context, req *arkv1.OnboardRequest) (*arkv1.OnboardResponse, error) {
 	if req.GetUserPubkey() == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing user pubkey")
@@ -120,7 +151,15 @@ func (h *handler) RegisterPayment(ctx context.Context, req *arkv1.RegisterPaymen
 		})
 	}
 
-	id, err := h.svc.SpendVtxos(ctx, vtxosKeys)
+	if len(req.GetSignatures()) != len(vtxosKeys) {
+		return nil, status.Error(
+			codes.InvalidArgument, "missing signatures of the inputs",
+		)
+	}
+
+	id, err := h.svc.SpendVtxos(
+		ctx, vtxosKeys, req.GetClientPaymentId(), req.GetSignatures(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -136,13 +175,150 @@ func (h *handler) ClaimPayment(ctx context.Context, req *arkv1.ClaimPaymentReque
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err := h.svc.ClaimVtxos(ctx, req.GetId(), receivers); err != nil {
+	if err := h.svc.ClaimVtxos(
+		ctx, req.GetId(), receivers, req.GetEphemeralPubkey(),
+	); err != nil {
 		return nil, err
 	}
 
 	return &arkv1.ClaimPaymentResponse{}, nil
 }
 
+func (h *handler) SendTreeNonces(ctx context.Context, req *arkv1.SendTreeNoncesRequest) (*arkv1.SendTreeNoncesResponse, error) {
+	if len(req.GetRoundId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round id")
+	}
+	pubkey, err := parsePubkey(req.GetPublicKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+	if len(req.GetTreeNonces()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing tree nonces")
+	}
+
+	if err := h.svc.RegisterCosignerNonces(
+		ctx, req.GetRoundId(), pubkey, req.GetTreeNonces(),
+	); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &arkv1.SendTreeNoncesResponse{}, nil
+}
+
+func (h *handler) SendTreeSignatures(ctx context.Context, req *arkv1.SendTreeSignaturesRequest) (*arkv1.SendTreeSignaturesResponse, error) {
+	if len(req.GetRoundId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round id")
+	}
+	pubkey, err := parsePubkey(req.GetPublicKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+	if len(req.GetTreeSignatures()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing tree signatures")
+	}
+
+	if err := h.svc.RegisterCosignerSignatures(
+		ctx, req.GetRoundId(), pubkey, req.GetTreeSignatures(),
+	); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &arkv1.SendTreeSignaturesResponse{}, nil
+}
+
+func (h *handler) SendForfeitNonces(ctx context.Context, req *arkv1.SendForfeitNoncesRequest) (*arkv1.SendForfeitNoncesResponse, error) {
+	if len(req.GetRoundId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round id")
+	}
+	if len(req.GetNonces()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing forfeit nonces")
+	}
+	nonces := make(map[string]string, len(req.GetNonces()))
+	for _, nonce := range req.GetNonces() {
+		if len(nonce.GetForfeitTxid()) <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "missing forfeit txid")
+		}
+		nonces[nonce.GetForfeitTxid()] = nonce.GetNonce()
+	}
+
+	aspNonces, err := h.svc.RegisterForfeitNonces(ctx, req.GetRoundId(), nonces)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := make([]*arkv1.ForfeitNonce, 0, len(aspNonces))
+	for txid, nonce := range aspNonces {
+		resp = append(resp, &arkv1.ForfeitNonce{
+			ForfeitTxid: txid,
+			Nonce:       nonce,
+		})
+	}
+	return &arkv1.SendForfeitNoncesResponse{Nonces: resp}, nil
+}
+
+func (h *handler) SendForfeitSignatures(ctx context.Context, req *arkv1.SendForfeitSignaturesRequest) (*arkv1.SendForfeitSignaturesResponse, error) {
+	if len(req.GetRoundId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round id")
+	}
+	if len(req.GetSignatures()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing forfeit signatures")
+	}
+	sigs := make(map[string]string, len(req.GetSignatures()))
+	for _, sig := range req.GetSignatures() {
+		if len(sig.GetForfeitTxid()) <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "missing forfeit txid")
+		}
+		sigs[sig.GetForfeitTxid()] = sig.GetSignature()
+	}
+
+	if err := h.svc.RegisterForfeitSignatures(
+		ctx, req.GetRoundId(), sigs,
+	); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &arkv1.SendForfeitSignaturesResponse{}, nil
+}
+
+func (h *handler) CreateAsyncPayment(ctx context.Context, req *arkv1.CreateAsyncPaymentRequest) (*arkv1.CreateAsyncPaymentResponse, error) {
+	if len(req.GetInputs()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing inputs")
+	}
+	vtxosKeys := make([]domain.VtxoKey, 0, len(req.GetInputs()))
+	for _, input := range req.GetInputs() {
+		vtxosKeys = append(vtxosKeys, domain.VtxoKey{
+			Txid: input.GetTxid(),
+			VOut: input.GetVout(),
+		})
+	}
+
+	receivers, err := parseReceivers(req.GetReceivers())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	redeemTx, err := h.svc.CreateAsyncPayment(ctx, vtxosKeys, receivers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.CreateAsyncPaymentResponse{
+		RedeemTx: redeemTx,
+	}, nil
+}
+
+func (h *handler) CompleteAsyncPayment(ctx context.Context, req *arkv1.CompleteAsyncPaymentRequest) (*arkv1.CompleteAsyncPaymentResponse, error) {
+	if len(req.GetSignedRedeemTx()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing signed redeem tx")
+	}
+
+	if err := h.svc.CompleteAsyncPayment(ctx, req.GetSignedRedeemTx()); err != nil {
+		return nil, err
+	}
+
+	return &arkv1.CompleteAsyncPaymentResponse{}, nil
+}
+
 func (h *handler) FinalizePayment(ctx context.Context, req *arkv1.FinalizePaymentRequest) (*arkv1.FinalizePaymentResponse, error) {
 	forfeitTxs, err := parseTxs(req.GetSignedForfeitTxs())
 	if err != nil {
@@ -171,6 +347,7 @@ func (h *handler) GetRound(ctx context.Context, req *arkv1.GetRoundRequest) (*ar
 				CongestionTree: castCongestionTree(round.CongestionTree),
 				ForfeitTxs:     round.ForfeitTxs,
 				Connectors:     round.Connectors,
+				Stage:          castRoundStage(round.Stage),
 			},
 		}, nil
 	}
@@ -189,6 +366,63 @@ func (h *handler) GetRound(ctx context.Context, req *arkv1.GetRoundRequest) (*ar
 			CongestionTree: castCongestionTree(round.CongestionTree),
 			ForfeitTxs:     round.ForfeitTxs,
 			Connectors:     round.Connectors,
+			Stage:          castRoundStage(round.Stage),
+		},
+	}, nil
+}
+
+func (h *handler) GetRoundById(ctx context.Context, req *arkv1.GetRoundByIdRequest) (*arkv1.GetRoundByIdResponse, error) {
+	id := req.GetId()
+	if len(id) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round id")
+	}
+
+	round, err := h.svc.GetRoundById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetRoundByIdResponse{
+		Round: &arkv1.Round{
+			Id:             round.Id,
+			Start:          round.StartingTimestamp,
+			End:            round.EndingTimestamp,
+			PoolTx:         round.UnsignedTx,
+			CongestionTree: castCongestionTree(round.CongestionTree),
+			ForfeitTxs:     round.ForfeitTxs,
+			Connectors:     round.Connectors,
+			Stage:          castRoundStage(round.Stage),
+		},
+	}, nil
+}
+
+func (h *handler) GetReceipt(ctx context.Context, req *arkv1.GetReceiptRequest) (*arkv1.GetReceiptResponse, error) {
+	outpoint := req.GetOutpoint()
+	if outpoint == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing outpoint")
+	}
+	if len(outpoint.GetTxid()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing outpoint txid")
+	}
+
+	receipt, err := h.svc.GetReceipt(ctx, domain.VtxoKey{
+		Txid: outpoint.GetTxid(),
+		VOut: outpoint.GetVout(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetReceiptResponse{
+		Receipt: &arkv1.Receipt{
+			PoolTxid:  receipt.PoolTxid,
+			VtxoTxid:  receipt.VtxoTxid,
+			Vout:      receipt.Vout,
+			Amount:    receipt.Amount,
+			Pubkey:    receipt.Pubkey,
+			AspPubkey: receipt.AspPubkey,
+			CreatedAt: receipt.CreatedAt,
+			Signature: receipt.Signature,
 		},
 	}, nil
 }
@@ -249,12 +483,66 @@ func (h *handler) GetInfo(ctx context.Context, req *arkv1.GetInfoRequest) (*arkv
 	}
 
 	return &arkv1.GetInfoResponse{
-		Pubkey:              info.PubKey,
-		RoundLifetime:       info.RoundLifetime,
-		UnilateralExitDelay: info.UnilateralExitDelay,
-		RoundInterval:       info.RoundInterval,
-		Network:             info.Network,
-		MinRelayFee:         info.MinRelayFee,
+		Pubkey:                  info.PubKey,
+		RoundLifetime:           info.RoundLifetime,
+		UnilateralExitDelay:     info.UnilateralExitDelay,
+		RoundInterval:           info.RoundInterval,
+		Network:                 info.Network,
+		MinRelayFee:             info.MinRelayFee,
+		ExitFee:                 info.ExitFee,
+		FeeSponsorshipThreshold: info.FeeSponsorshipThreshold,
+		Capabilities:            uint64(info.Capabilities),
+		FeePolicy: &arkv1.FeePolicy{
+			FlatFee:            info.FeePolicy.FlatFee,
+			ProportionalFeePpm: info.FeePolicy.ProportionalFeePpm,
+			OutputFee:          info.FeePolicy.OutputFee,
+		},
+	}, nil
+}
+
+func (h *handler) PushSyncMessage(ctx context.Context, req *arkv1.PushSyncMessageRequest) (*arkv1.PushSyncMessageResponse, error) {
+	if len(req.GetMailboxId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing mailbox id")
+	}
+	if len(req.GetPayload()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing payload")
+	}
+
+	epoch, sequence, err := h.svc.PushSyncMessage(ctx, req.GetMailboxId(), req.GetPayload())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &arkv1.PushSyncMessageResponse{
+		Sequence: sequence,
+		Epoch:    epoch,
+	}, nil
+}
+
+func (h *handler) GetSyncMessages(ctx context.Context, req *arkv1.GetSyncMessagesRequest) (*arkv1.GetSyncMessagesResponse, error) {
+	if len(req.GetMailboxId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing mailbox id")
+	}
+
+	messages, epoch, err := h.svc.GetSyncMessages(
+		ctx, req.GetMailboxId(), req.GetEpoch(), req.GetAfterSequence(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*arkv1.SyncMessage, 0, len(messages))
+	for _, msg := range messages {
+		list = append(list, &arkv1.SyncMessage{
+			Sequence:  msg.Sequence,
+			Payload:   msg.Payload,
+			Timestamp: msg.Timestamp,
+		})
+	}
+
+	return &arkv1.GetSyncMessagesResponse{
+		Messages: list,
+		Epoch:    epoch,
 	}, nil
 }
 
@@ -314,6 +602,44 @@ func (h *handler) listenToEvents() {
 					},
 				},
 			}
+		case domain.RoundSigningStarted:
+			ev = &arkv1.GetEventStreamResponse{
+				Event: &arkv1.GetEventStreamResponse_RoundSigning{
+					RoundSigning: &arkv1.RoundSigningEvent{
+						Id:               e.Id,
+						CosignersPubkeys: e.Cosigners,
+						UnsignedTree:     castCongestionTree(e.UnsignedVtxoTree),
+						UnsignedPoolTx:   e.UnsignedRoundTx,
+					},
+				},
+			}
+		case domain.RoundSigningNoncesGenerated:
+			ev = &arkv1.GetEventStreamResponse{
+				Event: &arkv1.GetEventStreamResponse_RoundSigningNoncesGenerated{
+					RoundSigningNoncesGenerated: &arkv1.RoundSigningNoncesGeneratedEvent{
+						Id:         e.Id,
+						TreeNonces: e.Nonces,
+					},
+				},
+			}
+		case domain.PaymentInputsRejected:
+			inputs := make([]*arkv1.Input, 0, len(e.Inputs))
+			for _, in := range e.Inputs {
+				inputs = append(inputs, &arkv1.Input{
+					Txid: in.Txid,
+					Vout: in.VOut,
+				})
+			}
+			ev = &arkv1.GetEventStreamResponse{
+				Event: &arkv1.GetEventStreamResponse_PaymentInputsRejected{
+					PaymentInputsRejected: &arkv1.PaymentInputsRejected{
+						Id:        e.Id,
+						PaymentId: e.PaymentId,
+						Inputs:    inputs,
+						Reason:    e.Reason,
+					},
+				},
+			}
 		}
 
 		if ev != nil {
@@ -349,6 +675,7 @@ func (v vtxoList) toProto(hrp string, aspKey *secp256k1.PublicKey) []*arkv1.Vtxo
 			ExpireAt: vv.ExpireAt,
 			SpentBy:  vv.SpentBy,
 			Swept:    vv.Swept,
+			RedeemTx: vv.RedeemTx,
 		})
 	}
 	return list
@@ -409,3 +736,21 @@ func toCongestionTree(treeFromProto *arkv1.Tree) (tree.CongestionTree, error) {
 
 	return levels, nil
 }
+
+func castRoundStage(stage domain.Stage) arkv1.RoundStage {
+	if stage.Failed {
+		return arkv1.RoundStage_ROUND_STAGE_FAILED
+	}
+
+	switch stage.Code {
+	case domain.RegistrationStage:
+		return arkv1.RoundStage_ROUND_STAGE_REGISTRATION
+	case domain.FinalizationStage:
+		if stage.Ended {
+			return arkv1.RoundStage_ROUND_STAGE_FINALIZED
+		}
+		return arkv1.RoundStage_ROUND_STAGE_FINALIZATION
+	default:
+		return arkv1.RoundStage_ROUND_STAGE_UNSPECIFIED
+	}
+}
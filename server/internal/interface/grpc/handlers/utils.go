@@ -31,6 +31,14 @@ func parseAddress(addr string) (string, *secp256k1.PublicKey, *secp256k1.PublicK
 	return common.DecodeAddress(addr)
 }
 
+func parsePubkey(pubkey string) (*secp256k1.PublicKey, error) {
+	buf, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pubkey format")
+	}
+	return secp256k1.ParsePubKey(buf)
+}
+
 func parseReceivers(outs []*arkv1.Output) ([]domain.Receiver, error) {
 	receivers := make([]domain.Receiver, 0, len(outs))
 	for _, out := range outs {
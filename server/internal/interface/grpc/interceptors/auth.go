@@ -10,6 +10,7 @@ import (
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
@@ -28,6 +29,11 @@ func unaryAuthenticator(user, pass string) grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
+		// whitelist the health service for probes
+		if strings.Contains(info.FullMethod, grpchealth.Health_ServiceDesc.ServiceName) {
+			return handler(ctx, req)
+		}
+
 		token, err := grpc_auth.AuthFromMD(ctx, "basic")
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "no basic header found: %v", err)
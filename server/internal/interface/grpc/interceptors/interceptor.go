@@ -1,19 +1,28 @@
 package interceptors
 
 import (
+	"github.com/ark-network/ark/internal/core/ports"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 )
 
-// UnaryInterceptor returns the unary interceptor
-func UnaryInterceptor(user, pass string) grpc.ServerOption {
+// UnaryInterceptor returns the unary interceptor, limiting the payment
+// registrations to the given number per minute of each client ip.
+func UnaryInterceptor(
+	user, pass string, registrationRateLimit uint64,
+	metrics ports.MetricsService,
+) grpc.ServerOption {
 	return grpc.UnaryInterceptor(middleware.ChainUnaryServer(
+		unaryMetrics(metrics),
 		unaryAuthenticator(user, pass),
+		unaryRateLimiter(registrationRateLimit),
 		unaryLogger,
 	))
 }
 
 // StreamInterceptor returns the stream interceptor with a logrus log
-func StreamInterceptor() grpc.ServerOption {
-	return grpc.StreamInterceptor(middleware.ChainStreamServer(streamLogger))
+func StreamInterceptor(metrics ports.MetricsService) grpc.ServerOption {
+	return grpc.StreamInterceptor(middleware.ChainStreamServer(
+		streamMetrics(metrics), streamLogger,
+	))
 }
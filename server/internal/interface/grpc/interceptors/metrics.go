@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/ark-network/ark/internal/core/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func unaryMetrics(metrics ports.MetricsService) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		metrics.RequestHandled(info.FullMethod, status.Code(err).String())
+		return resp, err
+	}
+}
+
+func streamMetrics(metrics ports.MetricsService) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		err := handler(srv, stream)
+		metrics.RequestHandled(info.FullMethod, status.Code(err).String())
+		return err
+	}
+}
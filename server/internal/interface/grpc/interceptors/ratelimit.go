@@ -0,0 +1,131 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimitedMethods are the methods whose requests are limited per client ip,
+// those registering payments, that are kept in memory by the server.
+var rateLimitedMethods = map[string]struct{}{
+	fmt.Sprintf("/%s/RegisterPayment", arkv1.ArkService_ServiceDesc.ServiceName): {},
+}
+
+// staleLimiterTimeout is how long the bucket of an ip is kept since its last
+// request.
+const staleLimiterTimeout = 10 * time.Minute
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// ipRateLimiter is a token bucket per client ip, holding up to a minute worth
+// of requests.
+type ipRateLimiter struct {
+	lock      sync.Mutex
+	perMinute float64
+	buckets   map[string]*bucket
+	lastClean time.Time
+}
+
+func (l *ipRateLimiter) allow(ip string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastClean) > staleLimiterTimeout {
+		for key, b := range l.buckets {
+			if now.Sub(b.lastSeen) > staleLimiterTimeout {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastClean = now
+	}
+
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &bucket{tokens: l.perMinute, lastSeen: now}
+		l.buckets[ip] = b
+	}
+	b.tokens += now.Sub(b.lastSeen).Minutes() * l.perMinute
+	if b.tokens > l.perMinute {
+		b.tokens = l.perMinute
+	}
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// unaryRateLimiter rejects the requests of the rate limited methods exceeding
+// the given number per minute of the same client ip, no limit if zero.
+func unaryRateLimiter(perMinute uint64) grpc.UnaryServerInterceptor {
+	limiter := &ipRateLimiter{
+		perMinute: float64(perMinute),
+		buckets:   make(map[string]*bucket),
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if _, ok := rateLimitedMethods[info.FullMethod]; !ok || perMinute == 0 {
+			return handler(ctx, req)
+		}
+
+		if !limiter.allow(clientIP(ctx)) {
+			return nil, status.Error(
+				codes.ResourceExhausted, "too many requests, retry later",
+			)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// gatewayNetwork is the network of the in memory listener the REST gateway
+// reaches the grpc server through.
+const gatewayNetwork = "bufconn"
+
+// clientIP returns the ip of the client of the request. The one forwarded by
+// the REST gateway is trusted only for the requests coming from the gateway
+// itself.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	if p.Addr.Network() == gatewayNetwork {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+				// the gateway appends the remote address of the client to the
+				// forwarded ones, that are set by the client and not trusted.
+				addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+				return strings.TrimSpace(addrs[len(addrs)-1])
+			}
+		}
+	}
+
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
@@ -2,11 +2,13 @@ package grpcservice
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/ark-network/ark/api-spec/openapi"
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	appconfig "github.com/ark-network/ark/internal/app-config"
 	interfaces "github.com/ark-network/ark/internal/interface"
@@ -14,19 +16,46 @@ import (
 	"github.com/ark-network/ark/internal/interface/grpc/interceptors"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// shutdownTimeout bounds the time waited for the requests in flight to
+// complete when the server is stopped.
+const shutdownTimeout = 10 * time.Second
+
+// gatewayBufferSize is the size of the in memory connection between the REST
+// gateway and the grpc server.
+const gatewayBufferSize = 1024 * 1024
+
+// healthCheckedServices are the services whose status is reported by the
+// grpc.health.v1 service, the empty name stands for the overall server status.
+var healthCheckedServices = []string{
+	"",
+	arkv1.ArkService_ServiceDesc.ServiceName,
+	arkv1.AdminService_ServiceDesc.ServiceName,
+}
+
 type service struct {
 	config    Config
 	appConfig *appconfig.Config
 	server    *http.Server
+	health    *health.Server
+	// gatewayServer is set only if the REST gateway listens on a dedicated
+	// port.
+	gatewayServer *http.Server
+	grpcServer    *grpc.Server
+	// gatewayListener is the in memory listener the REST gateway reaches the
+	// grpc server through.
+	gatewayListener *bufconn.Listener
 }
 
 func NewService(
@@ -40,17 +69,21 @@ func NewService(
 	}
 
 	grpcConfig := []grpc.ServerOption{
-		interceptors.UnaryInterceptor(svcConfig.AuthUser, svcConfig.AuthPass),
-		interceptors.StreamInterceptor(),
-	}
-	if !svcConfig.NoTLS {
-		return nil, fmt.Errorf("tls termination not supported yet")
+		interceptors.UnaryInterceptor(
+			svcConfig.AuthUser, svcConfig.AuthPass,
+			svcConfig.RegistrationRateLimit, appConfig.MetricsService(),
+		),
+		interceptors.StreamInterceptor(appConfig.MetricsService()),
+		// the trace context of the clients is propagated to the spans of the
+		// app service.
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
-	creds := insecure.NewCredentials()
-	if !svcConfig.insecure() {
-		creds = credentials.NewTLS(svcConfig.tlsConfig())
+	// the TLS connections are terminated by the http server, the grpc one
+	// is served over them.
+	tlsConfig, err := svcConfig.tlsConfig()
+	if err != nil {
+		return nil, err
 	}
-	grpcConfig = append(grpcConfig, grpc.Creds(creds))
 
 	// Server grpc.
 	grpcServer := grpc.NewServer(grpcConfig...)
@@ -61,19 +94,29 @@ func NewService(
 	adminHandler := handlers.NewAdminHandler(appConfig.AdminService())
 	arkv1.RegisterAdminServiceServer(grpcServer, adminHandler)
 
-	healthHandler := handlers.NewHealthHandler()
-	grpchealth.RegisterHealthServer(grpcServer, healthHandler)
-
-	// Creds for grpc gateway reverse proxy.
-	gatewayCreds := insecure.NewCredentials()
-	if !svcConfig.insecure() {
-		gatewayCreds = credentials.NewTLS(&tls.Config{
-			InsecureSkipVerify: true, // #nosec
-		})
+	// Services are reported as not serving until the app service is started.
+	healthServer := health.NewServer()
+	for _, svc := range healthCheckedServices {
+		healthServer.SetServingStatus(
+			svc, grpchealth.HealthCheckResponse_NOT_SERVING,
+		)
 	}
-	gatewayOpts := grpc.WithTransportCredentials(gatewayCreds)
+	grpchealth.RegisterHealthServer(grpcServer, healthServer)
+
+	reflection.Register(grpcServer)
+
+	// The grpc gateway reverse proxy reaches the grpc server in memory, not
+	// to go through the TLS termination and the client certificate check.
+	gatewayListener := bufconn.Listen(gatewayBufferSize)
 	conn, err := grpc.NewClient(
-		svcConfig.gatewayAddress(), gatewayOpts,
+		"passthrough:///arkd",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return gatewayListener.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, err
@@ -102,9 +145,22 @@ func NewService(
 	); err != nil {
 		return nil, err
 	}
-	grpcGateway := http.Handler(gwmux)
+	gatewayMux := http.NewServeMux()
+	// OpenAPI documents are served at /swagger/ark/v1/<service>.swagger.json.
+	gatewayMux.Handle("/swagger/", http.FileServer(http.FS(openapi.Swagger)))
+	gatewayMux.Handle("/", gwmux)
+	grpcGateway := withCORS(gatewayMux, svcConfig)
 
+	var gatewayServer *http.Server
 	handler := router(grpcServer, grpcGateway)
+	if svcConfig.hasDedicatedGatewayPort() {
+		handler = grpcServer
+		gatewayServer = &http.Server{
+			Addr:      svcConfig.gatewayListenAddress(),
+			Handler:   grpcGateway,
+			TLSConfig: tlsConfig,
+		}
+	}
 	mux := http.NewServeMux()
 	mux.Handle("/", handler)
 
@@ -116,13 +172,19 @@ func NewService(
 	server := &http.Server{
 		Addr:      svcConfig.address(),
 		Handler:   httpServerHandler,
-		TLSConfig: svcConfig.tlsConfig(),
+		TLSConfig: tlsConfig,
 	}
 
-	return &service{svcConfig, appConfig, server}, nil
+	return &service{
+		svcConfig, appConfig, server, healthServer, gatewayServer,
+		grpcServer, gatewayListener,
+	}, nil
 }
 
 func (s *service) Start() error {
+	// nolint:all
+	go s.grpcServer.Serve(s.gatewayListener)
+
 	if s.config.insecure() {
 		// nolint:all
 		go s.server.ListenAndServe()
@@ -132,42 +194,86 @@ func (s *service) Start() error {
 	}
 	log.Infof("started listening at %s", s.config.address())
 
+	if s.gatewayServer != nil {
+		if s.config.insecure() {
+			// nolint:all
+			go s.gatewayServer.ListenAndServe()
+		} else {
+			// nolint:all
+			go s.gatewayServer.ListenAndServeTLS("", "")
+		}
+		log.Infof(
+			"started REST gateway listening at %s",
+			s.config.gatewayListenAddress(),
+		)
+	}
+
+	if err := s.appConfig.MetricsService().Start(); err != nil {
+		return fmt.Errorf("failed to start metrics service: %s", err)
+	}
+
 	if err := s.appConfig.AppService().Start(); err != nil {
 		return fmt.Errorf("failed to start app service: %s", err)
 	}
 	log.Info("started app service")
 
+	for _, svc := range healthCheckedServices {
+		s.health.SetServingStatus(svc, grpchealth.HealthCheckResponse_SERVING)
+	}
+
 	return nil
 }
 
 func (s *service) Stop() {
-	// nolint:all
-	s.server.Shutdown(context.Background())
-	log.Info("stopped grpc server")
+	// let probes know the service is going away before closing connections.
+	s.health.Shutdown()
+	// the app service is stopped first, for the clients to be notified of
+	// the end of the current round over the still open event streams.
 	s.appConfig.AppService().Stop()
 	log.Info("stopped app service")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	// nolint:all
+	s.server.Shutdown(ctx)
+	log.Info("stopped grpc server")
+	if s.gatewayServer != nil {
+		// nolint:all
+		s.gatewayServer.Shutdown(ctx)
+		log.Info("stopped REST gateway")
+	}
+	s.grpcServer.Stop()
+	s.appConfig.MetricsService().Stop()
 }
 
 func router(
 	grpcServer *grpc.Server, grpcGateway http.Handler,
 ) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if isOptionRequest(r) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Headers", "*")
-			w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+		if isGrpcRequest(r) {
+			grpcServer.ServeHTTP(w, r)
 			return
 		}
+		grpcGateway.ServeHTTP(w, r)
+	})
+}
 
-		if isHttpRequest(r) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
+// withCORS adds the CORS headers to the responses for the requests coming
+// from one of the allowed origins, and replies to the preflight ones.
+func withCORS(handler http.Handler, config Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(origin) > 0 && config.isCORSAllowedOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Headers", "*")
 			w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+			w.Header().Add("Vary", "Origin")
+		}
 
-			grpcGateway.ServeHTTP(w, r)
+		if isOptionRequest(r) {
 			return
 		}
-		grpcServer.ServeHTTP(w, r)
+		handler.ServeHTTP(w, r)
 	})
 }
 
@@ -175,7 +281,9 @@ func isOptionRequest(req *http.Request) bool {
 	return req.Method == http.MethodOptions
 }
 
-func isHttpRequest(req *http.Request) bool {
-	return req.Method == http.MethodGet ||
-		strings.Contains(req.Header.Get("Content-Type"), "application/json")
+// isGrpcRequest tells the grpc requests apart from the REST ones, whatever
+// their content type, like those of curl posting json bodies as form data.
+func isGrpcRequest(req *http.Request) bool {
+	return req.ProtoMajor == 2 &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
 }